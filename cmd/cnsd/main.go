@@ -16,12 +16,18 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/api"
 )
 
 func main() {
-	if err := api.Serve(); err != nil {
+	serve := api.Serve
+	if len(os.Args) > 1 && os.Args[1] == api.DriftCommand {
+		serve = api.ServeDrift
+	}
+
+	if err := serve(); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -16,9 +16,10 @@
 //
 // This package acts as a thin wrapper around the reusable pkg/server package,
 // configuring it with application-specific routes and handlers. It exposes the
-// recipe generation functionality (Step 2 of the four-stage workflow) via REST API.
-// Note: The API server does not support snapshot capture (Step 1) or validation (Step 3);
-// use the CLI for these operations.
+// recipe generation (Step 2) and validation (Step 3) functionality of the
+// four-stage workflow via REST API.
+// Note: The API server does not support snapshot capture (Step 1); use the CLI
+// or the agent for this operation.
 //
 // # Usage
 //
@@ -55,6 +56,13 @@
 // Application Endpoints (with rate limiting):
 //   - GET /v1/recipe  - Generate configuration recipe based on query parameters
 //   - POST /v1/recipe - Generate configuration recipe from criteria body (JSON/YAML)
+//   - GET /v1/recipe/matrix - Overlay coverage of every criteria combination
+//   - POST /v1/bundle - Generate deployment bundles from a recipe
+//   - POST /v1/validate - Validate a recipe against a snapshot
+//   - POST /v1/snapshots - Store a snapshot (with CNS_SNAPSHOT_STORE_DIR)
+//   - GET /v1/snapshots - List stored snapshots
+//   - GET /v1/snapshots/{id} - Get a stored snapshot
+//   - GET /v1/snapshots/{id}/diff/{other} - Diff two stored snapshots
 //
 // System Endpoints (no rate limiting):
 //   - GET /health  - Health check (liveness probe)
@@ -96,11 +104,25 @@
 //	  -H "Content-Type: application/yaml" \
 //	  -d @criteria.yaml
 //
+// # Request Body (POST /v1/validate)
+//
+// POST /v1/validate accepts the recipe and the snapshot either as the "recipe"
+// and "snapshot" parts of a multipart form, or as one JSON envelope:
+//
+//	{"recipe": {...}, "snapshot": {...}}
+//
+// The ValidationResult is returned as JSON, or as YAML with Accept: application/yaml:
+//
+//	curl -X POST http://localhost:8080/v1/validate \
+//	  -H "Accept: application/yaml" \
+//	  -F recipe=@recipe.yaml -F snapshot=@snapshot.yaml
+//
 // # Configuration
 //
 // The server is configured via environment variables:
 //   - PORT: HTTP server port (default: 8080)
 //   - LOG_LEVEL: Logging level (debug, info, warn, error)
+//   - CNS_SNAPSHOT_STORE_DIR: Directory of the snapshot store; enables /v1/snapshots
 //
 // Version information is set at build time using ldflags:
 //
@@ -0,0 +1,94 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector/k8s"
+	"github.com/NVIDIA/cloud-native-stack/pkg/drift"
+	"github.com/NVIDIA/cloud-native-stack/pkg/k8s/client"
+	"github.com/NVIDIA/cloud-native-stack/pkg/logging"
+	"github.com/NVIDIA/cloud-native-stack/pkg/server"
+)
+
+// DriftCommand is the cnsd argument that starts the drift controller.
+const DriftCommand = "drift"
+
+// ServeDrift runs the drift controller until shutdown, serving /health, /ready
+// and /metrics alongside it. The controller is configured from the environment
+// (see drift.ParseEnvConfig).
+func ServeDrift() error {
+	logging.SetDefaultStructuredLogger(name, version)
+	slog.Debug("starting drift controller",
+		"name", name,
+		"version", version,
+		"commit", commit,
+		"date", date,
+	)
+
+	cfg, err := drift.ParseEnvConfig()
+	if err != nil {
+		return fmt.Errorf("failed to parse drift configuration from environment: %w", err)
+	}
+
+	opts, err := cfg.Options()
+	if err != nil {
+		return err
+	}
+	opts = append(opts, drift.WithVersion(version))
+
+	kubeClient, _, err := client.GetKubeClient()
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes client: %w", err)
+	}
+	opts = append(opts, drift.WithEventRecorder(
+		drift.NewKubeEventRecorder(kubeClient, cfg.Namespace, cfg.ReportName(), k8s.GetNodeName())))
+
+	controller, err := drift.New(opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create drift controller: %w", err)
+	}
+
+	s := server.New(
+		server.WithName(name),
+		server.WithVersion(version),
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.Start(gctx)
+	})
+	g.Go(func() error {
+		return controller.Run(gctx)
+	})
+
+	if err := g.Wait(); err != nil {
+		slog.Error("drift controller exited with error", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -19,11 +19,14 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler"
 	"github.com/NVIDIA/cloud-native-stack/pkg/logging"
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 	"github.com/NVIDIA/cloud-native-stack/pkg/server"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter/store"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
 )
 
 const (
@@ -65,12 +68,14 @@ func Serve() error {
 			"services", len(allowLists.Services),
 			"intents", len(allowLists.Intents),
 			"os_types", len(allowLists.OSTypes),
+			"dimensions", len(allowLists.Dimensions),
 		)
 		slog.Debug("criteria allowlists loaded",
 			"accelerators", allowLists.AcceleratorStrings(),
 			"services", allowLists.ServiceStrings(),
 			"intents", allowLists.IntentStrings(),
 			"os_types", allowLists.OSTypeStrings(),
+			"dimensions", allowLists.Dimensions,
 		)
 	}
 
@@ -88,9 +93,31 @@ func Serve() error {
 		return fmt.Errorf("failed to create bundler: %w", err)
 	}
 
+	// Setup validate handler
+	vv := validator.New(
+		validator.WithVersion(version),
+		validator.WithAllowLists(allowLists),
+	)
+
 	r := map[string]http.HandlerFunc{
-		"/v1/recipe": rb.HandleRecipes,
-		"/v1/bundle": bb.HandleBundles,
+		"/v1/recipe":        rb.HandleRecipes,
+		"/v1/recipe/matrix": rb.HandleMatrix,
+		"/v1/bundle":        bb.HandleBundles,
+		"/v1/validate":      vv.HandleValidate,
+	}
+
+	// Setup snapshot handler (only when a store directory is configured)
+	if dir := os.Getenv(store.EnvDir); dir != "" {
+		ss, storeErr := store.OpenFileStore(dir)
+		if storeErr != nil {
+			return fmt.Errorf("failed to open snapshot store: %w", storeErr)
+		}
+		defer ss.Close()
+
+		sh := store.NewHandler(ss, store.WithVersion(version))
+		r[store.Path] = sh.HandleSnapshots
+		r[store.Path+"/"] = sh.HandleSnapshot
+		slog.Info("snapshot store configured", "dir", dir)
 	}
 
 	// Create and run server
@@ -0,0 +1,211 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/airgap"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/helm"
+	"github.com/NVIDIA/cloud-native-stack/pkg/component"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// validateAirgap checks that the recipe can be deployed without network access
+// to chart or Git repositories. Kustomize components are rejected: their
+// sources are not part of the bundle and their images cannot be listed.
+func (b *DefaultBundler) validateAirgap(recipeResult *recipe.RecipeResult) error {
+	if b.Config.Deployer() != config.DeployerHelm {
+		return errors.New(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("air-gapped bundles require the %s deployer, got %s", config.DeployerHelm, b.Config.Deployer()))
+	}
+
+	for _, ref := range recipeResult.ComponentRefs {
+		if helm.IsKustomizeComponent(ref) {
+			return errors.NewWithContext(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("component %s is a Kustomize component, which air-gapped bundles cannot include", ref.Name),
+				map[string]any{"component": ref.Name, "source": ref.Source})
+		}
+	}
+
+	return nil
+}
+
+// vendorCharts copies the chart of every Helm component into the bundle's
+// charts/ directory and rewrites image registries in componentValues to the
+// mirror registry, if one is configured. Returns the vendored charts and the
+// source images they deploy, read from the charts and manifests rendered with
+// the bundle values.
+func (b *DefaultBundler) vendorCharts(ctx context.Context, recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, manifestContents map[string][]byte, dir string) ([]string, []string, error) {
+	registry, err := recipe.GetComponentRegistry()
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeInternal, "failed to load component registry", err)
+	}
+
+	chartsDir := filepath.Join(dir, airgap.ChartsDirName)
+	mirror := b.Config.MirrorRegistry()
+	images := make(map[string]bool)
+	chartFiles := make([]string, 0, len(recipeResult.ComponentRefs))
+
+	// Manifests are rendered before the values are rewritten to the mirror
+	manifestImages, err := renderManifestImages(recipeResult, componentValues, manifestContents)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, image := range manifestImages {
+		images[image] = true
+	}
+
+	for _, ref := range recipeResult.ComponentRefs {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		chartPath, err := airgap.VendorChart(ref, helm.ResolveChartName(ref.Name), b.Config.ChartCache(), chartsDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		chartFiles = append(chartFiles, chartPath)
+
+		chart, err := airgap.LoadChartFile(chartPath)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("invalid chart archive for component %s", ref.Name), err)
+		}
+
+		values := componentValues[ref.Name]
+		if values == nil {
+			values = make(map[string]any)
+			componentValues[ref.Name] = values
+		}
+
+		release := airgap.Release{Name: ref.Name, Namespace: helm.ResolveNamespace(ref.Name)}
+		chartImages, err := chart.Images(release, values)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("failed to render chart of component %s", ref.Name), err)
+		}
+		for _, image := range chartImages {
+			images[image] = true
+		}
+
+		if mirror == "" {
+			continue
+		}
+		overrides := airgap.MirrorOverrides(chart, values, registry.Get(ref.Name).GetMirrorRegistryPaths(), mirror)
+		if applyErr := component.ApplyMapOverrides(values, overrides); applyErr != nil {
+			return nil, nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to apply mirror registry to component %s", ref.Name), applyErr)
+		}
+		mirroredImages, err := chart.Images(release, values)
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("failed to render chart of component %s", ref.Name), err)
+		}
+		warnUnmirrored(ref.Name, mirroredImages, mirror)
+	}
+
+	if mirror != "" {
+		mirroredImages, err := renderManifestImages(recipeResult, componentValues, manifestContents)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnUnmirrored("manifests", mirroredImages, mirror)
+	}
+
+	imageList := make([]string, 0, len(images))
+	for image := range images {
+		imageList = append(imageList, image)
+	}
+	sort.Strings(imageList)
+
+	return chartFiles, imageList, nil
+}
+
+// renderManifestImages returns the images of the component manifests, which
+// are rendered as chart templates with the component values keyed by
+// component name, as in the generated bundle.
+func renderManifestImages(recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, manifestContents map[string][]byte) ([]string, error) {
+	values := make(map[string]any, len(componentValues))
+	for name, v := range componentValues {
+		values[name] = v
+	}
+
+	images := make(map[string]bool)
+	for _, ref := range recipeResult.ComponentRefs {
+		if len(ref.ManifestFiles) == 0 {
+			continue
+		}
+
+		chart := &airgap.Chart{
+			Name:      ref.Name + "-manifests",
+			Values:    values,
+			Templates: make(map[string][]byte, len(ref.ManifestFiles)),
+		}
+		for _, manifestPath := range ref.ManifestFiles {
+			if content, ok := manifestContents[manifestPath]; ok {
+				chart.Templates[path.Join("templates", manifestPath)] = content
+			}
+		}
+
+		manifestImages, err := chart.Images(airgap.Release{Name: chart.Name, Namespace: helm.ResolveNamespace(ref.Name)}, nil)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("failed to render manifests of component %s", ref.Name), err)
+		}
+		for _, image := range manifestImages {
+			images[image] = true
+		}
+	}
+
+	imageList := make([]string, 0, len(images))
+	for image := range images {
+		imageList = append(imageList, image)
+	}
+	sort.Strings(imageList)
+	return imageList, nil
+}
+
+// warnUnmirrored logs the images of a component that are not served by the
+// mirror registry.
+func warnUnmirrored(component string, images []string, mirror string) {
+	for _, image := range images {
+		if !airgap.IsMirrored(image, mirror) {
+			slog.Warn("image is not rewritten to the mirror registry",
+				"component", component, "image", image, "mirror", mirror)
+		}
+	}
+}
+
+// fileSizes returns the total size of the given files.
+func fileSizes(files []string) (int64, error) {
+	var total int64
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return 0, errors.Wrap(errors.ErrCodeInternal, "failed to stat bundle file", err)
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+const (
+	// ChartsDirName is the bundle subdirectory holding vendored charts.
+	ChartsDirName = "charts"
+
+	// fileScheme is the URL scheme of local chart repositories and chart directories.
+	fileScheme = "file://"
+
+	// maxChartSize limits the uncompressed size of a chart archive.
+	maxChartSize = 256 << 20
+)
+
+// Chart is a Helm chart loaded from a chart archive.
+type Chart struct {
+	// Name is the chart name from Chart.yaml.
+	Name string
+
+	// Version is the chart version from Chart.yaml.
+	Version string
+
+	// AppVersion is the application version from Chart.yaml.
+	AppVersion string
+
+	// Values are the default values from the chart's values.yaml.
+	Values map[string]any
+
+	// Templates are the chart templates by path relative to the chart
+	// directory (e.g. "templates/deployment.yaml").
+	Templates map[string][]byte
+
+	// Files are the remaining chart files by path relative to the chart
+	// directory, available to templates through .Files.
+	Files map[string][]byte
+
+	// Subcharts are the charts bundled in the chart's charts/ directory,
+	// indexed by the key of their values (the dependency alias or name).
+	Subcharts map[string]*Chart
+
+	// metadata is the content of Chart.yaml, exposed to templates as .Chart.
+	metadata map[string]any
+
+	// dependencies are the Chart.yaml dependencies by the key of their values.
+	dependencies map[string]chartDependency
+}
+
+// chartFile is the subset of Chart.yaml read by the loader.
+type chartFile struct {
+	Name         string            `yaml:"name"`
+	Version      string            `yaml:"version"`
+	AppVersion   string            `yaml:"appVersion"`
+	Dependencies []chartDependency `yaml:"dependencies"`
+}
+
+// chartDependency is a dependency declared in Chart.yaml.
+type chartDependency struct {
+	Name      string   `yaml:"name"`
+	Alias     string   `yaml:"alias"`
+	Condition string   `yaml:"condition"`
+	Tags      []string `yaml:"tags"`
+}
+
+// VendorChart copies the chart of a Helm component into dir as
+// <chart>-<version>.tgz and returns its path.
+//
+// The chart archive is looked up, in order, in the cache directory (a flat
+// directory of .tgz files such as the Helm repository cache), and in the
+// component source when it is a file:// URL. A file:// source may point at a
+// chart directory, which is packaged, or at a chart repository directory with
+// an index.yaml or <chart>-<version>.tgz files.
+func VendorChart(ref recipe.ComponentRef, chartName, cacheDir, dir string) (string, error) {
+	data, err := locateChart(ref, chartName, cacheDir)
+	if err != nil {
+		return "", err
+	}
+
+	chart, err := LoadChartArchive(data)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("invalid chart archive for component %s", ref.Name), err)
+	}
+	if chart.Name != chartName {
+		return "", errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("chart archive for component %s contains chart %q, want %q", ref.Name, chart.Name, chartName),
+			map[string]any{"component": ref.Name})
+	}
+	if ref.Version != "" && !sameVersion(chart.Version, ref.Version) {
+		return "", errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("chart archive for component %s has version %s, want %s", ref.Name, chart.Version, ref.Version),
+			map[string]any{"component": ref.Name})
+	}
+
+	if mkdirErr := os.MkdirAll(dir, 0755); mkdirErr != nil {
+		return "", errors.Wrap(errors.ErrCodeInternal, "failed to create charts directory", mkdirErr)
+	}
+	chartPath := filepath.Join(dir, fmt.Sprintf("%s-%s.tgz", chart.Name, chart.Version))
+	if writeErr := os.WriteFile(chartPath, data, 0600); writeErr != nil {
+		return "", errors.Wrap(errors.ErrCodeInternal, "failed to write chart archive", writeErr)
+	}
+
+	return chartPath, nil
+}
+
+// LoadChartFile loads a chart from a .tgz archive on disk.
+func LoadChartFile(path string) (*Chart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to read chart archive", err)
+	}
+	return LoadChartArchive(data)
+}
+
+// LoadChartArchive loads a chart, including its subcharts, from the contents
+// of a .tgz archive as produced by `helm package`.
+func LoadChartArchive(data []byte) (*Chart, error) {
+	files, err := readArchive(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Archives contain a single top-level directory named after the chart
+	root := ""
+	for name := range files {
+		if dir, file, ok := strings.Cut(name, "/"); ok && file == "Chart.yaml" {
+			root = dir + "/"
+			break
+		}
+	}
+	if root == "" {
+		return nil, fmt.Errorf("archive has no Chart.yaml")
+	}
+
+	return loadChart(files, root)
+}
+
+// loadChart loads the chart rooted at the given directory of an archive.
+func loadChart(files map[string][]byte, root string) (*Chart, error) {
+	var meta chartFile
+	if err := yaml.Unmarshal(files[root+"Chart.yaml"], &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %sChart.yaml: %w", root, err)
+	}
+	if meta.Name == "" {
+		return nil, fmt.Errorf("%sChart.yaml has no name", root)
+	}
+
+	var metadata map[string]any
+	if err := yaml.Unmarshal(files[root+"Chart.yaml"], &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %sChart.yaml: %w", root, err)
+	}
+
+	chart := &Chart{
+		Name:         meta.Name,
+		Version:      meta.Version,
+		AppVersion:   meta.AppVersion,
+		Values:       make(map[string]any),
+		Templates:    make(map[string][]byte),
+		Files:        make(map[string][]byte),
+		Subcharts:    make(map[string]*Chart),
+		metadata:     metadata,
+		dependencies: make(map[string]chartDependency, len(meta.Dependencies)),
+	}
+
+	if values, ok := files[root+"values.yaml"]; ok {
+		if err := yaml.Unmarshal(values, &chart.Values); err != nil {
+			return nil, fmt.Errorf("failed to parse %svalues.yaml: %w", root, err)
+		}
+		if chart.Values == nil {
+			chart.Values = make(map[string]any)
+		}
+	}
+
+	// Values of a subchart are keyed by the dependency alias, if any
+	aliases := make(map[string]string, len(meta.Dependencies))
+	for _, dep := range meta.Dependencies {
+		key := dep.Name
+		if dep.Alias != "" {
+			aliases[dep.Name] = dep.Alias
+			key = dep.Alias
+		}
+		chart.dependencies[key] = dep
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	subRoots := make(map[string]bool)
+	for _, name := range names {
+		rel := strings.TrimPrefix(name, root)
+		if rel == name {
+			continue
+		}
+
+		switch {
+		case rel == "Chart.yaml" || rel == "values.yaml":
+			continue
+
+		case strings.HasPrefix(rel, "templates/"):
+			chart.Templates[rel] = files[name]
+
+		case strings.HasPrefix(rel, "charts/"):
+			entry := strings.TrimPrefix(rel, "charts/")
+			var sub *Chart
+			var err error
+			if dir, file, ok := strings.Cut(entry, "/"); ok {
+				// Unpacked subchart directory
+				if file != "Chart.yaml" || subRoots[dir] {
+					continue
+				}
+				subRoots[dir] = true
+				sub, err = loadChart(files, root+"charts/"+dir+"/")
+			} else if strings.HasSuffix(entry, ".tgz") {
+				sub, err = LoadChartArchive(files[name])
+			} else {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to load subchart %s: %w", entry, err)
+			}
+			key := sub.Name
+			if alias, ok := aliases[sub.Name]; ok {
+				key = alias
+			}
+			chart.Subcharts[key] = sub
+
+		default:
+			chart.Files[rel] = files[name]
+		}
+	}
+
+	return chart, nil
+}
+
+// readArchive returns the regular files of a .tgz archive by path.
+func readArchive(data []byte) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chart archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	files := make(map[string][]byte)
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chart archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		total += hdr.Size
+		if total > maxChartSize {
+			return nil, fmt.Errorf("chart archive exceeds %d bytes", maxChartSize)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		files[path.Clean(strings.TrimPrefix(hdr.Name, "./"))] = content
+	}
+
+	return files, nil
+}
+
+// locateChart returns the archive of a component's chart.
+func locateChart(ref recipe.ComponentRef, chartName, cacheDir string) ([]byte, error) {
+	names := archiveNames(chartName, ref.Version)
+	searched := make([]string, 0, 2)
+
+	if cacheDir != "" {
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(cacheDir, name))
+			if err == nil {
+				return data, nil
+			}
+		}
+		searched = append(searched, cacheDir)
+	}
+
+	if source, ok := strings.CutPrefix(ref.Source, fileScheme); ok {
+		// A chart directory is packaged on the fly
+		if _, err := os.Stat(filepath.Join(source, "Chart.yaml")); err == nil {
+			return packageChartDir(source, chartName)
+		}
+		if data, err := fromIndex(source, chartName, ref.Version); err == nil {
+			return data, nil
+		}
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(source, name))
+			if err == nil {
+				return data, nil
+			}
+		}
+		searched = append(searched, source)
+	}
+
+	if len(searched) == 0 {
+		return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("no local source for chart %s of component %s: set a chart cache or use a file:// repository",
+				chartName, ref.Name), map[string]any{"source": ref.Source})
+	}
+	return nil, errors.NewWithContext(errors.ErrCodeNotFound,
+		fmt.Sprintf("chart %s-%s of component %s not found in %s",
+			chartName, ref.Version, ref.Name, strings.Join(searched, ", ")),
+		map[string]any{"source": ref.Source})
+}
+
+// fromIndex reads a chart archive through the index.yaml of a local chart repository.
+func fromIndex(repoDir, chartName, version string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(repoDir, "index.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var index struct {
+		Entries map[string][]struct {
+			Version string   `yaml:"version"`
+			URLs    []string `yaml:"urls"`
+		} `yaml:"entries"`
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index.yaml: %w", err)
+	}
+
+	for _, entry := range index.Entries[chartName] {
+		if !sameVersion(entry.Version, version) || len(entry.URLs) == 0 {
+			continue
+		}
+		url := entry.URLs[0]
+		if local, ok := strings.CutPrefix(url, fileScheme); ok {
+			return os.ReadFile(local)
+		}
+		if strings.Contains(url, "://") {
+			return nil, fmt.Errorf("chart %s-%s is not stored locally: %s", chartName, version, url)
+		}
+		return os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(url)))
+	}
+	return nil, fmt.Errorf("chart %s-%s not listed in index.yaml", chartName, version)
+}
+
+// packageChartDir packages a chart directory into a .tgz archive laid out like
+// `helm package` output. The archive is reproducible: entries are sorted and
+// carry no timestamps or ownership.
+func packageChartDir(dir, chartName string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name:     path.Join(chartName, filepath.ToSlash(rel)),
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+			Format:   tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			fmt.Sprintf("failed to package chart directory %s", dir), err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to package chart", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to package chart", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// archiveNames returns the candidate archive file names of a chart version.
+// Chart versions are looked up with and without a leading "v".
+func archiveNames(chartName, version string) []string {
+	versions := []string{version}
+	if trimmed, ok := strings.CutPrefix(version, "v"); ok {
+		versions = append(versions, trimmed)
+	} else if version != "" {
+		versions = append(versions, "v"+version)
+	}
+
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, fmt.Sprintf("%s-%s.tgz", chartName, v))
+	}
+	return names
+}
+
+// sameVersion reports whether two chart versions are equal, ignoring a leading "v".
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
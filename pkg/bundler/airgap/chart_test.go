@@ -0,0 +1,261 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// chartArchive builds a .tgz chart archive from a map of paths to contents.
+func chartArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		content := []byte(files[name])
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// writeChartArchive writes a minimal chart archive to dir/<name>-<version>.tgz.
+func writeChartArchive(t *testing.T, dir, name, version string) string {
+	t.Helper()
+
+	data := chartArchive(t, map[string]string{
+		name + "/Chart.yaml":  "apiVersion: v2\nname: " + name + "\nversion: " + version + "\nappVersion: v1.0.0\n",
+		name + "/values.yaml": "image:\n  repository: nvcr.io/nvidia/" + name + "\n",
+	})
+	p := filepath.Join(dir, name+"-"+version+".tgz")
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLoadChartArchive(t *testing.T) {
+	sub := chartArchive(t, map[string]string{
+		"nfd/Chart.yaml":  "apiVersion: v2\nname: node-feature-discovery\nversion: 0.17.0\n",
+		"nfd/values.yaml": "image:\n  repository: registry.k8s.io/nfd/node-feature-discovery\n  tag: v0.17.0\n",
+	})
+
+	data := chartArchive(t, map[string]string{
+		"operator/Chart.yaml": `apiVersion: v2
+name: operator
+version: 1.2.3
+appVersion: v1.2.3
+dependencies:
+- name: node-feature-discovery
+  alias: nfd
+`,
+		"operator/values.yaml":             "operator:\n  repository: nvcr.io/nvidia\n  image: operator\n",
+		"operator/templates/job.yaml":      "containers:\n- name: hook\n  image: docker.io/bitnami/kubectl:1.30\n",
+		"operator/charts/nfd-0.17.0.tgz":   string(sub),
+		"operator/charts/local/Chart.yaml": "apiVersion: v2\nname: local\nversion: 0.1.0\n",
+	})
+
+	chart, err := LoadChartArchive(data)
+	if err != nil {
+		t.Fatalf("LoadChartArchive() error = %v", err)
+	}
+
+	if chart.Name != "operator" || chart.Version != "1.2.3" || chart.AppVersion != "v1.2.3" {
+		t.Errorf("chart metadata = %s %s %s", chart.Name, chart.Version, chart.AppVersion)
+	}
+	if _, ok := chart.Templates["templates/job.yaml"]; !ok || len(chart.Templates) != 1 {
+		t.Errorf("Templates = %v", chart.Templates)
+	}
+	if dep, ok := chart.dependencies["nfd"]; !ok || dep.Name != "node-feature-discovery" {
+		t.Errorf("dependency not indexed by alias: %v", chart.dependencies)
+	}
+	if _, ok := chart.Subcharts["nfd"]; !ok {
+		t.Errorf("subchart not indexed by alias: %v", chart.Subcharts)
+	}
+	if _, ok := chart.Subcharts["local"]; !ok {
+		t.Errorf("unpacked subchart not loaded: %v", chart.Subcharts)
+	}
+}
+
+func TestLoadChartArchive_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not gzip", []byte("not a chart")},
+		{"no Chart.yaml", chartArchive(t, map[string]string{"chart/values.yaml": "a: b\n"})},
+		{"no name", chartArchive(t, map[string]string{"chart/Chart.yaml": "version: 1.0.0\n"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadChartArchive(tt.data); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestVendorChart(t *testing.T) {
+	cacheDir := t.TempDir()
+	writeChartArchive(t, cacheDir, "gpu-operator", "25.3.0")
+
+	repoDir := t.TempDir()
+	writeChartArchive(t, repoDir, "cert-manager", "1.17.2")
+
+	indexDir := t.TempDir()
+	archivePath := writeChartArchive(t, t.TempDir(), "network-operator", "25.4.0")
+	index := "apiVersion: v1\nentries:\n  network-operator:\n  - version: 25.4.0\n    urls:\n    - file://" + archivePath + "\n"
+	if err := os.WriteFile(filepath.Join(indexDir, "index.yaml"), []byte(index), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	chartDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(chartDir, "templates"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"Chart.yaml":          "apiVersion: v2\nname: dra-driver\nversion: 0.1.0\n",
+		"values.yaml":         "image:\n  repository: nvcr.io/nvidia/dra-driver\n  tag: v0.1.0\n",
+		"templates/ds.yaml":   "kind: DaemonSet\n",
+		"templates/notes.txt": "notes\n",
+	} {
+		if err := os.WriteFile(filepath.Join(chartDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		ref       recipe.ComponentRef
+		chart     string
+		cacheDir  string
+		wantFile  string
+		wantError string
+	}{
+		{
+			name:     "from cache with v prefix",
+			ref:      recipe.ComponentRef{Name: "gpu-operator", Version: "v25.3.0", Source: "https://helm.ngc.nvidia.com/nvidia"},
+			chart:    "gpu-operator",
+			cacheDir: cacheDir,
+			wantFile: "gpu-operator-25.3.0.tgz",
+		},
+		{
+			name:     "from file repository",
+			ref:      recipe.ComponentRef{Name: "cert-manager", Version: "1.17.2", Source: "file://" + repoDir},
+			chart:    "cert-manager",
+			wantFile: "cert-manager-1.17.2.tgz",
+		},
+		{
+			name:     "from file repository index",
+			ref:      recipe.ComponentRef{Name: "network-operator", Version: "25.4.0", Source: "file://" + indexDir},
+			chart:    "network-operator",
+			wantFile: "network-operator-25.4.0.tgz",
+		},
+		{
+			name:     "from chart directory",
+			ref:      recipe.ComponentRef{Name: "nvidia-dra-driver-gpu", Version: "0.1.0", Source: "file://" + chartDir},
+			chart:    "dra-driver",
+			wantFile: "dra-driver-0.1.0.tgz",
+		},
+		{
+			name:      "remote source without cache",
+			ref:       recipe.ComponentRef{Name: "gpu-operator", Version: "25.3.0", Source: "https://helm.ngc.nvidia.com/nvidia"},
+			chart:     "gpu-operator",
+			wantError: "no local source",
+		},
+		{
+			name:      "missing from cache",
+			ref:       recipe.ComponentRef{Name: "gpu-operator", Version: "24.9.0", Source: "https://helm.ngc.nvidia.com/nvidia"},
+			chart:     "gpu-operator",
+			cacheDir:  cacheDir,
+			wantError: "not found",
+		},
+		{
+			name:      "version mismatch",
+			ref:       recipe.ComponentRef{Name: "nvidia-dra-driver-gpu", Version: "0.2.0", Source: "file://" + chartDir},
+			chart:     "dra-driver",
+			wantError: "has version 0.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			got, err := VendorChart(tt.ref, tt.chart, tt.cacheDir, outDir)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("VendorChart() error = %v, want containing %q", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VendorChart() error = %v", err)
+			}
+			if filepath.Base(got) != tt.wantFile {
+				t.Errorf("VendorChart() = %s, want %s", filepath.Base(got), tt.wantFile)
+			}
+			if _, err := LoadChartFile(got); err != nil {
+				t.Errorf("vendored chart does not load: %v", err)
+			}
+		})
+	}
+}
+
+func TestPackageChartDir_Reproducible(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("apiVersion: v2\nname: app\nversion: 1.0.0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := packageChartDir(dir, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := packageChartDir(dir, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("packaging the same chart directory twice produced different archives")
+	}
+}
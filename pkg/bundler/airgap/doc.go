@@ -0,0 +1,61 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package airgap prepares Helm umbrella chart bundles for disconnected sites.
+
+An air-gapped bundle contains everything needed to install the stack without
+network access to the chart repositories: every dependency chart is vendored
+into charts/ as a .tgz archive, so `helm dependency update` is not needed, and
+every container image is listed in images.txt so it can be copied to a mirror
+registry ahead of time.
+
+# Chart Vendoring
+
+VendorChart copies the chart archive of a component into the bundle. Archives
+are looked up in a chart cache directory (a flat directory of
+<chart>-<version>.tgz files such as the Helm repository cache) and in
+file:// component sources. A file:// source may be a chart repository
+directory (with index.yaml or <chart>-<version>.tgz files) or an unpacked chart
+directory, which is packaged reproducibly.
+
+	chartPath, err := airgap.VendorChart(ref, "gpu-operator", cacheDir, filepath.Join(bundleDir, airgap.ChartsDirName))
+
+# Image Enumeration
+
+Chart.Images renders the chart with the bundle values, like `helm template`
+without a cluster, and lists the images of the rendered manifests: container
+images and the image maps of custom resources whose operators deploy further
+images, such as the GPU Operator ClusterPolicy:
+
+	driver:
+	  repository: nvcr.io/nvidia
+	  image: driver
+	  version: "580.65.06"
+
+Templates are rendered with the Sprig and Helm template functions. Subcharts
+are rendered when enabled by their dependency condition or tags; lookup
+returns no objects and .Capabilities reports the built-in Kubernetes APIs.
+
+	images, err := chart.Images(airgap.Release{Name: "gpu-operator"}, values)
+
+# Registry Mirroring
+
+MirrorOverrides rewrites registry value paths, declared per component in the
+component registry (mirror.registryPaths), to point at a mirror registry:
+
+	overrides := airgap.MirrorOverrides(chart, values, cfg.GetMirrorRegistryPaths(), "registry.example.com")
+	// operator.repository: nvcr.io/nvidia -> registry.example.com/nvidia
+*/
+package airgap
@@ -0,0 +1,356 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+)
+
+// ImagesFileName is the name of the image manifest written to air-gapped bundles.
+const ImagesFileName = "images.txt"
+
+// Images returns the container images deployed by the chart when installed
+// as the given release with the given values, which are merged over the chart
+// defaults.
+//
+// The chart is rendered (see Render) and images are read from the rendered
+// manifests: plain image strings, such as container images, and the
+// {registry, repository, image, tag/version, digest} maps of custom resources
+// whose operators deploy further images.
+func (c *Chart) Images(release Release, values map[string]any) ([]string, error) {
+	manifests, err := c.Render(release, values)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for name, manifest := range manifests {
+		if err := manifestImages(manifest, seen); err != nil {
+			return nil, fmt.Errorf("failed to parse rendered template %s: %w", name, err)
+		}
+	}
+	return sortedKeys(seen), nil
+}
+
+// manifestImages adds the images referenced by the documents of a YAML
+// stream to seen.
+func manifestImages(manifest string, seen map[string]bool) error {
+	dec := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		var doc any
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		walkImages(doc, seen)
+	}
+}
+
+// containerListKeys are the pod spec fields listing containers, whose images
+// are taken as is, even without a registry or tag.
+var containerListKeys = map[string]bool{
+	"containers":          true,
+	"initContainers":      true,
+	"ephemeralContainers": true,
+}
+
+// walkImages adds the image references found in a manifest tree to seen.
+func walkImages(value any, seen map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		if image := imageFromMap(v); image != "" {
+			seen[image] = true
+		}
+		for key, child := range v {
+			if containers, ok := child.([]any); ok && containerListKeys[key] {
+				for _, container := range containers {
+					spec, _ := container.(map[string]any)
+					if image := stringValue(spec["image"]); image != "" {
+						seen[image] = true
+					}
+				}
+			}
+			walkImages(child, seen)
+		}
+	case []any:
+		for _, child := range v {
+			walkImages(child, seen)
+		}
+	}
+}
+
+// imageFromMap composes an image reference from a manifest map, or returns ""
+// if the map does not describe an image.
+//
+// Supported layouts:
+//
+//	image: nvcr.io/nvidia/k8s-device-plugin:v0.17.0
+//	{registry: quay.io, repository: prometheus/prometheus, tag: v3.0.0}
+//	{repository: nvcr.io/nvidia, image: gpu-operator, version: v25.3.0}
+//	{repository: ghcr.io/example/app, digest: sha256:...}
+func imageFromMap(m map[string]any) string {
+	repository := stringValue(m["repository"])
+	if repository == "" {
+		image := stringValue(m["image"])
+		if !isImageReference(image) {
+			return ""
+		}
+		if tag := stringValue(m["tag"]); tag != "" && !hasTagOrDigest(image) {
+			image += ":" + tag
+		}
+		return image
+	}
+
+	if strings.Contains(repository, "://") || strings.Contains(repository, "{{") {
+		return ""
+	}
+
+	parts := make([]string, 0, 3)
+	if registry := stringValue(m["registry"]); registry != "" {
+		parts = append(parts, strings.TrimSuffix(registry, "/"))
+	}
+	parts = append(parts, strings.Trim(repository, "/"))
+	if name := stringValue(m["image"]); name != "" {
+		parts = append(parts, strings.Trim(name, "/"))
+	}
+	ref := strings.Join(parts, "/")
+	if strings.Contains(ref, "{{") {
+		return ""
+	}
+
+	if digest := stringValue(m["digest"]); digest != "" {
+		return ref + "@" + digest
+	}
+	if hasTagOrDigest(ref) {
+		return ref
+	}
+
+	tag := stringValue(m["tag"])
+	if tag == "" {
+		tag = stringValue(m["version"])
+	}
+	if tag == "" {
+		return ref
+	}
+	return ref + ":" + tag
+}
+
+// RewriteRegistry rewrites an image reference, repository or registry host to
+// the mirror registry. The registry host of the reference is replaced by the
+// mirror; references without a registry host are prefixed with the mirror.
+// References already under the mirror are returned unchanged.
+//
+//	RewriteRegistry("nvcr.io/nvidia/gpu-operator:v25.3.0", "mirror.local:5000") // mirror.local:5000/nvidia/gpu-operator:v25.3.0
+//	RewriteRegistry("quay.io", "mirror.local:5000")                              // mirror.local:5000
+//	RewriteRegistry("jetstack/cert-manager-controller", "mirror.local:5000")    // mirror.local:5000/jetstack/cert-manager-controller
+func RewriteRegistry(ref, mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if ref == "" || mirror == "" || IsMirrored(ref, mirror) {
+		return ref
+	}
+
+	host, rest, found := strings.Cut(ref, "/")
+	if !found {
+		// A single segment is a registry host ("quay.io") or an image ("busybox:1.36")
+		if isBareRegistry(host) {
+			return mirror
+		}
+		return mirror + "/" + ref
+	}
+	if isRegistryHost(host) {
+		return mirror + "/" + rest
+	}
+	return mirror + "/" + ref
+}
+
+// IsMirrored reports whether an image reference is served by the mirror registry.
+func IsMirrored(ref, mirror string) bool {
+	mirror = strings.TrimSuffix(mirror, "/")
+	return ref == mirror || strings.HasPrefix(ref, mirror+"/")
+}
+
+// MirrorOverrides returns the value overrides that point the given registry
+// value paths of a chart at the mirror registry. Paths are dot-notation paths
+// into the values (e.g. "operator.repository"); each path is resolved against
+// the values merged over the chart and subchart defaults, and paths that do
+// not resolve to a string are skipped.
+func MirrorOverrides(chart *Chart, values map[string]any, paths []string, mirror string) map[string]string {
+	overrides := make(map[string]string)
+
+	for _, p := range paths {
+		current := chart.lookup(values, p)
+		if current == "" || strings.Contains(current, "{{") {
+			continue
+		}
+		if rewritten := RewriteRegistry(current, mirror); rewritten != current {
+			overrides[p] = rewritten
+		}
+	}
+
+	return overrides
+}
+
+// WriteImagesFile writes the image manifest, one reference per line, and
+// returns its path.
+func WriteImagesFile(dir string, images []string) (string, error) {
+	var sb strings.Builder
+	for _, image := range images {
+		sb.WriteString(image)
+		sb.WriteString("\n")
+	}
+
+	imagesPath := filepath.Join(dir, ImagesFileName)
+	if err := os.WriteFile(imagesPath, []byte(sb.String()), 0600); err != nil {
+		return "", errors.Wrap(errors.ErrCodeInternal, "failed to write image manifest", err)
+	}
+	return imagesPath, nil
+}
+
+// MergeValues returns a deep merge of overrides over base. Neither input is modified.
+func MergeValues(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = copyValue(v)
+	}
+	for k, v := range overrides {
+		baseMap, baseOK := merged[k].(map[string]any)
+		overrideMap, overrideOK := v.(map[string]any)
+		if baseOK && overrideOK {
+			merged[k] = MergeValues(baseMap, overrideMap)
+			continue
+		}
+		merged[k] = copyValue(v)
+	}
+	return merged
+}
+
+// copyValue returns a deep copy of maps and slices in a values tree.
+func copyValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		return MergeValues(t, nil)
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = copyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// lookup returns the string at a dot-notation path of the values merged over
+// the chart defaults. Paths below a subchart key fall back to the subchart defaults.
+func (c *Chart) lookup(values map[string]any, path string) string {
+	merged := MergeValues(c.Values, values)
+	if s := lookupPath(merged, path); s != "" {
+		return s
+	}
+
+	key, rest, found := strings.Cut(path, ".")
+	sub, ok := c.Subcharts[key]
+	if !found || !ok {
+		return ""
+	}
+	subValues, _ := merged[key].(map[string]any)
+	return sub.lookup(subValues, rest)
+}
+
+// lookupPath returns the string at a dot-notation path, or "" if there is none.
+func lookupPath(values map[string]any, path string) string {
+	parts := strings.Split(path, ".")
+	current := values
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			return ""
+		}
+		current = next
+	}
+	s, _ := current[parts[len(parts)-1]].(string)
+	return s
+}
+
+// stringValue formats scalar values (tags are often unquoted numbers in values files).
+func stringValue(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any, []any:
+		return ""
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// isImageReference reports whether s looks like a complete image reference
+// rather than a bare name or template expression.
+func isImageReference(s string) bool {
+	return s != "" && !strings.Contains(s, "{{") && !strings.Contains(s, "://") &&
+		strings.ContainsAny(s, "/:") && !strings.ContainsAny(s, " \t")
+}
+
+// hasTagOrDigest reports whether an image reference carries a tag or digest.
+func hasTagOrDigest(ref string) bool {
+	if strings.Contains(ref, "@") {
+		return true
+	}
+	last := ref[strings.LastIndex(ref, "/")+1:]
+	return strings.Contains(last, ":")
+}
+
+// isRegistryHost reports whether the first path segment of a reference is a
+// registry host rather than a Docker Hub namespace.
+func isRegistryHost(segment string) bool {
+	return strings.ContainsAny(segment, ".:") || segment == "localhost"
+}
+
+// isBareRegistry reports whether a single-segment value is a registry host
+// (quay.io, localhost:5000) rather than an image (busybox:1.36).
+func isBareRegistry(segment string) bool {
+	name, port, hasPort := strings.Cut(segment, ":")
+	if hasPort {
+		if _, err := strconv.Atoi(port); err != nil {
+			return false
+		}
+	}
+	return strings.Contains(name, ".") || name == "localhost"
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testChart returns a chart with gpu-operator style values, a custom resource
+// listing operand images and an aliased subchart.
+func testChart() *Chart {
+	return &Chart{
+		Name:       "operator",
+		Version:    "1.0.0",
+		AppVersion: "v1.0.0",
+		Values: map[string]any{
+			"operator": map[string]any{
+				"repository": "nvcr.io/nvidia",
+				"image":      "gpu-operator",
+			},
+			"driver": map[string]any{
+				"enabled":    true,
+				"repository": "nvcr.io/nvidia",
+				"image":      "driver",
+				"version":    "580.65.06",
+			},
+			"unused":     map[string]any{"image": "docker.io/example/unused:1.0"},
+			"helmSource": map[string]any{"repository": "https://helm.ngc.nvidia.com/nvidia"},
+			"nfd":        map[string]any{"enabled": true},
+		},
+		Templates: map[string][]byte{
+			"templates/_helpers.tpl": []byte(`{{- define "operator.image" -}}
+{{ .repository }}/{{ .image }}:{{ .version | default $.Chart.AppVersion }}
+{{- end -}}`),
+			"templates/operator.yaml": []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: gpu-operator
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+      containers:
+      - name: operator
+        image: {{ include "operator.image" (merge (dict "Chart" .Chart) .Values.operator) | quote }}
+`),
+			"templates/clusterpolicy.yaml": []byte(`apiVersion: nvidia.com/v1
+kind: ClusterPolicy
+metadata:
+  name: cluster-policy
+spec:
+  driver:
+    enabled: {{ .Values.driver.enabled }}
+{{- if .Values.driver.enabled }}
+    repository: {{ .Values.driver.repository }}
+    image: {{ .Values.driver.image }}
+    version: {{ .Values.driver.version | quote }}
+{{- end }}
+`),
+			"templates/NOTES.txt": []byte("image: docker.io/example/notes:1.0\n"),
+		},
+		Subcharts: map[string]*Chart{
+			"nfd": {
+				Name: "node-feature-discovery",
+				Values: map[string]any{
+					"image": map[string]any{
+						"repository": "registry.k8s.io/nfd/node-feature-discovery",
+						"tag":        "v0.17.0",
+					},
+				},
+				Templates: map[string][]byte{
+					"templates/ds.yaml": []byte(`apiVersion: apps/v1
+kind: DaemonSet
+spec:
+  template:
+    spec:
+      containers:
+      - name: nfd
+        image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+`),
+				},
+			},
+		},
+		dependencies: map[string]chartDependency{
+			"nfd": {Name: "node-feature-discovery", Alias: "nfd", Condition: "nfd.enabled"},
+		},
+	}
+}
+
+func TestChartImages(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   []string
+	}{
+		{
+			name: "defaults",
+			want: []string{
+				"busybox",
+				"nvcr.io/nvidia/driver:580.65.06",
+				"nvcr.io/nvidia/gpu-operator:v1.0.0",
+				"registry.k8s.io/nfd/node-feature-discovery:v0.17.0",
+			},
+		},
+		{
+			name: "overrides and disabled subchart",
+			values: map[string]any{
+				"operator": map[string]any{"version": "v25.3.0"},
+				"driver":   map[string]any{"version": 570, "repository": "mirror.local/nvidia"},
+				"nfd":      map[string]any{"enabled": false},
+			},
+			want: []string{
+				"busybox",
+				"mirror.local/nvidia/driver:570",
+				"nvcr.io/nvidia/gpu-operator:v25.3.0",
+			},
+		},
+		{
+			name: "disabled operand",
+			values: map[string]any{
+				"driver": map[string]any{"enabled": false},
+			},
+			want: []string{
+				"busybox",
+				"nvcr.io/nvidia/gpu-operator:v1.0.0",
+				"registry.k8s.io/nfd/node-feature-discovery:v0.17.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testChart().Images(Release{Name: "operator"}, tt.values)
+			if err != nil {
+				t.Fatalf("Images() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Images() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChartImages_RenderError(t *testing.T) {
+	chart := &Chart{
+		Name: "app",
+		Templates: map[string][]byte{
+			"templates/deployment.yaml": []byte(`image: {{ required "image is required" .Values.image }}`),
+		},
+	}
+	_, err := chart.Images(Release{Name: "app"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "image is required") {
+		t.Errorf("Images() error = %v, want required value error", err)
+	}
+}
+
+func TestRewriteRegistry(t *testing.T) {
+	tests := []struct {
+		ref    string
+		mirror string
+		want   string
+	}{
+		{"nvcr.io/nvidia/gpu-operator:v25.3.0", "mirror.local:5000", "mirror.local:5000/nvidia/gpu-operator:v25.3.0"},
+		{"nvcr.io/nvidia", "mirror.local:5000/", "mirror.local:5000/nvidia"},
+		{"quay.io", "mirror.local:5000", "mirror.local:5000"},
+		{"jetstack/cert-manager-controller", "mirror.local", "mirror.local/jetstack/cert-manager-controller"},
+		{"busybox:1.36", "mirror.local", "mirror.local/busybox:1.36"},
+		{"localhost/app", "mirror.local", "mirror.local/app"},
+		{"mirror.local/nvidia/driver", "mirror.local", "mirror.local/nvidia/driver"},
+		{"", "mirror.local", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			if got := RewriteRegistry(tt.ref, tt.mirror); got != tt.want {
+				t.Errorf("RewriteRegistry(%q, %q) = %q, want %q", tt.ref, tt.mirror, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMirrorOverrides(t *testing.T) {
+	values := map[string]any{
+		"driver": map[string]any{"repository": "mirror.local/nvidia"},
+	}
+	paths := []string{
+		"operator.repository",
+		"driver.repository",
+		"nfd.image.repository",
+		"missing.repository",
+	}
+
+	got := MirrorOverrides(testChart(), values, paths, "mirror.local")
+	want := map[string]string{
+		"operator.repository":  "mirror.local/nvidia",
+		"nfd.image.repository": "mirror.local/nfd/node-feature-discovery",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MirrorOverrides() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	base := map[string]any{
+		"a": map[string]any{"b": 1, "c": 2},
+		"d": []any{"x"},
+	}
+	overrides := map[string]any{
+		"a": map[string]any{"c": 3},
+		"e": "f",
+	}
+
+	got := MergeValues(base, overrides)
+	want := map[string]any{
+		"a": map[string]any{"b": 1, "c": 3},
+		"d": []any{"x"},
+		"e": "f",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeValues() = %v, want %v", got, want)
+	}
+
+	got["a"].(map[string]any)["b"] = 10
+	if base["a"].(map[string]any)["b"] != 1 {
+		t.Error("MergeValues() modified the base values")
+	}
+}
+
+func TestWriteImagesFile(t *testing.T) {
+	dir := t.TempDir()
+	p, err := WriteImagesFile(dir, []string{"a/b:1", "c/d:2"})
+	if err != nil {
+		t.Fatalf("WriteImagesFile() error = %v", err)
+	}
+	if p != filepath.Join(dir, ImagesFileName) {
+		t.Errorf("WriteImagesFile() path = %s", p)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "a/b:1\nc/d:2\n" {
+		t.Errorf("images.txt = %q", data)
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/sprig/v3"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// defaultKubeVersion is the Kubernetes version reported to templates
+	// through .Capabilities, matching the client libraries as `helm template`
+	// does without a cluster.
+	defaultKubeVersion = "v1.35.0"
+
+	// maxIncludeDepth limits recursive include calls, as in Helm.
+	maxIncludeDepth = 1000
+
+	// noValue is printed by text/template for missing values; Helm removes it.
+	noValue = "<no value>"
+)
+
+// Release describes the Helm release a chart is rendered for.
+type Release struct {
+	// Name is the release name.
+	Name string
+
+	// Namespace is the release namespace, "default" if empty.
+	Namespace string
+}
+
+// Render renders the templates of the chart and its enabled subcharts with
+// the given values merged over the chart defaults, the way `helm template`
+// does without a cluster: lookup returns no objects and .Capabilities reports
+// the built-in API versions. Returns the rendered manifests by template name
+// (e.g. "gpu-operator/templates/clusterpolicy.yaml"); partials and NOTES.txt
+// are not included.
+func (c *Chart) Render(release Release, values map[string]any) (map[string]string, error) {
+	if release.Namespace == "" {
+		release.Namespace = "default"
+	}
+
+	var entries []renderEntry
+	c.collectTemplates(c.Name, release, MergeValues(c.Values, values), &entries)
+
+	// Deeper templates are parsed first so that parent charts override
+	// subchart definitions of the same name
+	sort.SliceStable(entries, func(i, j int) bool {
+		di, dj := strings.Count(entries[i].name, "/"), strings.Count(entries[j].name, "/")
+		if di != dj {
+			return di > dj
+		}
+		return entries[i].name < entries[j].name
+	})
+
+	t := template.New("gotpl").Option("missingkey=zero")
+	t.Funcs(renderFuncs(t))
+	for _, e := range entries {
+		if _, err := t.New(e.name).Parse(string(e.content)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", e.name, err)
+		}
+	}
+
+	rendered := make(map[string]string, len(entries))
+	for _, e := range entries {
+		base := path.Base(e.name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		var buf strings.Builder
+		if err := t.ExecuteTemplate(&buf, e.name, e.scope); err != nil {
+			return nil, fmt.Errorf("failed to render template %s: %w", e.name, err)
+		}
+		rendered[e.name] = strings.ReplaceAll(buf.String(), noValue, "")
+	}
+
+	return rendered, nil
+}
+
+// renderEntry is a template with the scope it is rendered with.
+type renderEntry struct {
+	name    string
+	content []byte
+	scope   map[string]any
+}
+
+// collectTemplates adds the templates of the chart and its enabled subcharts,
+// rooted at chartPath, to entries. Subchart values are exported back into the
+// parent values under the subchart key, as Helm does.
+func (c *Chart) collectTemplates(chartPath string, release Release, values map[string]any, entries *[]renderEntry) {
+	keys := make([]string, 0, len(c.Subcharts))
+	for key := range c.Subcharts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !c.subchartEnabled(key, values) {
+			continue
+		}
+		sub := c.Subcharts[key]
+		subValues, _ := values[key].(map[string]any)
+		merged := MergeValues(sub.Values, subValues)
+		if global, ok := values["global"].(map[string]any); ok {
+			subGlobal, _ := merged["global"].(map[string]any)
+			merged["global"] = MergeValues(subGlobal, global)
+		}
+		values[key] = merged
+		sub.collectTemplates(path.Join(chartPath, ChartsDirName, sub.Name), release, merged, entries)
+	}
+
+	base := map[string]any{
+		"Values": values,
+		"Chart":  c.chartMetadata(),
+		"Release": map[string]any{
+			"Name":      release.Name,
+			"Namespace": release.Namespace,
+			"Service":   "Helm",
+			"Revision":  1,
+			"IsInstall": true,
+			"IsUpgrade": false,
+		},
+		"Capabilities": defaultCapabilities(),
+		"Files":        chartFiles(c.Files),
+	}
+
+	for rel, content := range c.Templates {
+		name := path.Join(chartPath, rel)
+		scope := make(map[string]any, len(base)+1)
+		for k, v := range base {
+			scope[k] = v
+		}
+		scope["Template"] = map[string]any{
+			"Name":     name,
+			"BasePath": path.Join(chartPath, "templates"),
+		}
+		*entries = append(*entries, renderEntry{name: name, content: content, scope: scope})
+	}
+}
+
+// subchartEnabled reports whether the subchart with the given values key is
+// enabled by the condition or tags of its dependency. The first condition path
+// that resolves to a boolean decides; otherwise the dependency is enabled
+// unless all of its tags are disabled.
+func (c *Chart) subchartEnabled(key string, values map[string]any) bool {
+	dep, ok := c.dependencies[key]
+	if !ok {
+		return true
+	}
+
+	for _, condition := range strings.Split(dep.Condition, ",") {
+		if condition = strings.TrimSpace(condition); condition == "" {
+			continue
+		}
+		if enabled, ok := valueAt(values, condition).(bool); ok {
+			return enabled
+		}
+	}
+
+	tags, _ := values["tags"].(map[string]any)
+	found := false
+	for _, tag := range dep.Tags {
+		enabled, ok := tags[tag].(bool)
+		if !ok {
+			continue
+		}
+		if enabled {
+			return true
+		}
+		found = true
+	}
+	return !found
+}
+
+// chartMetadata returns the .Chart object: the Chart.yaml fields with the
+// capitalized names Helm exposes (e.g. .Chart.AppVersion).
+func (c *Chart) chartMetadata() map[string]any {
+	metadata := make(map[string]any, len(c.metadata)+3)
+	for k, v := range c.metadata {
+		switch {
+		case k == "apiVersion":
+			metadata["APIVersion"] = v
+		case k != "":
+			metadata[strings.ToUpper(k[:1])+k[1:]] = v
+		}
+	}
+	metadata["Name"] = c.Name
+	metadata["Version"] = c.Version
+	metadata["AppVersion"] = c.AppVersion
+	return metadata
+}
+
+// valueAt returns the value at a dot-notation path, or nil if there is none.
+func valueAt(values map[string]any, path string) any {
+	var current any = values
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+// capabilities is the .Capabilities object of templates.
+type capabilities struct {
+	KubeVersion kubeVersion
+	APIVersions apiVersions
+}
+
+// kubeVersion is the .Capabilities.KubeVersion object of templates.
+type kubeVersion struct {
+	Version string
+	Major   string
+	Minor   string
+}
+
+// GitVersion returns the Kubernetes version, as reported by the API server.
+func (v kubeVersion) GitVersion() string {
+	return v.Version
+}
+
+// String returns the Kubernetes version.
+func (v kubeVersion) String() string {
+	return v.Version
+}
+
+// apiVersions is the .Capabilities.APIVersions object of templates.
+type apiVersions []string
+
+// Has reports whether a group/version or group/version/kind is available.
+func (a apiVersions) Has(version string) bool {
+	for _, v := range a {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultCapabilities returns the capabilities of a cluster of the default
+// Kubernetes version serving the built-in API versions only.
+var defaultCapabilities = sync.OnceValue(func() *capabilities {
+	seen := make(map[string]bool)
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		gv := gvk.GroupVersion().String()
+		seen[gv] = true
+		seen[gv+"/"+gvk.Kind] = true
+	}
+
+	major, minor, _ := strings.Cut(strings.TrimPrefix(defaultKubeVersion, "v"), ".")
+	minor, _, _ = strings.Cut(minor, ".")
+	return &capabilities{
+		KubeVersion: kubeVersion{Version: defaultKubeVersion, Major: major, Minor: minor},
+		APIVersions: sortedKeys(seen),
+	}
+})
+
+// chartFiles is the .Files object of templates.
+type chartFiles map[string][]byte
+
+// Get returns the content of a file, or "" if there is none.
+func (f chartFiles) Get(name string) string {
+	return string(f[name])
+}
+
+// GetBytes returns the content of a file, or nil if there is none.
+func (f chartFiles) GetBytes(name string) []byte {
+	return f[name]
+}
+
+// Glob returns the files matching a path.Match pattern.
+func (f chartFiles) Glob(pattern string) chartFiles {
+	matched := make(chartFiles)
+	for name, content := range f {
+		if ok, _ := path.Match(pattern, name); ok {
+			matched[name] = content
+		}
+	}
+	return matched
+}
+
+// Lines returns the lines of a file.
+func (f chartFiles) Lines(name string) []string {
+	if len(f[name]) == 0 {
+		return []string{}
+	}
+	return strings.Split(string(f[name]), "\n")
+}
+
+// AsConfig returns the files as ConfigMap data, keyed by base name.
+func (f chartFiles) AsConfig() string {
+	data := make(map[string]string, len(f))
+	for name, content := range f {
+		data[path.Base(name)] = string(content)
+	}
+	return toYAML(data)
+}
+
+// AsSecrets returns the files as base64-encoded Secret data, keyed by base name.
+func (f chartFiles) AsSecrets() string {
+	data := make(map[string][]byte, len(f))
+	for name, content := range f {
+		data[path.Base(name)] = content
+	}
+	return toYAML(data)
+}
+
+// renderFuncs returns the template functions of Helm: the Sprig functions
+// without environment access, and the Helm functions operating on t.
+func renderFuncs(t *template.Template) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	delete(funcs, "env")
+	delete(funcs, "expandenv")
+
+	includeDepth := make(map[string]int)
+	for name, fn := range map[string]any{
+		"toYaml":        toYAML,
+		"fromYaml":      fromYAML,
+		"fromYamlArray": fromYAMLArray,
+		"fromJson":      fromJSON,
+		"fromJsonArray": fromJSONArray,
+		"toToml":        toTOML,
+		"include": func(name string, data any) (string, error) {
+			if includeDepth[name] >= maxIncludeDepth {
+				return "", fmt.Errorf("rendering template has a nested reference name: %s", name)
+			}
+			includeDepth[name]++
+			defer func() { includeDepth[name]-- }()
+
+			var buf strings.Builder
+			if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+		"tpl": func(text string, data any) (string, error) {
+			clone, err := t.Clone()
+			if err != nil {
+				return "", err
+			}
+			tpl, err := clone.New("tpl").Parse(text)
+			if err != nil {
+				return "", fmt.Errorf("cannot parse template %q: %w", text, err)
+			}
+			var buf strings.Builder
+			if err := tpl.Execute(&buf, data); err != nil {
+				return "", fmt.Errorf("error during tpl function execution for %q: %w", text, err)
+			}
+			return strings.ReplaceAll(buf.String(), noValue, ""), nil
+		},
+		"required": func(msg string, value any) (any, error) {
+			if s, ok := value.(string); value == nil || (ok && s == "") {
+				return nil, fmt.Errorf("%s", msg)
+			}
+			return value, nil
+		},
+		"lookup": func(string, string, string, string) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+	} {
+		funcs[name] = fn
+	}
+
+	return funcs
+}
+
+// toYAML encodes a value as YAML without the trailing newline, or returns "".
+func toYAML(v any) string {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// fromYAML decodes a YAML mapping, reporting errors under the "Error" key.
+func fromYAML(s string) map[string]any {
+	m := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+		m["Error"] = err.Error()
+	}
+	return m
+}
+
+// fromYAMLArray decodes a YAML sequence, reporting errors as its only element.
+func fromYAMLArray(s string) []any {
+	var a []any
+	if err := yaml.Unmarshal([]byte(s), &a); err != nil {
+		a = []any{err.Error()}
+	}
+	return a
+}
+
+// fromJSON decodes a JSON object, reporting errors under the "Error" key.
+func fromJSON(s string) map[string]any {
+	m := make(map[string]any)
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		m["Error"] = err.Error()
+	}
+	return m
+}
+
+// fromJSONArray decodes a JSON array, reporting errors as its only element.
+func fromJSONArray(s string) []any {
+	var a []any
+	if err := json.Unmarshal([]byte(s), &a); err != nil {
+		a = []any{err.Error()}
+	}
+	return a
+}
+
+// toTOML encodes a value as TOML, or returns the encoding error.
+func toTOML(v any) string {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return err.Error()
+	}
+	return buf.String()
+}
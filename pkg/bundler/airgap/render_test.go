@@ -0,0 +1,143 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package airgap
+
+import (
+	"testing"
+)
+
+func TestChartRender(t *testing.T) {
+	chart := &Chart{
+		Name:       "app",
+		Version:    "1.0.0",
+		AppVersion: "v2.0.0",
+		Values: map[string]any{
+			"global":  map[string]any{"registry": "nvcr.io"},
+			"message": "{{ .Release.Name }}-{{ .Chart.AppVersion }}",
+		},
+		Templates: map[string][]byte{
+			"templates/_helpers.tpl": []byte(`{{ define "app.name" }}{{ .Chart.Name }}{{ end }}`),
+			"templates/main.yaml": []byte(`name: {{ include "app.name" . }}
+namespace: {{ .Release.Namespace }}
+message: {{ tpl .Values.message . }}
+missing: {{ .Values.missing }}
+policy: {{ .Capabilities.APIVersions.Has "policy/v1" }}
+monitoring: {{ .Capabilities.APIVersions.Has "monitoring.coreos.com/v1" }}
+config: {{ .Files.Get "files/config.txt" }}
+lookup: {{ len (lookup "v1" "Secret" "default" "app") }}
+sub: {{ .Values.sub.image }}
+`),
+			"templates/NOTES.txt": []byte("installed\n"),
+		},
+		Files: map[string][]byte{"files/config.txt": []byte("value")},
+		Subcharts: map[string]*Chart{
+			"sub": {
+				Name:   "sub",
+				Values: map[string]any{"image": "sub"},
+				Templates: map[string][]byte{
+					"templates/sub.yaml": []byte(`registry: {{ .Values.global.registry }}`),
+				},
+			},
+			"tagged": {
+				Name: "tagged",
+				Templates: map[string][]byte{
+					"templates/tagged.yaml": []byte(`tagged: true`),
+				},
+			},
+		},
+		dependencies: map[string]chartDependency{
+			"tagged": {Name: "tagged", Tags: []string{"extras"}},
+		},
+	}
+
+	rendered, err := chart.Render(Release{Name: "rel"}, map[string]any{"tags": map[string]any{"extras": false}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := map[string]string{
+		"app/templates/main.yaml": `name: app
+namespace: default
+message: rel-v2.0.0
+missing: 
+policy: true
+monitoring: false
+config: value
+lookup: 0
+sub: sub
+`,
+		"app/charts/sub/templates/sub.yaml": "registry: nvcr.io",
+	}
+	if len(rendered) != len(want) {
+		t.Errorf("Render() returned %d templates, want %d: %v", len(rendered), len(want), rendered)
+	}
+	for name, content := range want {
+		if rendered[name] != content {
+			t.Errorf("Render()[%s] = %q, want %q", name, rendered[name], content)
+		}
+	}
+}
+
+func TestChartRender_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"parse error", "{{ .Values.a "},
+		{"env function removed", `{{ env "HOME" }}`},
+		{"fail", `{{ fail "unsupported" }}`},
+		{"recursive include", `{{ define "loop" }}{{ include "loop" . }}{{ end }}{{ include "loop" . }}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := &Chart{Name: "app", Templates: map[string][]byte{"templates/t.yaml": []byte(tt.template)}}
+			if _, err := chart.Render(Release{Name: "app"}, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestSubchartEnabled(t *testing.T) {
+	chart := &Chart{
+		dependencies: map[string]chartDependency{
+			"cond":   {Name: "cond", Condition: "missing.enabled, cond.enabled"},
+			"tagged": {Name: "tagged", Tags: []string{"a", "b"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		key    string
+		values map[string]any
+		want   bool
+	}{
+		{"no dependency entry", "other", nil, true},
+		{"condition unset", "cond", nil, true},
+		{"condition false", "cond", map[string]any{"cond": map[string]any{"enabled": false}}, false},
+		{"condition overrides tags", "cond", map[string]any{"cond": map[string]any{"enabled": true}, "tags": map[string]any{"a": false}}, true},
+		{"all tags disabled", "tagged", map[string]any{"tags": map[string]any{"a": false, "b": false}}, false},
+		{"one tag enabled", "tagged", map[string]any{"tags": map[string]any{"a": false, "b": true}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chart.subchartEnabled(tt.key, tt.values); got != tt.want {
+				t.Errorf("subchartEnabled(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
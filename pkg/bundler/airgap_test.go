@@ -0,0 +1,229 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// testChartTemplate deploys the operator image of the test charts and lists
+// the driver image in a custom resource, like the GPU Operator ClusterPolicy.
+const testChartTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+spec:
+  template:
+    spec:
+      containers:
+      - name: operator
+        image: "{{ .Values.operator.repository }}/{{ .Values.operator.image }}:{{ .Values.operator.version }}"
+{{- with .Values.driver }}
+---
+apiVersion: nvidia.com/v1
+kind: ClusterPolicy
+metadata:
+  name: cluster-policy
+spec:
+  driver:
+    {{- toYaml . | nindent 4 }}
+{{- end }}
+`
+
+// writeTestChart writes a chart archive with the given values.yaml to dir.
+func writeTestChart(t *testing.T, dir, name, version, values string) {
+	t.Helper()
+
+	files := []struct{ name, content string }{
+		{name + "/Chart.yaml", "apiVersion: v2\nname: " + name + "\nversion: " + version + "\n"},
+		{name + "/values.yaml", values},
+		{name + "/templates/operator.yaml", testChartTemplate},
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+"-"+version+".tgz"), buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testChartCache returns a chart cache holding the charts of verifyTestRecipe.
+func testChartCache(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeTestChart(t, dir, "gpu-operator", "v25.3.3", `operator:
+  repository: nvcr.io/nvidia
+  image: gpu-operator
+  version: v25.3.3
+driver:
+  repository: nvcr.io/nvidia
+  image: driver
+  version: "550.163.01"
+`)
+	writeTestChart(t, dir, "network-operator", "25.4.0", `operator:
+  repository: nvcr.io/nvidia/cloud-native
+  image: network-operator
+  version: v25.4.0
+`)
+	return dir
+}
+
+func TestMake_Airgap(t *testing.T) {
+	b, err := NewWithConfig(config.NewConfig(
+		config.WithVersion("v1.2.3"),
+		config.WithAirgap(true),
+		config.WithChartCache(testChartCache(t)),
+		config.WithMirrorRegistry("registry.example.com"),
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpuoperator": {"driver.version": "570.133.20"},
+		}),
+	))
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	output, err := b.Make(context.Background(), verifyTestRecipe(), dir)
+	if err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+
+	for _, name := range []string{"gpu-operator-v25.3.3.tgz", "network-operator-25.4.0.tgz"} {
+		if _, statErr := os.Stat(filepath.Join(dir, "charts", name)); statErr != nil {
+			t.Errorf("chart %s not vendored: %v", name, statErr)
+		}
+	}
+
+	images, err := os.ReadFile(filepath.Join(dir, "images.txt"))
+	if err != nil {
+		t.Fatalf("images.txt not written: %v", err)
+	}
+	wantImages := "nvcr.io/nvidia/cloud-native/network-operator:v25.4.0\n" +
+		"nvcr.io/nvidia/driver:570.133.20\n" +
+		"nvcr.io/nvidia/gpu-operator:v25.3.3\n"
+	if string(images) != wantImages {
+		t.Errorf("images.txt = %q, want %q", images, wantImages)
+	}
+
+	values, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"registry.example.com/nvidia", "registry.example.com/nvidia/cloud-native"} {
+		if !strings.Contains(string(values), want) {
+			t.Errorf("values.yaml does not contain mirrored repository %s", want)
+		}
+	}
+
+	for _, step := range output.Deployment.Steps {
+		if strings.Contains(step, "helm dependency update") {
+			t.Errorf("air-gapped deployment steps contain %q", step)
+		}
+	}
+
+	report, err := Verify(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !report.Valid || !report.Regenerated {
+		t.Errorf("Verify() valid = %v, regenerated = %v, issues = %+v, warnings = %v",
+			report.Valid, report.Regenerated, report.Issues, report.Warnings)
+	}
+}
+
+func TestMake_AirgapErrors(t *testing.T) {
+	kustomizeRecipe := verifyTestRecipe()
+	kustomizeRecipe.ComponentRefs = append(kustomizeRecipe.ComponentRefs, recipe.ComponentRef{
+		Name:   "node-config",
+		Type:   recipe.ComponentTypeKustomize,
+		Source: "./node-config",
+		Path:   "overlays/gpu",
+	})
+	kustomizeRecipe.DeploymentOrder = append(kustomizeRecipe.DeploymentOrder, "node-config")
+
+	tests := []struct {
+		name    string
+		opts    []config.Option
+		recipe  *recipe.RecipeResult
+		wantErr string
+	}{
+		{
+			name:    "unsupported deployer",
+			opts:    []config.Option{config.WithDeployer(config.DeployerArgoCD), config.WithChartCache(t.TempDir())},
+			wantErr: "require the helm deployer",
+		},
+		{
+			name:    "no chart source",
+			wantErr: "no local source",
+		},
+		{
+			name:    "chart missing from cache",
+			opts:    []config.Option{config.WithChartCache(t.TempDir())},
+			wantErr: "not found",
+		},
+		{
+			name:    "kustomize component",
+			opts:    []config.Option{config.WithChartCache(t.TempDir())},
+			recipe:  kustomizeRecipe,
+			wantErr: "air-gapped bundles cannot include",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]config.Option{config.WithAirgap(true)}, tt.opts...)
+			b, err := NewWithConfig(config.NewConfig(opts...))
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+
+			rec := tt.recipe
+			if rec == nil {
+				rec = verifyTestRecipe()
+			}
+			_, err = b.Make(context.Background(), rec, t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Make() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
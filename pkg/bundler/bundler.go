@@ -19,12 +19,16 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/airgap"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/argocd"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/flux"
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/helm"
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/result"
 	"github.com/NVIDIA/cloud-native-stack/pkg/component"
@@ -100,7 +104,8 @@ func NewWithConfig(cfg *config.Config) (*DefaultBundler, error) {
 
 // Make generates a deployment bundle from the given recipe.
 // By default, generates a Helm umbrella chart. If deployer is set to "argocd",
-// generates ArgoCD Application manifests.
+// generates ArgoCD Application manifests; if set to "flux", generates Flux
+// sources and releases.
 //
 // For umbrella chart output:
 //   - Chart.yaml: Helm chart metadata with dependencies
@@ -115,6 +120,13 @@ func NewWithConfig(cfg *config.Config) (*DefaultBundler, error) {
 //   - <component>/values.yaml: Values for each component
 //   - README.md: Deployment instructions
 //
+// For Flux output:
+//   - stack.yaml: GitRepository and top-level Flux Kustomization
+//   - kustomization.yaml: Resources applied by the top-level Kustomization
+//   - <component>/source.yaml: Flux source per component
+//   - <component>/helmrelease.yaml: HelmRelease per component
+//   - README.md: Deployment instructions
+//
 // Returns a result.Output summarizing the generation results.
 func (b *DefaultBundler) Make(ctx context.Context, input recipe.RecipeInput, dir string) (*result.Output, error) {
 	start := time.Now()
@@ -136,6 +148,12 @@ func (b *DefaultBundler) Make(ctx context.Context, input recipe.RecipeInput, dir
 			"recipe must contain at least one component reference")
 	}
 
+	if b.Config.Airgap() {
+		if err := b.validateAirgap(recipeResult); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set default output directory
 	if dir == "" {
 		dir = "."
@@ -156,10 +174,31 @@ func (b *DefaultBundler) Make(ctx context.Context, input recipe.RecipeInput, dir
 			"failed to extract component values", err)
 	}
 
-	// Route based on deployer
-	deployer := b.Config.Deployer()
-	if deployer == config.DeployerArgoCD {
+	// Validate component values against the chart values schemas
+	warnings, err := b.validateComponentValues(recipeResult, componentValues)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := b.makeDeployment(ctx, recipeResult, componentValues, dir, start)
+	if err != nil {
+		return nil, err
+	}
+	output.Warnings = warnings
+
+	return output, nil
+}
+
+// makeDeployment generates the bundle of the configured deployer.
+func (b *DefaultBundler) makeDeployment(ctx context.Context, recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, dir string, start time.Time) (*result.Output, error) {
+	switch b.Config.Deployer() {
+	case config.DeployerArgoCD:
 		return b.makeArgoCD(ctx, recipeResult, componentValues, dir, start)
+	case config.DeployerFlux:
+		return b.makeFlux(ctx, recipeResult, componentValues, dir, start)
+	case config.DeployerHelmReleases:
+		return b.makeHelmReleases(ctx, recipeResult, componentValues, dir, start)
+	case config.DeployerHelm:
 	}
 	return b.makeUmbrellaChart(ctx, recipeResult, componentValues, dir, start)
 }
@@ -178,6 +217,23 @@ func (b *DefaultBundler) makeUmbrellaChart(ctx context.Context, recipeResult *re
 			"failed to collect manifest contents", err)
 	}
 
+	// Collect patch contents for Kustomize components
+	patchContents, err := b.collectPatchContents(recipeResult)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to collect patch contents", err)
+	}
+
+	// Vendor dependency charts before values.yaml is generated, so mirror
+	// registry rewrites are part of the chart values
+	var airgapFiles, images []string
+	if b.Config.Airgap() {
+		airgapFiles, images, err = b.vendorCharts(ctx, recipeResult, componentValues, manifestContents, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate umbrella chart
 	generator := helm.NewGenerator()
 	generatorInput := &helm.GeneratorInput{
@@ -186,6 +242,10 @@ func (b *DefaultBundler) makeUmbrellaChart(ctx context.Context, recipeResult *re
 		Version:          b.Config.Version(),
 		IncludeChecksums: b.Config.IncludeChecksums(),
 		ManifestContents: manifestContents,
+		PatchContents:    patchContents,
+		KustomizeSources: b.kustomizeSources(recipeResult),
+		Airgap:           b.Config.Airgap(),
+		MirrorRegistry:   b.Config.MirrorRegistry(),
 	}
 
 	output, err := generator.Generate(ctx, generatorInput, dir)
@@ -194,20 +254,34 @@ func (b *DefaultBundler) makeUmbrellaChart(ctx context.Context, recipeResult *re
 			"failed to generate umbrella chart", err)
 	}
 
-	// Write recipe file
-	recipeSize, err := b.writeRecipeFile(recipeResult, dir)
+	if b.Config.Airgap() {
+		imagesPath, writeErr := airgap.WriteImagesFile(dir, images)
+		if writeErr != nil {
+			return nil, writeErr
+		}
+		airgapFiles = append(airgapFiles, imagesPath)
+
+		airgapSize, sizeErr := fileSizes(airgapFiles)
+		if sizeErr != nil {
+			return nil, sizeErr
+		}
+		output.Files = append(output.Files, airgapFiles...)
+		output.TotalSize += airgapSize
+	}
+
+	// Write recipe and metadata files
+	bundleFiles, bundleSize, err := b.writeBundleFiles(ctx, recipeResult, dir, output.Files)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrCodeInternal,
-			"failed to write recipe file", err)
+		return nil, err
 	}
 
-	// Build result output - includes umbrella chart files + recipe.yaml
+	// Build result output - includes umbrella chart files + recipe.yaml and bundle.yaml
 	resultOutput := &result.Output{
 		Results:       make([]*result.Result, 0),
 		Errors:        make([]result.BundleError, 0),
 		TotalDuration: time.Since(start),
-		TotalSize:     output.TotalSize + recipeSize,
-		TotalFiles:    len(output.Files) + 1, // +1 for recipe.yaml
+		TotalSize:     output.TotalSize + bundleSize,
+		TotalFiles:    len(output.Files) + len(bundleFiles),
 		OutputDir:     dir,
 	}
 
@@ -236,6 +310,86 @@ func (b *DefaultBundler) makeUmbrellaChart(ctx context.Context, recipeResult *re
 	return resultOutput, nil
 }
 
+// makeHelmReleases generates one Helm release per component with ordered deploy scripts.
+func (b *DefaultBundler) makeHelmReleases(ctx context.Context, recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, dir string, start time.Time) (*result.Output, error) {
+	slog.Debug("generating helm releases",
+		"component_count", len(recipeResult.ComponentRefs),
+		"output_dir", dir,
+	)
+
+	// Collect manifest contents from components
+	manifestContents, err := b.collectManifestContents(recipeResult)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to collect manifest contents", err)
+	}
+
+	// Collect patch contents for Kustomize components
+	patchContents, err := b.collectPatchContents(recipeResult)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to collect patch contents", err)
+	}
+
+	// Generate per-component releases
+	generator := helm.NewReleasesGenerator()
+	generatorInput := &helm.GeneratorInput{
+		RecipeResult:     recipeResult,
+		ComponentValues:  componentValues,
+		Version:          b.Config.Version(),
+		IncludeChecksums: b.Config.IncludeChecksums(),
+		ManifestContents: manifestContents,
+		PatchContents:    patchContents,
+		KustomizeSources: b.kustomizeSources(recipeResult),
+	}
+
+	output, err := generator.Generate(ctx, generatorInput, dir)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to generate helm releases", err)
+	}
+
+	// Write recipe and metadata files
+	bundleFiles, bundleSize, err := b.writeBundleFiles(ctx, recipeResult, dir, output.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build result output - includes release files + recipe.yaml and bundle.yaml
+	resultOutput := &result.Output{
+		Results:       make([]*result.Result, 0),
+		Errors:        make([]result.BundleError, 0),
+		TotalDuration: time.Since(start),
+		TotalSize:     output.TotalSize + bundleSize,
+		TotalFiles:    len(output.Files) + len(bundleFiles),
+		OutputDir:     dir,
+	}
+
+	// Add a single result for the releases
+	releasesResult := &result.Result{
+		Type:     "helm-releases",
+		Success:  true,
+		Files:    output.Files,
+		Size:     output.TotalSize,
+		Duration: output.Duration,
+	}
+	resultOutput.Results = append(resultOutput.Results, releasesResult)
+
+	// Populate deployment info from generator output
+	resultOutput.Deployment = &result.DeploymentInfo{
+		Type:  "Helm releases",
+		Steps: output.DeploymentSteps,
+	}
+
+	slog.Debug("helm releases generation complete",
+		"files", len(output.Files),
+		"size_bytes", output.TotalSize,
+		"duration", output.Duration,
+	)
+
+	return resultOutput, nil
+}
+
 // makeArgoCD generates ArgoCD Application manifests.
 func (b *DefaultBundler) makeArgoCD(ctx context.Context, recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, dir string, start time.Time) (*result.Output, error) {
 	slog.Debug("generating argocd applications",
@@ -243,6 +397,13 @@ func (b *DefaultBundler) makeArgoCD(ctx context.Context, recipeResult *recipe.Re
 		"output_dir", dir,
 	)
 
+	// Collect patch contents for Kustomize components
+	patchContents, err := b.collectPatchContents(recipeResult)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to collect patch contents", err)
+	}
+
 	// Generate ArgoCD applications
 	generator := argocd.NewGenerator()
 	generatorInput := &argocd.GeneratorInput{
@@ -251,6 +412,7 @@ func (b *DefaultBundler) makeArgoCD(ctx context.Context, recipeResult *recipe.Re
 		Version:          b.Config.Version(),
 		RepoURL:          b.Config.RepoURL(),
 		IncludeChecksums: b.Config.IncludeChecksums(),
+		PatchContents:    patchContents,
 	}
 
 	output, err := generator.Generate(ctx, generatorInput, dir)
@@ -259,13 +421,19 @@ func (b *DefaultBundler) makeArgoCD(ctx context.Context, recipeResult *recipe.Re
 			"failed to generate argocd applications", err)
 	}
 
-	// Build result output
+	// Write recipe and metadata files
+	bundleFiles, bundleSize, err := b.writeBundleFiles(ctx, recipeResult, dir, output.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build result output - includes ArgoCD files + recipe.yaml and bundle.yaml
 	resultOutput := &result.Output{
 		Results:       make([]*result.Result, 0),
 		Errors:        make([]result.BundleError, 0),
 		TotalDuration: time.Since(start),
-		TotalSize:     output.TotalSize,
-		TotalFiles:    len(output.Files),
+		TotalSize:     output.TotalSize + bundleSize,
+		TotalFiles:    len(output.Files) + len(bundleFiles),
 		OutputDir:     dir,
 	}
 
@@ -295,6 +463,79 @@ func (b *DefaultBundler) makeArgoCD(ctx context.Context, recipeResult *recipe.Re
 	return resultOutput, nil
 }
 
+// makeFlux generates Flux sources, HelmReleases and the top-level Kustomization.
+func (b *DefaultBundler) makeFlux(ctx context.Context, recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any, dir string, start time.Time) (*result.Output, error) {
+	slog.Debug("generating flux resources",
+		"component_count", len(recipeResult.ComponentRefs),
+		"output_dir", dir,
+	)
+
+	// Collect patch contents for Kustomize components
+	patchContents, err := b.collectPatchContents(recipeResult)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to collect patch contents", err)
+	}
+
+	// Generate Flux resources
+	generator := flux.NewGenerator()
+	generatorInput := &flux.GeneratorInput{
+		RecipeResult:     recipeResult,
+		ComponentValues:  componentValues,
+		Version:          b.Config.Version(),
+		RepoURL:          b.Config.RepoURL(),
+		IncludeChecksums: b.Config.IncludeChecksums(),
+		PatchContents:    patchContents,
+	}
+
+	output, err := generator.Generate(ctx, generatorInput, dir)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to generate flux resources", err)
+	}
+
+	// Write recipe and metadata files
+	bundleFiles, bundleSize, err := b.writeBundleFiles(ctx, recipeResult, dir, output.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build result output - includes Flux files + recipe.yaml and bundle.yaml
+	resultOutput := &result.Output{
+		Results:       make([]*result.Result, 0),
+		Errors:        make([]result.BundleError, 0),
+		TotalDuration: time.Since(start),
+		TotalSize:     output.TotalSize + bundleSize,
+		TotalFiles:    len(output.Files) + len(bundleFiles),
+		OutputDir:     dir,
+	}
+
+	// Add a single result for the Flux resources
+	fluxResult := &result.Result{
+		Type:     "flux-resources",
+		Success:  true,
+		Files:    output.Files,
+		Size:     output.TotalSize,
+		Duration: output.Duration,
+	}
+	resultOutput.Results = append(resultOutput.Results, fluxResult)
+
+	// Populate deployment info from generator output
+	resultOutput.Deployment = &result.DeploymentInfo{
+		Type:  "Flux resources",
+		Steps: output.DeploymentSteps,
+		Notes: output.DeploymentNotes,
+	}
+
+	slog.Debug("flux resources generation complete",
+		"files", len(output.Files),
+		"size_bytes", output.TotalSize,
+		"duration", output.Duration,
+	)
+
+	return resultOutput, nil
+}
+
 // extractComponentValues extracts and processes values for each component in the recipe.
 // It loads base values from the recipe, applies user overrides, and applies node selectors.
 func (b *DefaultBundler) extractComponentValues(ctx context.Context, recipeResult *recipe.RecipeResult) (map[string]map[string]any, error) {
@@ -315,6 +556,24 @@ func (b *DefaultBundler) extractComponentValues(ctx context.Context, recipeResul
 			values = make(map[string]any)
 		}
 
+		// Apply user values files from --values flags, then structured
+		// overrides from --set-json flags
+		if b.Config != nil {
+			if files, ok := componentOverrides(b.Config.ValuesFiles(), ref.Name); ok {
+				for _, file := range files {
+					component.MergeMapValues(values, file)
+				}
+			}
+			if overrides, ok := componentOverrides(b.Config.JSONValueOverrides(), ref.Name); ok {
+				if applyErr := component.ApplyTypedMapOverrides(values, overrides); applyErr != nil {
+					slog.Warn("failed to apply some structured value overrides",
+						"component", ref.Name,
+						"error", applyErr,
+					)
+				}
+			}
+		}
+
 		// Apply user value overrides from --set flags
 		if overrides := b.getValueOverridesForComponent(ref.Name); len(overrides) > 0 {
 			if applyErr := component.ApplyMapOverrides(values, overrides); applyErr != nil {
@@ -341,14 +600,22 @@ func (b *DefaultBundler) getValueOverridesForComponent(componentName string) map
 		return nil
 	}
 
-	allOverrides := b.Config.ValueOverrides()
-	if allOverrides == nil {
-		return nil
+	overrides, _ := componentOverrides(b.Config.ValueOverrides(), componentName)
+	return overrides
+}
+
+// componentOverrides returns the entry of a per-component overrides map for a
+// component. Uses the component registry to match both exact names and
+// alternative override keys (e.g., "gpuoperator" for "gpu-operator").
+func componentOverrides[T any](all map[string]T, componentName string) (T, bool) {
+	var zero T
+	if all == nil {
+		return zero, false
 	}
 
 	// Check exact name first
-	if overrides, ok := allOverrides[componentName]; ok {
-		return overrides
+	if overrides, ok := all[componentName]; ok {
+		return overrides, true
 	}
 
 	// Use component registry to find component by any override key
@@ -357,27 +624,27 @@ func (b *DefaultBundler) getValueOverridesForComponent(componentName string) map
 		// Fall back to non-hyphenated check if registry fails
 		nonHyphenated := removeHyphens(componentName)
 		if nonHyphenated != componentName {
-			if overrides, ok := allOverrides[nonHyphenated]; ok {
-				return overrides
+			if overrides, ok := all[nonHyphenated]; ok {
+				return overrides, true
 			}
 		}
-		return nil
+		return zero, false
 	}
 
 	// Get the component config to access its value override keys
 	comp := registry.Get(componentName)
 	if comp == nil {
-		return nil
+		return zero, false
 	}
 
 	// Check each alternative override key
 	for _, key := range comp.ValueOverrideKeys {
-		if overrides, ok := allOverrides[key]; ok {
-			return overrides
+		if overrides, ok := all[key]; ok {
+			return overrides, true
 		}
 	}
 
-	return nil
+	return zero, false
 }
 
 // applyNodeSchedulingOverrides applies node selectors and tolerations to component values.
@@ -431,6 +698,42 @@ func (b *DefaultBundler) applyNodeSchedulingOverrides(componentName string, valu
 	}
 }
 
+// writeBundleFiles writes the recipe and bundle metadata files that allow the
+// bundle to be re-derived and verified, and refreshes checksums.txt so it also
+// covers them. Returns the paths and total size of the written files.
+func (b *DefaultBundler) writeBundleFiles(ctx context.Context, recipeResult *recipe.RecipeResult, dir string, generated []string) ([]string, int64, error) {
+	recipeSize, err := b.writeRecipeFile(recipeResult, dir)
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+			"failed to write recipe file", err)
+	}
+
+	metadataPath, metadataSize, err := b.writeMetadataFile(dir)
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+			"failed to write bundle metadata", err)
+	}
+
+	files := []string{filepath.Join(dir, RecipeFileName), metadataPath}
+
+	if b.Config.IncludeChecksums() {
+		checksumPath := checksum.GetChecksumFilePath(dir)
+		covered := make([]string, 0, len(generated)+len(files))
+		for _, f := range generated {
+			if filepath.Clean(f) != filepath.Clean(checksumPath) {
+				covered = append(covered, f)
+			}
+		}
+		covered = append(covered, files...)
+		if err := checksum.GenerateChecksums(ctx, dir, covered); err != nil {
+			return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+				"failed to generate checksums", err)
+		}
+	}
+
+	return files, recipeSize + metadataSize, nil
+}
+
 // writeRecipeFile serializes the recipe to the bundle directory.
 func (b *DefaultBundler) writeRecipeFile(recipeResult *recipe.RecipeResult, dir string) (int64, error) {
 	recipeData, err := yaml.Marshal(recipeResult)
@@ -438,7 +741,7 @@ func (b *DefaultBundler) writeRecipeFile(recipeResult *recipe.RecipeResult, dir
 		return 0, fmt.Errorf("failed to serialize recipe: %w", err)
 	}
 
-	recipePath := fmt.Sprintf("%s/recipe.yaml", dir)
+	recipePath := filepath.Join(dir, RecipeFileName)
 	if err := os.WriteFile(recipePath, recipeData, 0600); err != nil {
 		return 0, fmt.Errorf("failed to write recipe file: %w", err)
 	}
@@ -479,3 +782,46 @@ func (b *DefaultBundler) collectManifestContents(recipeResult *recipe.RecipeResu
 
 	return contents, nil
 }
+
+// kustomizeSources returns the copies of local Kustomize sources in the
+// configured bundle directory, if any, by component name.
+func (b *DefaultBundler) kustomizeSources(recipeResult *recipe.RecipeResult) map[string]string {
+	dir := b.Config.KustomizeBundleDir()
+	if dir == "" {
+		return nil
+	}
+
+	sources := make(map[string]string)
+	for _, ref := range recipeResult.ComponentRefs {
+		if helm.IsKustomizeComponent(ref) && !helm.IsRemoteKustomizeSource(ref.Source) {
+			sources[ref.Name] = helm.KustomizeSourceCopy(dir, ref.Name)
+		}
+	}
+	return sources
+}
+
+// collectPatchContents gathers patch file contents from all Kustomize components.
+// Patch paths are resolved through the data provider, like manifest files.
+func (b *DefaultBundler) collectPatchContents(recipeResult *recipe.RecipeResult) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+
+	for _, ref := range recipeResult.ComponentRefs {
+		if ref.Type != recipe.ComponentTypeKustomize {
+			continue
+		}
+		for _, patchPath := range ref.Patches {
+			if _, exists := contents[patchPath]; exists {
+				continue
+			}
+
+			content, err := recipe.GetManifestContent(patchPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load patch %s for component %s: %w",
+					patchPath, ref.Name, err)
+			}
+			contents[patchPath] = content
+		}
+	}
+
+	return contents, nil
+}
@@ -226,6 +226,66 @@ func TestMake_WithValueOverrides(t *testing.T) {
 	}
 }
 
+func TestMake_WithStructuredValues(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithValuesFiles(map[string][]map[string]any{
+			"gpu-operator": {{
+				"driver": map[string]any{"version": "570.86.16"},
+				"daemonsets": map[string]any{
+					"tolerations": []any{map[string]any{"operator": "Exists"}},
+				},
+			}},
+		}),
+		config.WithJSONValueOverrides(map[string]map[string]any{
+			"gpuoperator": {
+				"daemonsets.tolerations[1]": map[string]any{"key": "gpu", "effect": "NoSchedule"},
+				"devicePlugin.maxSize":      float64(1048576),
+			},
+		}),
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpu-operator": {"daemonsets.tolerations[0].effect": "NoExecute"},
+		}),
+	)
+	bundler, err := New(WithConfig(cfg))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	recipeResult := &recipe.RecipeResult{
+		APIVersion: "cns.nvidia.com/v1alpha1",
+		Kind:       "Recipe",
+		ComponentRefs: []recipe.ComponentRef{
+			{
+				Name:    "gpu-operator",
+				Version: "v25.3.3",
+				Type:    "helm",
+				Source:  "https://helm.ngc.nvidia.com/nvidia",
+			},
+		},
+	}
+
+	if _, err := bundler.Make(context.Background(), recipeResult, tmpDir); err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read values.yaml: %v", err)
+	}
+	values := string(content)
+	for _, want := range []string{
+		"version: 570.86.16",
+		"effect: NoExecute",
+		"key: gpu",
+		"maxSize: 1048576",
+	} {
+		if !strings.Contains(values, want) {
+			t.Errorf("values.yaml missing %q:\n%s", want, values)
+		}
+	}
+}
+
 func TestMake_WithNodeSelectors(t *testing.T) {
 	cfg := config.NewConfig(
 		config.WithSystemNodeSelector(map[string]string{
@@ -81,3 +81,37 @@ func GenerateChecksums(ctx context.Context, bundleDir string, files []string) er
 func GetChecksumFilePath(bundleDir string) string {
 	return filepath.Join(bundleDir, ChecksumFileName)
 }
+
+// ReadChecksums parses the checksums.txt file in the bundle directory and
+// returns the SHA256 checksum of each file indexed by its relative path.
+func ReadChecksums(bundleDir string) (map[string]string, error) {
+	checksumPath := GetChecksumFilePath(bundleDir)
+	data, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checksums: %w", err)
+	}
+
+	checksums := make(map[string]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		hash, path, ok := strings.Cut(line, "  ")
+		if !ok || len(hash) != sha256.Size*2 || path == "" {
+			return nil, fmt.Errorf("invalid checksum entry at %s:%d", ChecksumFileName, i+1)
+		}
+		checksums[filepath.ToSlash(path)] = hash
+	}
+
+	return checksums, nil
+}
+
+// FileChecksum returns the hex-encoded SHA256 checksum of a file.
+func FileChecksum(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s for checksum: %w", path, err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
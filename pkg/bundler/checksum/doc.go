@@ -27,4 +27,10 @@
 // The checksums.txt file format is compatible with sha256sum:
 //
 //	sha256sum -c checksums.txt
+//
+// ReadChecksums parses an existing checksums.txt and FileChecksum computes the
+// checksum of a single file, which bundle verification uses to detect changes:
+//
+//	expected, err := checksum.ReadChecksums("/path/to/bundle")
+//	actual, err := checksum.FileChecksum("/path/to/bundle/values.yaml")
 package checksum
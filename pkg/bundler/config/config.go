@@ -15,6 +15,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -31,6 +32,10 @@ const (
 	DeployerHelm DeployerType = "helm"
 	// DeployerArgoCD generates ArgoCD App of Apps manifests.
 	DeployerArgoCD DeployerType = "argocd"
+	// DeployerFlux generates Flux sources, HelmReleases and a Kustomization.
+	DeployerFlux DeployerType = "flux"
+	// DeployerHelmReleases generates one Helm release per component with ordered deploy scripts.
+	DeployerHelmReleases DeployerType = "helm-releases"
 )
 
 // ParseDeployerType parses a string into a DeployerType.
@@ -41,6 +46,10 @@ func ParseDeployerType(s string) (DeployerType, error) {
 		return DeployerHelm, nil
 	case string(DeployerArgoCD):
 		return DeployerArgoCD, nil
+	case string(DeployerFlux):
+		return DeployerFlux, nil
+	case string(DeployerHelmReleases):
+		return DeployerHelmReleases, nil
 	default:
 		return "", fmt.Errorf("invalid deployer type %q: must be one of %v", s, GetDeployerTypes())
 	}
@@ -52,6 +61,8 @@ func GetDeployerTypes() []string {
 	types := []string{
 		string(DeployerHelm),
 		string(DeployerArgoCD),
+		string(DeployerFlux),
+		string(DeployerHelmReleases),
 	}
 	sort.Strings(types)
 	return types
@@ -82,6 +93,14 @@ type Config struct {
 	// Map structure: bundler_name -> (path -> value)
 	valueOverrides map[string]map[string]string
 
+	// jsonValueOverrides contains user-specified structured value overrides per bundler.
+	// Map structure: bundler_name -> (path -> decoded JSON value, nil deletes)
+	jsonValueOverrides map[string]map[string]any
+
+	// valuesFiles contains user-specified values documents per bundler, deep
+	// merged in order over the recipe values.
+	valuesFiles map[string][]map[string]any
+
 	// systemNodeSelector contains node selector labels for system components.
 	systemNodeSelector map[string]string
 
@@ -99,6 +118,23 @@ type Config struct {
 
 	// repoURL specifies the Git repository URL for ArgoCD applications.
 	repoURL string
+
+	// airgap vendors dependency charts and lists images for disconnected installs.
+	airgap bool
+
+	// chartCache is a local directory of chart archives used in air-gapped mode.
+	chartCache string
+
+	// mirrorRegistry is the registry that images are rewritten to in air-gapped mode.
+	mirrorRegistry string
+
+	// kustomizeBundleDir is a generated bundle whose copies of local Kustomize
+	// sources are used instead of the component sources.
+	kustomizeBundleDir string
+
+	// strictValues fails the bundle when component values do not match the
+	// chart's values schema instead of reporting warnings.
+	strictValues bool
 }
 
 // Getter methods for read-only access
@@ -138,6 +174,36 @@ func (c *Config) ValueOverrides() map[string]map[string]string {
 	return overrides
 }
 
+// JSONValueOverrides returns a deep copy of the structured value overrides.
+func (c *Config) JSONValueOverrides() map[string]map[string]any {
+	if c.jsonValueOverrides == nil {
+		return nil
+	}
+	overrides := make(map[string]map[string]any, len(c.jsonValueOverrides))
+	for bundler, paths := range c.jsonValueOverrides {
+		overrides[bundler] = make(map[string]any, len(paths))
+		for path, value := range paths {
+			overrides[bundler][path] = copyValue(value)
+		}
+	}
+	return overrides
+}
+
+// ValuesFiles returns a deep copy of the values documents per bundler.
+func (c *Config) ValuesFiles() map[string][]map[string]any {
+	if c.valuesFiles == nil {
+		return nil
+	}
+	files := make(map[string][]map[string]any, len(c.valuesFiles))
+	for bundler, docs := range c.valuesFiles {
+		files[bundler] = make([]map[string]any, len(docs))
+		for i, doc := range docs {
+			files[bundler][i], _ = copyValue(doc).(map[string]any)
+		}
+	}
+	return files
+}
+
 // SystemNodeSelector returns a copy of the system node selector map.
 func (c *Config) SystemNodeSelector() map[string]string {
 	if c.systemNodeSelector == nil {
@@ -182,7 +248,7 @@ func (c *Config) AcceleratedNodeTolerations() []corev1.Toleration {
 	return result
 }
 
-// Deployer returns the deployment method (DeployerHelm or DeployerArgoCD).
+// Deployer returns the deployment method (DeployerHelm, DeployerHelmReleases, DeployerArgoCD or DeployerFlux).
 func (c *Config) Deployer() DeployerType {
 	return c.deployer
 }
@@ -192,6 +258,31 @@ func (c *Config) RepoURL() string {
 	return c.repoURL
 }
 
+// Airgap returns whether the bundle is generated for disconnected installs.
+func (c *Config) Airgap() bool {
+	return c.airgap
+}
+
+// StrictValues returns whether values schema violations fail the bundle.
+func (c *Config) StrictValues() bool {
+	return c.strictValues
+}
+
+// ChartCache returns the local chart archive directory for air-gapped bundles.
+func (c *Config) ChartCache() string {
+	return c.chartCache
+}
+
+// MirrorRegistry returns the mirror registry for air-gapped bundles.
+func (c *Config) MirrorRegistry() string {
+	return c.mirrorRegistry
+}
+
+// KustomizeBundleDir returns the bundle local Kustomize sources are copied from.
+func (c *Config) KustomizeBundleDir() string {
+	return c.kustomizeBundleDir
+}
+
 // Validate checks if the Config has valid settings.
 func (c *Config) Validate() error {
 	return nil
@@ -245,6 +336,38 @@ func WithValueOverrides(overrides map[string]map[string]string) Option {
 	}
 }
 
+// WithJSONValueOverrides sets structured value overrides for the bundler.
+func WithJSONValueOverrides(overrides map[string]map[string]any) Option {
+	return func(c *Config) {
+		if overrides == nil {
+			return
+		}
+		// Deep copy to prevent external modifications
+		for bundler, paths := range overrides {
+			if c.jsonValueOverrides[bundler] == nil {
+				c.jsonValueOverrides[bundler] = make(map[string]any)
+			}
+			for path, value := range paths {
+				c.jsonValueOverrides[bundler][path] = copyValue(value)
+			}
+		}
+	}
+}
+
+// WithValuesFiles appends values documents for the bundler, deep merged in
+// order over the recipe values before --set-json and --set overrides.
+func WithValuesFiles(files map[string][]map[string]any) Option {
+	return func(c *Config) {
+		// Deep copy to prevent external modifications
+		for bundler, docs := range files {
+			for _, doc := range docs {
+				docCopy, _ := copyValue(doc).(map[string]any)
+				c.valuesFiles[bundler] = append(c.valuesFiles[bundler], docCopy)
+			}
+		}
+	}
+}
+
 // WithSystemNodeSelector sets the node selector for system components.
 func WithSystemNodeSelector(selector map[string]string) Option {
 	return func(c *Config) {
@@ -307,15 +430,56 @@ func WithRepoURL(repoURL string) Option {
 	}
 }
 
+// WithAirgap sets whether dependency charts are vendored into the bundle and
+// images are listed for disconnected installs.
+func WithAirgap(enabled bool) Option {
+	return func(c *Config) {
+		c.airgap = enabled
+	}
+}
+
+// WithStrictValues sets whether component values that do not match the chart's
+// values schema fail the bundle. When disabled, violations are reported as warnings.
+func WithStrictValues(enabled bool) Option {
+	return func(c *Config) {
+		c.strictValues = enabled
+	}
+}
+
+// WithChartCache sets the local directory of chart archives for air-gapped bundles.
+func WithChartCache(dir string) Option {
+	return func(c *Config) {
+		c.chartCache = dir
+	}
+}
+
+// WithKustomizeBundleDir sets a generated bundle whose copies of local
+// Kustomize sources are used instead of the component sources, so that the
+// bundle can be re-derived without the original sources.
+func WithKustomizeBundleDir(dir string) Option {
+	return func(c *Config) {
+		c.kustomizeBundleDir = dir
+	}
+}
+
+// WithMirrorRegistry sets the registry that images are rewritten to in air-gapped bundles.
+func WithMirrorRegistry(registry string) Option {
+	return func(c *Config) {
+		c.mirrorRegistry = registry
+	}
+}
+
 // NewConfig returns a Config with default values.
 func NewConfig(options ...Option) *Config {
 	c := &Config{
-		deployer:         DeployerHelm,
-		includeChecksums: true,
-		includeReadme:    true,
-		valueOverrides:   make(map[string]map[string]string),
-		verbose:          false,
-		version:          "dev",
+		deployer:           DeployerHelm,
+		includeChecksums:   true,
+		includeReadme:      true,
+		valueOverrides:     make(map[string]map[string]string),
+		jsonValueOverrides: make(map[string]map[string]any),
+		valuesFiles:        make(map[string][]map[string]any),
+		verbose:            false,
+		version:            "dev",
 	}
 	for _, opt := range options {
 		opt(c)
@@ -362,3 +526,69 @@ func ParseValueOverrides(overrides []string) (map[string]map[string]string, erro
 
 	return result, nil
 }
+
+// ParseJSONValueOverrides parses structured value override strings in format
+// "bundler:path.to.field=<json>", e.g. 'gpuoperator:tolerations=[{"operator":"Exists"}]'.
+// A null value deletes the field. Returns a map of bundler -> (path -> decoded value).
+// This function is used by both CLI and API handlers to parse --set-json flags.
+func ParseJSONValueOverrides(overrides []string) (map[string]map[string]any, error) {
+	result := make(map[string]map[string]any)
+
+	for _, override := range overrides {
+		bundlerName, pathValue, ok := strings.Cut(override, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid format '%s': expected 'bundler:path=json'", override)
+		}
+
+		path, raw, ok := strings.Cut(pathValue, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid format '%s': expected 'bundler:path=json'", override)
+		}
+
+		if bundlerName == "" || path == "" || raw == "" {
+			return nil, fmt.Errorf("invalid format '%s': bundler, path and value cannot be empty", override)
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("invalid JSON value in '%s': %w", override, err)
+		}
+
+		if result[bundlerName] == nil {
+			result[bundlerName] = make(map[string]any)
+		}
+		result[bundlerName][path] = value
+	}
+
+	return result, nil
+}
+
+// ParseValuesFile parses a values file argument in format "bundler=path/to/values.yaml".
+// Returns the bundler name and the file path.
+func ParseValuesFile(arg string) (string, string, error) {
+	bundlerName, path, ok := strings.Cut(arg, "=")
+	if !ok || bundlerName == "" || path == "" {
+		return "", "", fmt.Errorf("invalid format '%s': expected 'bundler=path/to/values.yaml'", arg)
+	}
+	return bundlerName, path, nil
+}
+
+// copyValue returns a deep copy of the maps and lists in a values tree.
+func copyValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			out[k] = copyValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = copyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
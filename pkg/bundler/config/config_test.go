@@ -15,6 +15,7 @@
 package config
 
 import (
+	"reflect"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -90,6 +91,7 @@ func TestAllGetters(t *testing.T) {
 		WithIncludeReadme(true),
 		WithIncludeChecksums(false),
 		WithVerbose(true),
+		WithStrictValues(true),
 	)
 
 	tests := []struct {
@@ -101,6 +103,7 @@ func TestAllGetters(t *testing.T) {
 		{"IncludeReadme", cfg.IncludeReadme(), true, "IncludeReadme()"},
 		{"IncludeChecksums", cfg.IncludeChecksums(), false, "IncludeChecksums()"},
 		{"Verbose", cfg.Verbose(), true, "Verbose()"},
+		{"StrictValues", cfg.StrictValues(), true, "StrictValues()"},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +347,29 @@ func TestDeployerOptions(t *testing.T) {
 			t.Errorf("RepoURL() = %s, want empty string", cfg.RepoURL())
 		}
 	})
+
+	t.Run("air-gapped options", func(t *testing.T) {
+		cfg := NewConfig(
+			WithAirgap(true),
+			WithChartCache("/var/cache/charts"),
+			WithMirrorRegistry("registry.example.com"),
+		)
+		if !cfg.Airgap() {
+			t.Error("Airgap() = false, want true")
+		}
+		if cfg.ChartCache() != "/var/cache/charts" {
+			t.Errorf("ChartCache() = %s, want /var/cache/charts", cfg.ChartCache())
+		}
+		if cfg.MirrorRegistry() != "registry.example.com" {
+			t.Errorf("MirrorRegistry() = %s, want registry.example.com", cfg.MirrorRegistry())
+		}
+	})
+
+	t.Run("air-gapped mode disabled by default", func(t *testing.T) {
+		if NewConfig().Airgap() {
+			t.Error("Airgap() = true, want false")
+		}
+	})
 }
 
 func TestParseValueOverrides(t *testing.T) {
@@ -438,6 +464,103 @@ func TestParseValueOverrides(t *testing.T) {
 	})
 }
 
+func TestParseJSONValueOverrides(t *testing.T) {
+	t.Run("structured values", func(t *testing.T) {
+		result, err := ParseJSONValueOverrides([]string{
+			`gpuoperator:daemonsets.tolerations=[{"operator":"Exists"}]`,
+			`gpuoperator:driver.enabled=true`,
+			`gpuoperator:dcgmExporter.serviceMonitor=null`,
+			`networkoperator:ofedDriver.env={"A":"b=c"}`,
+		})
+		if err != nil {
+			t.Fatalf("ParseJSONValueOverrides() error = %v", err)
+		}
+		want := map[string]map[string]any{
+			"gpuoperator": {
+				"daemonsets.tolerations":      []any{map[string]any{"operator": "Exists"}},
+				"driver.enabled":              true,
+				"dcgmExporter.serviceMonitor": nil,
+			},
+			"networkoperator": {
+				"ofedDriver.env": map[string]any{"A": "b=c"},
+			},
+		}
+		if !reflect.DeepEqual(result, want) {
+			t.Errorf("ParseJSONValueOverrides() = %v, want %v", result, want)
+		}
+	})
+
+	invalid := []string{
+		"invalid-no-colon",
+		"bundler:path-no-equals",
+		"bundler:=true",
+		"bundler:path=",
+		":path=true",
+		"bundler:path={invalid",
+	}
+	for _, override := range invalid {
+		t.Run(override, func(t *testing.T) {
+			if _, err := ParseJSONValueOverrides([]string{override}); err == nil {
+				t.Errorf("ParseJSONValueOverrides(%q) expected error, got nil", override)
+			}
+		})
+	}
+}
+
+func TestParseValuesFile(t *testing.T) {
+	name, path, err := ParseValuesFile("gpu-operator=values/gpu=a.yaml")
+	if err != nil {
+		t.Fatalf("ParseValuesFile() error = %v", err)
+	}
+	if name != "gpu-operator" || path != "values/gpu=a.yaml" {
+		t.Errorf("ParseValuesFile() = (%s, %s), want (gpu-operator, values/gpu=a.yaml)", name, path)
+	}
+
+	for _, arg := range []string{"values.yaml", "=values.yaml", "gpu-operator="} {
+		if _, _, err := ParseValuesFile(arg); err == nil {
+			t.Errorf("ParseValuesFile(%q) expected error, got nil", arg)
+		}
+	}
+}
+
+func TestStructuredValuesImmutability(t *testing.T) {
+	tolerations := []any{map[string]any{"operator": "Exists"}}
+	values := map[string]any{"driver": map[string]any{"version": "570.86.16"}}
+
+	cfg := NewConfig(
+		WithJSONValueOverrides(map[string]map[string]any{"gpuoperator": {"tolerations": tolerations}}),
+		WithValuesFiles(map[string][]map[string]any{"gpu-operator": {values}}),
+		WithValuesFiles(map[string][]map[string]any{"gpu-operator": {{"cdi": map[string]any{"enabled": true}}}}),
+	)
+
+	// Modifying inputs must not affect config
+	tolerations[0] = "modified"
+	values["driver"].(map[string]any)["version"] = "modified"
+
+	jsonOverrides := cfg.JSONValueOverrides()
+	if !reflect.DeepEqual(jsonOverrides["gpuoperator"]["tolerations"], []any{map[string]any{"operator": "Exists"}}) {
+		t.Errorf("JSONValueOverrides() = %v, input modification leaked", jsonOverrides)
+	}
+
+	files := cfg.ValuesFiles()
+	if len(files["gpu-operator"]) != 2 {
+		t.Fatalf("ValuesFiles() len = %d, want 2 (options append)", len(files["gpu-operator"]))
+	}
+	if files["gpu-operator"][0]["driver"].(map[string]any)["version"] != "570.86.16" {
+		t.Errorf("ValuesFiles() = %v, input modification leaked", files)
+	}
+
+	// Modifying returned values must not affect config
+	files["gpu-operator"][0]["driver"].(map[string]any)["version"] = "modified"
+	jsonOverrides["gpuoperator"]["tolerations"] = nil
+	if cfg.ValuesFiles()["gpu-operator"][0]["driver"].(map[string]any)["version"] != "570.86.16" {
+		t.Error("modifying returned values file affected config - not immutable")
+	}
+	if cfg.JSONValueOverrides()["gpuoperator"]["tolerations"] == nil {
+		t.Error("modifying returned overrides affected config - not immutable")
+	}
+}
+
 func TestParseDeployerType(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -454,7 +577,9 @@ func TestParseDeployerType(t *testing.T) {
 		{"helm with spaces", "  helm  ", DeployerHelm, false},
 		{"invalid type", "invalid", "", true},
 		{"empty string", "", "", true},
-		{"flux not supported", "flux", "", true},
+		{"flux lowercase", "flux", DeployerFlux, false},
+		{"flux uppercase", "FLUX", DeployerFlux, false},
+		{"helm-releases lowercase", "helm-releases", DeployerHelmReleases, false},
 	}
 
 	for _, tt := range tests {
@@ -475,8 +600,8 @@ func TestGetDeployerTypes(t *testing.T) {
 	types := GetDeployerTypes()
 
 	// Verify we get the expected types
-	if len(types) != 2 {
-		t.Errorf("GetDeployerTypes() returned %d types, want 2", len(types))
+	if len(types) != 4 {
+		t.Errorf("GetDeployerTypes() returned %d types, want 4", len(types))
 	}
 
 	// Verify types are sorted alphabetically
@@ -498,6 +623,12 @@ func TestGetDeployerTypes(t *testing.T) {
 	if !found[string(DeployerHelm)] {
 		t.Error("GetDeployerTypes() missing 'helm'")
 	}
+	if !found[string(DeployerFlux)] {
+		t.Error("GetDeployerTypes() missing 'flux'")
+	}
+	if !found[string(DeployerHelmReleases)] {
+		t.Error("GetDeployerTypes() missing 'helm-releases'")
+	}
 }
 
 func TestDeployerTypeString(t *testing.T) {
@@ -507,6 +638,8 @@ func TestDeployerTypeString(t *testing.T) {
 	}{
 		{DeployerHelm, "helm"},
 		{DeployerArgoCD, "argocd"},
+		{DeployerFlux, "flux"},
+		{DeployerHelmReleases, "helm-releases"},
 	}
 
 	for _, tt := range tests {
@@ -20,18 +20,22 @@
 //
 // # Configuration Options
 //
-//   - Deployer: Deployment method (DeployerHelm or DeployerArgoCD)
+//   - Deployer: Deployment method (DeployerHelm, DeployerHelmReleases, DeployerArgoCD or DeployerFlux)
 //   - IncludeReadme: Generate deployment documentation
 //   - IncludeChecksums: Generate SHA256 checksums.txt file
 //   - Version: Bundler version string
 //   - ValueOverrides: Per-bundler value overrides from CLI --set flags
+//   - JSONValueOverrides: Per-bundler structured value overrides from CLI --set-json flags
+//   - ValuesFiles: Per-component values files from CLI --values flags
 //   - Verbose: Enable verbose output
 //
 // # Deployer Types
 //
 // DeployerType constants define supported deployment methods:
 //   - DeployerHelm: Generates Helm umbrella charts (default)
+//   - DeployerHelmReleases: Generates one Helm release per component with ordered deploy scripts
 //   - DeployerArgoCD: Generates ArgoCD App of Apps manifests
+//   - DeployerFlux: Generates Flux sources, HelmReleases and a top-level Kustomization
 //
 // Use ParseDeployerType() to parse user input and GetDeployerTypes() for CLI help.
 //
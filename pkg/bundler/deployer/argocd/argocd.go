@@ -30,6 +30,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/helm"
 	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 )
@@ -37,6 +38,9 @@ import (
 //go:embed templates/application.yaml.tmpl
 var applicationTemplate string
 
+//go:embed templates/kustomize-application.yaml.tmpl
+var kustomizeApplicationTemplate string
+
 //go:embed templates/app-of-apps.yaml.tmpl
 var appOfAppsTemplate string
 
@@ -46,6 +50,11 @@ var readmeTemplate string
 // defaultNamespace is the default namespace for component deployment.
 const defaultNamespace = "nvidia-system"
 
+// templateFuncs are the helper functions available to all templates.
+var templateFuncs = template.FuncMap{
+	"indent": indent,
+}
+
 // ApplicationData contains data for rendering an ArgoCD Application.
 type ApplicationData struct {
 	Name       string
@@ -54,6 +63,16 @@ type ApplicationData struct {
 	Chart      string
 	Version    string
 	SyncWave   int
+
+	// Kustomize indicates the component is rendered from a kustomization
+	// instead of a Helm chart.
+	Kustomize bool
+
+	// Path is the kustomization directory within Repository (Kustomize only).
+	Path string
+
+	// Patches holds the inline patch documents (Kustomize only).
+	Patches []string
 }
 
 // AppOfAppsData contains data for rendering the App of Apps manifest.
@@ -87,6 +106,10 @@ type GeneratorInput struct {
 
 	// IncludeChecksums indicates whether to generate a checksums.txt file.
 	IncludeChecksums bool
+
+	// PatchContents maps Kustomize patch file paths to their contents.
+	// Patches are inlined into the Application's kustomize source.
+	PatchContents map[string][]byte
 }
 
 // GeneratorOutput contains the result of ArgoCD Application generation.
@@ -150,6 +173,14 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 			Version:    normalizeVersion(comp.Version),
 			SyncWave:   i, // Use index as sync wave
 		}
+		if comp.Type == recipe.ComponentTypeKustomize {
+			kustomizeData, err := newKustomizeApplicationData(comp, input.PatchContents)
+			if err != nil {
+				return nil, err
+			}
+			kustomizeData.SyncWave = i
+			appData = *kustomizeData
+		}
 		appDataList = append(appDataList, appData)
 	}
 
@@ -168,8 +199,12 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 		}
 
 		// Generate application.yaml
+		tmpl := applicationTemplate
+		if appData.Kustomize {
+			tmpl = kustomizeApplicationTemplate
+		}
 		appPath := filepath.Join(componentDir, "application.yaml")
-		appSize, err := g.generateFromTemplate(applicationTemplate, appData, appPath)
+		appSize, err := g.generateFromTemplate(tmpl, appData, appPath)
 		if err != nil {
 			return nil, errors.Wrap(errors.ErrCodeInternal,
 				fmt.Sprintf("failed to generate application.yaml for %s", appData.Name), err)
@@ -177,6 +212,11 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 		output.Files = append(output.Files, appPath)
 		output.TotalSize += appSize
 
+		// Kustomize applications carry their configuration inline; no values file
+		if appData.Kustomize {
+			continue
+		}
+
 		// Generate values.yaml
 		valuesPath := filepath.Join(componentDir, "values.yaml")
 		values := input.ComponentValues[appData.Name]
@@ -263,7 +303,7 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 
 // generateFromTemplate renders a template to a file.
 func (g *Generator) generateFromTemplate(tmplContent string, data any, outputPath string) (int64, error) {
-	tmpl, err := template.New("template").Parse(tmplContent)
+	tmpl, err := template.New("template").Funcs(templateFuncs).Parse(tmplContent)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -338,6 +378,54 @@ func sortComponentsByDeploymentOrder(refs []recipe.ComponentRef, order []string)
 	return sorted
 }
 
+// newKustomizeApplicationData builds the application data for a Kustomize component.
+// The Git tag is used verbatim as the target revision and patch files are inlined.
+func newKustomizeApplicationData(comp recipe.ComponentRef, patchContents map[string][]byte) (*ApplicationData, error) {
+	// The controller fetches the source itself, so it must be a Git URL
+	if !helm.IsRemoteKustomizeSource(comp.Source) {
+		return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("component %s has a local Kustomize source, which an Argo CD Application cannot fetch", comp.Name),
+			map[string]any{"component": comp.Name, "source": comp.Source})
+	}
+
+	path := comp.Path
+	if path == "" {
+		path = "."
+	}
+
+	patches := make([]string, 0, len(comp.Patches))
+	for _, patchPath := range comp.Patches {
+		content, ok := patchContents[patchPath]
+		if !ok {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("patch %s for component %s not provided", patchPath, comp.Name))
+		}
+		patches = append(patches, strings.TrimRight(string(content), "\n"))
+	}
+
+	return &ApplicationData{
+		Name:       comp.Name,
+		Namespace:  getNamespace(comp),
+		Repository: comp.Source,
+		Version:    comp.Tag,
+		Kustomize:  true,
+		Path:       path,
+		Patches:    patches,
+	}, nil
+}
+
+// indent prefixes every non-empty line of s with the given number of spaces.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // getNamespace returns the namespace for a component.
 func getNamespace(comp recipe.ComponentRef) string {
 	// Use component name as namespace, or default
@@ -21,6 +21,8 @@ import (
 	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 )
 
@@ -615,3 +617,165 @@ func TestGenerate_NoTimestampInOutput(t *testing.T) {
 		t.Fatalf("failed to walk directory: %v", err)
 	}
 }
+
+func TestGenerate_KustomizeComponent(t *testing.T) {
+	g := NewGenerator()
+	outputDir := t.TempDir()
+
+	patch, err := os.ReadFile(filepath.Join("testdata", "kustomize", "patches", "replicas.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read patch fixture: %v", err)
+	}
+
+	recipeResult := &recipe.RecipeResult{}
+	recipeResult.Metadata.Version = testVersion
+	recipeResult.ComponentRefs = []recipe.ComponentRef{
+		{
+			Name:    "cert-manager",
+			Version: "v1.17.2",
+			Type:    recipe.ComponentTypeHelm,
+			Source:  "https://charts.jetstack.io",
+		},
+		{
+			Name:    "example-app",
+			Type:    recipe.ComponentTypeKustomize,
+			Source:  "https://github.com/example/app",
+			Tag:     "v1.2.0",
+			Path:    "testdata/kustomize/base",
+			Patches: []string{"components/example-app/patches/replicas.yaml"},
+		},
+	}
+	recipeResult.DeploymentOrder = []string{"cert-manager", "example-app"}
+
+	input := &GeneratorInput{
+		RecipeResult: recipeResult,
+		ComponentValues: map[string]map[string]any{
+			"cert-manager": {"installCRDs": true},
+		},
+		Version: testVersion,
+		PatchContents: map[string][]byte{
+			"components/example-app/patches/replicas.yaml": patch,
+		},
+	}
+
+	if _, err = g.Generate(context.Background(), input, outputDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Kustomize applications carry no values file
+	if _, statErr := os.Stat(filepath.Join(outputDir, "example-app", "values.yaml")); !os.IsNotExist(statErr) {
+		t.Error("kustomize component should not have values.yaml")
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "example-app", "application.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read application.yaml: %v", err)
+	}
+
+	var app struct {
+		Spec struct {
+			Source struct {
+				RepoURL        string `yaml:"repoURL"`
+				TargetRevision string `yaml:"targetRevision"`
+				Path           string `yaml:"path"`
+				Kustomize      struct {
+					Patches []struct {
+						Patch string `yaml:"patch"`
+					} `yaml:"patches"`
+				} `yaml:"kustomize"`
+			} `yaml:"source"`
+		} `yaml:"spec"`
+	}
+	if err = yaml.Unmarshal(content, &app); err != nil {
+		t.Fatalf("application.yaml is not valid YAML: %v\n%s", err, content)
+	}
+
+	src := app.Spec.Source
+	if src.RepoURL != "https://github.com/example/app" {
+		t.Errorf("repoURL = %q", src.RepoURL)
+	}
+	if src.TargetRevision != "v1.2.0" {
+		t.Errorf("targetRevision = %q, want v1.2.0", src.TargetRevision)
+	}
+	if src.Path != "testdata/kustomize/base" {
+		t.Errorf("path = %q", src.Path)
+	}
+	if len(src.Kustomize.Patches) != 1 {
+		t.Fatalf("expected 1 inline patch, got %d", len(src.Kustomize.Patches))
+	}
+	if got, want := src.Kustomize.Patches[0].Patch, strings.TrimRight(string(patch), "\n"); got != want {
+		t.Errorf("inline patch = %q, want %q", got, want)
+	}
+
+	// The fixture referenced by path must itself be a kustomization
+	if _, statErr := os.Stat(filepath.Join(src.Path, "kustomization.yaml")); statErr != nil {
+		t.Errorf("path does not resolve to a kustomization: %v", statErr)
+	}
+}
+
+func TestGenerate_KustomizeMissingPatch(t *testing.T) {
+	g := NewGenerator()
+
+	recipeResult := &recipe.RecipeResult{}
+	recipeResult.ComponentRefs = []recipe.ComponentRef{
+		{
+			Name:    "example-app",
+			Type:    recipe.ComponentTypeKustomize,
+			Source:  "https://github.com/example/app",
+			Patches: []string{"missing.yaml"},
+		},
+	}
+	recipeResult.DeploymentOrder = []string{"example-app"}
+
+	input := &GeneratorInput{
+		RecipeResult: recipeResult,
+		Version:      testVersion,
+	}
+
+	if _, err := g.Generate(context.Background(), input, t.TempDir()); err == nil {
+		t.Error("expected error for missing patch content")
+	}
+}
+
+func TestGenerate_KustomizeLocalSource(t *testing.T) {
+	recipeResult := &recipe.RecipeResult{}
+	recipeResult.ComponentRefs = []recipe.ComponentRef{
+		{
+			Name:   "example-app",
+			Type:   recipe.ComponentTypeKustomize,
+			Source: "./example-app",
+		},
+	}
+	recipeResult.DeploymentOrder = []string{"example-app"}
+
+	input := &GeneratorInput{
+		RecipeResult: recipeResult,
+		Version:      testVersion,
+	}
+
+	_, err := NewGenerator().Generate(context.Background(), input, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "local Kustomize source") {
+		t.Errorf("Generate() error = %v, want error for local Kustomize source", err)
+	}
+}
+
+func TestIndent(t *testing.T) {
+	tests := []struct {
+		name   string
+		spaces int
+		in     string
+		want   string
+	}{
+		{"single line", 2, "a: b", "  a: b"},
+		{"multi line", 4, "a:\n  b: c", "    a:\n      b: c"},
+		{"blank lines preserved", 2, "a\n\nb", "  a\n\n  b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indent(tt.spaces, tt.in); got != tt.want {
+				t.Errorf("indent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -67,6 +67,13 @@ sync-wave annotations starting from 0.
 	    ├── application.yaml       # ArgoCD Application (sync-wave: 2)
 	    └── values.yaml
 
+# Kustomize Components
+
+Components of type Kustomize are rendered as Applications with a kustomize
+source: repoURL is the component Source, path is its Path and targetRevision
+its Tag. Patch files listed on the component are inlined under
+spec.source.kustomize.patches, so no values.yaml is written for them.
+
 # Configuration
 
 The RepoURL field in GeneratorInput sets the Git repository URL in the
@@ -0,0 +1,89 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package flux provides Flux resource generation for Cloud Native Stack recipes.
+
+The flux package generates Flux sources and releases from RecipeResult objects,
+enabling GitOps-based deployment with the Flux toolkit.
+
+# Overview
+
+For every component the package generates:
+  - A source: HelmRepository for Helm repositories, OCIRepository for charts
+    in OCI registries (oci://...), or GitRepository for Kustomize components
+  - A release: HelmRelease with the component values inlined under spec.values,
+    or a Flux Kustomization for Kustomize components
+  - For Helm components, a Flux Kustomization applying the source and
+    HelmRelease from the bundle repository
+
+A kustomization.yaml lists the Flux Kustomization of every component, and
+stack.yaml contains a GitRepository and the top-level Flux Kustomization that
+applies them.
+
+# Deployment Ordering
+
+Components are generated in the order of the recipe's DeploymentOrder field.
+Flux resolves dependsOn only between objects of one kind, so every component is
+deployed by a Flux Kustomization that lists the Flux Kustomization of the
+previous component in spec.dependsOn. The Flux Kustomization of a Helm
+component health-checks its HelmRelease, so it is ready only once the release
+is installed, and Helm and Kustomize components can depend on each other.
+
+Components install into the namespace declared in the component registry, or
+into a namespace named after the component.
+
+# Usage
+
+	generator := flux.NewGenerator()
+
+	input := &flux.GeneratorInput{
+		RecipeResult:     recipeResult,
+		ComponentValues:  componentValues,
+		Version:          "v0.9.0",
+		RepoURL:          "https://github.com/my-org/my-gitops-repo.git",
+		IncludeChecksums: true,
+	}
+
+	output, err := generator.Generate(ctx, input, "/path/to/output")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+# Generated Structure
+
+	output/
+	├── stack.yaml                 # GitRepository + top-level Kustomization
+	├── kustomization.yaml         # Resources applied by the top-level Kustomization
+	├── README.md                  # Deployment instructions
+	├── checksums.txt              # SHA256 checksums (optional)
+	├── cert-manager/
+	│   ├── source.yaml            # HelmRepository
+	│   ├── helmrelease.yaml       # HelmRelease
+	│   ├── kustomization.yaml     # source.yaml and helmrelease.yaml
+	│   └── flux-kustomization.yaml # Flux Kustomization health-checking the HelmRelease
+	└── gpu-operator/
+	    ├── source.yaml            # HelmRepository
+	    ├── helmrelease.yaml       # HelmRelease
+	    ├── kustomization.yaml     # source.yaml and helmrelease.yaml
+	    └── flux-kustomization.yaml # Flux Kustomization (dependsOn: cert-manager)
+
+# Configuration
+
+The RepoURL field in GeneratorInput sets the Git repository URL in stack.yaml.
+If not provided, a placeholder URL is used that must be updated manually
+before deployment. All Flux objects are created in the flux-system namespace
+and install into the component's target namespace.
+*/
+package flux
@@ -0,0 +1,621 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flux provides Flux source and release generation for recipes.
+package flux
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/deployer/helm"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+//go:embed templates/source.yaml.tmpl
+var sourceTemplate string
+
+//go:embed templates/helmrelease.yaml.tmpl
+var helmReleaseTemplate string
+
+//go:embed templates/flux-kustomization.yaml.tmpl
+var fluxKustomizationTemplate string
+
+//go:embed templates/helm-kustomization.yaml.tmpl
+var helmKustomizationTemplate string
+
+//go:embed templates/stack.yaml.tmpl
+var stackTemplate string
+
+//go:embed templates/README.md.tmpl
+var readmeTemplate string
+
+const (
+	// fluxNamespace is the namespace holding the generated Flux objects.
+	fluxNamespace = "flux-system"
+
+	// stackName is the name of the GitRepository and top-level Kustomization
+	// in stack.yaml.
+	stackName = "nvidia-stack"
+
+	// fluxKustomizationFile is the file of the Flux Kustomization deploying a component.
+	fluxKustomizationFile = "flux-kustomization.yaml"
+
+	// placeholderRepoURL is used in stack.yaml when no repository URL is configured.
+	placeholderRepoURL = "https://github.com/YOUR-ORG/YOUR-REPO.git"
+)
+
+// Source kinds emitted for components.
+const (
+	SourceKindHelmRepository = "HelmRepository"
+	SourceKindOCIRepository  = "OCIRepository"
+	SourceKindGitRepository  = "GitRepository"
+)
+
+// Release kinds emitted for components.
+const (
+	ReleaseKindHelmRelease   = "HelmRelease"
+	ReleaseKindKustomization = "Kustomization"
+)
+
+// ociScheme is the URL scheme of Helm charts stored in OCI registries.
+const ociScheme = "oci://"
+
+// templateFuncs are the helper functions available to all templates.
+var templateFuncs = template.FuncMap{
+	"indent": indent,
+}
+
+// ComponentData contains data for rendering the Flux objects of a component.
+type ComponentData struct {
+	Name          string
+	Namespace     string
+	FluxNamespace string
+
+	// SourceKind is the Flux source kind (HelmRepository, OCIRepository or GitRepository).
+	SourceKind string
+
+	// ReleaseKind is the Flux object that deploys the component
+	// (HelmRelease or Kustomization).
+	ReleaseKind string
+
+	// Repository is the source URL. For OCIRepository sources it includes the chart name.
+	Repository string
+	Chart      string
+	Version    string
+
+	// DependsOn is the component this component waits for, if any. It is
+	// the name of the Flux Kustomization deploying that component.
+	DependsOn string
+
+	// StackName is the GitRepository of the bundle (Helm only).
+	StackName string
+
+	// Values holds the Helm values as a YAML document (Helm only).
+	Values string
+
+	// Kustomize indicates the component is deployed from a kustomization
+	// instead of a Helm chart.
+	Kustomize bool
+
+	// Path is the kustomization directory within Repository, or the
+	// component directory within the bundle for Helm components.
+	Path string
+
+	// Patches holds the inline patch documents (Kustomize only).
+	Patches []string
+}
+
+// StackData contains data for rendering the top-level stack manifest.
+type StackData struct {
+	Name          string
+	FluxNamespace string
+	RepoURL       string
+	Branch        string
+	Path          string
+}
+
+// ReadmeData contains data for rendering the README.
+type ReadmeData struct {
+	RecipeVersion  string
+	BundlerVersion string
+	FluxNamespace  string
+	Components     []ComponentData
+}
+
+// Kustomization is the kustomize.config.k8s.io/v1beta1 Kustomization listing
+// the resources applied by the top-level Flux Kustomization.
+type Kustomization struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Resources  []string `yaml:"resources"`
+}
+
+// GeneratorInput contains all data needed to generate Flux resources.
+type GeneratorInput struct {
+	// RecipeResult contains the recipe metadata and component references.
+	RecipeResult *recipe.RecipeResult
+
+	// ComponentValues maps component names to their values.
+	ComponentValues map[string]map[string]any
+
+	// Version is the generator version.
+	Version string
+
+	// RepoURL is the Git repository URL the top-level Kustomization syncs from.
+	// If empty, a placeholder URL will be used.
+	RepoURL string
+
+	// IncludeChecksums indicates whether to generate a checksums.txt file.
+	IncludeChecksums bool
+
+	// PatchContents maps Kustomize patch file paths to their contents.
+	// Patches are inlined into the component's Flux Kustomization.
+	PatchContents map[string][]byte
+}
+
+// GeneratorOutput contains the result of Flux resource generation.
+type GeneratorOutput struct {
+	// Files contains the paths of generated files.
+	Files []string
+
+	// TotalSize is the total size of all generated files.
+	TotalSize int64
+
+	// Duration is the time taken to generate the resources.
+	Duration time.Duration
+
+	// DeploymentSteps contains ordered deployment instructions for the user.
+	DeploymentSteps []string
+
+	// DeploymentNotes contains optional notes (e.g., "Update repo URL").
+	DeploymentNotes []string
+}
+
+// Generator creates Flux resources from recipe results.
+type Generator struct{}
+
+// NewGenerator creates a new Flux resource generator.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Generate creates Flux sources, releases and the top-level Kustomization
+// from the given input.
+func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputDir string) (*GeneratorOutput, error) {
+	start := time.Now()
+
+	output := &GeneratorOutput{
+		Files: make([]string, 0),
+	}
+
+	if input == nil || input.RecipeResult == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "input and recipe result are required")
+	}
+
+	// Create output directory
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to create output directory", err)
+	}
+
+	components, err := buildComponentData(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate each component's directory and files
+	resources := make([]string, 0, 2*len(components))
+	for _, comp := range components {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(errors.ErrCodeInternal, "context cancelled", ctx.Err())
+		default:
+		}
+
+		componentDir := filepath.Join(outputDir, comp.Name)
+		if err := os.MkdirAll(componentDir, 0755); err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to create directory for %s", comp.Name), err)
+		}
+
+		for _, f := range componentFiles(comp) {
+			filePath := filepath.Join(componentDir, f.name)
+			size, genErr := g.generateFromTemplate(f.tmpl, comp, filePath)
+			if genErr != nil {
+				return nil, errors.Wrap(errors.ErrCodeInternal,
+					fmt.Sprintf("failed to generate %s for %s", f.name, comp.Name), genErr)
+			}
+			output.Files = append(output.Files, filePath)
+			output.TotalSize += size
+			if f.resource {
+				resources = append(resources, path.Join(comp.Name, f.name))
+			}
+		}
+
+		// The Flux Kustomization of a Helm component applies these resources
+		if !comp.Kustomize {
+			componentKustomizationPath := filepath.Join(componentDir, "kustomization.yaml")
+			size, kErr := g.writeKustomization([]string{"source.yaml", "helmrelease.yaml"}, componentKustomizationPath)
+			if kErr != nil {
+				return nil, errors.Wrap(errors.ErrCodeInternal,
+					fmt.Sprintf("failed to generate kustomization.yaml for %s", comp.Name), kErr)
+			}
+			output.Files = append(output.Files, componentKustomizationPath)
+			output.TotalSize += size
+		}
+	}
+
+	// Generate kustomization.yaml listing the resources to apply
+	kustomizationPath := filepath.Join(outputDir, "kustomization.yaml")
+	kustomizationSize, err := g.writeKustomization(resources, kustomizationPath)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to generate kustomization.yaml", err)
+	}
+	output.Files = append(output.Files, kustomizationPath)
+	output.TotalSize += kustomizationSize
+
+	// Generate stack.yaml
+	repoURL := input.RepoURL
+	if repoURL == "" {
+		repoURL = placeholderRepoURL
+	}
+	stackData := StackData{
+		Name:          stackName,
+		FluxNamespace: fluxNamespace,
+		RepoURL:       repoURL,
+		Branch:        "main",
+		Path:          "./",
+	}
+	stackPath := filepath.Join(outputDir, "stack.yaml")
+	stackSize, err := g.generateFromTemplate(stackTemplate, stackData, stackPath)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to generate stack.yaml", err)
+	}
+	output.Files = append(output.Files, stackPath)
+	output.TotalSize += stackSize
+
+	// Generate README.md
+	readmeData := ReadmeData{
+		RecipeVersion:  input.RecipeResult.Metadata.Version,
+		BundlerVersion: input.Version,
+		FluxNamespace:  fluxNamespace,
+		Components:     components,
+	}
+	readmePath := filepath.Join(outputDir, "README.md")
+	readmeSize, err := g.generateFromTemplate(readmeTemplate, readmeData, readmePath)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to generate README.md", err)
+	}
+	output.Files = append(output.Files, readmePath)
+	output.TotalSize += readmeSize
+
+	// Generate checksums if requested
+	if input.IncludeChecksums {
+		if err := checksum.GenerateChecksums(ctx, outputDir, output.Files); err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to generate checksums", err)
+		}
+		checksumPath := checksum.GetChecksumFilePath(outputDir)
+		checksumInfo, statErr := os.Stat(checksumPath)
+		if statErr != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to stat checksums file", statErr)
+		}
+		output.Files = append(output.Files, checksumPath)
+		output.TotalSize += checksumInfo.Size()
+	}
+
+	output.Duration = time.Since(start)
+
+	// Populate deployment steps for CLI output
+	output.DeploymentSteps = []string{
+		"Push the generated files to your GitOps repository",
+		fmt.Sprintf("kubectl apply -f %s/stack.yaml", outputDir),
+		"flux get kustomizations --watch",
+	}
+	// Add note if repo URL needs to be updated
+	if input.RepoURL == "" {
+		output.DeploymentNotes = []string{
+			"Update stack.yaml with your repository URL before applying",
+		}
+	}
+
+	slog.Debug("flux resources generated",
+		"components", len(components),
+		"files", len(output.Files),
+		"size_bytes", output.TotalSize,
+	)
+
+	return output, nil
+}
+
+// componentFile is a templated file of a component directory.
+type componentFile struct {
+	name string
+	tmpl string
+
+	// resource indicates the file is listed in the top-level kustomization.yaml.
+	resource bool
+}
+
+// componentFiles returns the templated files of a component. A Kustomize
+// component is deployed by its Flux Kustomization. A Helm component is wrapped
+// in a Flux Kustomization applying its source and HelmRelease, so that
+// components of both kinds can depend on each other.
+func componentFiles(comp ComponentData) []componentFile {
+	if comp.Kustomize {
+		return []componentFile{
+			{name: "source.yaml", tmpl: sourceTemplate, resource: true},
+			{name: fluxKustomizationFile, tmpl: fluxKustomizationTemplate, resource: true},
+		}
+	}
+	return []componentFile{
+		{name: "source.yaml", tmpl: sourceTemplate},
+		{name: "helmrelease.yaml", tmpl: helmReleaseTemplate},
+		{name: fluxKustomizationFile, tmpl: helmKustomizationTemplate, resource: true},
+	}
+}
+
+// buildComponentData builds the template data of every component in
+// deployment order. Every component is deployed by a Flux Kustomization that
+// depends on the one of the previous component, whatever the component kinds.
+func buildComponentData(input *GeneratorInput) ([]ComponentData, error) {
+	refs := sortComponentsByDeploymentOrder(
+		input.RecipeResult.ComponentRefs,
+		input.RecipeResult.DeploymentOrder,
+	)
+
+	previous := ""
+	components := make([]ComponentData, 0, len(refs))
+	for _, ref := range refs {
+		var (
+			comp *ComponentData
+			err  error
+		)
+		if ref.Type == recipe.ComponentTypeKustomize {
+			comp, err = newKustomizeComponentData(ref, input.PatchContents)
+		} else {
+			comp, err = newHelmComponentData(ref, input.ComponentValues[ref.Name])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		comp.DependsOn = previous
+		previous = comp.Name
+		components = append(components, *comp)
+	}
+
+	return components, nil
+}
+
+// newHelmComponentData builds the data for a Helm component. Charts in OCI
+// registries are served by an OCIRepository; all others by a HelmRepository.
+func newHelmComponentData(ref recipe.ComponentRef, values map[string]any) (*ComponentData, error) {
+	comp := &ComponentData{
+		Name:          ref.Name,
+		Namespace:     resolveNamespace(ref.Name),
+		FluxNamespace: fluxNamespace,
+		SourceKind:    SourceKindHelmRepository,
+		ReleaseKind:   ReleaseKindHelmRelease,
+		Repository:    ref.Source,
+		Chart:         resolveChartName(ref.Name),
+		Version:       ref.Version,
+		StackName:     stackName,
+		Path:          "./" + ref.Name,
+	}
+
+	if strings.HasPrefix(ref.Source, ociScheme) {
+		if ref.Version == "" {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("component %s: OCI chart source requires a version", ref.Name))
+		}
+		comp.SourceKind = SourceKindOCIRepository
+		comp.Repository = strings.TrimSuffix(ref.Source, "/") + "/" + comp.Chart
+	}
+
+	if len(values) > 0 {
+		yamlBytes, err := marshalYAML(values)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to marshal values for %s", ref.Name), err)
+		}
+		comp.Values = strings.TrimRight(string(yamlBytes), "\n")
+	}
+
+	return comp, nil
+}
+
+// newKustomizeComponentData builds the data for a Kustomize component.
+// The Git tag is used as the source revision and patch files are inlined.
+func newKustomizeComponentData(ref recipe.ComponentRef, patchContents map[string][]byte) (*ComponentData, error) {
+	// The controller fetches the source itself, so it must be a Git URL
+	if !helm.IsRemoteKustomizeSource(ref.Source) {
+		return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("component %s has a local Kustomize source, which a Flux GitRepository cannot fetch", ref.Name),
+			map[string]any{"component": ref.Name, "source": ref.Source})
+	}
+
+	kustomizePath := ref.Path
+	if kustomizePath == "" {
+		kustomizePath = "./"
+	}
+
+	patches := make([]string, 0, len(ref.Patches))
+	for _, patchPath := range ref.Patches {
+		content, ok := patchContents[patchPath]
+		if !ok {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("patch %s for component %s not provided", patchPath, ref.Name))
+		}
+		patches = append(patches, strings.TrimRight(string(content), "\n"))
+	}
+
+	return &ComponentData{
+		Name:          ref.Name,
+		Namespace:     resolveNamespace(ref.Name),
+		FluxNamespace: fluxNamespace,
+		SourceKind:    SourceKindGitRepository,
+		ReleaseKind:   ReleaseKindKustomization,
+		Repository:    ref.Source,
+		Version:       ref.Tag,
+		Kustomize:     true,
+		Path:          kustomizePath,
+		Patches:       patches,
+	}, nil
+}
+
+// generateFromTemplate renders a template to a file.
+func (g *Generator) generateFromTemplate(tmplContent string, data any, outputPath string) (int64, error) {
+	tmpl, err := template.New("template").Funcs(templateFuncs).Parse(tmplContent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return 0, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	content := buf.String()
+	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return int64(len(content)), nil
+}
+
+// writeKustomization writes the kustomization.yaml listing the given resources.
+func (g *Generator) writeKustomization(resources []string, outputPath string) (int64, error) {
+	kustomization := Kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	}
+
+	yamlBytes, err := marshalYAML(kustomization)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+
+	content := "# Generated by Cloud Native Stack\n" + string(yamlBytes)
+	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return int64(len(content)), nil
+}
+
+// marshalYAML encodes v as YAML with the two-space indentation used by
+// Kubernetes manifests.
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sortComponentsByDeploymentOrder sorts components based on deployment order.
+// Components missing from the order are placed last, sorted by name.
+func sortComponentsByDeploymentOrder(refs []recipe.ComponentRef, order []string) []recipe.ComponentRef {
+	orderMap := make(map[string]int, len(order))
+	for i, name := range order {
+		orderMap[name] = i
+	}
+
+	sorted := make([]recipe.ComponentRef, len(refs))
+	copy(sorted, refs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		orderI, okI := orderMap[sorted[i].Name]
+		orderJ, okJ := orderMap[sorted[j].Name]
+
+		if !okI && !okJ {
+			return sorted[i].Name < sorted[j].Name
+		}
+		if !okI {
+			return false
+		}
+		if !okJ {
+			return true
+		}
+		return orderI < orderJ
+	})
+
+	return sorted
+}
+
+// resolveChartName returns the Helm chart name for a component: the part of
+// the registry's DefaultChart after the last "/", or the component name.
+func resolveChartName(componentName string) string {
+	registry, err := recipe.GetComponentRegistry()
+	if err != nil {
+		return componentName
+	}
+
+	config := registry.Get(componentName)
+	if config == nil || config.Helm.DefaultChart == "" {
+		return componentName
+	}
+
+	defaultChart := config.Helm.DefaultChart
+	if idx := strings.LastIndex(defaultChart, "/"); idx >= 0 {
+		return defaultChart[idx+1:]
+	}
+	return defaultChart
+}
+
+// indent prefixes every non-empty line of s with the given number of spaces.
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// resolveNamespace returns the namespace a component is installed into: the
+// namespace declared in the component registry, or the component name.
+func resolveNamespace(componentName string) string {
+	registry, err := recipe.GetComponentRegistry()
+	if err != nil {
+		return componentName
+	}
+	if ns := registry.Get(componentName).GetNamespace(); ns != "" {
+		return ns
+	}
+	return componentName
+}
@@ -0,0 +1,450 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// fluxObject is the subset of a Flux object inspected by the tests.
+type fluxObject struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		URL             string `yaml:"url"`
+		TargetNamespace string `yaml:"targetNamespace"`
+		Path            string `yaml:"path"`
+		Ref             struct {
+			Tag    string `yaml:"tag"`
+			Branch string `yaml:"branch"`
+		} `yaml:"ref"`
+		DependsOn []struct {
+			Name string `yaml:"name"`
+		} `yaml:"dependsOn"`
+		Chart struct {
+			Spec struct {
+				Chart     string `yaml:"chart"`
+				Version   string `yaml:"version"`
+				SourceRef struct {
+					Kind string `yaml:"kind"`
+					Name string `yaml:"name"`
+				} `yaml:"sourceRef"`
+			} `yaml:"spec"`
+		} `yaml:"chart"`
+		ChartRef struct {
+			Kind string `yaml:"kind"`
+			Name string `yaml:"name"`
+		} `yaml:"chartRef"`
+		Values  map[string]any `yaml:"values"`
+		Patches []struct {
+			Patch string `yaml:"patch"`
+		} `yaml:"patches"`
+		SourceRef struct {
+			Kind string `yaml:"kind"`
+			Name string `yaml:"name"`
+		} `yaml:"sourceRef"`
+		HealthChecks []struct {
+			Kind      string `yaml:"kind"`
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"healthChecks"`
+	} `yaml:"spec"`
+}
+
+func readObject(t *testing.T, path string) fluxObject {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	var obj fluxObject
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("failed to parse %s: %v\n%s", path, err, data)
+	}
+	return obj
+}
+
+func dependsOn(obj fluxObject) []string {
+	names := make([]string, 0, len(obj.Spec.DependsOn))
+	for _, d := range obj.Spec.DependsOn {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+func readKustomization(t *testing.T, path string) Kustomization {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	var k Kustomization
+	if err := yaml.Unmarshal(data, &k); err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+	return k
+}
+
+func newTestRecipe() *recipe.RecipeResult {
+	rec := &recipe.RecipeResult{}
+	rec.Metadata.Version = "v1.0.0"
+	rec.ComponentRefs = []recipe.ComponentRef{
+		{
+			Name:    "gpu-operator",
+			Version: "v25.3.3",
+			Type:    recipe.ComponentTypeHelm,
+			Source:  "https://helm.ngc.nvidia.com/nvidia",
+		},
+		{
+			Name:    "cert-manager",
+			Version: "v1.17.2",
+			Type:    recipe.ComponentTypeHelm,
+			Source:  "https://charts.jetstack.io",
+		},
+		{
+			Name:    "nvsentinel",
+			Version: "v0.3.0",
+			Type:    recipe.ComponentTypeHelm,
+			Source:  "oci://ghcr.io/nvidia/charts/",
+		},
+	}
+	rec.DeploymentOrder = []string{"cert-manager", "gpu-operator", "nvsentinel"}
+	return rec
+}
+
+func TestGenerate_Success(t *testing.T) {
+	outputDir := t.TempDir()
+
+	input := &GeneratorInput{
+		RecipeResult: newTestRecipe(),
+		ComponentValues: map[string]map[string]any{
+			"gpu-operator": {
+				"driver": map[string]any{"enabled": true},
+			},
+		},
+		Version:          "v0.9.0",
+		RepoURL:          "https://github.com/my-org/gitops.git",
+		IncludeChecksums: true,
+	}
+
+	output, err := NewGenerator().Generate(context.Background(), input, outputDir)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if output.TotalSize == 0 {
+		t.Error("Generate() returned zero total size")
+	}
+	if len(output.DeploymentNotes) != 0 {
+		t.Errorf("DeploymentNotes = %v, want none when RepoURL is set", output.DeploymentNotes)
+	}
+
+	for _, relPath := range []string{
+		"cert-manager/source.yaml",
+		"cert-manager/helmrelease.yaml",
+		"cert-manager/kustomization.yaml",
+		"cert-manager/flux-kustomization.yaml",
+		"gpu-operator/source.yaml",
+		"gpu-operator/helmrelease.yaml",
+		"gpu-operator/kustomization.yaml",
+		"gpu-operator/flux-kustomization.yaml",
+		"nvsentinel/source.yaml",
+		"nvsentinel/helmrelease.yaml",
+		"nvsentinel/kustomization.yaml",
+		"nvsentinel/flux-kustomization.yaml",
+		"kustomization.yaml",
+		"stack.yaml",
+		"README.md",
+		"checksums.txt",
+	} {
+		if _, statErr := os.Stat(filepath.Join(outputDir, relPath)); statErr != nil {
+			t.Errorf("expected file %s: %v", relPath, statErr)
+		}
+	}
+
+	t.Run("helm repository source", func(t *testing.T) {
+		src := readObject(t, filepath.Join(outputDir, "gpu-operator", "source.yaml"))
+		if src.Kind != SourceKindHelmRepository || src.Spec.URL != "https://helm.ngc.nvidia.com/nvidia" {
+			t.Errorf("source = %s %s, want HelmRepository https://helm.ngc.nvidia.com/nvidia", src.Kind, src.Spec.URL)
+		}
+		if src.Metadata.Namespace != fluxNamespace {
+			t.Errorf("namespace = %q, want %q", src.Metadata.Namespace, fluxNamespace)
+		}
+	})
+
+	t.Run("helm release", func(t *testing.T) {
+		rel := readObject(t, filepath.Join(outputDir, "gpu-operator", "helmrelease.yaml"))
+		if rel.Kind != ReleaseKindHelmRelease {
+			t.Errorf("kind = %q, want HelmRelease", rel.Kind)
+		}
+		if rel.Spec.TargetNamespace != "gpu-operator" {
+			t.Errorf("targetNamespace = %q, want gpu-operator", rel.Spec.TargetNamespace)
+		}
+		chart := rel.Spec.Chart.Spec
+		if chart.Chart != "gpu-operator" || chart.Version != "v25.3.3" {
+			t.Errorf("chart = %s@%s, want gpu-operator@v25.3.3", chart.Chart, chart.Version)
+		}
+		if chart.SourceRef.Kind != SourceKindHelmRepository || chart.SourceRef.Name != "gpu-operator" {
+			t.Errorf("sourceRef = %+v, want HelmRepository/gpu-operator", chart.SourceRef)
+		}
+		want := map[string]any{"driver": map[string]any{"enabled": true}}
+		if !reflect.DeepEqual(rel.Spec.Values, want) {
+			t.Errorf("values = %v, want %v", rel.Spec.Values, want)
+		}
+	})
+
+	t.Run("oci repository source", func(t *testing.T) {
+		src := readObject(t, filepath.Join(outputDir, "nvsentinel", "source.yaml"))
+		if src.Kind != SourceKindOCIRepository {
+			t.Errorf("kind = %q, want OCIRepository", src.Kind)
+		}
+		if src.Spec.URL != "oci://ghcr.io/nvidia/charts/nvsentinel" || src.Spec.Ref.Tag != "v0.3.0" {
+			t.Errorf("source = %s:%s, want oci://ghcr.io/nvidia/charts/nvsentinel:v0.3.0", src.Spec.URL, src.Spec.Ref.Tag)
+		}
+		rel := readObject(t, filepath.Join(outputDir, "nvsentinel", "helmrelease.yaml"))
+		if rel.Spec.ChartRef.Kind != SourceKindOCIRepository || rel.Spec.ChartRef.Name != "nvsentinel" {
+			t.Errorf("chartRef = %+v, want OCIRepository/nvsentinel", rel.Spec.ChartRef)
+		}
+		if rel.Spec.Values != nil {
+			t.Errorf("values = %v, want none", rel.Spec.Values)
+		}
+		// The namespace is taken from the component registry
+		if rel.Spec.TargetNamespace != "nvsentinel" {
+			t.Errorf("targetNamespace = %q, want nvsentinel", rel.Spec.TargetNamespace)
+		}
+	})
+
+	t.Run("dependsOn follows deployment order", func(t *testing.T) {
+		for name, want := range map[string][]string{
+			"cert-manager": {},
+			"gpu-operator": {"cert-manager"},
+			"nvsentinel":   {"gpu-operator"},
+		} {
+			ks := readObject(t, filepath.Join(outputDir, name, "flux-kustomization.yaml"))
+			if ks.Kind != ReleaseKindKustomization || ks.Spec.Path != "./"+name || ks.Spec.SourceRef.Name != stackName {
+				t.Errorf("%s wrapper = %s path %q source %q", name, ks.Kind, ks.Spec.Path, ks.Spec.SourceRef.Name)
+			}
+			if got := dependsOn(ks); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s dependsOn = %v, want %v", name, got, want)
+			}
+			if len(ks.Spec.HealthChecks) != 1 || ks.Spec.HealthChecks[0].Kind != ReleaseKindHelmRelease ||
+				ks.Spec.HealthChecks[0].Name != name || ks.Spec.HealthChecks[0].Namespace != fluxNamespace {
+				t.Errorf("%s healthChecks = %+v, want its HelmRelease", name, ks.Spec.HealthChecks)
+			}
+
+			// Ordering is expressed once, on the Flux Kustomization
+			rel := readObject(t, filepath.Join(outputDir, name, "helmrelease.yaml"))
+			if got := dependsOn(rel); len(got) != 0 {
+				t.Errorf("%s HelmRelease dependsOn = %v, want none", name, got)
+			}
+
+			k := readKustomization(t, filepath.Join(outputDir, name, "kustomization.yaml"))
+			if !reflect.DeepEqual(k.Resources, []string{"source.yaml", "helmrelease.yaml"}) {
+				t.Errorf("%s resources = %v", name, k.Resources)
+			}
+		}
+	})
+
+	t.Run("kustomization lists resources", func(t *testing.T) {
+		k := readKustomization(t, filepath.Join(outputDir, "kustomization.yaml"))
+		want := []string{
+			"cert-manager/flux-kustomization.yaml",
+			"gpu-operator/flux-kustomization.yaml",
+			"nvsentinel/flux-kustomization.yaml",
+		}
+		if !reflect.DeepEqual(k.Resources, want) {
+			t.Errorf("resources = %v, want %v", k.Resources, want)
+		}
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		data, readErr := os.ReadFile(filepath.Join(outputDir, "stack.yaml"))
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		docs := strings.Split(string(data), "\n---\n")
+		if len(docs) != 2 {
+			t.Fatalf("stack.yaml has %d documents, want 2", len(docs))
+		}
+		var repo, kustomization fluxObject
+		if err := yaml.Unmarshal([]byte(docs[0]), &repo); err != nil {
+			t.Fatal(err)
+		}
+		if err := yaml.Unmarshal([]byte(docs[1]), &kustomization); err != nil {
+			t.Fatal(err)
+		}
+		if repo.Kind != "GitRepository" || repo.Spec.URL != input.RepoURL {
+			t.Errorf("source = %s %s, want GitRepository %s", repo.Kind, repo.Spec.URL, input.RepoURL)
+		}
+		if kustomization.APIVersion != "kustomize.toolkit.fluxcd.io/v1" || kustomization.Spec.Path != "./" {
+			t.Errorf("kustomization = %s path %q, want kustomize.toolkit.fluxcd.io/v1 path ./",
+				kustomization.APIVersion, kustomization.Spec.Path)
+		}
+	})
+}
+
+func TestGenerate_PlaceholderRepoURL(t *testing.T) {
+	outputDir := t.TempDir()
+
+	output, err := NewGenerator().Generate(context.Background(), &GeneratorInput{
+		RecipeResult: newTestRecipe(),
+	}, outputDir)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "stack.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), placeholderRepoURL) {
+		t.Errorf("stack.yaml does not use the placeholder repository URL:\n%s", data)
+	}
+	if len(output.DeploymentNotes) != 1 {
+		t.Errorf("DeploymentNotes = %v, want a note about the repository URL", output.DeploymentNotes)
+	}
+}
+
+func TestGenerate_KustomizeComponent(t *testing.T) {
+	outputDir := t.TempDir()
+
+	rec := newTestRecipe()
+	rec.ComponentRefs = append(rec.ComponentRefs,
+		recipe.ComponentRef{
+			Name:    "node-config",
+			Type:    recipe.ComponentTypeKustomize,
+			Source:  "https://github.com/example/node-config",
+			Tag:     "v1.2.0",
+			Path:    "deploy/prod",
+			Patches: []string{"components/node-config/patches/replicas.yaml"},
+		},
+		recipe.ComponentRef{
+			Name:   "node-tuning",
+			Type:   recipe.ComponentTypeKustomize,
+			Source: "https://github.com/example/node-tuning",
+		},
+	)
+	// Kustomize and Helm components follow each other
+	rec.DeploymentOrder = []string{"cert-manager", "node-config", "gpu-operator", "nvsentinel", "node-tuning"}
+
+	patch := "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 3\n"
+	_, err := NewGenerator().Generate(context.Background(), &GeneratorInput{
+		RecipeResult: rec,
+		PatchContents: map[string][]byte{
+			"components/node-config/patches/replicas.yaml": []byte(patch),
+		},
+	}, outputDir)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	src := readObject(t, filepath.Join(outputDir, "node-config", "source.yaml"))
+	if src.Kind != SourceKindGitRepository || src.Spec.Ref.Tag != "v1.2.0" {
+		t.Errorf("source = %s tag %q, want GitRepository tag v1.2.0", src.Kind, src.Spec.Ref.Tag)
+	}
+
+	ks := readObject(t, filepath.Join(outputDir, "node-config", "flux-kustomization.yaml"))
+	if ks.Kind != ReleaseKindKustomization || ks.Spec.Path != "deploy/prod" {
+		t.Errorf("kustomization = %s path %q, want Kustomization path deploy/prod", ks.Kind, ks.Spec.Path)
+	}
+	if len(ks.Spec.Patches) != 1 || ks.Spec.Patches[0].Patch != strings.TrimRight(patch, "\n") {
+		t.Errorf("patches = %+v, want the inlined patch", ks.Spec.Patches)
+	}
+	if ks.Spec.TargetNamespace != "node-config" {
+		t.Errorf("targetNamespace = %q, want node-config", ks.Spec.TargetNamespace)
+	}
+	if got := dependsOn(ks); !reflect.DeepEqual(got, []string{"cert-manager"}) {
+		t.Errorf("node-config dependsOn = %v, want [cert-manager]", got)
+	}
+	gpuOperator := readObject(t, filepath.Join(outputDir, "gpu-operator", "flux-kustomization.yaml"))
+	if got := dependsOn(gpuOperator); !reflect.DeepEqual(got, []string{"node-config"}) {
+		t.Errorf("gpu-operator dependsOn = %v, want [node-config]", got)
+	}
+
+	untagged := readObject(t, filepath.Join(outputDir, "node-tuning", "source.yaml"))
+	if untagged.Spec.Ref.Branch != "main" {
+		t.Errorf("untagged source ref = %+v, want branch main", untagged.Spec.Ref)
+	}
+	tuning := readObject(t, filepath.Join(outputDir, "node-tuning", "flux-kustomization.yaml"))
+	if got := dependsOn(tuning); !reflect.DeepEqual(got, []string{"nvsentinel"}) {
+		t.Errorf("node-tuning dependsOn = %v, want [nvsentinel]", got)
+	}
+	if _, statErr := os.Stat(filepath.Join(outputDir, "node-config", "helmrelease.yaml")); !os.IsNotExist(statErr) {
+		t.Error("Kustomize component should not have a helmrelease.yaml")
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	ociWithoutVersion := newTestRecipe()
+	ociWithoutVersion.ComponentRefs[2].Version = ""
+
+	missingPatch := newTestRecipe()
+	missingPatch.ComponentRefs = append(missingPatch.ComponentRefs, recipe.ComponentRef{
+		Name:    "node-config",
+		Type:    recipe.ComponentTypeKustomize,
+		Source:  "https://github.com/example/node-config",
+		Patches: []string{"missing.yaml"},
+	})
+
+	localSource := newTestRecipe()
+	localSource.ComponentRefs = append(localSource.ComponentRefs, recipe.ComponentRef{
+		Name:   "node-config",
+		Type:   recipe.ComponentTypeKustomize,
+		Source: "./node-config",
+	})
+
+	tests := []struct {
+		name    string
+		input   *GeneratorInput
+		wantErr string
+	}{
+		{name: "nil input", input: nil, wantErr: "input and recipe result are required"},
+		{name: "nil recipe", input: &GeneratorInput{}, wantErr: "input and recipe result are required"},
+		{name: "oci source without version", input: &GeneratorInput{RecipeResult: ociWithoutVersion}, wantErr: "requires a version"},
+		{name: "missing patch", input: &GeneratorInput{RecipeResult: missingPatch}, wantErr: "patch missing.yaml"},
+		{name: "local kustomize source", input: &GeneratorInput{RecipeResult: localSource}, wantErr: "local Kustomize source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator().Generate(context.Background(), tt.input, t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerate_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewGenerator().Generate(ctx, &GeneratorInput{RecipeResult: newTestRecipe()}, t.TempDir())
+	if err == nil {
+		t.Error("Generate() expected error for cancelled context")
+	}
+}
@@ -21,6 +21,18 @@
 //   - README.md with deployment instructions
 //   - checksums.txt for verification (optional)
 //
+// Components of type Kustomize are not chart dependencies. Each one is written
+// to kustomize/<name>/kustomization.yaml (with its patches copied alongside, and
+// a local source copied to kustomize/<name>/base) and applied with
+// "kubectl apply -k"; those a chart component depends on are applied before
+// "helm install".
+//
+// ReleasesGenerator is the alternative to the umbrella chart: it installs each
+// component as its own release, in its own namespace and with its own values
+// file. The generated deploy.sh installs the releases in the order returned by
+// ReleaseOrder (dependencyRefs first, deploymentOrder otherwise), waiting for
+// each one to be ready, and undeploy.sh uninstalls them in reverse order.
+//
 // Usage:
 //
 //	generator := helm.NewGenerator()
@@ -29,6 +41,7 @@
 //	    ComponentValues:  componentValues,
 //	    Version:          "1.0.0",
 //	    IncludeChecksums: true,
+//	    PatchContents:    patchContents,
 //	}
 //	output, err := generator.Generate(ctx, input, "/path/to/output")
 package helm
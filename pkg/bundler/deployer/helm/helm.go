@@ -79,6 +79,21 @@ type GeneratorInput struct {
 	// ManifestContents maps manifest file paths to their contents.
 	// These are copied to the chart's templates/ directory.
 	ManifestContents map[string][]byte
+
+	// PatchContents maps Kustomize patch file paths to their contents.
+	// These are copied next to each Kustomize component's kustomization.yaml.
+	PatchContents map[string][]byte
+
+	// KustomizeSources maps Kustomize component names to the directory their
+	// local source is copied from. Defaults to the component source.
+	KustomizeSources map[string]string
+
+	// Airgap indicates that the dependency charts are vendored into charts/,
+	// so the deployment needs no access to the chart repositories.
+	Airgap bool
+
+	// MirrorRegistry is the registry images were rewritten to (air-gapped only).
+	MirrorRegistry string
 }
 
 // GeneratorOutput contains the result of umbrella chart generation.
@@ -158,6 +173,15 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 	output.Files = append(output.Files, templateFiles...)
 	output.TotalSize += templateSize
 
+	// Generate kustomizations for Kustomize components
+	kustomizeFiles, kustomizeSize, err := g.generateKustomizations(ctx, input, outputDir)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to generate kustomizations", err)
+	}
+	output.Files = append(output.Files, kustomizeFiles...)
+	output.TotalSize += kustomizeSize
+
 	// Generate checksums.txt if requested
 	if input.IncludeChecksums {
 		if err := checksum.GenerateChecksums(ctx, outputDir, output.Files); err != nil {
@@ -174,12 +198,18 @@ func (g *Generator) Generate(ctx context.Context, input *GeneratorInput, outputD
 
 	output.Duration = time.Since(start)
 
-	// Populate deployment steps for CLI output
-	output.DeploymentSteps = []string{
-		fmt.Sprintf("cd %s", outputDir),
-		"helm dependency update",
-		"helm install cns-stack . -n cns-stack --create-namespace",
+	// Populate deployment steps for CLI output.
+	// Kustomize components needed by Helm components are applied first.
+	kustomizeBefore, kustomizeAfter := kustomizeDeploymentSteps(input.RecipeResult)
+	output.DeploymentSteps = []string{fmt.Sprintf("cd %s", outputDir)}
+	output.DeploymentSteps = append(output.DeploymentSteps, kustomizeBefore...)
+	if !input.Airgap {
+		output.DeploymentSteps = append(output.DeploymentSteps, "helm dependency update")
 	}
+	output.DeploymentSteps = append(output.DeploymentSteps,
+		"helm install cns-stack . -n cns-stack --create-namespace",
+	)
+	output.DeploymentSteps = append(output.DeploymentSteps, kustomizeAfter...)
 
 	slog.Debug("umbrella chart generated",
 		"files", len(output.Files),
@@ -206,13 +236,14 @@ func (g *Generator) generateChartYAML(ctx context.Context, input *GeneratorInput
 	}
 
 	// Add dependencies in deployment order
+	// Kustomize components are not chart dependencies; they get their own kustomization.
 	for _, name := range input.RecipeResult.DeploymentOrder {
 		ref, ok := componentMap[name]
-		if !ok {
+		if !ok || IsKustomizeComponent(ref) {
 			continue
 		}
 		dep := Dependency{
-			Name:       resolveChartName(ref.Name),
+			Name:       ResolveChartName(ref.Name),
 			Version:    ref.Version,
 			Repository: ref.Source,
 		}
@@ -224,7 +255,10 @@ func (g *Generator) generateChartYAML(ctx context.Context, input *GeneratorInput
 
 	// Add any components not in deployment order (shouldn't happen, but be safe)
 	for _, ref := range input.RecipeResult.ComponentRefs {
-		chartName := resolveChartName(ref.Name)
+		if IsKustomizeComponent(ref) {
+			continue
+		}
+		chartName := ResolveChartName(ref.Name)
 		found := false
 		for _, d := range deps {
 			if d.Name == chartName {
@@ -304,8 +338,17 @@ func (g *Generator) generateValuesYAML(ctx context.Context, input *GeneratorInpu
 	// Structure: component-name -> values
 	values := make(map[string]any)
 
+	// Kustomize components are not sub-charts, so they have no entry in values.yaml
+	skip := make(map[string]bool)
+	for _, ref := range kustomizeComponents(input.RecipeResult) {
+		skip[ref.Name] = true
+	}
+
 	// Add components in deployment order for consistent output
 	for _, name := range input.RecipeResult.DeploymentOrder {
+		if skip[name] {
+			continue
+		}
 		if componentValues, ok := input.ComponentValues[name]; ok {
 			// Add enabled flag (default true)
 			componentWithEnabled := make(map[string]any)
@@ -319,7 +362,7 @@ func (g *Generator) generateValuesYAML(ctx context.Context, input *GeneratorInpu
 
 	// Add any components not in deployment order
 	for name, componentValues := range input.ComponentValues {
-		if _, exists := values[name]; !exists {
+		if _, exists := values[name]; !exists && !skip[name] {
 			componentWithEnabled := make(map[string]any)
 			componentWithEnabled["enabled"] = true
 			for k, v := range componentValues {
@@ -373,9 +416,16 @@ func (g *Generator) generateREADME(ctx context.Context, input *GeneratorInput, o
 		componentMap[ref.Name] = ref
 	}
 
+	// KustomizeInfo describes a Kustomize component applied with kubectl.
+	type KustomizeInfo struct {
+		Name     string
+		Resource string
+		Dir      string
+	}
+
 	components := make([]ComponentInfo, 0, len(input.RecipeResult.DeploymentOrder))
 	for _, name := range input.RecipeResult.DeploymentOrder {
-		if ref, ok := componentMap[name]; ok {
+		if ref, ok := componentMap[name]; ok && !IsKustomizeComponent(ref) {
 			components = append(components, ComponentInfo{
 				Name:       ref.Name,
 				Version:    ref.Version,
@@ -384,6 +434,15 @@ func (g *Generator) generateREADME(ctx context.Context, input *GeneratorInput, o
 		}
 	}
 
+	kustomizations := make([]KustomizeInfo, 0)
+	for _, ref := range kustomizeComponents(input.RecipeResult) {
+		kustomizations = append(kustomizations, KustomizeInfo{
+			Name:     ref.Name,
+			Resource: KustomizeResourceURL(ref),
+			Dir:      fmt.Sprintf("%s/%s", kustomizeDirName, ref.Name),
+		})
+	}
+
 	// Build criteria string for README
 	criteriaLines := []string{}
 	if input.RecipeResult.Criteria != nil {
@@ -409,16 +468,22 @@ func (g *Generator) generateREADME(ctx context.Context, input *GeneratorInput, o
 		RecipeVersion  string
 		BundlerVersion string
 		Components     []ComponentInfo
+		Kustomizations []KustomizeInfo
 		Criteria       []string
 		Constraints    []recipe.Constraint
 		ChartName      string
+		Airgap         bool
+		MirrorRegistry string
 	}{
 		RecipeVersion:  input.RecipeResult.Metadata.Version,
 		BundlerVersion: input.Version,
 		Components:     components,
+		Kustomizations: kustomizations,
 		Criteria:       criteriaLines,
 		Constraints:    constraints,
 		ChartName:      "cns-stack",
+		Airgap:         input.Airgap,
+		MirrorRegistry: input.MirrorRegistry,
 	}
 
 	// Render template
@@ -454,11 +519,24 @@ func normalizeVersion(v string) string {
 	return v
 }
 
-// resolveChartName returns the Helm chart name for a component.
+// ResolveNamespace returns the namespace a component is installed into: the
+// namespace declared in the component registry, or the component name.
+func ResolveNamespace(componentName string) string {
+	registry, err := recipe.GetComponentRegistry()
+	if err != nil {
+		return componentName
+	}
+	if ns := registry.Get(componentName).GetNamespace(); ns != "" {
+		return ns
+	}
+	return componentName
+}
+
+// ResolveChartName returns the Helm chart name for a component.
 // It looks up the component in the registry and extracts the chart name from DefaultChart.
 // The chart name is the part after the last "/" in DefaultChart (e.g., "prometheus-community/kube-prometheus-stack" -> "kube-prometheus-stack").
 // Falls back to the component name if not found in registry or no DefaultChart is set.
-func resolveChartName(componentName string) string {
+func ResolveChartName(componentName string) string {
 	registry, err := recipe.GetComponentRegistry()
 	if err != nil {
 		return componentName
@@ -202,6 +202,41 @@ func TestGenerate_WithChecksums(t *testing.T) {
 	}
 }
 
+func TestGenerate_Airgap(t *testing.T) {
+	g := NewGenerator()
+	ctx := context.Background()
+	outputDir := t.TempDir()
+
+	input := &GeneratorInput{
+		RecipeResult:   createTestRecipeResult(),
+		Version:        "v1.0.0",
+		Airgap:         true,
+		MirrorRegistry: "registry.example.com",
+	}
+
+	output, err := g.Generate(ctx, input, outputDir)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, step := range output.DeploymentSteps {
+		if strings.Contains(step, "helm dependency update") {
+			t.Errorf("air-gapped deployment steps contain %q", step)
+		}
+	}
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README.md: %v", err)
+	}
+	if strings.Contains(string(readme), "helm repo add") {
+		t.Error("air-gapped README.md contains helm repo add")
+	}
+	if !strings.Contains(string(readme), "images.txt") || !strings.Contains(string(readme), "registry.example.com") {
+		t.Error("air-gapped README.md missing image mirroring instructions")
+	}
+}
+
 func TestNormalizeVersion(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -258,9 +293,9 @@ func TestResolveChartName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := resolveChartName(tt.componentName)
+			result := ResolveChartName(tt.componentName)
 			if result != tt.expected {
-				t.Errorf("resolveChartName(%q) = %q, want %q", tt.componentName, result, tt.expected)
+				t.Errorf("ResolveChartName(%q) = %q, want %q", tt.componentName, result, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,340 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+const (
+	// kustomizeDirName is the bundle subdirectory holding Kustomize components.
+	kustomizeDirName = "kustomize"
+
+	// kustomizationFileName is the file name kustomize looks for in a directory.
+	kustomizationFileName = "kustomization.yaml"
+
+	// patchesDirName is the subdirectory (per component) holding patch files.
+	patchesDirName = "patches"
+
+	// kustomizeBaseDirName is the subdirectory (per component) holding the copy
+	// of a local Kustomize source.
+	kustomizeBaseDirName = "base"
+)
+
+// Kustomization is the subset of the kustomize.config.k8s.io/v1beta1
+// Kustomization resource generated for Kustomize components.
+type Kustomization struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Resources  []string         `yaml:"resources"`
+	Patches    []KustomizePatch `yaml:"patches,omitempty"`
+}
+
+// KustomizePatch references a patch file relative to the kustomization.
+type KustomizePatch struct {
+	Path string `yaml:"path"`
+}
+
+// IsKustomizeComponent reports whether the component is deployed with Kustomize
+// rather than as a dependency of the umbrella chart.
+func IsKustomizeComponent(ref recipe.ComponentRef) bool {
+	return ref.Type == recipe.ComponentTypeKustomize
+}
+
+// KustomizeResourceURL builds the kustomize resource reference for a component.
+//
+// Remote sources use the kustomize remote target syntax, with Path appended as
+// a "//" subdirectory and Tag as the "ref" query parameter:
+//
+//	https://github.com/example/app//deploy/prod?ref=v1.0.0
+//
+// Local sources (no URL scheme) are joined with Path and returned as-is. Bundles
+// reference a copy of them (see KustomizeSourceCopy), so that they can be
+// rendered without network access wherever the bundle is moved.
+func KustomizeResourceURL(ref recipe.ComponentRef) string {
+	if !IsRemoteKustomizeSource(ref.Source) {
+		if ref.Path == "" {
+			return ref.Source
+		}
+		return filepath.Join(ref.Source, ref.Path)
+	}
+
+	target := strings.TrimSuffix(ref.Source, "/")
+	if p := strings.Trim(ref.Path, "/"); p != "" {
+		target = target + "//" + p
+	}
+	if ref.Tag != "" {
+		target = target + "?ref=" + ref.Tag
+	}
+	return target
+}
+
+// IsRemoteKustomizeSource reports whether the source is a remote Git target.
+func IsRemoteKustomizeSource(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@")
+}
+
+// KustomizeSourceCopy returns the directory holding the copy of the local
+// source of a Kustomize component in a bundle.
+func KustomizeSourceCopy(bundleDir, component string) string {
+	return filepath.Join(bundleDir, kustomizeDirName, component, kustomizeBaseDirName)
+}
+
+// kustomizeResource returns the resource reference written to the
+// kustomization.yaml in componentDir. Local sources are copied from sourceDir
+// (the component source when empty) into the base/ subdirectory, so that the
+// bundle does not depend on the directory it was generated in. Returns the
+// copied files and their total size.
+func kustomizeResource(ref recipe.ComponentRef, sourceDir, componentDir string) (string, []string, int64, error) {
+	if IsRemoteKustomizeSource(ref.Source) {
+		return KustomizeResourceURL(ref), nil, 0, nil
+	}
+
+	resource := kustomizeBaseDirName
+	if ref.Path != "" {
+		if !filepath.IsLocal(ref.Path) {
+			return "", nil, 0, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("kustomize path %s of component %s must be within its source", ref.Path, ref.Name))
+		}
+		resource = path.Join(resource, filepath.ToSlash(filepath.Clean(ref.Path)))
+	}
+
+	if sourceDir == "" {
+		sourceDir = ref.Source
+	}
+	files, size, err := copyKustomizeSource(sourceDir, filepath.Join(componentDir, kustomizeBaseDirName))
+	if err != nil {
+		return "", nil, 0, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+			"failed to copy kustomize source", err,
+			map[string]any{"component": ref.Name, "source": sourceDir})
+	}
+	return resource, files, size, nil
+}
+
+// copyKustomizeSource copies the regular files below src into dst, skipping
+// .git directories. Returns the copied files and their total size.
+func copyKustomizeSource(src, dst string) ([]string, int64, error) {
+	var files []string
+	var totalSize int64
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case !d.Type().IsRegular():
+			return fmt.Errorf("%s is not a regular file", p)
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, content, 0600); err != nil {
+			return err
+		}
+		files = append(files, target)
+		totalSize += int64(len(content))
+		return nil
+	})
+	return files, totalSize, err
+}
+
+// relativeFilePaths maps source file paths to slash-separated paths below their
+// longest common directory, so that files copied into the bundle keep their
+// relative layout instead of colliding on equal base names. Returns an error
+// when two sources map to the same path or a path would leave the target
+// directory.
+func relativeFilePaths(sources []string) (map[string]string, error) {
+	cleaned := make([]string, len(sources))
+	var common []string
+	for i, src := range sources {
+		cleaned[i] = path.Clean(filepath.ToSlash(src))
+		dir := strings.Split(path.Dir(cleaned[i]), "/")
+		if i == 0 {
+			common = dir
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(dir) && common[n] == dir[n] {
+			n++
+		}
+		common = common[:n]
+	}
+
+	prefix := strings.Join(common, "/")
+	rels := make(map[string]string, len(sources))
+	owners := make(map[string]string, len(sources))
+	for i, src := range sources {
+		rel := cleaned[i]
+		if len(common) > 0 {
+			rel = strings.TrimPrefix(strings.TrimPrefix(rel, prefix), "/")
+		}
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("file %s cannot be placed in the bundle", src))
+		}
+		if other, exists := owners[rel]; exists && other != src {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("files %s and %s map to the same bundle path %s", other, src, rel))
+		}
+		owners[rel] = src
+		rels[src] = rel
+	}
+	return rels, nil
+}
+
+// generateKustomizations writes a kustomization.yaml (and its patch files) for
+// every Kustomize component into <outputDir>/kustomize/<component>/.
+func (g *Generator) generateKustomizations(ctx context.Context, input *GeneratorInput, outputDir string) ([]string, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	var files []string
+	var totalSize int64
+
+	for _, ref := range kustomizeComponents(input.RecipeResult) {
+		componentDir := filepath.Join(outputDir, kustomizeDirName, ref.Name)
+		if err := os.MkdirAll(componentDir, 0755); err != nil {
+			return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to create kustomize directory for %s", ref.Name), err)
+		}
+
+		resource, sourceFiles, sourceSize, err := kustomizeResource(ref, input.KustomizeSources[ref.Name], componentDir)
+		if err != nil {
+			return nil, 0, err
+		}
+		files = append(files, sourceFiles...)
+		totalSize += sourceSize
+
+		kustomization := Kustomization{
+			APIVersion: "kustomize.config.k8s.io/v1beta1",
+			Kind:       "Kustomization",
+			Resources:  []string{resource},
+		}
+
+		// Copy patch files next to the kustomization so the directory is
+		// self-contained, keeping their relative layout
+		patchPaths, err := relativeFilePaths(ref.Patches)
+		if err != nil {
+			return nil, 0, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("invalid patches for component %s", ref.Name), err)
+		}
+		for _, patchPath := range ref.Patches {
+			content, ok := input.PatchContents[patchPath]
+			if !ok {
+				return nil, 0, errors.New(errors.ErrCodeInvalidRequest,
+					fmt.Sprintf("patch %s for component %s not provided", patchPath, ref.Name))
+			}
+
+			relPath := path.Join(patchesDirName, patchPaths[patchPath])
+			patchFile := filepath.Join(componentDir, filepath.FromSlash(relPath))
+			if err := os.MkdirAll(filepath.Dir(patchFile), 0755); err != nil {
+				return nil, 0, errors.Wrap(errors.ErrCodeInternal, "failed to create patches directory", err)
+			}
+			if err := os.WriteFile(patchFile, content, 0600); err != nil {
+				return nil, 0, errors.WrapWithContext(errors.ErrCodeInternal, "failed to write patch", err,
+					map[string]any{"component": ref.Name, "patch": patchPath})
+			}
+
+			kustomization.Patches = append(kustomization.Patches, KustomizePatch{Path: relPath})
+			files = append(files, patchFile)
+			totalSize += int64(len(content))
+		}
+
+		data, err := yaml.Marshal(kustomization)
+		if err != nil {
+			return nil, 0, errors.Wrap(errors.ErrCodeInternal, "failed to marshal kustomization", err)
+		}
+
+		kustomizationPath := filepath.Join(componentDir, kustomizationFileName)
+		if err := os.WriteFile(kustomizationPath, data, 0600); err != nil {
+			return nil, 0, errors.WrapWithContext(errors.ErrCodeInternal, "failed to write kustomization", err,
+				map[string]any{"component": ref.Name})
+		}
+
+		files = append(files, kustomizationPath)
+		totalSize += int64(len(data))
+	}
+
+	return files, totalSize, nil
+}
+
+// kustomizeComponents returns the Kustomize components in deployment order.
+func kustomizeComponents(recipeResult *recipe.RecipeResult) []recipe.ComponentRef {
+	refs := make([]recipe.ComponentRef, 0)
+	for _, name := range orderedComponentNames(recipeResult) {
+		ref := recipeResult.GetComponentRef(name)
+		if ref != nil && IsKustomizeComponent(*ref) {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// orderedComponentNames returns all component names, sorted by deployment order.
+func orderedComponentNames(recipeResult *recipe.RecipeResult) []string {
+	names := make([]string, 0, len(recipeResult.ComponentRefs))
+	for _, ref := range recipeResult.ComponentRefs {
+		names = append(names, ref.Name)
+	}
+	return SortComponentsByDeploymentOrder(names, recipeResult.DeploymentOrder)
+}
+
+// kustomizeDeploymentSteps returns the kubectl commands for Kustomize components,
+// split into those that must be applied before the umbrella chart (because a Helm
+// component depends on them) and those applied after it.
+func kustomizeDeploymentSteps(recipeResult *recipe.RecipeResult) (before, after []string) {
+	neededByHelm := make(map[string]bool)
+	for _, ref := range recipeResult.ComponentRefs {
+		if IsKustomizeComponent(ref) {
+			continue
+		}
+		for _, dep := range ref.DependencyRefs {
+			neededByHelm[dep] = true
+		}
+	}
+
+	for _, ref := range kustomizeComponents(recipeResult) {
+		step := fmt.Sprintf("kubectl apply -k %s/%s", kustomizeDirName, ref.Name)
+		if neededByHelm[ref.Name] {
+			before = append(before, step)
+		} else {
+			after = append(after, step)
+		}
+	}
+	return before, after
+}
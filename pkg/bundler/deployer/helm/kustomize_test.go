@@ -0,0 +1,360 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+const testPatchPath = "components/example-app/patches/replicas.yaml"
+
+// createKustomizeRecipeResult returns a recipe with a Helm component that depends
+// on a Kustomize component sourced from the local testdata fixture.
+func createKustomizeRecipeResult(t *testing.T) *recipe.RecipeResult {
+	t.Helper()
+
+	source, err := filepath.Abs(filepath.Join("testdata", "kustomize"))
+	if err != nil {
+		t.Fatalf("failed to resolve fixture path: %v", err)
+	}
+
+	result := createTestRecipeResult()
+	result.ComponentRefs = append(result.ComponentRefs, recipe.ComponentRef{
+		Name:    "example-app",
+		Type:    recipe.ComponentTypeKustomize,
+		Source:  source,
+		Path:    "base",
+		Patches: []string{testPatchPath},
+	})
+	result.ComponentRefs[1].DependencyRefs = []string{"example-app"}
+	result.DeploymentOrder = []string{"cert-manager", "example-app", "gpu-operator"}
+	return result
+}
+
+func loadTestPatches(t *testing.T) map[string][]byte {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "kustomize", "patches", "replicas.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read patch fixture: %v", err)
+	}
+	return map[string][]byte{testPatchPath: content}
+}
+
+func TestGenerate_KustomizeComponent(t *testing.T) {
+	g := NewGenerator()
+	outputDir := t.TempDir()
+
+	input := &GeneratorInput{
+		RecipeResult: createKustomizeRecipeResult(t),
+		ComponentValues: map[string]map[string]any{
+			"cert-manager": {"installCRDs": true},
+			"gpu-operator": {},
+			"example-app":  {"ignored": true},
+		},
+		Version:       "v1.0.0",
+		PatchContents: loadTestPatches(t),
+	}
+
+	output, err := g.Generate(context.Background(), input, outputDir)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Chart.yaml, values.yaml, README.md, kustomization.yaml, patch and the
+	// three files of the copied source
+	if len(output.Files) != 8 {
+		t.Errorf("expected 8 files, got %d: %v", len(output.Files), output.Files)
+	}
+
+	// The kustomization must reference the copy of the local fixture in the bundle
+	componentDir := filepath.Join(outputDir, "kustomize", "example-app")
+	data, err := os.ReadFile(filepath.Join(componentDir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read kustomization.yaml: %v", err)
+	}
+	var kustomization Kustomization
+	if err = yaml.Unmarshal(data, &kustomization); err != nil {
+		t.Fatalf("failed to parse kustomization.yaml: %v", err)
+	}
+	if kustomization.Kind != "Kustomization" {
+		t.Errorf("kind = %q, want Kustomization", kustomization.Kind)
+	}
+	if len(kustomization.Resources) != 1 || kustomization.Resources[0] != "base/base" {
+		t.Fatalf("resources = %v, want [base/base]", kustomization.Resources)
+	}
+	if _, statErr := os.Stat(filepath.Join(componentDir, "base", "base", "kustomization.yaml")); statErr != nil {
+		t.Errorf("resource %s does not resolve to a kustomization: %v", kustomization.Resources[0], statErr)
+	}
+	if len(kustomization.Patches) != 1 || kustomization.Patches[0].Path != "patches/replicas.yaml" {
+		t.Errorf("unexpected patches: %+v", kustomization.Patches)
+	}
+
+	patch, err := os.ReadFile(filepath.Join(outputDir, "kustomize", "example-app", "patches", "replicas.yaml"))
+	if err != nil {
+		t.Fatalf("patch not copied: %v", err)
+	}
+	if !strings.Contains(string(patch), "replicas: 3") {
+		t.Error("copied patch has unexpected content")
+	}
+
+	// Kustomize components are not umbrella chart dependencies
+	chartContent, err := os.ReadFile(filepath.Join(outputDir, "Chart.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read Chart.yaml: %v", err)
+	}
+	if strings.Contains(string(chartContent), "example-app") {
+		t.Error("Chart.yaml should not list the kustomize component")
+	}
+	valuesContent, err := os.ReadFile(filepath.Join(outputDir, "values.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read values.yaml: %v", err)
+	}
+	if strings.Contains(string(valuesContent), "example-app") {
+		t.Error("values.yaml should not contain the kustomize component")
+	}
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	if err != nil {
+		t.Fatalf("failed to read README.md: %v", err)
+	}
+	if !strings.Contains(string(readme), "kubectl apply -k kustomize/example-app") {
+		t.Error("README.md missing kustomize apply step")
+	}
+
+	// gpu-operator depends on example-app, so it must be applied before helm install
+	applyIdx, installIdx := -1, -1
+	for i, step := range output.DeploymentSteps {
+		switch {
+		case step == "kubectl apply -k kustomize/example-app":
+			applyIdx = i
+		case strings.HasPrefix(step, "helm install"):
+			installIdx = i
+		}
+	}
+	if applyIdx == -1 || installIdx == -1 || applyIdx > installIdx {
+		t.Errorf("expected kustomize apply before helm install, got %v", output.DeploymentSteps)
+	}
+}
+
+func TestGenerate_KustomizeMissingPatch(t *testing.T) {
+	g := NewGenerator()
+
+	input := &GeneratorInput{
+		RecipeResult: createKustomizeRecipeResult(t),
+		Version:      "v1.0.0",
+	}
+
+	if _, err := g.Generate(context.Background(), input, t.TempDir()); err == nil {
+		t.Error("expected error for missing patch content")
+	}
+}
+
+func TestKustomizeResourceURL(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  recipe.ComponentRef
+		want string
+	}{
+		{
+			name: "remote with path and tag",
+			ref:  recipe.ComponentRef{Source: "https://github.com/example/app", Path: "deploy/prod", Tag: "v1.0.0"},
+			want: "https://github.com/example/app//deploy/prod?ref=v1.0.0",
+		},
+		{
+			name: "remote without path",
+			ref:  recipe.ComponentRef{Source: "https://github.com/example/app/", Tag: "main"},
+			want: "https://github.com/example/app?ref=main",
+		},
+		{
+			name: "ssh remote",
+			ref:  recipe.ComponentRef{Source: "git@github.com:example/app.git", Path: "/base/"},
+			want: "git@github.com:example/app.git//base",
+		},
+		{
+			name: "local with path",
+			ref:  recipe.ComponentRef{Source: "/opt/kustomize", Path: "overlays/prod"},
+			want: "/opt/kustomize/overlays/prod",
+		},
+		{
+			name: "local without path",
+			ref:  recipe.ComponentRef{Source: "/opt/kustomize"},
+			want: "/opt/kustomize",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KustomizeResourceURL(tt.ref); got != tt.want {
+				t.Errorf("KustomizeResourceURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_KustomizeRelativeSource(t *testing.T) {
+	g := NewGenerator()
+	outputDir := t.TempDir()
+
+	result := createKustomizeRecipeResult(t)
+	ref := &result.ComponentRefs[len(result.ComponentRefs)-1]
+	ref.Source = filepath.Join("testdata", "kustomize")
+	ref.Patches = []string{
+		"components/example-app/patches/replicas.yaml",
+		"components/example-app/patches/prod/replicas.yaml",
+	}
+	patches := loadTestPatches(t)
+	patches[ref.Patches[1]] = patches[ref.Patches[0]]
+
+	input := &GeneratorInput{
+		RecipeResult:  result,
+		Version:       "v1.0.0",
+		PatchContents: patches,
+	}
+	if _, err := g.Generate(context.Background(), input, outputDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	componentDir := filepath.Join(outputDir, "kustomize", "example-app")
+	data, err := os.ReadFile(filepath.Join(componentDir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read kustomization.yaml: %v", err)
+	}
+	var kustomization Kustomization
+	if err = yaml.Unmarshal(data, &kustomization); err != nil {
+		t.Fatalf("failed to parse kustomization.yaml: %v", err)
+	}
+
+	// The relative source is copied into the bundle
+	if len(kustomization.Resources) != 1 || !filepath.IsLocal(kustomization.Resources[0]) {
+		t.Fatalf("expected one resource within the bundle, got %v", kustomization.Resources)
+	}
+	resource := filepath.Join(componentDir, filepath.FromSlash(kustomization.Resources[0]))
+	if _, statErr := os.Stat(filepath.Join(resource, "kustomization.yaml")); statErr != nil {
+		t.Errorf("resource %s does not resolve to a kustomization: %v", kustomization.Resources[0], statErr)
+	}
+
+	// Patches with the same name keep their relative layout
+	var got []string
+	for _, p := range kustomization.Patches {
+		got = append(got, p.Path)
+		if _, statErr := os.Stat(filepath.Join(componentDir, filepath.FromSlash(p.Path))); statErr != nil {
+			t.Errorf("patch %s not copied: %v", p.Path, statErr)
+		}
+	}
+	want := []string{"patches/replicas.yaml", "patches/prod/replicas.yaml"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("patches = %v, want %v", got, want)
+	}
+}
+
+func TestGenerate_KustomizeSourceOverride(t *testing.T) {
+	outputDir := t.TempDir()
+
+	// A previously generated bundle is re-derived from its own copy of the source
+	result := createKustomizeRecipeResult(t)
+	result.ComponentRefs[len(result.ComponentRefs)-1].Source = "./does-not-exist"
+	input := &GeneratorInput{
+		RecipeResult:     result,
+		Version:          "v1.0.0",
+		PatchContents:    loadTestPatches(t),
+		KustomizeSources: map[string]string{"example-app": filepath.Join("testdata", "kustomize")},
+	}
+	if _, err := NewGenerator().Generate(context.Background(), input, outputDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "kustomize", "example-app", "base", "base", "deployment.yaml")); err != nil {
+		t.Errorf("source not copied from override: %v", err)
+	}
+
+	// Without the override the missing source is an error
+	input.KustomizeSources = nil
+	if _, err := NewGenerator().Generate(context.Background(), input, t.TempDir()); err == nil {
+		t.Error("expected error for missing kustomize source")
+	}
+
+	// The path must stay within the source
+	result.ComponentRefs[len(result.ComponentRefs)-1].Path = "../outside"
+	input.KustomizeSources = map[string]string{"example-app": filepath.Join("testdata", "kustomize")}
+	if _, err := NewGenerator().Generate(context.Background(), input, t.TempDir()); err == nil {
+		t.Error("expected error for path outside the source")
+	}
+}
+
+func TestRelativeFilePaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		sources []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "single file",
+			sources: []string{"components/app/patches/replicas.yaml"},
+			want:    map[string]string{"components/app/patches/replicas.yaml": "replicas.yaml"},
+		},
+		{
+			name:    "same base name in different directories",
+			sources: []string{"components/app/a/patch.yaml", "components/app/b/patch.yaml"},
+			want: map[string]string{
+				"components/app/a/patch.yaml": "a/patch.yaml",
+				"components/app/b/patch.yaml": "b/patch.yaml",
+			},
+		},
+		{
+			name:    "nested directories",
+			sources: []string{"components/app/patch.yaml", "components/app/prod/patch.yaml"},
+			want: map[string]string{
+				"components/app/patch.yaml":      "patch.yaml",
+				"components/app/prod/patch.yaml": "prod/patch.yaml",
+			},
+		},
+		{
+			name:    "duplicate after cleaning",
+			sources: []string{"components/app/patch.yaml", "components/app/./patch.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "escapes directory",
+			sources: []string{"../patch.yaml", "patch.yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := relativeFilePaths(tt.sources)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("relativeFilePaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for src, want := range tt.want {
+				if got[src] != want {
+					t.Errorf("relativeFilePaths()[%q] = %q, want %q", src, got[src], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+//go:embed templates/deploy.sh.tmpl
+var deployScriptTemplate string
+
+//go:embed templates/undeploy.sh.tmpl
+var undeployScriptTemplate string
+
+//go:embed templates/releases-README.md.tmpl
+var releasesReadmeTemplate string
+
+const (
+	// DeployScriptName is the script that installs the releases in order.
+	DeployScriptName = "deploy.sh"
+
+	// UndeployScriptName is the script that uninstalls the releases in reverse order.
+	UndeployScriptName = "undeploy.sh"
+
+	// manifestsDirName is the per-component chart holding the component's manifests.
+	manifestsDirName = "manifests"
+)
+
+// releaseTemplateFuncs are the helper functions available to the release templates.
+var releaseTemplateFuncs = template.FuncMap{
+	"quote": shellQuote,
+	"join":  strings.Join,
+}
+
+// Release describes the Helm release (or Kustomize application) of one component.
+type Release struct {
+	// Step is the 1-based position of the release in the install order.
+	Step int
+
+	// Name is the component name, used as the release name.
+	Name string
+
+	// Namespace is the namespace the component is installed into.
+	Namespace string
+
+	// Chart is the chart reference passed to helm: the chart name when
+	// Repository is set, or the full oci:// reference.
+	Chart string
+
+	// Repository is the chart repository URL (empty for OCI charts).
+	Repository string
+
+	// Version is the chart version.
+	Version string
+
+	// ValuesFile is the component's values file, relative to the bundle root.
+	ValuesFile string
+
+	// ManifestsChart is the chart holding the component's additional
+	// manifests, relative to the bundle root (empty if there are none).
+	ManifestsChart string
+
+	// Kustomize indicates the component is applied with kubectl apply -k.
+	Kustomize bool
+
+	// Dir is the kustomization directory, relative to the bundle root (Kustomize only).
+	Dir string
+
+	// DependsOn lists the components that are installed before this one.
+	DependsOn []string
+}
+
+// releasesData contains the data for rendering the release scripts and README.
+type releasesData struct {
+	RecipeVersion  string
+	BundlerVersion string
+	Releases       []Release
+	Reversed       []Release
+	HasKustomize   bool
+	Total          int
+}
+
+// ReleasesGenerator creates one Helm release per component, with scripts that
+// install the releases in dependency order and uninstall them in reverse.
+//
+// Unlike the umbrella chart, every component keeps its own namespace, values
+// file and release, so a failed component does not roll back the others.
+type ReleasesGenerator struct{}
+
+// NewReleasesGenerator creates a new per-component release generator.
+func NewReleasesGenerator() *ReleasesGenerator {
+	return &ReleasesGenerator{}
+}
+
+// Generate writes a values file per component, deploy.sh, undeploy.sh and a
+// README.md to outputDir. Airgap settings of the input are ignored.
+func (g *ReleasesGenerator) Generate(ctx context.Context, input *GeneratorInput, outputDir string) (*GeneratorOutput, error) {
+	start := time.Now()
+
+	output := &GeneratorOutput{
+		Files: make([]string, 0),
+	}
+
+	if input == nil || input.RecipeResult == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "input and recipe result are required")
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to create output directory", err)
+	}
+
+	order, err := ReleaseOrder(input.RecipeResult)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(order))
+	for i, ref := range order {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		release := newRelease(ref, input.RecipeResult)
+		release.Step = i + 1
+
+		if !release.Kustomize {
+			files, size, writeErr := g.writeComponentFiles(ref, &release, input, outputDir)
+			if writeErr != nil {
+				return nil, writeErr
+			}
+			output.Files = append(output.Files, files...)
+			output.TotalSize += size
+		}
+
+		releases = append(releases, release)
+	}
+
+	// Kustomize components are written exactly as for the umbrella chart
+	kustomizeFiles, kustomizeSize, err := NewGenerator().generateKustomizations(ctx, input, outputDir)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal,
+			"failed to generate kustomizations", err)
+	}
+	output.Files = append(output.Files, kustomizeFiles...)
+	output.TotalSize += kustomizeSize
+
+	data := releasesData{
+		RecipeVersion:  input.RecipeResult.Metadata.Version,
+		BundlerVersion: input.Version,
+		Releases:       releases,
+		Reversed:       slices.Clone(releases),
+		Total:          len(releases),
+	}
+	slices.Reverse(data.Reversed)
+	for _, r := range releases {
+		if r.Kustomize {
+			data.HasKustomize = true
+		}
+	}
+
+	for _, f := range []struct {
+		name       string
+		tmpl       string
+		executable bool
+	}{
+		{DeployScriptName, deployScriptTemplate, true},
+		{UndeployScriptName, undeployScriptTemplate, true},
+		{"README.md", releasesReadmeTemplate, false},
+	} {
+		filePath := filepath.Join(outputDir, f.name)
+		size, renderErr := renderReleaseTemplate(f.tmpl, data, filePath)
+		if renderErr != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to generate %s", f.name), renderErr)
+		}
+		if f.executable {
+			if chmodErr := os.Chmod(filePath, 0755); chmodErr != nil {
+				return nil, errors.Wrap(errors.ErrCodeInternal,
+					fmt.Sprintf("failed to make %s executable", f.name), chmodErr)
+			}
+		}
+		output.Files = append(output.Files, filePath)
+		output.TotalSize += size
+	}
+
+	if input.IncludeChecksums {
+		if err := checksum.GenerateChecksums(ctx, outputDir, output.Files); err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				"failed to generate checksums", err)
+		}
+		checksumPath := checksum.GetChecksumFilePath(outputDir)
+		info, statErr := os.Stat(checksumPath)
+		if statErr == nil {
+			output.Files = append(output.Files, checksumPath)
+			output.TotalSize += info.Size()
+		}
+	}
+
+	output.Duration = time.Since(start)
+	output.DeploymentSteps = []string{
+		fmt.Sprintf("cd %s", outputDir),
+		"./" + DeployScriptName,
+	}
+
+	slog.Debug("helm releases generated",
+		"releases", len(releases),
+		"files", len(output.Files),
+		"total_size", output.TotalSize,
+		"duration", output.Duration,
+	)
+
+	return output, nil
+}
+
+// writeComponentFiles writes the values file of a Helm component and, if the
+// component has manifests, a chart holding them. The manifests are Helm
+// templates that read the component values from .Values.<component>, as in the
+// umbrella chart, so the chart's values nest the component values under its name.
+func (g *ReleasesGenerator) writeComponentFiles(ref recipe.ComponentRef, release *Release, input *GeneratorInput, outputDir string) ([]string, int64, error) {
+	componentDir := filepath.Join(outputDir, ref.Name)
+	if err := os.MkdirAll(componentDir, 0755); err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+			fmt.Sprintf("failed to create directory for %s", ref.Name), err)
+	}
+
+	values := input.ComponentValues[ref.Name]
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	var files []string
+	var totalSize int64
+
+	header := fmt.Sprintf("# Cloud Native Stack - %s values\n# Recipe Version: %s\n# Bundler Version: %s\n",
+		ref.Name, input.RecipeResult.Metadata.Version, input.Version)
+	valuesPath := filepath.Join(componentDir, "values.yaml")
+	size, err := writeYAMLFile(valuesPath, header, values)
+	if err != nil {
+		return nil, 0, errors.WrapWithContext(errors.ErrCodeInternal, "failed to write values.yaml", err,
+			map[string]any{"component": ref.Name})
+	}
+	files = append(files, valuesPath)
+	totalSize += size
+
+	if len(ref.ManifestFiles) == 0 {
+		return files, totalSize, nil
+	}
+
+	chartDir := filepath.Join(componentDir, manifestsDirName)
+	if err := os.MkdirAll(filepath.Join(chartDir, "templates"), 0755); err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal,
+			fmt.Sprintf("failed to create manifests chart for %s", ref.Name), err)
+	}
+
+	chart := map[string]any{
+		"apiVersion":  "v2",
+		"name":        ref.Name + "-manifests",
+		"description": fmt.Sprintf("Additional manifests for %s", ref.Name),
+		"type":        "application",
+		"version":     normalizeVersion(input.Version),
+	}
+	chartPath := filepath.Join(chartDir, "Chart.yaml")
+	if size, err = writeYAMLFile(chartPath, "", chart); err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal, "failed to write manifests Chart.yaml", err)
+	}
+	files = append(files, chartPath)
+	totalSize += size
+
+	chartValuesPath := filepath.Join(chartDir, "values.yaml")
+	if size, err = writeYAMLFile(chartValuesPath, header, map[string]any{ref.Name: values}); err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInternal, "failed to write manifests values.yaml", err)
+	}
+	files = append(files, chartValuesPath)
+	totalSize += size
+
+	// Keep the relative layout of the manifests so equal file names do not collide
+	manifestPaths, err := relativeFilePaths(ref.ManifestFiles)
+	if err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("invalid manifests for component %s", ref.Name), err)
+	}
+	for _, manifestPath := range ref.ManifestFiles {
+		content, ok := input.ManifestContents[manifestPath]
+		if !ok {
+			return nil, 0, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("manifest %s for component %s not provided", manifestPath, ref.Name))
+		}
+		templatePath := filepath.Join(chartDir, "templates", filepath.FromSlash(manifestPaths[manifestPath]))
+		if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
+			return nil, 0, errors.Wrap(errors.ErrCodeInternal, "failed to create manifests templates directory", err)
+		}
+		if err := os.WriteFile(templatePath, content, 0600); err != nil {
+			return nil, 0, errors.WrapWithContext(errors.ErrCodeInternal, "failed to write manifest", err,
+				map[string]any{"component": ref.Name, "manifest": manifestPath})
+		}
+		files = append(files, templatePath)
+		totalSize += int64(len(content))
+	}
+
+	release.ManifestsChart = "./" + path.Join(ref.Name, manifestsDirName)
+	return files, totalSize, nil
+}
+
+// ReleaseOrder returns the components of a recipe in install order: every
+// component follows the components it lists in dependencyRefs, and otherwise
+// the recipe's DeploymentOrder is kept. Dependencies on components that are
+// not part of the recipe are ignored. Returns an error on circular dependencies.
+func ReleaseOrder(recipeResult *recipe.RecipeResult) ([]recipe.ComponentRef, error) {
+	names := orderedComponentNames(recipeResult)
+
+	byName := make(map[string]recipe.ComponentRef, len(names))
+	for _, ref := range recipeResult.ComponentRefs {
+		byName[ref.Name] = ref
+	}
+
+	installed := make(map[string]bool, len(names))
+	order := make([]recipe.ComponentRef, 0, len(names))
+
+	// Repeatedly take the first pending component whose dependencies are installed
+	pending := names
+	for len(pending) > 0 {
+		next := -1
+		for i, name := range pending {
+			if dependenciesInstalled(byName[name], byName, installed) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("circular dependency between components: %s", strings.Join(pending, ", ")),
+				map[string]any{"components": pending})
+		}
+
+		name := pending[next]
+		installed[name] = true
+		order = append(order, byName[name])
+		pending = slices.Delete(slices.Clone(pending), next, next+1)
+	}
+
+	return order, nil
+}
+
+// dependenciesInstalled reports whether all in-recipe dependencies of a component are installed.
+func dependenciesInstalled(ref recipe.ComponentRef, byName map[string]recipe.ComponentRef, installed map[string]bool) bool {
+	for _, dep := range ref.DependencyRefs {
+		if _, inRecipe := byName[dep]; inRecipe && !installed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// newRelease builds the release description of a component.
+func newRelease(ref recipe.ComponentRef, recipeResult *recipe.RecipeResult) Release {
+	release := Release{
+		Name:      ref.Name,
+		Namespace: ResolveNamespace(ref.Name),
+	}
+
+	for _, dep := range ref.DependencyRefs {
+		if recipeResult.GetComponentRef(dep) != nil {
+			release.DependsOn = append(release.DependsOn, dep)
+		}
+	}
+
+	if IsKustomizeComponent(ref) {
+		release.Kustomize = true
+		release.Dir = "./" + path.Join(kustomizeDirName, ref.Name)
+		return release
+	}
+
+	chartName := ResolveChartName(ref.Name)
+	release.Version = ref.Version
+	release.ValuesFile = path.Join(ref.Name, "values.yaml")
+	if strings.HasPrefix(ref.Source, "oci://") {
+		release.Chart = strings.TrimSuffix(ref.Source, "/") + "/" + chartName
+	} else {
+		release.Chart = chartName
+		release.Repository = ref.Source
+	}
+
+	return release
+}
+
+// renderReleaseTemplate renders a template to a file.
+func renderReleaseTemplate(tmplContent string, data any, outputPath string) (int64, error) {
+	tmpl, err := template.New("release").Funcs(releaseTemplateFuncs).Parse(tmplContent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return 0, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	content := buf.String()
+	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return int64(len(content)), nil
+}
+
+// writeYAMLFile writes a value as YAML, preceded by an optional comment header.
+func writeYAMLFile(outputPath, header string, value any) (int64, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	content := header + string(data)
+	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return int64(len(content)), nil
+}
+
+// shellQuote quotes a string for use as a single shell word. Strings made only
+// of characters that are safe in shell words are returned unchanged.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@+,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+func TestReleaseOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		refs    []recipe.ComponentRef
+		order   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "deployment order without dependencies",
+			refs:  []recipe.ComponentRef{{Name: "b"}, {Name: "a"}, {Name: "c"}},
+			order: []string{"a", "b", "c"},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name: "dependency moves component after its dependency",
+			refs: []recipe.ComponentRef{
+				{Name: "gpu-operator", DependencyRefs: []string{"cert-manager"}},
+				{Name: "cert-manager"},
+				{Name: "network-operator"},
+			},
+			order: []string{"gpu-operator", "network-operator", "cert-manager"},
+			want:  []string{"network-operator", "cert-manager", "gpu-operator"},
+		},
+		{
+			name: "dependency outside the recipe is ignored",
+			refs: []recipe.ComponentRef{
+				{Name: "gpu-operator", DependencyRefs: []string{"cert-manager"}},
+			},
+			order: []string{"gpu-operator"},
+			want:  []string{"gpu-operator"},
+		},
+		{
+			name: "circular dependency",
+			refs: []recipe.ComponentRef{
+				{Name: "a", DependencyRefs: []string{"b"}},
+				{Name: "b", DependencyRefs: []string{"a"}},
+			},
+			order:   []string{"a", "b"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := createEmptyRecipeResult()
+			result.ComponentRefs = tt.refs
+			result.DeploymentOrder = tt.order
+
+			refs, err := ReleaseOrder(result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReleaseOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := make([]string, 0, len(refs))
+			for _, ref := range refs {
+				got = append(got, ref.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ReleaseOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleasesGenerate(t *testing.T) {
+	result := createKustomizeRecipeResult(t)
+	result.ComponentRefs[1].ManifestFiles = []string{"components/gpu-operator/manifests/dcgm-exporter.yaml"}
+	result.ComponentRefs = append(result.ComponentRefs, recipe.ComponentRef{
+		Name:    "nvidia-dra-driver-gpu",
+		Version: "25.8.0",
+		Source:  "oci://ghcr.io/nvidia/charts",
+	})
+	result.DeploymentOrder = append(result.DeploymentOrder, "nvidia-dra-driver-gpu")
+
+	input := &GeneratorInput{
+		RecipeResult: result,
+		ComponentValues: map[string]map[string]any{
+			"gpu-operator": {"driver": map[string]any{"enabled": true}},
+		},
+		Version: "v1.0.0",
+		ManifestContents: map[string][]byte{
+			"components/gpu-operator/manifests/dcgm-exporter.yaml": []byte("{{ index .Values \"gpu-operator\" }}\n"),
+		},
+		PatchContents:    loadTestPatches(t),
+		IncludeChecksums: true,
+	}
+
+	outputDir := t.TempDir()
+	output, err := NewReleasesGenerator().Generate(context.Background(), input, outputDir)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, f := range []string{
+		"deploy.sh",
+		"undeploy.sh",
+		"README.md",
+		"checksums.txt",
+		"cert-manager/values.yaml",
+		"gpu-operator/values.yaml",
+		"gpu-operator/manifests/Chart.yaml",
+		"gpu-operator/manifests/values.yaml",
+		"gpu-operator/manifests/templates/dcgm-exporter.yaml",
+		"kustomize/example-app/kustomization.yaml",
+	} {
+		if _, statErr := os.Stat(filepath.Join(outputDir, f)); statErr != nil {
+			t.Errorf("expected file %s: %v", f, statErr)
+		}
+	}
+
+	if len(output.DeploymentSteps) != 2 || output.DeploymentSteps[1] != "./deploy.sh" {
+		t.Errorf("DeploymentSteps = %v", output.DeploymentSteps)
+	}
+
+	for _, script := range []string{"deploy.sh", "undeploy.sh"} {
+		info, statErr := os.Stat(filepath.Join(outputDir, script))
+		if statErr != nil {
+			t.Fatal(statErr)
+		}
+		if info.Mode().Perm()&0100 == 0 {
+			t.Errorf("%s is not executable: %v", script, info.Mode())
+		}
+	}
+
+	deploy, err := os.ReadFile(filepath.Join(outputDir, "deploy.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInOrder(t, string(deploy),
+		"helm upgrade --install cert-manager cert-manager \\\n  --repo https://charts.jetstack.io",
+		"kubectl apply -k ./kustomize/example-app",
+		"helm upgrade --install gpu-operator gpu-operator",
+		"--namespace gpu-operator --create-namespace",
+		"--values gpu-operator/values.yaml",
+		"helm upgrade --install gpu-operator-manifests ./gpu-operator/manifests",
+		"helm upgrade --install nvidia-dra-driver-gpu oci://ghcr.io/nvidia/charts/nvidia-dra-driver-gpu",
+	)
+	if strings.Count(string(deploy), "--wait") != 4 {
+		t.Errorf("deploy.sh should wait for every helm release:\n%s", deploy)
+	}
+
+	undeploy, err := os.ReadFile(filepath.Join(outputDir, "undeploy.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertInOrder(t, string(undeploy),
+		"helm uninstall nvidia-dra-driver-gpu",
+		"helm uninstall gpu-operator-manifests",
+		"helm uninstall gpu-operator ",
+		"kubectl delete -k ./kustomize/example-app",
+		"helm uninstall cert-manager",
+	)
+
+	values, err := os.ReadFile(filepath.Join(outputDir, "gpu-operator", "manifests", "values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(values), "gpu-operator:\n    driver:") {
+		t.Errorf("manifests chart values not nested under component name:\n%s", values)
+	}
+}
+
+func TestReleasesGenerate_ManifestLayout(t *testing.T) {
+	manifests := []string{
+		"components/gpu-operator/manifests/config.yaml",
+		"components/gpu-operator/manifests/dcgm/config.yaml",
+	}
+	result := createTestRecipeResult()
+	result.ComponentRefs[1].ManifestFiles = manifests
+
+	input := &GeneratorInput{
+		RecipeResult: result,
+		Version:      "v1.0.0",
+		ManifestContents: map[string][]byte{
+			manifests[0]: []byte("kind: ConfigMap\n"),
+			manifests[1]: []byte("kind: Secret\n"),
+		},
+	}
+
+	outputDir := t.TempDir()
+	if _, err := NewReleasesGenerator().Generate(context.Background(), input, outputDir); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	templatesDir := filepath.Join(outputDir, "gpu-operator", "manifests", "templates")
+	for rel, want := range map[string]string{
+		"config.yaml":      "kind: ConfigMap",
+		"dcgm/config.yaml": "kind: Secret",
+	} {
+		content, err := os.ReadFile(filepath.Join(templatesDir, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Errorf("expected template %s: %v", rel, err)
+			continue
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("template %s = %q, want %q", rel, content, want)
+		}
+	}
+
+	// Paths that resolve to the same template are rejected
+	result.ComponentRefs[1].ManifestFiles = []string{manifests[0], "components/gpu-operator/manifests/./config.yaml"}
+	input.ManifestContents["components/gpu-operator/manifests/./config.yaml"] = []byte("kind: Secret\n")
+	if _, err := NewReleasesGenerator().Generate(context.Background(), input, t.TempDir()); err == nil {
+		t.Error("expected error for colliding manifest paths")
+	}
+}
+
+func TestReleasesGenerate_NilInput(t *testing.T) {
+	if _, err := NewReleasesGenerator().Generate(context.Background(), nil, t.TempDir()); err == nil {
+		t.Error("expected error for nil input")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"gpu-operator", "gpu-operator"},
+		{"https://helm.ngc.nvidia.com/nvidia", "https://helm.ngc.nvidia.com/nvidia"},
+		{"", "''"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"$(rm -rf /)", "'$(rm -rf /)'"},
+	}
+
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// assertInOrder checks that the substrings appear in content in the given order.
+func assertInOrder(t *testing.T, content string, substrings ...string) {
+	t.Helper()
+
+	offset := 0
+	for _, s := range substrings {
+		idx := strings.Index(content[offset:], s)
+		if idx < 0 {
+			t.Errorf("%q not found after offset %d in:\n%s", s, offset, content)
+			return
+		}
+		offset += idx + len(s)
+	}
+}
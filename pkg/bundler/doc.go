@@ -15,15 +15,15 @@
 /*
 Package bundler provides orchestration for generating deployment bundles from recipes.
 
-The bundler package generates deployment-ready artifacts (Helm umbrella charts or
-ArgoCD applications) from recipe configurations. Component configuration is loaded
+The bundler package generates deployment-ready artifacts (Helm umbrella charts,
+ArgoCD applications or Flux resources) from recipe configurations. Component configuration is loaded
 from the declarative component registry (pkg/recipe/data/registry.yaml).
 
 # Architecture
 
-  - DefaultBundler: Generates Helm umbrella charts or ArgoCD applications
+  - DefaultBundler: Generates Helm umbrella charts, ArgoCD applications or Flux resources
   - Component Registry: Declarative configuration in pkg/recipe/data/components.yaml
-  - Deployers: Helm (default) and ArgoCD output formats
+  - Deployers: Helm (default), Helm releases, ArgoCD and Flux output formats
   - result.Output: Aggregated generation results
 
 # Quick Start
@@ -58,12 +58,73 @@ Helm (default):
   - values.yaml: Combined values for all components
   - README.md: Deployment instructions
   - recipe.yaml: Copy of the input recipe
+  - bundle.yaml: Bundler version and settings used for generation
   - templates/: Custom manifest templates (if any)
 
+Helm releases:
+  - deploy.sh: Installs components in dependency order, waiting for each
+  - undeploy.sh: Uninstalls components in reverse order
+  - <component>/values.yaml: Values for each component's release
+  - <component>/manifests/: Chart with the component's additional manifests
+  - recipe.yaml, bundle.yaml: As above
+
 ArgoCD:
   - app-of-apps.yaml: Parent ArgoCD Application
   - <component>/application.yaml: ArgoCD Application per component
   - <component>/values.yaml: Values for each component
+  - recipe.yaml, bundle.yaml: As above
+
+Flux:
+  - stack.yaml: GitRepository and top-level Flux Kustomization
+  - kustomization.yaml: Resources applied by the top-level Kustomization
+  - <component>/source.yaml: HelmRepository, OCIRepository or GitRepository
+  - <component>/helmrelease.yaml: HelmRelease with inline values and dependsOn
+  - recipe.yaml, bundle.yaml: As above
+
+# Air-Gapped Bundles
+
+With config.WithAirgap, the Helm umbrella chart is made self-contained for
+disconnected sites: every dependency chart is vendored into charts/ from a
+local chart cache (config.WithChartCache) or a file:// component source, and
+the container images of all charts are listed in images.txt. With
+config.WithMirrorRegistry, the registry value paths declared under
+mirror.registryPaths in the component registry are rewritten to the mirror:
+
+	cfg := config.NewConfig(
+	    config.WithAirgap(true),
+	    config.WithChartCache("/var/cache/helm/repository"),
+	    config.WithMirrorRegistry("registry.example.com"),
+	)
+
+Verify re-derives air-gapped bundles from their own charts/ directory, so no
+chart cache is needed to verify them.
+
+# Values Schema Validation
+
+Rendered component values are validated against the values.schema.json that
+the component registry references for the chart version (valuesSchemas).
+Unknown keys and type mismatches are returned as result.BundleError entries in
+Output.Warnings. With config.WithStrictValues, any violation fails Make with an
+ErrCodeInvalidRequest error listing the violations instead:
+
+	cfg := config.NewConfig(config.WithStrictValues(true))
+
+Components without a schema for their version are not validated.
+
+# Verification
+
+Verify recomputes the checksums of a generated bundle and re-derives it from
+its recipe.yaml using the settings recorded in bundle.yaml. Files that were
+edited, removed or added after generation are reported as issues. With
+WithVerifierVersion, bundles generated by another version are only checked
+against their checksums and a warning is recorded:
+
+	report, err := bundler.Verify(ctx, "./bundle", bundler.WithVerifierVersion(version))
+	if err == nil && !report.Valid {
+	    for _, issue := range report.Issues {
+	        fmt.Printf("%s: %s\n", issue.Type, issue.Path)
+	    }
+	}
 
 # Configuration
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -41,14 +42,36 @@ import (
 // Exported for backwards compatibility; prefer using defaults.BundleHandlerTimeout.
 const DefaultBundleTimeout = defaults.BundleHandlerTimeout
 
+// bundleRequest is the /v1/bundle request body: the recipe (RecipeResult)
+// with optional value overrides alongside its fields.
+type bundleRequest struct {
+	recipe.RecipeResult
+
+	// Values are per-component values deep merged over the recipe values,
+	// like values files passed to cnsctl bundle --values.
+	Values map[string]map[string]any `json:"values,omitempty"`
+
+	// Set are value overrides in format "bundler:path.to.field=value".
+	Set []string `json:"set,omitempty"`
+
+	// SetJSON are structured value overrides in format "bundler:path.to.field=<json>".
+	SetJSON []string `json:"setJSON,omitempty"`
+}
+
 // HandleBundles processes bundle generation requests.
-// It accepts a POST request with a JSON body containing the recipe (RecipeResult).
+// It accepts a POST request with a JSON body containing the recipe (RecipeResult)
+// and optional values, set and setJSON override fields.
 // Supports query parameters:
 //   - set: Value overrides in format "bundler:path.to.field=value" (can be repeated)
+//   - set-json: Structured value overrides in format "bundler:path.to.field=<json>" (can be repeated)
 //   - system-node-selector: Node selectors for system components in format "key=value" (can be repeated)
 //   - system-node-toleration: Tolerations for system components in format "key=value:effect" (can be repeated)
 //   - accelerated-node-selector: Node selectors for GPU nodes in format "key=value" (can be repeated)
 //   - accelerated-node-toleration: Tolerations for GPU nodes in format "key=value:effect" (can be repeated)
+//   - strict-values: Fail with 400 when component values do not match the chart's values schema
+//
+// Values schema violations that do not fail the request are counted in the
+// X-Bundle-Warnings response header.
 //
 // The response is a zip archive containing the umbrella Helm chart:
 //   - Chart.yaml: Helm chart metadata with dependencies
@@ -83,9 +106,9 @@ func (b *DefaultBundler) HandleBundles(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse request body directly as RecipeResult
-	var recipeResult recipe.RecipeResult
-	err = json.NewDecoder(r.Body).Decode(&recipeResult)
+	// Parse request body as RecipeResult with optional override fields
+	var req bundleRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		server.WriteError(w, r, http.StatusBadRequest, cnserrors.ErrCodeInvalidRequest,
 			"Invalid request body", false, map[string]any{
@@ -93,6 +116,13 @@ func (b *DefaultBundler) HandleBundles(w http.ResponseWriter, r *http.Request) {
 			})
 		return
 	}
+	recipeResult := req.RecipeResult
+
+	// Merge body overrides with query parameters (body applied last)
+	if err := params.addBodyOverrides(&req); err != nil {
+		server.WriteErrorFromErr(w, r, err, "Invalid request body", nil)
+		return
+	}
 
 	// Validate recipe has component references
 	if len(recipeResult.ComponentRefs) == 0 {
@@ -112,6 +142,8 @@ func (b *DefaultBundler) HandleBundles(w http.ResponseWriter, r *http.Request) {
 	slog.Debug("bundle request received",
 		"components", len(recipeResult.ComponentRefs),
 		"value_overrides", len(params.valueOverrides),
+		"json_value_overrides", len(params.jsonValueOverrides),
+		"values_files", len(params.valuesFiles),
 		"system_node_selectors", len(params.systemNodeSelector),
 		"accelerated_node_selectors", len(params.acceleratedNodeSelector),
 	)
@@ -129,12 +161,15 @@ func (b *DefaultBundler) HandleBundles(w http.ResponseWriter, r *http.Request) {
 	bundler, err := New(
 		WithConfig(config.NewConfig(
 			config.WithValueOverrides(params.valueOverrides),
+			config.WithJSONValueOverrides(params.jsonValueOverrides),
+			config.WithValuesFiles(params.valuesFiles),
 			config.WithSystemNodeSelector(params.systemNodeSelector),
 			config.WithSystemNodeTolerations(params.systemNodeTolerations),
 			config.WithAcceleratedNodeSelector(params.acceleratedNodeSelector),
 			config.WithAcceleratedNodeTolerations(params.acceleratedNodeTolerations),
 			config.WithDeployer(params.deployer),
 			config.WithRepoURL(params.repoURL),
+			config.WithStrictValues(params.strictValues),
 		)),
 	)
 	if err != nil {
@@ -184,6 +219,7 @@ func streamZipResponse(w http.ResponseWriter, dir string, output *result.Output)
 	w.Header().Set("X-Bundle-Files", strconv.Itoa(output.TotalFiles))
 	w.Header().Set("X-Bundle-Size", strconv.FormatInt(output.TotalSize, 10))
 	w.Header().Set("X-Bundle-Duration", output.TotalDuration.String())
+	w.Header().Set("X-Bundle-Warnings", strconv.Itoa(len(output.Warnings)))
 
 	// Create zip writer directly to response
 	zw := zip.NewWriter(w)
@@ -247,12 +283,15 @@ func streamZipResponse(w http.ResponseWriter, dir string, output *result.Output)
 // bundleParams holds parsed query parameters for bundle generation
 type bundleParams struct {
 	valueOverrides             map[string]map[string]string
+	jsonValueOverrides         map[string]map[string]any
+	valuesFiles                map[string][]map[string]any
 	systemNodeSelector         map[string]string
 	systemNodeTolerations      []corev1.Toleration
 	acceleratedNodeSelector    map[string]string
 	acceleratedNodeTolerations []corev1.Toleration
 	deployer                   config.DeployerType
 	repoURL                    string
+	strictValues               bool
 }
 
 // parseQueryParams extracts and validates all query parameters from the request
@@ -268,6 +307,12 @@ func parseQueryParams(r *http.Request) (*bundleParams, error) {
 		return nil, cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid set parameter", err)
 	}
 
+	// Parse structured value overrides
+	params.jsonValueOverrides, err = config.ParseJSONValueOverrides(query["set-json"])
+	if err != nil {
+		return nil, cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid set-json parameter", err)
+	}
+
 	// Parse system node selectors
 	params.systemNodeSelector, err = snapshotter.ParseNodeSelectors(query["system-node-selector"])
 	if err != nil {
@@ -292,7 +337,7 @@ func parseQueryParams(r *http.Request) (*bundleParams, error) {
 		return nil, cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid accelerated-node-toleration", err)
 	}
 
-	// Parse deployer type (helm, argocd)
+	// Parse deployer type (helm, argocd, flux)
 	deployerStr := query.Get("deployer")
 	if deployerStr == "" {
 		params.deployer = config.DeployerHelm // default
@@ -303,8 +348,50 @@ func parseQueryParams(r *http.Request) (*bundleParams, error) {
 		}
 	}
 
-	// Parse repo URL (for ArgoCD deployer)
+	// Parse repo URL (for ArgoCD and Flux deployers)
 	params.repoURL = query.Get("repo")
 
+	// Parse strict values schema validation
+	if strict := query.Get("strict-values"); strict != "" {
+		params.strictValues, err = strconv.ParseBool(strict)
+		if err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid strict-values parameter", err)
+		}
+	}
+
 	return params, nil
 }
+
+// addBodyOverrides merges the override fields of the request body into the
+// parsed query parameters, the body taking precedence on conflicting paths.
+func (p *bundleParams) addBodyOverrides(req *bundleRequest) error {
+	set, err := config.ParseValueOverrides(req.Set)
+	if err != nil {
+		return cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid set field", err)
+	}
+	for name, overrides := range set {
+		if p.valueOverrides[name] == nil {
+			p.valueOverrides[name] = make(map[string]string)
+		}
+		maps.Copy(p.valueOverrides[name], overrides)
+	}
+
+	setJSON, err := config.ParseJSONValueOverrides(req.SetJSON)
+	if err != nil {
+		return cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest, "Invalid setJSON field", err)
+	}
+	for name, overrides := range setJSON {
+		if p.jsonValueOverrides[name] == nil {
+			p.jsonValueOverrides[name] = make(map[string]any)
+		}
+		maps.Copy(p.jsonValueOverrides[name], overrides)
+	}
+
+	if len(req.Values) > 0 {
+		p.valuesFiles = make(map[string][]map[string]any, len(req.Values))
+		for name, values := range req.Values {
+			p.valuesFiles[name] = []map[string]any{values}
+		}
+	}
+	return nil
+}
@@ -369,6 +369,54 @@ func TestBundleRequestQueryParamParsing(t *testing.T) {
 			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}]}`,
 			wantStatus: http.StatusOK,
 		},
+		{
+			name:       "structured value override param",
+			queryParam: "set-json=gpuoperator:daemonsets.tolerations=%5B%7B%22operator%22%3A%22Exists%22%7D%5D",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid structured value override param",
+			queryParam: "set-json=gpuoperator:driver.enabled=%7Binvalid",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "value override body fields",
+			body: `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}],
+				"values": {"gpu-operator": {"driver": {"version": "570.86.16"}}},
+				"set": ["gpuoperator:gds.enabled=true"],
+				"setJSON": ["gpuoperator:daemonsets.tolerations[0]={\"operator\":\"Exists\"}"]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "strict values param",
+			queryParam: "strict-values=true",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid strict values param",
+			queryParam: "strict-values=maybe",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "strict values schema violation",
+			queryParam: "strict-values=true&set=gpuoperator:gpuDriver.enabled=true",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v25.10.1"}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid set body field",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}], "set": ["no-colon"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid setJSON body field",
+			body:       `{"apiVersion": "v1", "kind": "Recipe", "componentRefs": [{"name": "gpu-operator", "version": "v1"}], "setJSON": ["gpuoperator:a=nope"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -384,6 +432,12 @@ func TestBundleRequestQueryParamParsing(t *testing.T) {
 			b.HandleBundles(w, req)
 
 			// Allow both OK and internal error (bundler may fail but parsing should succeed)
+			if tt.wantStatus == http.StatusBadRequest {
+				if w.Code != tt.wantStatus {
+					t.Errorf("status = %d, want %d. Body: %s", w.Code, tt.wantStatus, w.Body.String())
+				}
+				return
+			}
 			if w.Code != tt.wantStatus && w.Code != http.StatusInternalServerError {
 				t.Errorf("status = %d, want %d or %d. Body: %s", w.Code, tt.wantStatus, http.StatusInternalServerError, w.Body.String())
 			}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+)
+
+const (
+	// MetadataFileName is the name of the file recording how a bundle was generated.
+	MetadataFileName = "bundle.yaml"
+
+	// RecipeFileName is the name of the copy of the input recipe in a bundle.
+	RecipeFileName = "recipe.yaml"
+
+	// metadataKind is the kind of the bundle metadata file.
+	metadataKind = "BundleMetadata"
+
+	// metadataAPIVersion is the API version of the bundle metadata file.
+	metadataAPIVersion = "cns.nvidia.com/v1alpha1"
+)
+
+// Metadata records the bundler version and settings used to generate a bundle,
+// so the bundle can be re-derived from its recipe.yaml during verification.
+type Metadata struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Version is the bundler (CLI) version that generated the bundle.
+	Version string `json:"version"`
+
+	// Deployer is the deployment method of the bundle (helm, argocd, flux).
+	Deployer config.DeployerType `json:"deployer"`
+
+	// RepoURL is the Git repository URL used for ArgoCD applications.
+	RepoURL string `json:"repoURL,omitempty"`
+
+	// Airgap records that dependency charts are vendored into the bundle's
+	// charts/ directory. The chart cache used at generation time is not recorded;
+	// the vendored charts are the source when the bundle is re-derived.
+	Airgap bool `json:"airgap,omitempty"`
+
+	// MirrorRegistry is the registry images were rewritten to in air-gapped mode.
+	MirrorRegistry string `json:"mirrorRegistry,omitempty"`
+
+	// StrictValues records that values schema violations fail the bundle.
+	StrictValues bool `json:"strictValues,omitempty"`
+
+	// ValueOverrides are the --set overrides, indexed by component.
+	ValueOverrides map[string]map[string]string `json:"valueOverrides,omitempty"`
+
+	// JSONValueOverrides are the --set-json overrides, indexed by component.
+	JSONValueOverrides map[string]map[string]any `json:"jsonValueOverrides,omitempty"`
+
+	// ValuesFiles are the contents of the --values files, indexed by component.
+	ValuesFiles map[string][]map[string]any `json:"valuesFiles,omitempty"`
+
+	SystemNodeSelector         map[string]string   `json:"systemNodeSelector,omitempty"`
+	SystemNodeTolerations      []corev1.Toleration `json:"systemNodeTolerations,omitempty"`
+	AcceleratedNodeSelector    map[string]string   `json:"acceleratedNodeSelector,omitempty"`
+	AcceleratedNodeTolerations []corev1.Toleration `json:"acceleratedNodeTolerations,omitempty"`
+}
+
+// NewMetadata captures the generation settings of the given bundler config.
+func NewMetadata(cfg *config.Config) *Metadata {
+	return &Metadata{
+		APIVersion:                 metadataAPIVersion,
+		Kind:                       metadataKind,
+		Version:                    cfg.Version(),
+		Deployer:                   cfg.Deployer(),
+		RepoURL:                    cfg.RepoURL(),
+		Airgap:                     cfg.Airgap(),
+		MirrorRegistry:             cfg.MirrorRegistry(),
+		StrictValues:               cfg.StrictValues(),
+		ValueOverrides:             cfg.ValueOverrides(),
+		JSONValueOverrides:         cfg.JSONValueOverrides(),
+		ValuesFiles:                cfg.ValuesFiles(),
+		SystemNodeSelector:         cfg.SystemNodeSelector(),
+		SystemNodeTolerations:      cfg.SystemNodeTolerations(),
+		AcceleratedNodeSelector:    cfg.AcceleratedNodeSelector(),
+		AcceleratedNodeTolerations: cfg.AcceleratedNodeTolerations(),
+	}
+}
+
+// Config returns a bundler config that reproduces the recorded settings.
+// Additional options, such as the chart cache of an air-gapped bundle, are
+// applied after the recorded settings.
+func (m *Metadata) Config(opts ...config.Option) *config.Config {
+	options := []config.Option{
+		config.WithVersion(m.Version),
+		config.WithDeployer(m.Deployer),
+		config.WithRepoURL(m.RepoURL),
+		config.WithAirgap(m.Airgap),
+		config.WithMirrorRegistry(m.MirrorRegistry),
+		config.WithStrictValues(m.StrictValues),
+		config.WithValueOverrides(m.ValueOverrides),
+		config.WithJSONValueOverrides(m.JSONValueOverrides),
+		config.WithValuesFiles(m.ValuesFiles),
+		config.WithSystemNodeSelector(m.SystemNodeSelector),
+		config.WithSystemNodeTolerations(m.SystemNodeTolerations),
+		config.WithAcceleratedNodeSelector(m.AcceleratedNodeSelector),
+		config.WithAcceleratedNodeTolerations(m.AcceleratedNodeTolerations),
+	}
+	return config.NewConfig(append(options, opts...)...)
+}
+
+// ReadMetadata loads the bundle metadata file from a bundle directory.
+func ReadMetadata(dir string) (*Metadata, error) {
+	path := filepath.Join(dir, MetadataFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeNotFound,
+			"failed to read bundle metadata", err, map[string]any{"path": path})
+	}
+
+	var m Metadata
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+			"failed to parse bundle metadata", err, map[string]any{"path": path})
+	}
+	if m.Kind != metadataKind {
+		return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("unexpected bundle metadata kind %q", m.Kind), map[string]any{"path": path})
+	}
+	if m.Deployer == "" {
+		m.Deployer = config.DeployerHelm
+	}
+
+	return &m, nil
+}
+
+// writeMetadataFile records the generation settings in the bundle directory.
+func (b *DefaultBundler) writeMetadataFile(dir string) (string, int64, error) {
+	data, err := yaml.Marshal(NewMetadata(b.Config))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to serialize bundle metadata: %w", err)
+	}
+
+	path := filepath.Join(dir, MetadataFileName)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", 0, fmt.Errorf("failed to write bundle metadata: %w", err)
+	}
+
+	return path, int64(len(data)), nil
+}
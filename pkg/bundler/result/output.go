@@ -51,6 +51,10 @@ type Output struct {
 	// Errors contains errors from failed bundlers.
 	Errors []BundleError `json:"errors,omitempty" yaml:"errors,omitempty"`
 
+	// Warnings contains problems that did not fail the bundle, such as
+	// component values that do not match the chart's values schema.
+	Warnings []BundleError `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+
 	// OutputDir is the directory where bundles were generated.
 	OutputDir string `json:"output_dir" yaml:"output_dir"`
 
@@ -59,9 +63,12 @@ type Output struct {
 }
 
 // BundleError represents an error from a specific bundler.
+// Values schema violations also set the offending values Path and the Reason.
 type BundleError struct {
 	BundlerType types.BundleType `json:"bundler_type" yaml:"bundler_type"`
 	Error       string           `json:"error" yaml:"error"`
+	Path        string           `json:"path,omitempty" yaml:"path,omitempty"`
+	Reason      string           `json:"reason,omitempty" yaml:"reason,omitempty"`
 }
 
 // HasErrors returns true if any bundler failed.
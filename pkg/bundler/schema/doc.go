@@ -0,0 +1,36 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema validates rendered component values against the chart's
+// values.schema.json before a bundle is generated.
+//
+// Only the subset of JSON Schema used by Helm chart values schemas is
+// supported: type, enum, properties, patternProperties, additionalProperties,
+// items, required, allOf, anyOf, oneOf and local $ref into definitions or
+// $defs. Other keywords are ignored.
+//
+// Usage:
+//
+//	s, err := schema.Parse(data)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, v := range s.Validate(values) {
+//	    fmt.Printf("%s: %s (%s)\n", v.Path, v.Message, v.Reason)
+//	}
+//
+// Violation paths use the same syntax as bundle value overrides, e.g.
+// "driver.version" or "daemonsets.tolerations[0].key", so a reported path can
+// be fixed with --set or --set-json directly.
+package schema
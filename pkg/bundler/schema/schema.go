@@ -0,0 +1,471 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxRefDepth bounds chains of $ref that do not descend into the values,
+// which would otherwise loop forever on a cyclic schema.
+const maxRefDepth = 32
+
+// Reason classifies a schema violation.
+type Reason string
+
+const (
+	// ReasonUnknownKey reports a key the schema does not allow, usually a typo.
+	ReasonUnknownKey Reason = "unknown-key"
+
+	// ReasonTypeMismatch reports a value of the wrong JSON type.
+	ReasonTypeMismatch Reason = "type-mismatch"
+
+	// ReasonInvalidValue reports a value outside the allowed enum.
+	ReasonInvalidValue Reason = "invalid-value"
+
+	// ReasonMissingRequired reports a required key that is not set.
+	ReasonMissingRequired Reason = "missing-required"
+)
+
+// Violation describes a values key that does not match the schema.
+type Violation struct {
+	// Path is the values path of the offending key, e.g. "driver.version".
+	Path string `json:"path" yaml:"path"`
+
+	// Reason classifies the violation.
+	Reason Reason `json:"reason" yaml:"reason"`
+
+	// Message describes the violation.
+	Message string `json:"message" yaml:"message"`
+}
+
+// String returns the violation as "path: message".
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Schema is a parsed values schema.
+type Schema struct {
+	root map[string]any
+}
+
+// Parse parses a values.schema.json document. It returns an error when the
+// document is not a JSON object or contains a $ref that cannot be resolved.
+func Parse(data []byte) (*Schema, error) {
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse values schema: %w", err)
+	}
+
+	s := &Schema{root: root}
+	if err := s.checkRefs(root); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks values against the schema and returns the violations,
+// sorted by path. It returns nil when the values are valid.
+func (s *Schema) Validate(values map[string]any) []Violation {
+	// Normalize Go values (typed maps and slices, integers) to their JSON form
+	data, err := json.Marshal(values)
+	if err != nil {
+		return []Violation{{
+			Reason:  ReasonTypeMismatch,
+			Message: fmt.Sprintf("values cannot be encoded as JSON: %v", err),
+		}}
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []Violation{{
+			Reason:  ReasonTypeMismatch,
+			Message: fmt.Sprintf("values cannot be decoded as JSON: %v", err),
+		}}
+	}
+
+	violations := s.validate(s.root, doc, "", 0)
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Message < b.Message
+	})
+	return violations
+}
+
+// validate checks value against a schema node, which is either an object or
+// a boolean (true accepts everything, false nothing).
+func (s *Schema) validate(node any, value any, path string, refDepth int) []Violation {
+	switch n := node.(type) {
+	case bool:
+		if !n {
+			return []Violation{{Path: path, Reason: ReasonUnknownKey, Message: "value is not allowed"}}
+		}
+		return nil
+	case map[string]any:
+		return s.validateObject(n, value, path, refDepth)
+	default:
+		return nil
+	}
+}
+
+func (s *Schema) validateObject(node map[string]any, value any, path string, refDepth int) []Violation {
+	var violations []Violation
+
+	if ref, ok := node["$ref"].(string); ok && refDepth < maxRefDepth {
+		target, _ := s.resolve(ref) // resolvable, checked by Parse
+		violations = append(violations, s.validate(target, value, path, refDepth+1)...)
+	}
+
+	if want, ok := node["type"]; ok {
+		if got := typeOf(value); !typeMatches(want, got) {
+			return append(violations, Violation{
+				Path:    path,
+				Reason:  ReasonTypeMismatch,
+				Message: fmt.Sprintf("expected %s, got %s", typeNames(want), got),
+			})
+		}
+	}
+
+	if enum, ok := node["enum"].([]any); ok && !slicesContain(enum, value) {
+		violations = append(violations, Violation{
+			Path:    path,
+			Reason:  ReasonInvalidValue,
+			Message: fmt.Sprintf("value %s is not one of %s", formatValue(value), formatValue(enum)),
+		})
+	}
+
+	for _, sub := range listOf(node["allOf"]) {
+		violations = append(violations, s.validate(sub, value, path, refDepth)...)
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		if branches := listOf(node[keyword]); len(branches) > 0 {
+			violations = append(violations, s.validateAny(branches, value, path, refDepth)...)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		violations = append(violations, s.validateProperties(node, v, path)...)
+	case []any:
+		violations = append(violations, s.validateItems(node, v, path)...)
+	}
+
+	return violations
+}
+
+// validateAny accepts value when it matches any branch (oneOf is not checked
+// for exclusivity). Otherwise it reports a single type mismatch when no branch
+// accepts the value's type, or the violations of the closest branch.
+func (s *Schema) validateAny(branches []any, value any, path string, refDepth int) []Violation {
+	if types, ok := branchTypes(branches); ok && !typeMatches(types, typeOf(value)) {
+		return []Violation{{
+			Path:    path,
+			Reason:  ReasonTypeMismatch,
+			Message: fmt.Sprintf("expected %s, got %s", typeNames(types), typeOf(value)),
+		}}
+	}
+
+	var closest []Violation
+	for i, branch := range branches {
+		violations := s.validate(branch, value, path, refDepth)
+		if len(violations) == 0 {
+			return nil
+		}
+		if i == 0 || len(violations) < len(closest) {
+			closest = violations
+		}
+	}
+	return closest
+}
+
+// branchTypes returns the type names allowed by the branches, or false when
+// a branch does not constrain the type.
+func branchTypes(branches []any) ([]any, bool) {
+	var types []any
+	for _, branch := range branches {
+		node, ok := branch.(map[string]any)
+		if !ok || node["type"] == nil {
+			return nil, false
+		}
+		for _, name := range typeList(node["type"]) {
+			types = append(types, name)
+		}
+	}
+	return types, true
+}
+
+func (s *Schema) validateProperties(node map[string]any, value map[string]any, path string) []Violation {
+	var violations []Violation
+
+	for _, key := range listOf(node["required"]) {
+		if name, ok := key.(string); ok {
+			if _, exists := value[name]; !exists {
+				violations = append(violations, Violation{
+					Path:    path,
+					Reason:  ReasonMissingRequired,
+					Message: fmt.Sprintf("missing required key %q", name),
+				})
+			}
+		}
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	patterns, _ := node["patternProperties"].(map[string]any)
+	additional, hasAdditional := node["additionalProperties"]
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := joinKey(path, key)
+		matched := false
+
+		if prop, ok := properties[key]; ok {
+			violations = append(violations, s.validate(prop, value[key], childPath, 0)...)
+			matched = true
+		}
+		for pattern, prop := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(key) {
+				violations = append(violations, s.validate(prop, value[key], childPath, 0)...)
+				matched = true
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+
+		if allowed, ok := additional.(bool); ok {
+			if !allowed {
+				violations = append(violations, Violation{
+					Path:    childPath,
+					Reason:  ReasonUnknownKey,
+					Message: unknownKeyMessage(key, properties),
+				})
+			}
+			continue
+		}
+		violations = append(violations, s.validate(additional, value[key], childPath, 0)...)
+	}
+
+	return violations
+}
+
+func (s *Schema) validateItems(node map[string]any, value []any, path string) []Violation {
+	var violations []Violation
+	switch items := node["items"].(type) {
+	case []any:
+		for i, item := range value {
+			if i < len(items) {
+				violations = append(violations, s.validate(items[i], item, indexPath(path, i), 0)...)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range value {
+			violations = append(violations, s.validate(items, item, indexPath(path, i), 0)...)
+		}
+	}
+	return violations
+}
+
+// resolve returns the schema node a local $ref points to, e.g.
+// "#/definitions/toleration" or "#/$defs/image".
+func (s *Schema) resolve(ref string) (any, error) {
+	if ref == "#" {
+		return s.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q: only local references are supported", ref)
+	}
+
+	var node any = s.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		// Unescape JSON pointer tokens
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+	return node, nil
+}
+
+// checkRefs returns an error for the first $ref below node that cannot be resolved.
+func (s *Schema) checkRefs(node any) error {
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok {
+			if _, err := s.resolve(ref); err != nil {
+				return err
+			}
+		}
+		for _, child := range n {
+			if err := s.checkRefs(child); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, child := range n {
+			if err := s.checkRefs(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// typeOf returns the JSON schema type of a decoded JSON value.
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// typeMatches reports whether got satisfies the type keyword, a type name or
+// a list of them. Integers are numbers.
+func typeMatches(want any, got string) bool {
+	for _, name := range typeList(want) {
+		if name == got || (name == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeList(want any) []string {
+	switch w := want.(type) {
+	case string:
+		return []string{w}
+	case []any:
+		names := make([]string, 0, len(w))
+		for _, item := range w {
+			if name, ok := item.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+func typeNames(want any) string {
+	return strings.Join(typeList(want), " or ")
+}
+
+func listOf(v any) []any {
+	list, _ := v.([]any)
+	return list
+}
+
+func slicesContain(list []any, value any) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// joinKey appends a key to a values path, escaping dots in the key.
+func joinKey(path, key string) string {
+	key = strings.ReplaceAll(key, ".", `\.`)
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// unknownKeyMessage describes an unknown key, suggesting the closest allowed
+// key when it looks like a typo.
+func unknownKeyMessage(key string, properties map[string]any) string {
+	best, bestDist := "", 3 // suggest keys at most two edits away
+	for name := range properties {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("unknown key %q (did you mean %q?)", key, best)
+	}
+	return fmt.Sprintf("unknown key %q", key)
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
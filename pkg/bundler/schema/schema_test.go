@@ -0,0 +1,208 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "driver": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["enabled"],
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "version": {"type": "string"},
+        "maxParallelUpgrades": {"type": "integer"},
+        "kernelModuleType": {"enum": ["auto", "open", "proprietary"]}
+      }
+    },
+    "daemonsets": {
+      "type": "object",
+      "properties": {
+        "tolerations": {"type": "array", "items": {"$ref": "#/definitions/toleration"}}
+      }
+    },
+    "nodeSelector": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    },
+    "labels": {
+      "type": "object",
+      "patternProperties": {"^nvidia\\.com/": {"type": "string"}},
+      "additionalProperties": false
+    },
+    "replicas": {"anyOf": [{"type": "integer"}, {"type": "string"}]},
+    "ratio": {"type": ["number", "null"]}
+  },
+  "definitions": {
+    "toleration": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "key": {"type": "string"},
+        "operator": {"enum": ["Exists", "Equal"]},
+        "effect": {"type": "string"}
+      }
+    }
+  }
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid schema", data: testSchema},
+		{name: "invalid JSON", data: `{"type": `, wantErr: true},
+		{name: "not an object", data: `["object"]`, wantErr: true},
+		{name: "unresolvable ref", data: `{"properties": {"a": {"$ref": "#/definitions/missing"}}}`, wantErr: true},
+		{name: "remote ref", data: `{"properties": {"a": {"$ref": "https://example.com/schema.json"}}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   []Violation
+	}{
+		{
+			name: "valid values",
+			values: map[string]any{
+				"driver": map[string]any{"enabled": true, "version": "580.105.08", "maxParallelUpgrades": 5},
+				"daemonsets": map[string]any{
+					"tolerations":       []map[string]any{{"operator": "Exists"}},
+					"priorityClassName": "system-node-critical",
+				},
+				"nodeSelector": map[string]string{"nodeGroup": "gpu"},
+				"labels":       map[string]any{"nvidia.com/gpu": "true"},
+				"replicas":     "2",
+				"ratio":        nil,
+			},
+		},
+		{
+			name:   "unknown key with suggestion",
+			values: map[string]any{"driver": map[string]any{"enabled": true, "verison": "580"}},
+			want: []Violation{
+				{Path: "driver.verison", Reason: ReasonUnknownKey, Message: `unknown key "verison" (did you mean "version"?)`},
+			},
+		},
+		{
+			name:   "unknown top-level key",
+			values: map[string]any{"gpuDriver": map[string]any{}},
+			want: []Violation{
+				{Path: "gpuDriver", Reason: ReasonUnknownKey, Message: `unknown key "gpuDriver"`},
+			},
+		},
+		{
+			name: "type mismatches",
+			values: map[string]any{
+				"driver":   map[string]any{"enabled": "yes", "maxParallelUpgrades": 1.5},
+				"ratio":    "half",
+				"replicas": true,
+			},
+			want: []Violation{
+				{Path: "driver.enabled", Reason: ReasonTypeMismatch, Message: "expected boolean, got string"},
+				{Path: "driver.maxParallelUpgrades", Reason: ReasonTypeMismatch, Message: "expected integer, got number"},
+				{Path: "ratio", Reason: ReasonTypeMismatch, Message: "expected number or null, got string"},
+				{Path: "replicas", Reason: ReasonTypeMismatch, Message: "expected integer or string, got boolean"},
+			},
+		},
+		{
+			name:   "enum and required",
+			values: map[string]any{"driver": map[string]any{"kernelModuleType": "closed"}},
+			want: []Violation{
+				{Path: "driver", Reason: ReasonMissingRequired, Message: `missing required key "enabled"`},
+				{Path: "driver.kernelModuleType", Reason: ReasonInvalidValue, Message: `value "closed" is not one of ["auto","open","proprietary"]`},
+			},
+		},
+		{
+			name: "list items via ref",
+			values: map[string]any{"daemonsets": map[string]any{
+				"tolerations": []any{map[string]any{"operator": "Exists"}, map[string]any{"operater": "Equal"}},
+			}},
+			want: []Violation{
+				{Path: "daemonsets.tolerations[1].operater", Reason: ReasonUnknownKey, Message: `unknown key "operater" (did you mean "operator"?)`},
+			},
+		},
+		{
+			name:   "pattern properties and escaped path",
+			values: map[string]any{"labels": map[string]any{"nvidia.com/gpu": 1, "example.com/x": "y"}, "nodeSelector": map[string]any{"a": 1}},
+			want: []Violation{
+				{Path: `labels.example\.com/x`, Reason: ReasonUnknownKey, Message: `unknown key "example.com/x"`},
+				{Path: `labels.nvidia\.com/gpu`, Reason: ReasonTypeMismatch, Message: "expected string, got integer"},
+				{Path: "nodeSelector.a", Reason: ReasonTypeMismatch, Message: "expected string, got integer"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Validate(tt.values)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_CyclicRef(t *testing.T) {
+	s, err := Parse([]byte(`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := s.Validate(map[string]any{"key": "value"}); len(got) != 0 {
+		t.Errorf("Validate() = %v, want no violations", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"version", "version", 0},
+		{"verison", "version", 2},
+		{"enable", "enabled", 1},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
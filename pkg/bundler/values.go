@@ -0,0 +1,93 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/result"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/schema"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/types"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// validateComponentValues checks the rendered values of each component against
+// the values schema the registry references for its chart version, and returns
+// the violations. Components without a schema for their version are skipped.
+// In strict mode any violation fails with an ErrCodeInvalidRequest error.
+func (b *DefaultBundler) validateComponentValues(recipeResult *recipe.RecipeResult, componentValues map[string]map[string]any) ([]result.BundleError, error) {
+	registry, err := recipe.GetComponentRegistry()
+	if err != nil {
+		slog.Debug("failed to load component registry for values validation",
+			"error", err,
+		)
+		return nil, nil
+	}
+
+	var violations []result.BundleError
+	for _, ref := range recipeResult.ComponentRefs {
+		comp := registry.Get(ref.Name)
+		version := ref.Version
+		if version == "" && comp != nil {
+			version = comp.Helm.DefaultVersion
+		}
+
+		file := comp.GetValuesSchemaFile(version)
+		if file == "" {
+			slog.Debug("no values schema for component version, skipping validation",
+				"component", ref.Name,
+				"version", version,
+			)
+			continue
+		}
+
+		data, err := recipe.GetDataProvider().ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("failed to read values schema of %s", ref.Name), err)
+		}
+		s, err := schema.Parse(data)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal,
+				fmt.Sprintf("invalid values schema of %s", ref.Name), err)
+		}
+
+		for _, v := range s.Validate(componentValues[ref.Name]) {
+			slog.Warn("component values do not match values schema",
+				"component", ref.Name,
+				"version", version,
+				"path", v.Path,
+				"reason", v.Reason,
+				"error", v.Message,
+			)
+			violations = append(violations, result.BundleError{
+				BundlerType: types.BundleType(ref.Name),
+				Error:       v.String(),
+				Path:        v.Path,
+				Reason:      string(v.Reason),
+			})
+		}
+	}
+
+	if len(violations) > 0 && b.Config.StrictValues() {
+		return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("component values do not match values schema (%d violations)", len(violations)),
+			map[string]any{"errors": violations})
+	}
+
+	return violations, nil
+}
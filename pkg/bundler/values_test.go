@@ -0,0 +1,172 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/result"
+	cnserrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// schemaRecipe returns a recipe with a GPU Operator version that has a values schema.
+func schemaRecipe() *recipe.RecipeResult {
+	return &recipe.RecipeResult{
+		APIVersion: "cns.nvidia.com/v1alpha1",
+		Kind:       "Recipe",
+		ComponentRefs: []recipe.ComponentRef{
+			{
+				Name:    "gpu-operator",
+				Version: "v25.10.1",
+				Type:    "helm",
+				Source:  "https://helm.ngc.nvidia.com/nvidia",
+			},
+		},
+	}
+}
+
+func TestMake_ValuesSchemaWarnings(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpuoperator": {
+				"dcgmExportr.enabled": "true",
+				"driver.enabled":      "yes",
+				"mig.strategy":        "mixed",
+				"devicePlugin.env":    "none",
+			},
+		}),
+	)
+	b, err := New(WithConfig(cfg))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	output, err := b.Make(context.Background(), schemaRecipe(), t.TempDir())
+	if err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+	if output.HasErrors() {
+		t.Errorf("Make() errors = %v, want warnings only", output.Errors)
+	}
+
+	want := []result.BundleError{
+		{BundlerType: "gpu-operator", Path: "dcgmExportr", Reason: "unknown-key", Error: `dcgmExportr: unknown key "dcgmExportr" (did you mean "dcgmExporter"?)`},
+		{BundlerType: "gpu-operator", Path: "devicePlugin.env", Reason: "type-mismatch", Error: "devicePlugin.env: expected array, got string"},
+		{BundlerType: "gpu-operator", Path: "driver.enabled", Reason: "type-mismatch", Error: "driver.enabled: expected boolean, got string"},
+	}
+	if fmt.Sprint(output.Warnings) != fmt.Sprint(want) {
+		t.Errorf("Warnings =\n%v\nwant\n%v", output.Warnings, want)
+	}
+}
+
+func TestMake_ValuesSchemaStrict(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithStrictValues(true),
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpuoperator": {"gpuDriver.enabled": "true"},
+		}),
+	)
+	b, err := New(WithConfig(cfg))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	_, err = b.Make(context.Background(), schemaRecipe(), dir)
+	if err == nil {
+		t.Fatal("Make() expected error in strict mode")
+	}
+
+	var se *cnserrors.StructuredError
+	if !stderrors.As(err, &se) || se.Code != cnserrors.ErrCodeInvalidRequest {
+		t.Fatalf("Make() error = %v, want %s", err, cnserrors.ErrCodeInvalidRequest)
+	}
+	violations, ok := se.Context["errors"].([]result.BundleError)
+	if !ok || len(violations) != 1 || violations[0].Path != "gpuDriver" {
+		t.Errorf("error context = %v, want one gpuDriver violation", se.Context)
+	}
+	if !strings.Contains(err.Error(), "1 violations") {
+		t.Errorf("error = %v, want violation count", err)
+	}
+}
+
+func TestMake_ValuesSchemaSkipsUnknownVersions(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithStrictValues(true),
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpuoperator": {"gpuDriver.enabled": "true"},
+		}),
+	)
+	b, err := New(WithConfig(cfg))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	rec := schemaRecipe()
+	rec.ComponentRefs[0].Version = "v25.3.3"
+	output, err := b.Make(context.Background(), rec, t.TempDir())
+	if err != nil {
+		t.Fatalf("Make() error = %v, want no validation without schema", err)
+	}
+	if len(output.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none", output.Warnings)
+	}
+}
+
+// TestEmbeddedRecipesMatchValuesSchemas ensures every embedded recipe renders
+// component values that match the vendored values schemas.
+func TestEmbeddedRecipesMatchValuesSchemas(t *testing.T) {
+	ctx := context.Background()
+	builder := recipe.NewBuilder()
+	b, err := New(WithConfig(config.NewConfig(config.WithStrictValues(true))))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, service := range recipe.GetCriteriaServiceTypes() {
+		for _, accelerator := range recipe.GetCriteriaAcceleratorTypes() {
+			for _, intent := range recipe.GetCriteriaIntentTypes() {
+				for _, os := range recipe.GetCriteriaOSTypes() {
+					criteria := recipe.NewCriteria()
+					criteria.Service = recipe.CriteriaServiceType(service)
+					criteria.Accelerator = recipe.CriteriaAcceleratorType(accelerator)
+					criteria.Intent = recipe.CriteriaIntentType(intent)
+					criteria.OS = recipe.CriteriaOSType(os)
+					name := strings.Join([]string{service, accelerator, intent, os}, "/")
+
+					rec, err := builder.BuildFromCriteria(ctx, criteria)
+					if err != nil {
+						t.Fatalf("%s: BuildFromCriteria() error = %v", name, err)
+					}
+					values, err := b.extractComponentValues(ctx, rec)
+					if err != nil {
+						t.Fatalf("%s: extractComponentValues() error = %v", name, err)
+					}
+					if _, err := b.validateComponentValues(rec, values); err != nil {
+						var se *cnserrors.StructuredError
+						stderrors.As(err, &se)
+						t.Errorf("%s: %v %v", name, err, se.Context["errors"])
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/airgap"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// ociLayoutDir is the local OCI image layout created next to the bundle files
+// when a bundle is packaged for a registry. It is not part of the bundle.
+const ociLayoutDir = "oci-layout"
+
+// IssueType classifies a bundle verification failure.
+type IssueType string
+
+const (
+	// IssueChecksumMismatch indicates the file content does not match checksums.txt.
+	IssueChecksumMismatch IssueType = "checksum-mismatch"
+
+	// IssueMissing indicates a file listed in checksums.txt, or produced when
+	// re-deriving the bundle, does not exist.
+	IssueMissing IssueType = "missing"
+
+	// IssueExtra indicates a file that is neither listed in checksums.txt nor
+	// produced when re-deriving the bundle.
+	IssueExtra IssueType = "extra"
+
+	// IssueModified indicates a file differs from the output re-derived from recipe.yaml,
+	// i.e. it was edited after generation.
+	IssueModified IssueType = "modified"
+
+	// IssueAttestationMismatch indicates a file does not match the signed
+	// attestation of the bundle artifact.
+	IssueAttestationMismatch IssueType = "attestation-mismatch"
+)
+
+// VerifyIssue describes a single file that failed verification.
+type VerifyIssue struct {
+	// Type is the kind of failure.
+	Type IssueType `json:"type" yaml:"type"`
+
+	// Path is the file path relative to the bundle directory.
+	Path string `json:"path" yaml:"path"`
+
+	// Detail explains the failure.
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// Provenance describes the verified signature and attestation of a bundle
+// pulled from an OCI registry.
+type Provenance struct {
+	// Digest is the manifest digest of the verified artifact.
+	Digest string `json:"digest" yaml:"digest"`
+
+	// Attested reports whether a signed bundle attestation is attached.
+	Attested bool `json:"attested" yaml:"attested"`
+
+	// CLIVersion is the CLI version recorded in the attestation.
+	CLIVersion string `json:"cliVersion,omitempty" yaml:"cliVersion,omitempty"`
+
+	// SnapshotDigest is the snapshot digest recorded in the attestation.
+	SnapshotDigest string `json:"snapshotDigest,omitempty" yaml:"snapshotDigest,omitempty"`
+}
+
+// VerifyReport is the result of verifying a bundle.
+type VerifyReport struct {
+	// Source is the bundle directory or OCI reference that was verified.
+	Source string `json:"source" yaml:"source"`
+
+	// Version is the bundler version recorded in the bundle metadata.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// Deployer is the deployer recorded in the bundle metadata.
+	Deployer config.DeployerType `json:"deployer,omitempty" yaml:"deployer,omitempty"`
+
+	// Valid is true when no issues were found.
+	Valid bool `json:"valid" yaml:"valid"`
+
+	// FilesChecked is the number of files listed in checksums.txt.
+	FilesChecked int `json:"filesChecked" yaml:"filesChecked"`
+
+	// Regenerated reports whether the bundle was re-derived from its recipe.yaml.
+	Regenerated bool `json:"regenerated" yaml:"regenerated"`
+
+	// Provenance is the verified signature and attestation, when checked.
+	Provenance *Provenance `json:"provenance,omitempty" yaml:"provenance,omitempty"`
+
+	// Warnings lists checks that could not be performed.
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+
+	// Issues lists the files that failed verification, ordered by path.
+	Issues []VerifyIssue `json:"issues" yaml:"issues"`
+}
+
+// VerifyOption is a functional option for Verify.
+type VerifyOption func(*verifyOptions)
+
+type verifyOptions struct {
+	version string
+}
+
+// WithVerifierVersion sets the version of the running bundler. Bundles
+// generated by a different version are not re-derived, because generator
+// changes between versions would be reported as modified files.
+func WithVerifierVersion(version string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.version = version
+	}
+}
+
+// Verify checks the integrity of a bundle directory.
+//
+// It recomputes the checksum of every file listed in checksums.txt and reports
+// mismatched, missing and extra files. When the bundle contains recipe.yaml and
+// bundle.yaml, the bundle is re-derived from the recipe with the recorded
+// bundler settings and every generated file that differs is reported as modified.
+// The data provider must serve the same recipe data that was used to generate
+// the bundle for the regeneration check to be meaningful. With
+// WithVerifierVersion, the regeneration check is skipped with a warning when
+// bundle.yaml records a different version.
+func Verify(ctx context.Context, dir string, opts ...VerifyOption) (*VerifyReport, error) {
+	var o verifyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	report := &VerifyReport{Source: dir, Issues: make([]VerifyIssue, 0)}
+
+	expected, err := checksum.ReadChecksums(dir)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeNotFound,
+			"bundle has no valid checksums", err, map[string]any{"dir": dir})
+	}
+	report.FilesChecked = len(expected)
+
+	actual, err := bundleFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make(map[string]VerifyIssue)
+	addIssue := func(issue VerifyIssue) {
+		key := string(issue.Type) + "\x00" + issue.Path
+		if _, exists := issues[key]; !exists {
+			issues[key] = issue
+		}
+	}
+
+	for path, want := range expected {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(errors.ErrCodeTimeout, "bundle verification cancelled", err)
+		}
+		if _, ok := actual[path]; !ok {
+			addIssue(VerifyIssue{Type: IssueMissing, Path: path, Detail: "listed in " + checksum.ChecksumFileName})
+			continue
+		}
+		got, sumErr := checksum.FileChecksum(filepath.Join(dir, path))
+		if sumErr != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to compute checksum", sumErr)
+		}
+		if got != want {
+			addIssue(VerifyIssue{Type: IssueChecksumMismatch, Path: path,
+				Detail: fmt.Sprintf("expected %s, got %s", want, got)})
+		}
+	}
+
+	// Re-derive the bundle from the embedded recipe and compare generated files
+	regenerated, warning, err := regenerateBundle(ctx, dir, o.version, report)
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		report.Warnings = append(report.Warnings, warning)
+	}
+	if regenerated != nil {
+		report.Regenerated = true
+		for path, want := range regenerated {
+			if _, ok := actual[path]; !ok {
+				addIssue(VerifyIssue{Type: IssueMissing, Path: path, Detail: "produced when re-deriving the bundle"})
+				continue
+			}
+			got, sumErr := checksum.FileChecksum(filepath.Join(dir, path))
+			if sumErr != nil {
+				return nil, errors.Wrap(errors.ErrCodeInternal, "failed to compute checksum", sumErr)
+			}
+			if got != want {
+				addIssue(VerifyIssue{Type: IssueModified, Path: path, Detail: "differs from the output re-derived from " + RecipeFileName})
+			}
+		}
+	}
+
+	for path := range actual {
+		_, listed := expected[path]
+		_, generated := regenerated[path]
+		switch {
+		case !listed && !generated:
+			addIssue(VerifyIssue{Type: IssueExtra, Path: path, Detail: "not listed in " + checksum.ChecksumFileName})
+		case regenerated != nil && !generated:
+			addIssue(VerifyIssue{Type: IssueExtra, Path: path, Detail: "not produced when re-deriving the bundle"})
+		}
+	}
+
+	for _, issue := range issues {
+		report.Issues = append(report.Issues, issue)
+	}
+	report.sortIssues()
+
+	return report, nil
+}
+
+// AddIssue records an issue found by a check outside Verify, such as a
+// signature attestation check, and marks the report invalid.
+func (r *VerifyReport) AddIssue(issue VerifyIssue) {
+	r.Issues = append(r.Issues, issue)
+	r.sortIssues()
+}
+
+func (r *VerifyReport) sortIssues() {
+	sort.Slice(r.Issues, func(i, j int) bool {
+		if r.Issues[i].Path != r.Issues[j].Path {
+			return r.Issues[i].Path < r.Issues[j].Path
+		}
+		return r.Issues[i].Type < r.Issues[j].Type
+	})
+	r.Valid = len(r.Issues) == 0
+}
+
+// ReadRecipe loads the copy of the input recipe from a bundle directory.
+func ReadRecipe(dir string) (*recipe.RecipeResult, error) {
+	path := filepath.Join(dir, RecipeFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeNotFound,
+			"failed to read bundle recipe", err, map[string]any{"path": path})
+	}
+
+	var rec recipe.RecipeResult
+	if err := yaml.Unmarshal(data, &rec); err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+			"failed to parse bundle recipe", err, map[string]any{"path": path})
+	}
+	return &rec, nil
+}
+
+// regenerateBundle re-derives the bundle from its recipe.yaml and bundle.yaml
+// into a temporary directory and returns the checksums of the generated files.
+// Returns a warning instead when the bundle cannot be re-derived, or was
+// generated by a version other than the non-empty version.
+func regenerateBundle(ctx context.Context, dir, version string, report *VerifyReport) (map[string]string, string, error) {
+	metadata, err := ReadMetadata(dir)
+	if err != nil {
+		return nil, fmt.Sprintf("skipped regeneration check: %s not readable", MetadataFileName), nil //nolint:nilerr // reported as warning
+	}
+	report.Version = metadata.Version
+	report.Deployer = metadata.Deployer
+
+	if version != "" && metadata.Version != version {
+		return nil, fmt.Sprintf("skipped regeneration check: bundle was generated by version %s, verifier is %s",
+			metadata.Version, version), nil
+	}
+
+	rec, err := ReadRecipe(dir)
+	if err != nil {
+		return nil, fmt.Sprintf("skipped regeneration check: %s not readable", RecipeFileName), nil //nolint:nilerr // reported as warning
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cns-bundle-verify-*")
+	if err != nil {
+		return nil, "", errors.Wrap(errors.ErrCodeInternal, "failed to create temporary directory", err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(tmpDir); removeErr != nil {
+			slog.Warn("failed to remove temporary directory", "path", tmpDir, "error", removeErr)
+		}
+	}()
+
+	// Local Kustomize sources are re-derived from their copies in the bundle,
+	// and air-gapped bundles from their own vendored charts
+	opts := []config.Option{config.WithKustomizeBundleDir(dir)}
+	if metadata.Airgap {
+		opts = append(opts, config.WithChartCache(filepath.Join(dir, airgap.ChartsDirName)))
+	}
+
+	b, err := NewWithConfig(metadata.Config(opts...))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := b.Make(ctx, rec, tmpDir); err != nil {
+		return nil, "", errors.Wrap(errors.ErrCodeInternal, "failed to re-derive bundle from recipe", err)
+	}
+
+	files, err := bundleFiles(tmpDir)
+	if err != nil {
+		return nil, "", err
+	}
+	sums := make(map[string]string, len(files))
+	for path := range files {
+		sum, sumErr := checksum.FileChecksum(filepath.Join(tmpDir, path))
+		if sumErr != nil {
+			return nil, "", errors.Wrap(errors.ErrCodeInternal, "failed to compute checksum", sumErr)
+		}
+		sums[path] = sum
+	}
+
+	return sums, "", nil
+}
+
+// bundleFiles lists the files of a bundle directory by slash-separated relative
+// path, excluding checksums.txt and the local OCI image layout.
+func bundleFiles(dir string) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == ociLayoutDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel == checksum.ChecksumFileName {
+			return nil
+		}
+		files[rel] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInternal,
+			"failed to list bundle files", err, map[string]any{"dir": dir})
+	}
+	return files, nil
+}
+
+// WriteTable renders the report as an aligned table, implementing
+// serializer.TableWriter.
+func (r *VerifyReport) WriteTable(w io.Writer) error {
+	for _, warning := range r.Warnings {
+		if _, err := fmt.Fprintf(w, "warning: %s\n", warning); err != nil {
+			return err
+		}
+	}
+
+	if p := r.Provenance; p != nil {
+		if _, err := fmt.Fprintf(w, "signature verified: %s\n", p.Digest); err != nil {
+			return err
+		}
+		if p.Attested {
+			snapshot := p.SnapshotDigest
+			if snapshot == "" {
+				snapshot = "none"
+			}
+			if _, err := fmt.Fprintf(w, "attestation verified: cli version %s, snapshot %s\n", p.CLIVersion, snapshot); err != nil {
+				return err
+			}
+		}
+	}
+
+	if r.Valid {
+		_, err := fmt.Fprintf(w, "bundle verified: %d files match %s\n", r.FilesChecked, checksum.ChecksumFileName)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ISSUE\tPATH\tDETAIL")
+	fmt.Fprintln(tw, "-----\t----\t------")
+	for _, issue := range r.Issues {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", issue.Type, issue.Path, issue.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nbundle verification failed: %d issue(s)\n", len(r.Issues))
+	return err
+}
@@ -0,0 +1,383 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundler
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/checksum"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+func verifyTestRecipe() *recipe.RecipeResult {
+	return &recipe.RecipeResult{
+		APIVersion: "cns.nvidia.com/v1alpha1",
+		Kind:       "Recipe",
+		ComponentRefs: []recipe.ComponentRef{
+			{
+				Name:    "gpu-operator",
+				Version: "v25.3.3",
+				Type:    "helm",
+				Source:  "https://helm.ngc.nvidia.com/nvidia",
+			},
+			{
+				Name:    "network-operator",
+				Version: "v25.4.0",
+				Type:    "helm",
+				Source:  "https://helm.ngc.nvidia.com/nvidia",
+			},
+		},
+		DeploymentOrder: []string{"gpu-operator", "network-operator"},
+	}
+}
+
+func makeVerifyTestBundle(t *testing.T, deployer config.DeployerType) string {
+	t.Helper()
+
+	b, err := NewWithConfig(config.NewConfig(
+		config.WithVersion("v1.2.3"),
+		config.WithDeployer(deployer),
+		config.WithValueOverrides(map[string]map[string]string{
+			"gpuoperator": {"driver.version": "570.133.20"},
+		}),
+	))
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := b.Make(context.Background(), verifyTestRecipe(), dir); err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+	return dir
+}
+
+func hasIssue(report *VerifyReport, typ IssueType, path string) bool {
+	for _, issue := range report.Issues {
+		if issue.Type == typ && issue.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		deployer   config.DeployerType
+		tamper     func(t *testing.T, dir string)
+		wantIssues []VerifyIssue
+	}{
+		{
+			name:     "helm bundle unchanged",
+			deployer: config.DeployerHelm,
+		},
+		{
+			name:     "argocd bundle unchanged",
+			deployer: config.DeployerArgoCD,
+		},
+		{
+			name:     "flux bundle unchanged",
+			deployer: config.DeployerFlux,
+		},
+		{
+			name:     "helm-releases bundle unchanged",
+			deployer: config.DeployerHelmReleases,
+		},
+		{
+			name:     "edited file",
+			deployer: config.DeployerHelm,
+			tamper: func(t *testing.T, dir string) {
+				appendToFile(t, filepath.Join(dir, "values.yaml"), "extra: true\n")
+			},
+			wantIssues: []VerifyIssue{
+				{Type: IssueChecksumMismatch, Path: "values.yaml"},
+				{Type: IssueModified, Path: "values.yaml"},
+			},
+		},
+		{
+			name:     "edited file with refreshed checksums",
+			deployer: config.DeployerHelm,
+			tamper: func(t *testing.T, dir string) {
+				appendToFile(t, filepath.Join(dir, "values.yaml"), "extra: true\n")
+				refreshChecksums(t, dir)
+			},
+			wantIssues: []VerifyIssue{
+				{Type: IssueModified, Path: "values.yaml"},
+			},
+		},
+		{
+			name:     "missing file",
+			deployer: config.DeployerHelm,
+			tamper: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "README.md")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantIssues: []VerifyIssue{
+				{Type: IssueMissing, Path: "README.md"},
+			},
+		},
+		{
+			name:     "extra file",
+			deployer: config.DeployerArgoCD,
+			tamper: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hi"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantIssues: []VerifyIssue{
+				{Type: IssueExtra, Path: "notes.txt"},
+			},
+		},
+		{
+			name:     "edited recipe",
+			deployer: config.DeployerHelm,
+			tamper: func(t *testing.T, dir string) {
+				path := filepath.Join(dir, RecipeFileName)
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				data = bytes.ReplaceAll(data, []byte("v25.4.0"), []byte("v25.10.0"))
+				if err := os.WriteFile(path, data, 0600); err != nil {
+					t.Fatal(err)
+				}
+				refreshChecksums(t, dir)
+			},
+			wantIssues: []VerifyIssue{
+				{Type: IssueModified, Path: "Chart.yaml"},
+				{Type: IssueModified, Path: "README.md"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := makeVerifyTestBundle(t, tt.deployer)
+			if tt.tamper != nil {
+				tt.tamper(t, dir)
+			}
+
+			report, err := Verify(context.Background(), dir)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			if !report.Regenerated {
+				t.Errorf("expected bundle to be regenerated, warnings: %v", report.Warnings)
+			}
+			if report.Version != "v1.2.3" || report.Deployer != tt.deployer {
+				t.Errorf("recorded version/deployer = %s/%s, want v1.2.3/%s", report.Version, report.Deployer, tt.deployer)
+			}
+			if report.Valid != (len(tt.wantIssues) == 0) {
+				t.Errorf("Valid = %v, issues: %+v", report.Valid, report.Issues)
+			}
+			for _, want := range tt.wantIssues {
+				if !hasIssue(report, want.Type, want.Path) {
+					t.Errorf("missing issue %s %s, got %+v", want.Type, want.Path, report.Issues)
+				}
+			}
+			if len(tt.wantIssues) == 0 && len(report.Issues) > 0 {
+				t.Errorf("unexpected issues: %+v", report.Issues)
+			}
+		})
+	}
+}
+
+func TestVerify_LocalKustomizeSource(t *testing.T) {
+	source := t.TempDir()
+	files := map[string]string{
+		"kustomization.yaml": "resources:\n  - configmap.yaml\n",
+		"configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: node-config\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(source, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := verifyTestRecipe()
+	rec.ComponentRefs = append(rec.ComponentRefs, recipe.ComponentRef{
+		Name:   "node-config",
+		Type:   recipe.ComponentTypeKustomize,
+		Source: source,
+	})
+	rec.DeploymentOrder = append(rec.DeploymentOrder, "node-config")
+
+	b, err := NewWithConfig(config.NewConfig(config.WithVersion("v1.2.3")))
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	dir := t.TempDir()
+	if _, err := b.Make(context.Background(), rec, dir); err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+
+	// The bundle carries its own copy of the source
+	if _, err := os.Stat(filepath.Join(dir, "kustomize", "node-config", "base", "configmap.yaml")); err != nil {
+		t.Fatalf("local source not copied into the bundle: %v", err)
+	}
+	if err := os.RemoveAll(source); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Verify(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !report.Regenerated || !report.Valid {
+		t.Errorf("Regenerated = %v, Valid = %v, issues: %+v, warnings: %v",
+			report.Regenerated, report.Valid, report.Issues, report.Warnings)
+	}
+}
+
+func TestVerify_NoChecksums(t *testing.T) {
+	dir := makeVerifyTestBundle(t, config.DeployerHelm)
+	if err := os.Remove(checksum.GetChecksumFilePath(dir)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Verify(context.Background(), dir); err == nil {
+		t.Error("expected error for bundle without checksums")
+	}
+}
+
+func TestVerify_WithoutMetadata(t *testing.T) {
+	dir := makeVerifyTestBundle(t, config.DeployerHelm)
+	if err := os.Remove(filepath.Join(dir, MetadataFileName)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Verify(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if report.Regenerated {
+		t.Error("expected regeneration to be skipped without bundle metadata")
+	}
+	if len(report.Warnings) == 0 {
+		t.Error("expected warning about skipped regeneration")
+	}
+	if !hasIssue(report, IssueMissing, MetadataFileName) {
+		t.Errorf("expected missing %s issue, got %+v", MetadataFileName, report.Issues)
+	}
+}
+
+func TestVerify_VerifierVersion(t *testing.T) {
+	dir := makeVerifyTestBundle(t, config.DeployerHelm)
+
+	tests := []struct {
+		name            string
+		version         string
+		wantRegenerated bool
+	}{
+		{"unset", "", true},
+		{"same version", "v1.2.3", true},
+		{"other version", "v1.3.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Verify(context.Background(), dir, WithVerifierVersion(tt.version))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if report.Regenerated != tt.wantRegenerated {
+				t.Errorf("Regenerated = %v, want %v", report.Regenerated, tt.wantRegenerated)
+			}
+			if tt.wantRegenerated == (len(report.Warnings) > 0) {
+				t.Errorf("Warnings = %v", report.Warnings)
+			}
+			if !report.Valid {
+				t.Errorf("unexpected issues: %+v", report.Issues)
+			}
+		})
+	}
+}
+
+func TestVerifyReport_WriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	valid := &VerifyReport{Valid: true, FilesChecked: 5}
+	if err := valid.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "bundle verified: 5 files") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	buf.Reset()
+	invalid := &VerifyReport{Issues: []VerifyIssue{{Type: IssueModified, Path: "values.yaml"}}}
+	if err := invalid.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	for _, want := range []string{"ISSUE", "modified", "values.yaml", "1 issue(s)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q: %q", want, buf.String())
+		}
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	dir := makeVerifyTestBundle(t, config.DeployerArgoCD)
+
+	m, err := ReadMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadMetadata() error = %v", err)
+	}
+	if m.Version != "v1.2.3" || m.Deployer != config.DeployerArgoCD {
+		t.Errorf("metadata = %+v", m)
+	}
+	if got := m.ValueOverrides["gpuoperator"]["driver.version"]; got != "570.133.20" {
+		t.Errorf("value override = %q, want 570.133.20", got)
+	}
+
+	if _, err := ReadMetadata(t.TempDir()); err == nil {
+		t.Error("expected error for missing metadata")
+	}
+}
+
+func appendToFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func refreshChecksums(t *testing.T, dir string) {
+	t.Helper()
+	files, err := bundleFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, filepath.Join(dir, path))
+	}
+	if err := checksum.GenerateChecksums(context.Background(), dir, paths); err != nil {
+		t.Fatal(err)
+	}
+}
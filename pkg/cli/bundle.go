@@ -16,11 +16,14 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"strings"
 
+	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/bundler"
@@ -41,16 +44,28 @@ type bundleCmdOptions struct {
 	deployer                   config.DeployerType
 	repoURL                    string
 	valueOverrides             map[string]map[string]string
+	jsonValueOverrides         map[string]map[string]any
+	valuesFiles                map[string][]map[string]any
 	systemNodeSelector         map[string]string
 	systemNodeTolerations      []corev1.Toleration
 	acceleratedNodeSelector    map[string]string
 	acceleratedNodeTolerations []corev1.Toleration
 
+	// Air-gapped bundle options
+	airgap         bool
+	chartCache     string
+	mirrorRegistry string
+
+	// Fail on values schema violations
+	strictValues bool
+
 	// OCI output reference (nil if outputting to local directory)
 	ociRef        *oci.Reference
 	plainHTTP     bool
 	insecureTLS   bool
 	imageRefsPath string // Path to write published image references (like ko --image-refs)
+	signKeyPath   string // Path to the private key used to sign the pushed artifact
+	snapshotPath  string // Snapshot the recipe was generated from, recorded in the attestation
 }
 
 // parseBundleCmdOptions parses and validates command options.
@@ -62,6 +77,17 @@ func parseBundleCmdOptions(cmd *cli.Command) (*bundleCmdOptions, error) {
 		insecureTLS:    cmd.Bool("insecure-tls"),
 		plainHTTP:      cmd.Bool("plain-http"),
 		imageRefsPath:  cmd.String("image-refs"),
+		signKeyPath:    cmd.String("sign-key"),
+		snapshotPath:   cmd.String("snapshot"),
+		airgap:         cmd.Bool("airgap"),
+		chartCache:     cmd.String("chart-cache"),
+		mirrorRegistry: cmd.String("mirror-registry"),
+		strictValues:   cmd.Bool("strict-values"),
+	}
+
+	// Not marked Required on the flag so that subcommands (verify) can run without it
+	if opts.recipeFilePath == "" {
+		return nil, fmt.Errorf("required flag \"recipe\" not set")
 	}
 
 	// Parse and validate deployer flag using strongly-typed parser
@@ -76,6 +102,13 @@ func parseBundleCmdOptions(cmd *cli.Command) (*bundleCmdOptions, error) {
 		opts.deployer = deployer
 	}
 
+	if !opts.airgap && cmd.IsSet("mirror-registry") {
+		return nil, fmt.Errorf("--mirror-registry requires --airgap")
+	}
+	if opts.airgap && opts.deployer != config.DeployerHelm {
+		return nil, fmt.Errorf("--airgap is only supported with --deployer %s", config.DeployerHelm)
+	}
+
 	// Parse output target (detects oci:// URI or local directory)
 	outputTarget := cmd.String("output")
 	ref, err := oci.ParseOutputTarget(outputTarget)
@@ -83,6 +116,10 @@ func parseBundleCmdOptions(cmd *cli.Command) (*bundleCmdOptions, error) {
 		return nil, fmt.Errorf("invalid --output value: %w", err)
 	}
 
+	if ref.IsOCI && ref.Digest != "" {
+		return nil, fmt.Errorf("invalid --output value: cannot push to a digest reference %q", outputTarget)
+	}
+
 	if ref.IsOCI {
 		// Use CLI version as default tag when not specified in URI
 		if ref.Tag == "" {
@@ -96,12 +133,28 @@ func parseBundleCmdOptions(cmd *cli.Command) (*bundleCmdOptions, error) {
 		opts.outputDir = ref.LocalPath
 	}
 
+	if opts.signKeyPath != "" && opts.ociRef == nil {
+		return nil, fmt.Errorf("--sign-key requires an OCI output (--output oci://...)")
+	}
+
 	// Parse value overrides from --set flags
 	opts.valueOverrides, err = config.ParseValueOverrides(cmd.StringSlice("set"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid --set flag: %w", err)
 	}
 
+	// Parse structured value overrides from --set-json flags
+	opts.jsonValueOverrides, err = config.ParseJSONValueOverrides(cmd.StringSlice("set-json"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --set-json flag: %w", err)
+	}
+
+	// Load values files from --values flags
+	opts.valuesFiles, err = loadValuesFiles(cmd.StringSlice("values"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --values flag: %w", err)
+	}
+
 	// Parse node selectors
 	opts.systemNodeSelector, err = snapshotter.ParseNodeSelectors(cmd.StringSlice("system-node-selector"))
 	if err != nil {
@@ -125,6 +178,30 @@ func parseBundleCmdOptions(cmd *cli.Command) (*bundleCmdOptions, error) {
 	return opts, nil
 }
 
+// loadValuesFiles reads the values files of "component=path/to/values.yaml"
+// arguments, in order, indexed by component.
+func loadValuesFiles(args []string) (map[string][]map[string]any, error) {
+	files := make(map[string][]map[string]any)
+	for _, arg := range args {
+		name, path, err := config.ParseValuesFile(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %q: %w", path, err)
+		}
+
+		values := make(map[string]any)
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse values file %q: %w", path, err)
+		}
+		files[name] = append(files[name], values)
+	}
+	return files, nil
+}
+
 func bundleCmd() *cli.Command {
 	return &cli.Command{
 		Name:                  "bundle",
@@ -132,13 +209,28 @@ func bundleCmd() *cli.Command {
 		EnableShellCompletion: true,
 		Usage:                 "Generate deployment bundle from a given recipe.",
 		Description: `Generates a deployment bundle from a given recipe. 
-Use --deployer argocd to generate ArgoCD Applications.
+Use --deployer argocd to generate ArgoCD Applications, --deployer flux
+to generate Flux sources and HelmReleases, or --deployer helm-releases to
+install each component as its own Helm release.
 
 Helm:
   - Chart.yaml: Helm chart metadata with component dependencies
   - values.yaml: Combined values for all components
   - README.md: Deployment instructions
   - recipe.yaml: Copy of the input recipe for reference
+  - bundle.yaml: Bundler version and settings used for generation
+  - checksums.txt: SHA256 checksums of generated files
+  - charts/<chart>-<version>.tgz: Vendored dependency charts (--airgap only)
+  - images.txt: Container images deployed by the bundle (--airgap only)
+
+Helm releases:
+  - deploy.sh: Installs the components in dependency order, waiting for each
+  - undeploy.sh: Uninstalls the components in reverse order
+  - <component>/values.yaml: Values for each component's release
+  - <component>/manifests/: Chart with the component's additional manifests
+  - README.md: Deployment instructions
+  - recipe.yaml: Copy of the input recipe for reference
+  - bundle.yaml: Bundler version and settings used for generation
   - checksums.txt: SHA256 checksums of generated files
 
 ArgoCD:
@@ -146,6 +238,18 @@ ArgoCD:
   - <component>/application.yaml: ArgoCD Application per component
   - <component>/values.yaml: Values for each component
   - README.md: Deployment instructions
+  - recipe.yaml: Copy of the input recipe for reference
+  - bundle.yaml: Bundler version and settings used for generation
+  - checksums.txt: SHA256 checksums of generated files
+
+Flux:
+  - stack.yaml: GitRepository and top-level Flux Kustomization
+  - kustomization.yaml: Resources applied by the top-level Kustomization
+  - <component>/source.yaml: HelmRepository, OCIRepository or GitRepository
+  - <component>/helmrelease.yaml: HelmRelease with values and dependsOn
+  - README.md: Deployment instructions
+  - recipe.yaml: Copy of the input recipe for reference
+  - bundle.yaml: Bundler version and settings used for generation
   - checksums.txt: SHA256 checksums of generated files
 
 Examples:
@@ -153,30 +257,62 @@ Examples:
 Generate Helm umbrella chart (default):
   cnsctl bundle --recipe recipe.yaml --output ./my-bundle
 
+Generate one Helm release per component with ordered deploy scripts:
+  cnsctl bundle --recipe recipe.yaml --output ./my-bundle --deployer helm-releases
+
 Generate ArgoCD App of Apps:
   cnsctl bundle --recipe recipe.yaml --output ./my-bundle --deployer argocd
 
+Generate Flux resources synced from a Git repository:
+  cnsctl bundle --recipe recipe.yaml --output ./my-bundle --deployer flux \
+    --repo https://github.com/my-org/my-gitops-repo.git
+
 Override values in generated bundle:
   cnsctl bundle --recipe recipe.yaml --set gpuoperator:driver.version=570.133.20
 
+Merge a values file and set structured values (null deletes a key):
+  cnsctl bundle --recipe recipe.yaml --values gpu-operator=gpu-values.yaml \
+    --set-json 'gpuoperator:daemonsets.tolerations=[{"operator":"Exists"}]' \
+    --set-json 'gpuoperator:dcgmExporter.serviceMonitor=null'
+
+Values are applied in order: recipe values, --values files, --set-json, --set.
+
 Set node selectors for GPU workloads:
   cnsctl bundle --recipe recipe.yaml \
     --accelerated-node-selector nodeGroup=gpu-nodes \
     --accelerated-node-toleration nvidia.com/gpu=present:NoSchedule
 
+Generate an air-gapped bundle from a local chart cache, with images
+rewritten to a mirror registry:
+  cnsctl bundle --recipe recipe.yaml --output ./my-bundle --airgap \
+    --chart-cache ~/.cache/helm/repository --mirror-registry registry.example.com
+
 Package and push bundle to OCI registry (uses CLI version as tag):
   cnsctl bundle --recipe recipe.yaml --output oci://ghcr.io/nvidia/cns-bundle
 
 Package with explicit tag (overrides CLI version):
   cnsctl bundle --recipe recipe.yaml --output oci://ghcr.io/nvidia/cns-bundle:v1.0.0
+
+Sign the pushed bundle and attach a provenance attestation:
+  cnsctl bundle --recipe recipe.yaml --snapshot snapshot.yaml \
+    --output oci://ghcr.io/nvidia/cns-bundle:v1.0.0 --sign-key cosign.key
+
+Pull a pushed bundle:
+  cnsctl bundle pull oci://ghcr.io/nvidia/cns-bundle:v1.0.0 -o ./my-bundle
+
+Verify a generated bundle:
+  cnsctl bundle verify ./my-bundle
 `,
+		Commands: []*cli.Command{
+			bundlePullCmd(),
+			bundleVerifyCmd(),
+		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     "recipe",
-				Aliases:  []string{"r"},
-				Required: true,
+				Name:    "recipe",
+				Aliases: []string{"r"},
 				Usage: `Path/URI to previously generated recipe from which to build the bundle.
-	Supports: file paths, HTTP/HTTPS URLs, or ConfigMap URIs (cm://namespace/name).`,
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
 			},
 			&cli.StringFlag{
 				Name:    "output",
@@ -191,6 +327,16 @@ Package with explicit tag (overrides CLI version):
 				Name: "set",
 				Usage: `Override values in generated bundle files 
 	(format: bundler:path.to.field=value, e.g., --set gpuoperator:gds.enabled=true)`,
+			},
+			&cli.StringSliceFlag{
+				Name: "set-json",
+				Usage: `Set structured values in generated bundle files, null deletes the field
+	(format: bundler:path.to.field=<json>, e.g., --set-json 'gpuoperator:tolerations[0]={"operator":"Exists"}')`,
+			},
+			&cli.StringSliceFlag{
+				Name: "values",
+				Usage: `Values file deep merged over a component's recipe values, can be repeated
+	(format: component=path/to/values.yaml, e.g., --values gpu-operator=gpu-values.yaml)`,
 			},
 			&cli.StringSliceFlag{
 				Name:  "system-node-selector",
@@ -217,7 +363,27 @@ Package with explicit tag (overrides CLI version):
 			&cli.StringFlag{
 				Name:  "repo",
 				Value: "",
-				Usage: "Git repository URL for ArgoCD applications (only used with --deployer argocd)",
+				Usage: "Git repository URL for GitOps deployers (used with --deployer argocd or flux)",
+			},
+			&cli.BoolFlag{
+				Name: "airgap",
+				Usage: `Generate a self-contained bundle for disconnected sites (Helm deployer only).
+	Vendors dependency charts into charts/ and lists all container images in images.txt.`,
+			},
+			&cli.StringFlag{
+				Name:    "chart-cache",
+				Sources: cli.EnvVars("HELM_REPOSITORY_CACHE"),
+				Usage: `Directory of chart archives (<chart>-<version>.tgz) used with --airgap.
+	Components with file:// sources are also read from their local repository.`,
+			},
+			&cli.StringFlag{
+				Name:  "mirror-registry",
+				Usage: "Registry that chart images are rewritten to (used with --airgap, e.g. registry.example.com/nvidia)",
+			},
+			&cli.BoolFlag{
+				Name: "strict-values",
+				Usage: `Fail when component values do not match the chart's values schema
+	(unknown keys, type mismatches). Violations are reported as warnings otherwise.`,
 			},
 			kubeconfigFlag,
 			dataFlag,
@@ -234,6 +400,15 @@ Package with explicit tag (overrides CLI version):
 				Name:  "image-refs",
 				Usage: "Path to file where the published image reference will be written (only used with OCI output)",
 			},
+			&cli.StringFlag{
+				Name: "sign-key",
+				Usage: `Path to a PEM encoded private key used to sign the pushed artifact (only used with OCI output).
+	Pushes a cosign-compatible signature and a signed in-toto attestation of the recipe and CLI version.`,
+			},
+			&cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "Path/URI to the snapshot the recipe was generated from; its digest is recorded in the attestation (only used with --sign-key)",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			// Initialize external data provider if --data flag is set
@@ -247,8 +422,15 @@ Package with explicit tag (overrides CLI version):
 			}
 
 			outputType := "Helm umbrella chart"
-			if opts.deployer == config.DeployerArgoCD {
+			switch opts.deployer {
+			case config.DeployerArgoCD:
 				outputType = "ArgoCD applications"
+			case config.DeployerFlux:
+				outputType = "Flux resources"
+			case config.DeployerHelmReleases:
+				outputType = "Helm releases"
+			case config.DeployerHelm:
+				// default output type
 			}
 			slog.Info("generating bundle",
 				slog.String("deployer", opts.deployer.String()),
@@ -270,7 +452,13 @@ Package with explicit tag (overrides CLI version):
 				config.WithVersion(version),
 				config.WithDeployer(opts.deployer),
 				config.WithRepoURL(opts.repoURL),
+				config.WithAirgap(opts.airgap),
+				config.WithStrictValues(opts.strictValues),
+				config.WithChartCache(opts.chartCache),
+				config.WithMirrorRegistry(opts.mirrorRegistry),
 				config.WithValueOverrides(opts.valueOverrides),
+				config.WithJSONValueOverrides(opts.jsonValueOverrides),
+				config.WithValuesFiles(opts.valuesFiles),
 				config.WithSystemNodeSelector(opts.systemNodeSelector),
 				config.WithSystemNodeTolerations(opts.systemNodeTolerations),
 				config.WithAcceleratedNodeSelector(opts.acceleratedNodeSelector),
@@ -305,7 +493,7 @@ Package with explicit tag (overrides CLI version):
 
 			// Package and push as OCI artifact when output is oci://
 			if opts.ociRef != nil {
-				if err := pushOCIBundle(ctx, opts, out); err != nil {
+				if err := pushOCIBundle(ctx, opts, rec, out); err != nil {
 					return err
 				}
 			}
@@ -316,7 +504,7 @@ Package with explicit tag (overrides CLI version):
 }
 
 // pushOCIBundle packages and pushes the bundle to an OCI registry.
-func pushOCIBundle(ctx context.Context, opts *bundleCmdOptions, out *result.Output) error {
+func pushOCIBundle(ctx context.Context, opts *bundleCmdOptions, rec *recipe.RecipeResult, out *result.Output) error {
 	pushResult, err := oci.PackageAndPush(ctx, oci.OutputConfig{
 		SourceDir:   opts.outputDir,
 		OutputDir:   opts.outputDir,
@@ -329,6 +517,12 @@ func pushOCIBundle(ctx context.Context, opts *bundleCmdOptions, out *result.Outp
 		return err
 	}
 
+	if opts.signKeyPath != "" {
+		if err := signOCIBundle(ctx, opts, rec, pushResult.Digest); err != nil {
+			return err
+		}
+	}
+
 	// Update results with OCI metadata
 	for i := range out.Results {
 		if out.Results[i].Success {
@@ -348,6 +542,62 @@ func pushOCIBundle(ctx context.Context, opts *bundleCmdOptions, out *result.Outp
 	return nil
 }
 
+// signOCIBundle signs the pushed bundle and attaches an attestation of the
+// recipe, snapshot digest and CLI version it was generated from.
+func signOCIBundle(ctx context.Context, opts *bundleCmdOptions, rec *recipe.RecipeResult, digest string) error {
+	key, err := oci.LoadPrivateKey(opts.signKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load signing key: %w", err)
+	}
+
+	recipeJSON, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to serialize recipe for attestation: %w", err)
+	}
+	attestation := &oci.BundleAttestation{
+		CLIVersion: version,
+		Recipe:     recipeJSON,
+	}
+	if opts.snapshotPath != "" {
+		attestation.SnapshotDigest, err = snapshotDigest(opts.snapshotPath, opts.kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	signResult, err := oci.Sign(ctx, oci.SignOptions{
+		Registry:    opts.ociRef.Registry,
+		Repository:  opts.ociRef.Repository,
+		Digest:      digest,
+		Key:         key,
+		Attestation: attestation,
+		PlainHTTP:   opts.plainHTTP,
+		InsecureTLS: opts.insecureTLS,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign bundle: %w", err)
+	}
+
+	slog.Info("bundle signed",
+		"signature", signResult.SignatureReference,
+		"attestation", signResult.AttestationDigest)
+	return nil
+}
+
+// snapshotDigest returns the SHA256 digest of the JSON encoding of a snapshot,
+// so the digest does not depend on the format or location it was loaded from.
+func snapshotDigest(path, kubeconfig string) (string, error) {
+	snap, err := serializer.FromFileWithKubeconfig[snapshotter.Snapshot](path, kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to load snapshot from %q: %w", path, err)
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize snapshot: %w", err)
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data)), nil
+}
+
 // printDeploymentInstructions prints user-friendly deployment instructions from the deployer.
 func printDeploymentInstructions(out *result.Output) {
 	fmt.Printf("\n%s generated successfully!\n", out.Deployment.Type)
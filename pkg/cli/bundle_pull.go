@@ -0,0 +1,158 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
+)
+
+func bundlePullCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "pull",
+		EnableShellCompletion: true,
+		Usage:                 "Pull a bundle from an OCI registry and unpack it.",
+		ArgsUsage:             "<oci://registry/repository[:tag][@digest]>",
+		Description: `Pull a bundle pushed with 'cnsctl bundle --output oci://...' and unpack it
+into a local directory.
+
+The manifest and every layer are verified against their digests while they
+are downloaded. Pin a digest in the reference (@sha256:...) to require that
+exact artifact; when a tag is also given it must still point at the digest.
+If neither a tag nor a digest is given, the CLI version is used as the tag,
+matching the default of 'cnsctl bundle'.
+
+Registry credentials are read from the Docker configuration (~/.docker/config.json).
+
+Examples:
+
+Pull a bundle into ./my-bundle:
+  cnsctl bundle pull oci://ghcr.io/nvidia/cns-bundle:v1.0.0 -o ./my-bundle
+
+Pull an exact artifact:
+  cnsctl bundle pull oci://ghcr.io/nvidia/cns-bundle@sha256:4f5c... -o ./my-bundle
+
+Require a valid signature before pulling:
+  cnsctl bundle pull oci://ghcr.io/nvidia/cns-bundle:v1.0.0 --key cosign.pub -o ./my-bundle
+
+Pull from a local development registry:
+  cnsctl bundle pull oci://localhost:5000/cns-bundle:dev --plain-http -o ./my-bundle
+`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   ".",
+				Usage:   "Directory to unpack the bundle into",
+			},
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "Path to a PEM encoded public key; the artifact must be signed with the matching private key",
+			},
+			&cli.BoolFlag{
+				Name:  "insecure-tls",
+				Usage: "Skip TLS certificate verification for OCI registry",
+			},
+			&cli.BoolFlag{
+				Name:  "plain-http",
+				Usage: "Use HTTP instead of HTTPS for OCI registry (for local development)",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return fmt.Errorf("expected exactly one OCI reference, got %d", cmd.Args().Len())
+			}
+			target := cmd.Args().First()
+
+			ref, err := oci.ParseOutputTarget(target)
+			if err != nil {
+				return fmt.Errorf("invalid bundle reference: %w", err)
+			}
+			if !ref.IsOCI {
+				return fmt.Errorf("bundle reference %q must be an OCI URI (oci://registry/repository:tag)", target)
+			}
+
+			result, _, err := pullOCIBundle(ctx, cmd, ref, cmd.String("output"))
+			if err != nil {
+				return err
+			}
+
+			slog.Info("bundle pulled",
+				"reference", result.Reference,
+				"digest", result.Digest,
+				"output_dir", result.OutputDir)
+			return nil
+		},
+	}
+}
+
+// pullOCIBundle pulls a bundle into outputDir. When --key is set, the signature
+// of the artifact is verified first and the verified digest is pulled, so the
+// tag cannot be moved between verification and download.
+func pullOCIBundle(ctx context.Context, cmd *cli.Command, ref *oci.Reference, outputDir string) (*oci.PullResult, *oci.VerifySignatureResult, error) {
+	// Use CLI version as default tag, as when pushing
+	if ref.Tag == "" && ref.Digest == "" {
+		ref = ref.WithTag(version)
+	}
+	plainHTTP := cmd.Bool("plain-http")
+	insecureTLS := cmd.Bool("insecure-tls")
+
+	pullDigest := ref.Digest
+	var signature *oci.VerifySignatureResult
+	if keyPath := cmd.String("key"); keyPath != "" {
+		key, err := oci.LoadPublicKey(keyPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load public key: %w", err)
+		}
+		verifyRef := ref.Digest
+		if verifyRef == "" {
+			verifyRef = ref.Tag
+		}
+		signature, err = oci.VerifySignature(ctx, oci.VerifySignatureOptions{
+			Registry:    ref.Registry,
+			Repository:  ref.Repository,
+			Reference:   verifyRef,
+			PublicKey:   key,
+			PlainHTTP:   plainHTTP,
+			InsecureTLS: insecureTLS,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature verification failed: %w", err)
+		}
+		slog.Info("signature verified", "digest", signature.Digest, "attested", signature.Attestation != nil)
+		pullDigest = signature.Digest
+	}
+
+	result, err := oci.Pull(ctx, oci.PullOptions{
+		Registry:    ref.Registry,
+		Repository:  ref.Repository,
+		Tag:         ref.Tag,
+		Digest:      pullDigest,
+		OutputDir:   outputDir,
+		PlainHTTP:   plainHTTP,
+		InsecureTLS: insecureTLS,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pull bundle: %w", err)
+	}
+	slog.Debug("pulled bundle", "reference", result.Reference, "digest", result.Digest)
+
+	return result, signature, nil
+}
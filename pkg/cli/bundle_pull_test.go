@@ -0,0 +1,85 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci/ocitest"
+)
+
+func TestBundlePullCmd_Run(t *testing.T) {
+	reg := ocitest.NewRegistry(t)
+	bundleDir := makeBundleVerifyTestBundle(t)
+
+	pushed, err := oci.PackageAndPush(context.Background(), oci.OutputConfig{
+		SourceDir: bundleDir,
+		OutputDir: t.TempDir(),
+		Reference: &oci.Reference{IsOCI: true, Registry: reg.Host(), Repository: "test/bundle", Tag: "v1"},
+		PlainHTTP: true,
+	})
+	if err != nil {
+		t.Fatalf("PackageAndPush() error = %v", err)
+	}
+	base := "oci://" + reg.Host() + "/test/bundle"
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "by tag", args: []string{"--plain-http", base + ":v1"}},
+		{name: "pinned digest", args: []string{"--plain-http", base + ":v1@" + pushed.Digest}},
+		{
+			name:    "digest mismatch",
+			args:    []string{"--plain-http", base + ":v1@sha256:" + strings.Repeat("0", 64)},
+			wantErr: "does not match the expected digest",
+		},
+		{name: "local path", args: []string{bundleDir}, wantErr: "must be an OCI URI"},
+		{name: "missing key file", args: []string{"--plain-http", "--key", filepath.Join(t.TempDir(), "missing.pub"), base + ":v1"},
+			wantErr: "failed to load public key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "pulled")
+			args := append([]string{"pull", "--output", out}, tt.args...)
+
+			err := bundlePullCmd().Run(context.Background(), args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// The pulled bundle must verify like the original
+			if _, err := os.Stat(filepath.Join(out, "checksums.txt")); err != nil {
+				t.Fatalf("pulled bundle missing checksums.txt: %v", err)
+			}
+			if err := bundleVerifyCmd().Run(context.Background(), []string{"verify", "--output", filepath.Join(t.TempDir(), "report"), out}); err != nil {
+				t.Errorf("pulled bundle failed verification: %v", err)
+			}
+		})
+	}
+}
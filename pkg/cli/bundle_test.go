@@ -15,6 +15,8 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -158,19 +160,22 @@ func TestBundleCmd(t *testing.T) {
 	}
 
 	// Required flags for the new URI-based output approach
-	requiredFlags := []string{"recipe", "r", "output", "o", "set", "plain-http", "insecure-tls"}
+	requiredFlags := []string{"recipe", "r", "output", "o", "set", "set-json", "values", "plain-http", "insecure-tls"}
 	for _, flag := range requiredFlags {
 		if !flagNames[flag] {
 			t.Errorf("expected flag %q to be defined", flag)
 		}
 	}
 
-	// Verify node selector/toleration flags exist
+	// Verify node selector/toleration and air-gap flags exist
 	nodeFlags := []string{
 		"system-node-selector",
 		"system-node-toleration",
 		"accelerated-node-selector",
 		"accelerated-node-toleration",
+		"airgap",
+		"chart-cache",
+		"mirror-registry",
 	}
 	for _, flag := range nodeFlags {
 		if !flagNames[flag] {
@@ -186,3 +191,42 @@ func TestBundleCmd(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadValuesFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.yaml")
+	second := filepath.Join(dir, "second.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	writeFile(first, "driver:\n  version: 570.86.16\n")
+	writeFile(second, "daemonsets:\n  tolerations:\n  - operator: Exists\n")
+	writeFile(invalid, "driver: [unterminated\n")
+
+	got, err := loadValuesFiles([]string{"gpu-operator=" + first, "gpu-operator=" + second})
+	if err != nil {
+		t.Fatalf("loadValuesFiles() error = %v", err)
+	}
+	want := map[string][]map[string]any{
+		"gpu-operator": {
+			{"driver": map[string]any{"version": "570.86.16"}},
+			{"daemonsets": map[string]any{"tolerations": []any{map[string]any{"operator": "Exists"}}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadValuesFiles() = %v, want %v", got, want)
+	}
+
+	for _, arg := range []string{
+		first,
+		"gpu-operator=" + filepath.Join(dir, "missing.yaml"),
+		"gpu-operator=" + invalid,
+	} {
+		if _, err := loadValuesFiles([]string{arg}); err == nil {
+			t.Errorf("loadValuesFiles(%q) expected error, got nil", arg)
+		}
+	}
+}
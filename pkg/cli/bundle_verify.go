@@ -0,0 +1,237 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+)
+
+func bundleVerifyCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "verify",
+		EnableShellCompletion: true,
+		Usage:                 "Verify a bundle against its checksums and recipe.",
+		ArgsUsage:             "<dir|oci://registry/repository:tag>",
+		Description: `Verify the integrity of a previously generated bundle:
+  - Recompute the SHA256 checksum of every file listed in checksums.txt
+  - Report files that are missing from, or not listed in, checksums.txt
+  - Re-derive the bundle from its recipe.yaml using the settings recorded in
+    bundle.yaml, and report generated files that were edited after generation.
+    This check is skipped with a warning when bundle.yaml records a CLI version
+    other than the running one, since generated output changes between versions
+
+With --key, a bundle pulled from an OCI registry must carry a signature made
+with the matching private key (see 'cnsctl bundle --sign-key'). The signed
+attestation, when attached, must match the bundle's recipe.yaml and the CLI
+version recorded in bundle.yaml. The verified digest is pulled, so the tag
+cannot be moved between verification and download.
+
+The command exits with a non-zero status when any issue is found.
+
+Examples:
+
+Verify a local bundle directory:
+  cnsctl bundle verify ./my-bundle
+
+Verify a bundle pushed to an OCI registry:
+  cnsctl bundle verify oci://ghcr.io/nvidia/cns-bundle:v1.0.0
+
+Verify the signature and attestation of a pushed bundle:
+  cnsctl bundle verify oci://ghcr.io/nvidia/cns-bundle:v1.0.0 --key cosign.pub
+
+Write the report as JSON:
+  cnsctl bundle verify ./my-bundle -t json -o report.json
+`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "Path to a PEM encoded public key; requires a valid signature on the OCI artifact",
+			},
+			&cli.BoolFlag{
+				Name:  "insecure-tls",
+				Usage: "Skip TLS certificate verification for OCI registry",
+			},
+			&cli.BoolFlag{
+				Name:  "plain-http",
+				Usage: "Use HTTP instead of HTTPS for OCI registry (for local development)",
+			},
+			dataFlag,
+			outputFlag,
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"t"},
+				Value:   string(serializer.FormatTable),
+				Usage:   fmt.Sprintf("output format (%s)", strings.Join(serializer.SupportedFormats(), ", ")),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return fmt.Errorf("expected exactly one bundle directory or OCI reference, got %d", cmd.Args().Len())
+			}
+			target := cmd.Args().First()
+
+			// Regeneration uses the same data provider as bundle generation
+			if err := initDataProvider(cmd); err != nil {
+				return fmt.Errorf("failed to initialize data provider: %w", err)
+			}
+
+			outFormat, err := parseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			dir, signature, cleanup, err := resolveBundleDir(ctx, cmd, target)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			slog.Info("verifying bundle", "source", target)
+
+			report, err := bundler.Verify(ctx, dir, bundler.WithVerifierVersion(version))
+			if err != nil {
+				return fmt.Errorf("failed to verify bundle: %w", err)
+			}
+			report.Source = target
+			if signature != nil {
+				checkAttestation(dir, report, signature)
+			}
+
+			for _, warning := range report.Warnings {
+				slog.Warn("bundle verification incomplete", "reason", warning)
+			}
+
+			ser, err := serializer.NewFileWriterOrStdout(outFormat, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to create output writer: %w", err)
+			}
+			defer func() {
+				if closer, ok := ser.(interface{ Close() error }); ok {
+					if err := closer.Close(); err != nil {
+						slog.Warn("failed to close serializer", "error", err)
+					}
+				}
+			}()
+
+			if err := ser.Serialize(ctx, report); err != nil {
+				return fmt.Errorf("failed to serialize verification report: %w", err)
+			}
+
+			if !report.Valid {
+				return fmt.Errorf("bundle verification failed: %d issue(s) found", len(report.Issues))
+			}
+
+			slog.Info("bundle verified", "files", report.FilesChecked, "regenerated", report.Regenerated)
+			return nil
+		},
+	}
+}
+
+// resolveBundleDir returns the local directory of a bundle target. OCI
+// references are pulled into a temporary directory that is removed by the
+// returned cleanup function.
+func resolveBundleDir(ctx context.Context, cmd *cli.Command, target string) (string, *oci.VerifySignatureResult, func(), error) {
+	ref, err := oci.ParseOutputTarget(target)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("invalid bundle reference: %w", err)
+	}
+	if !ref.IsOCI {
+		if cmd.String("key") != "" {
+			return "", nil, nil, fmt.Errorf("--key requires an OCI reference (oci://...)")
+		}
+		return ref.LocalPath, nil, func() {}, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cns-bundle-*")
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			slog.Warn("failed to remove temporary directory", "path", tmpDir, "error", err)
+		}
+	}
+
+	_, signature, err := pullOCIBundle(ctx, cmd, ref, tmpDir)
+	if err != nil {
+		cleanup()
+		return "", nil, nil, err
+	}
+	return tmpDir, signature, cleanup, nil
+}
+
+// checkAttestation records the verified signature in the report and compares
+// the attested recipe and CLI version with the pulled bundle.
+func checkAttestation(dir string, report *bundler.VerifyReport, signature *oci.VerifySignatureResult) {
+	report.Provenance = &bundler.Provenance{Digest: signature.Digest}
+
+	att := signature.Attestation
+	if att == nil {
+		report.Warnings = append(report.Warnings, "artifact is signed but has no bundle attestation")
+		return
+	}
+	report.Provenance.Attested = true
+	report.Provenance.CLIVersion = att.CLIVersion
+	report.Provenance.SnapshotDigest = att.SnapshotDigest
+
+	if report.Version != "" && att.CLIVersion != report.Version {
+		report.AddIssue(bundler.VerifyIssue{
+			Type:   bundler.IssueAttestationMismatch,
+			Path:   bundler.MetadataFileName,
+			Detail: fmt.Sprintf("records version %s, attestation records %s", report.Version, att.CLIVersion),
+		})
+	}
+
+	if len(att.Recipe) == 0 {
+		return
+	}
+	var attested recipe.RecipeResult
+	if err := json.Unmarshal(att.Recipe, &attested); err != nil {
+		report.AddIssue(bundler.VerifyIssue{
+			Type:   bundler.IssueAttestationMismatch,
+			Path:   bundler.RecipeFileName,
+			Detail: "attested recipe is not a valid recipe",
+		})
+		return
+	}
+	bundled, err := bundler.ReadRecipe(dir)
+	if err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("skipped attestation recipe check: %s not readable", bundler.RecipeFileName))
+		return
+	}
+	// Compare the JSON encodings so both sides are normalized the same way
+	want, wantErr := json.Marshal(attested)
+	got, gotErr := json.Marshal(bundled)
+	if wantErr != nil || gotErr != nil || !bytes.Equal(want, got) {
+		report.AddIssue(bundler.VerifyIssue{
+			Type:   bundler.IssueAttestationMismatch,
+			Path:   bundler.RecipeFileName,
+			Detail: "differs from the attested recipe",
+		})
+	}
+}
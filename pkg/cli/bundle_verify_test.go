@@ -0,0 +1,321 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler"
+	"github.com/NVIDIA/cloud-native-stack/pkg/bundler/config"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci/ocitest"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+func makeBundleVerifyTestBundle(t *testing.T) string {
+	t.Helper()
+
+	b, err := bundler.NewWithConfig(config.NewConfig(config.WithVersion(version)))
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	rec := &recipe.RecipeResult{
+		APIVersion: "cns.nvidia.com/v1alpha1",
+		Kind:       "Recipe",
+		ComponentRefs: []recipe.ComponentRef{
+			{
+				Name:    "gpu-operator",
+				Version: "v25.3.3",
+				Type:    "helm",
+				Source:  "https://helm.ngc.nvidia.com/nvidia",
+			},
+		},
+		DeploymentOrder: []string{"gpu-operator"},
+	}
+
+	dir := t.TempDir()
+	if _, err := b.Make(context.Background(), rec, dir); err != nil {
+		t.Fatalf("Make() error = %v", err)
+	}
+	return dir
+}
+
+func TestBundleVerifyCmd_CommandStructure(t *testing.T) {
+	cmd := bundleVerifyCmd()
+
+	if cmd.Name != "verify" {
+		t.Errorf("Name = %v, want verify", cmd.Name)
+	}
+
+	requiredFlags := []string{"plain-http", "insecure-tls", "data", "output", "format"}
+	for _, flagName := range requiredFlags {
+		found := false
+		for _, flag := range cmd.Flags {
+			if hasName(flag, flagName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("required flag %q not found", flagName)
+		}
+	}
+}
+
+func TestBundleVerifyCmd_Run(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(t *testing.T, dir string)
+		args    []string
+		wantErr string
+		wantOut string
+	}{
+		{
+			name:    "unchanged bundle",
+			wantOut: "bundle verified",
+		},
+		{
+			name: "edited values",
+			tamper: func(t *testing.T, dir string) {
+				path := filepath.Join(dir, "values.yaml")
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, append(data, []byte("extra: true\n")...), 0600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "bundle verification failed",
+			wantOut: "values.yaml",
+		},
+		{
+			name:    "json report",
+			args:    []string{"--format", "json"},
+			wantOut: `"valid": true`,
+		},
+		{
+			name: "not a bundle",
+			tamper: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "checksums.txt")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "failed to verify bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := makeBundleVerifyTestBundle(t)
+			if tt.tamper != nil {
+				tt.tamper(t, dir)
+			}
+
+			out := filepath.Join(t.TempDir(), "verify.out")
+			args := append([]string{"verify", "--output", out}, tt.args...)
+			args = append(args, dir)
+
+			err := bundleVerifyCmd().Run(context.Background(), args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantOut == "" {
+				return
+			}
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("failed to read output: %v", err)
+			}
+			if !strings.Contains(string(data), tt.wantOut) {
+				t.Errorf("output = %q, want it to contain %q", data, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestBundleVerifyCmd_RequiresArgument(t *testing.T) {
+	err := bundleVerifyCmd().Run(context.Background(), []string{"verify"})
+	if err == nil || !strings.Contains(err.Error(), "expected exactly one") {
+		t.Errorf("error = %v, want argument error", err)
+	}
+}
+
+const bundleVerifyTestRecipe = `apiVersion: cns.nvidia.com/v1alpha1
+kind: Recipe
+componentRefs:
+  - name: gpu-operator
+    version: v25.3.3
+    type: helm
+    source: https://helm.ngc.nvidia.com/nvidia
+deploymentOrder:
+  - gpu-operator
+`
+
+func writeTestKeyPair(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privPath := filepath.Join(dir, "cosign.key")
+	pubPath := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return privPath, pubPath
+}
+
+func TestBundleVerifyCmd_SignedOCIBundle(t *testing.T) {
+	ctx := context.Background()
+	reg := ocitest.NewRegistry(t)
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+
+	recipePath := filepath.Join(workDir, "recipe.yaml")
+	if err := os.WriteFile(recipePath, []byte(bundleVerifyTestRecipe), 0600); err != nil {
+		t.Fatal(err)
+	}
+	signKey, verifyKey := writeTestKeyPair(t, workDir)
+	_, otherKey := writeTestKeyPair(t, t.TempDir())
+	target := "oci://" + reg.Host() + "/test/bundle:v1"
+
+	err := bundleCmd().Run(ctx, []string{"bundle", "--recipe", recipePath, "--output", target,
+		"--plain-http", "--sign-key", signKey})
+	if err != nil {
+		t.Fatalf("bundle push error = %v", err)
+	}
+
+	verify := func(key string) (string, error) {
+		out := filepath.Join(t.TempDir(), "verify.out")
+		runErr := bundleVerifyCmd().Run(ctx, []string{"verify", "--plain-http", "--key", key,
+			"--format", "json", "--output", out, target})
+		data, _ := os.ReadFile(out)
+		return string(data), runErr
+	}
+
+	out, err := verify(verifyKey)
+	if err != nil {
+		t.Fatalf("verify error = %v", err)
+	}
+	for _, want := range []string{`"valid": true`, `"attested": true`, `"cliVersion": "` + version + `"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %s: %s", want, out)
+		}
+	}
+
+	if _, err := verify(otherKey); err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+		t.Errorf("verify with other key error = %v, want signature failure", err)
+	}
+
+	// Moving the tag to an unsigned bundle must fail verification
+	if err := os.RemoveAll(filepath.Join(workDir, "bundle")); err != nil {
+		t.Fatal(err)
+	}
+	err = bundleCmd().Run(ctx, []string{"bundle", "--recipe", recipePath, "--output", target,
+		"--plain-http", "--set", "gpuoperator:driver.version=1"})
+	if err != nil {
+		t.Fatalf("bundle push error = %v", err)
+	}
+	if _, err := verify(verifyKey); err == nil || !strings.Contains(err.Error(), "no signature found") {
+		t.Errorf("verify of unsigned tag error = %v, want missing signature", err)
+	}
+}
+
+func TestCheckAttestation(t *testing.T) {
+	dir := makeBundleVerifyTestBundle(t)
+
+	tests := []struct {
+		name      string
+		att       *oci.BundleAttestation
+		wantIssue string
+		wantWarn  bool
+	}{
+		{
+			name:     "no attestation",
+			wantWarn: true,
+		},
+		{
+			name: "matching attestation",
+			att:  &oci.BundleAttestation{CLIVersion: version},
+		},
+		{
+			name:      "version mismatch",
+			att:       &oci.BundleAttestation{CLIVersion: "v0.0.1"},
+			wantIssue: bundler.MetadataFileName,
+		},
+		{
+			name:      "recipe mismatch",
+			att:       &oci.BundleAttestation{CLIVersion: version, Recipe: []byte(`{"kind":"Recipe","deploymentOrder":["other"]}`)},
+			wantIssue: bundler.RecipeFileName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := bundler.Verify(context.Background(), dir)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			checkAttestation(dir, report, &oci.VerifySignatureResult{Digest: "sha256:abc", Attestation: tt.att})
+
+			if report.Provenance == nil || report.Provenance.Digest != "sha256:abc" {
+				t.Errorf("Provenance = %+v", report.Provenance)
+			}
+			if tt.wantWarn != (len(report.Warnings) > 0) {
+				t.Errorf("Warnings = %v", report.Warnings)
+			}
+			if tt.wantIssue == "" {
+				if !report.Valid {
+					t.Errorf("unexpected issues: %+v", report.Issues)
+				}
+				return
+			}
+			if report.Valid || len(report.Issues) != 1 || report.Issues[0].Path != tt.wantIssue ||
+				report.Issues[0].Type != bundler.IssueAttestationMismatch {
+				t.Errorf("Issues = %+v, want attestation mismatch on %s", report.Issues, tt.wantIssue)
+			}
+		})
+	}
+}
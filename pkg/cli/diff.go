@@ -0,0 +1,149 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+func diffCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "diff",
+		Category:              functionalCategoryName,
+		EnableShellCompletion: true,
+		Usage:                 "Compare two system snapshots.",
+		Description: `Compare two snapshots and report the readings that were added, removed or
+changed, grouped by measurement type, subtype and key.
+
+The command exits with a non-zero status when the snapshots differ, which
+allows it to gate CI pipelines on configuration drift.
+
+# Examples
+
+Compare two snapshot files:
+  cnsctl diff --before baseline.yaml --after current.yaml
+
+Compare a stored baseline with the snapshot written by the agent:
+  cnsctl diff --before baseline.yaml --after cm://gpu-operator/cns-snapshot
+
+Only compare kernel parameters, ignoring volatile values:
+  cnsctl diff --before a.yaml --after b.yaml --include 'OS.sysctl.*' --exclude '*random*'
+
+Report drift as JSON without failing:
+  cnsctl diff --before a.yaml --after b.yaml -t json --fail-on-drift=false
+`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "before",
+				Required: true,
+				Usage: `Path/URI to the baseline snapshot.
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
+			},
+			&cli.StringFlag{
+				Name:     "after",
+				Required: true,
+				Usage: `Path/URI to the snapshot compared against the baseline.
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
+			},
+			&cli.StringSliceFlag{
+				Name:  "include",
+				Usage: "Only compare readings whose {Type}.{Subtype}.{Key} path matches the pattern (wildcard '*', can be repeated)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "exclude",
+				Usage: "Ignore readings whose {Type}.{Subtype}.{Key} path matches the pattern (wildcard '*', can be repeated)",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-on-drift",
+				Value: true,
+				Usage: "Exit with non-zero status if the snapshots differ",
+			},
+			outputFlag,
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"t"},
+				Value:   string(serializer.FormatTable),
+				Usage:   fmt.Sprintf("output format (%s)", strings.Join(serializer.SupportedFormats(), ", ")),
+			},
+			kubeconfigFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			outFormat, err := parseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			beforePath := cmd.String("before")
+			afterPath := cmd.String("after")
+			kubeconfig := cmd.String("kubeconfig")
+
+			slog.Info("loading snapshots", "before", beforePath, "after", afterPath)
+
+			before, err := serializer.FromFileWithKubeconfig[snapshotter.Snapshot](beforePath, kubeconfig)
+			if err != nil {
+				return fmt.Errorf("failed to load snapshot from %q: %w", beforePath, err)
+			}
+			after, err := serializer.FromFileWithKubeconfig[snapshotter.Snapshot](afterPath, kubeconfig)
+			if err != nil {
+				return fmt.Errorf("failed to load snapshot from %q: %w", afterPath, err)
+			}
+
+			diff, err := snapshotter.NewSnapshotDiff(version, before, after, snapshotter.DiffFilter{
+				Include: cmd.StringSlice("include"),
+				Exclude: cmd.StringSlice("exclude"),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to compare snapshots: %w", err)
+			}
+			diff.Before = beforePath
+			diff.After = afterPath
+
+			ser, err := serializer.NewFileWriterOrStdout(outFormat, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to create output writer: %w", err)
+			}
+			defer func() {
+				if closer, ok := ser.(interface{ Close() error }); ok {
+					if err := closer.Close(); err != nil {
+						slog.Warn("failed to close serializer", "error", err)
+					}
+				}
+			}()
+
+			if err := ser.Serialize(ctx, diff); err != nil {
+				return fmt.Errorf("failed to serialize snapshot diff: %w", err)
+			}
+
+			slog.Info("diff completed",
+				"added", diff.Summary.Added,
+				"removed", diff.Summary.Removed,
+				"changed", diff.Summary.Changed)
+
+			if cmd.Bool("fail-on-drift") && diff.HasDrift() {
+				return fmt.Errorf("snapshots differ: %d reading(s) changed", diff.Summary.Total)
+			}
+
+			return nil
+		},
+	}
+}
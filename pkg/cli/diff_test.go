@@ -0,0 +1,132 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const diffTestSnapshot = `kind: Snapshot
+apiVersion: cns.nvidia.com/v1alpha1
+measurements:
+  - type: K8s
+    subtypes:
+      - subtype: server
+        data:
+          version: %s
+`
+
+func writeDiffSnapshot(t *testing.T, dir, name, version string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	content := strings.Replace(diffTestSnapshot, "%s", version, 1)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	return path
+}
+
+func TestDiffCmd_CommandStructure(t *testing.T) {
+	cmd := diffCmd()
+
+	if cmd.Name != "diff" {
+		t.Errorf("Name = %v, want diff", cmd.Name)
+	}
+
+	requiredFlags := []string{"before", "after", "include", "exclude", "fail-on-drift", "output", "format", "kubeconfig"}
+	for _, flagName := range requiredFlags {
+		found := false
+		for _, flag := range cmd.Flags {
+			if hasName(flag, flagName) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("required flag %q not found", flagName)
+		}
+	}
+}
+
+func TestDiffCmd_Run(t *testing.T) {
+	dir := t.TempDir()
+	a := writeDiffSnapshot(t, dir, "a.yaml", "v1.32.4")
+	b := writeDiffSnapshot(t, dir, "b.yaml", "v1.33.5")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+		wantOut string
+	}{
+		{
+			name:    "identical snapshots",
+			args:    []string{"--before", a, "--after", a},
+			wantOut: "no differences",
+		},
+		{
+			name:    "drift fails",
+			args:    []string{"--before", a, "--after", b},
+			wantErr: "snapshots differ",
+			wantOut: "K8s.server.version",
+		},
+		{
+			name:    "drift without failing",
+			args:    []string{"--before", a, "--after", b, "--fail-on-drift=false", "--format", "json"},
+			wantOut: `"change": "changed"`,
+		},
+		{
+			name:    "drift excluded",
+			args:    []string{"--before", a, "--after", b, "--exclude", "K8s.*"},
+			wantOut: "no differences",
+		},
+		{
+			name:    "missing snapshot",
+			args:    []string{"--before", filepath.Join(dir, "missing.yaml"), "--after", b},
+			wantErr: "failed to load snapshot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "diff.out")
+			args := append([]string{"diff", "--output", out}, tt.args...)
+
+			err := diffCmd().Run(context.Background(), args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want error containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantOut == "" {
+				return
+			}
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("failed to read output: %v", err)
+			}
+			if !strings.Contains(string(data), tt.wantOut) {
+				t.Errorf("output = %q, want it to contain %q", data, tt.wantOut)
+			}
+		})
+	}
+}
@@ -68,6 +68,20 @@
 //
 //	cnsctl recipe --criteria criteria.yaml --service gke  # service=gke overrides file
 //
+// Criteria flags are generated from the criteria dimensions declared in
+// registry.yaml. Dimensions added by an external data directory are set with
+// --dimension:
+//
+//	cnsctl recipe --data ./my-data --service eks --dimension region=us-east
+//
+// Check an external data directory before using it (exits non-zero on errors):
+//
+//	cnsctl recipe lint --data ./my-data --format table
+//
+// Report which criteria combinations have tuned overlays:
+//
+//	cnsctl recipe matrix --format markdown -o coverage.md
+//
 // validate - Validate recipe constraints (Step 3):
 //
 //	cnsctl validate --recipe recipe.yaml --snapshot snapshot.yaml
@@ -78,16 +92,51 @@
 // Supports version comparisons (>=, <=, >, <), equality (==, !=), and exact match.
 // Use --fail-on-error for CI/CD pipelines (non-zero exit on failures).
 //
+// diff - Compare two snapshots:
+//
+//	cnsctl diff --before baseline.yaml --after current.yaml
+//	cnsctl diff --before baseline.yaml --after cm://gpu-operator/cns-snapshot -t json
+//	cnsctl diff --before a.yaml --after b.yaml --include 'OS.sysctl.*' --exclude '*random*'
+//
+// Reports added, removed and changed readings per type/subtype/key.
+// Exits non-zero when the snapshots differ (disable with --fail-on-drift=false).
+//
 // bundle - Create deployment bundles (Step 4):
 //
 //	cnsctl bundle --recipe recipe.yaml --output ./bundles
 //	cnsctl bundle -r recipe.yaml --bundlers gpu-operator,network-operator -o ./bundles
 //	cnsctl bundle -r recipe.yaml --set gpuoperator:driver.version=580.86.16
+//	cnsctl bundle -r recipe.yaml --values gpu-operator=values.yaml --set-json 'gpuoperator:tolerations=[]'
 //
 // Generates deployment artifacts (Helm values, manifests, scripts) from recipes.
 // Supports multiple bundlers: gpu-operator, network-operator, cert-manager,
 // nvsentinel, skyhook.
 //
+// Use --airgap to vendor dependency charts into charts/ and list images in
+// images.txt for disconnected sites:
+//
+//	cnsctl bundle -r recipe.yaml -o ./bundles --airgap --chart-cache ./charts --mirror-registry registry.example.com
+//
+// bundle pull - Pull and unpack a bundle from an OCI registry:
+//
+//	cnsctl bundle pull oci://ghcr.io/nvidia/cns-bundle:v1.0.0 -o ./bundles
+//	cnsctl bundle pull oci://localhost:5000/cns-bundle@sha256:4f5c... --plain-http
+//
+// bundle verify - Verify a generated bundle:
+//
+//	cnsctl bundle verify ./bundles
+//	cnsctl bundle verify oci://ghcr.io/nvidia/cns-bundle:v1.0.0
+//
+// Recomputes checksums, reports missing and extra files, and re-derives the
+// bundle from its recipe.yaml to detect hand-edited files. Exits non-zero on
+// any mismatch.
+//
+// Bundles pushed with --sign-key carry a cosign-compatible signature and a
+// signed in-toto attestation of the recipe, snapshot digest and CLI version:
+//
+//	cnsctl bundle -r recipe.yaml -o oci://ghcr.io/nvidia/cns-bundle:v1.0.0 --sign-key cosign.key
+//	cnsctl bundle verify oci://ghcr.io/nvidia/cns-bundle:v1.0.0 --key cosign.pub
+//
 // # Global Flags
 //
 //	--output, -o   Output file path (default: stdout)
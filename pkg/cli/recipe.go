@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/urfave/cli/v3"
@@ -42,6 +44,10 @@ func recipeCmd() *cli.Command {
   - GPU node operating system (e.g. ubuntu, rhel, cos, amazonlinux)
   - Number of GPU nodes in the cluster
 
+Criteria dimensions and their values are declared in the criteria section of
+registry.yaml. An external --data registry can add values (e.g. an l40s
+accelerator) or new dimensions, which are set with --dimension name=value.
+
 The recipe returns a list of components with deployment order based on dependencies.
 Output can be in JSON or YAML format.
 
@@ -66,34 +72,22 @@ Override criteria file values with flags:
   cnsctl recipe --criteria criteria.yaml --service gke
 
 Override snapshot-detected criteria:
-  cnsctl recipe --snapshot cm://gpu-operator/cns-snapshot --service gke`,
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "service",
-				Usage: fmt.Sprintf("Kubernetes service type (e.g. %s)", strings.Join(recipe.GetCriteriaServiceTypes(), ", ")),
-			},
-			&cli.StringFlag{
-				Name:    "accelerator",
-				Aliases: []string{"gpu"},
-				Usage:   fmt.Sprintf("Accelerator/GPU type (e.g. %s)", strings.Join(recipe.GetCriteriaAcceleratorTypes(), ", ")),
-			},
-			&cli.StringFlag{
-				Name:  "intent",
-				Usage: fmt.Sprintf("Workload intent (e.g. %s)", strings.Join(recipe.GetCriteriaIntentTypes(), ", ")),
-			},
-			&cli.StringFlag{
-				Name:  "os",
-				Usage: fmt.Sprintf("Operating system type of the GPU node (e.g. %s)", strings.Join(recipe.GetCriteriaOSTypes(), ", ")),
-			},
-			&cli.IntFlag{
-				Name:  "nodes",
-				Usage: "Number of worker/GPU nodes in the cluster",
-			},
+  cnsctl recipe --snapshot cm://gpu-operator/cns-snapshot --service gke
+
+Use a criteria dimension declared in an external data directory:
+  cnsctl recipe --data ./my-data --service eks --accelerator h100 --dimension region=us-east
+
+Show which overlay set each field and why other overlays were rejected:
+  cnsctl recipe --service eks --accelerator h100 --intent training --explain
+
+Generate an overlay pinning the configuration of a known-good cluster:
+  cnsctl recipe generate-overlay --snapshot snapshot.yaml --name my-cluster`,
+		Flags: append(criteriaFlags(),
 			&cli.StringFlag{
 				Name:    "snapshot",
 				Aliases: []string{"s"},
 				Usage: `Path/URI to previously generated configuration snapshot.
-	Supports: file paths, HTTP/HTTPS URLs, or ConfigMap URIs (cm://namespace/name).
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).
 	If provided, criteria are extracted from the snapshot.`,
 			},
 			&cli.StringFlag{
@@ -102,10 +96,19 @@ Override snapshot-detected criteria:
 				Usage: `Path to criteria file (YAML/JSON), alternative to individual flags.
 	Criteria file fields can be overridden by individual flags.`,
 			},
+			&cli.BoolFlag{
+				Name:  "explain",
+				Usage: "Annotate the recipe with the overlay that set each component field, constraint and values key, and list rejected overlays",
+			},
 			dataFlag,
 			outputFlag,
 			formatFlag,
 			kubeconfigFlag,
+		),
+		Commands: []*cli.Command{
+			recipeGenerateOverlayCmd(),
+			recipeLintCmd(),
+			recipeMatrixCmd(),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			// Initialize external data provider if --data flag is set
@@ -122,6 +125,7 @@ Override snapshot-detected criteria:
 			// Create builder
 			builder := recipe.NewBuilder(
 				recipe.WithVersion(version),
+				recipe.WithExplain(cmd.Bool("explain")),
 			)
 
 			var result *recipe.RecipeResult
@@ -196,7 +200,7 @@ Override snapshot-detected criteria:
 
 				// Validate that at least some criteria was provided
 				if criteria.Specificity() == 0 {
-					return fmt.Errorf("no criteria provided: specify at least one of --service, --accelerator, --intent, --os, --nodes, --criteria, or use --snapshot to load from a snapshot file")
+					return fmt.Errorf("no criteria provided: specify at least one of --service, --accelerator, --intent, --os, --nodes, --dimension, --criteria, or use --snapshot to load from a snapshot file")
 				}
 
 				slog.Info("building recipe from criteria", "criteria", criteria.String())
@@ -235,21 +239,66 @@ Override snapshot-detected criteria:
 	}
 }
 
-// buildCriteriaFromCmd constructs a recipe.Criteria from CLI command flags.
-func buildCriteriaFromCmd(cmd *cli.Command) (*recipe.Criteria, error) {
-	var opts []recipe.CriteriaOption
+// criteriaFlags returns the flags setting individual criteria fields: one per
+// criteria dimension declared in the embedded registry, plus --nodes and
+// --dimension for dimensions declared only in a --data registry.
+func criteriaFlags() []cli.Flag {
+	dims := recipe.GetCriteriaDimensions()
+	flags := make([]cli.Flag, 0, len(dims)+2)
+	for _, d := range dims {
+		flags = append(flags, &cli.StringFlag{
+			Name:    d.Name,
+			Aliases: d.Aliases,
+			Usage:   fmt.Sprintf("%s (e.g. %s)", d.Description, strings.Join(d.SortedValues(), ", ")),
+		})
+	}
+	return append(flags,
+		&cli.IntFlag{
+			Name:  "nodes",
+			Usage: "Number of worker/GPU nodes in the cluster",
+		},
+		&cli.StringSliceFlag{
+			Name: "dimension",
+			Usage: `Criteria dimension declared in the --data registry, as name=value
+	(e.g. --dimension region=us-east). Can be repeated.`,
+		},
+	)
+}
 
-	if s := cmd.String("service"); s != "" {
-		opts = append(opts, recipe.WithCriteriaService(s))
+// criteriaFlagValues returns the criteria values set by flags, keyed by
+// dimension name. --dimension values take precedence over dimension flags.
+func criteriaFlagValues(cmd *cli.Command) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, d := range recipe.GetCriteriaDimensions() {
+		if s := cmd.String(d.Name); s != "" {
+			values[d.Name] = s
+		}
 	}
-	if s := cmd.String("accelerator"); s != "" {
-		opts = append(opts, recipe.WithCriteriaAccelerator(s))
+	for _, kv := range cmd.StringSlice("dimension") {
+		name, value, ok := strings.Cut(kv, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --dimension %q, expected name=value", kv)
+		}
+		d := recipe.GetCriteriaDimension(name)
+		if d == nil {
+			return nil, fmt.Errorf("unknown criteria dimension: %s", name)
+		}
+		values[d.Name] = value
 	}
-	if s := cmd.String("intent"); s != "" {
-		opts = append(opts, recipe.WithCriteriaIntent(s))
+	return values, nil
+}
+
+// buildCriteriaFromCmd constructs a recipe.Criteria from CLI command flags.
+func buildCriteriaFromCmd(cmd *cli.Command) (*recipe.Criteria, error) {
+	values, err := criteriaFlagValues(cmd)
+	if err != nil {
+		return nil, err
 	}
-	if s := cmd.String("os"); s != "" {
-		opts = append(opts, recipe.WithCriteriaOS(s))
+
+	opts := make([]recipe.CriteriaOption, 0, len(values)+1)
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		opts = append(opts, recipe.WithCriteriaDimension(name, values[name]))
 	}
 	if n := cmd.Int("nodes"); n > 0 {
 		opts = append(opts, recipe.WithCriteriaNodes(n))
@@ -306,32 +355,16 @@ func extractCriteriaFromSnapshot(snap *snapshotter.Snapshot) *recipe.Criteria {
 				if st.Name == "smi" || st.Name == "device" {
 					// Try "gpu.model" field (from nvidia-smi)
 					if model, ok := st.Data["gpu.model"]; ok {
-						modelStr := model.String()
 						// Map model names to accelerator types
-						switch {
-						case containsIgnoreCase(modelStr, "gb200"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorGB200
-						case containsIgnoreCase(modelStr, "h100"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorH100
-						case containsIgnoreCase(modelStr, "a100"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorA100
-						case containsIgnoreCase(modelStr, "l40"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorL40
+						if accelerator, found := detectAccelerator(model.String()); found {
+							criteria.Accelerator = accelerator
 						}
 					}
 
 					// Also try plain "model" field
 					if model, ok := st.Data["model"]; ok {
-						modelStr := model.String()
-						switch {
-						case containsIgnoreCase(modelStr, "gb200"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorGB200
-						case containsIgnoreCase(modelStr, "h100"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorH100
-						case containsIgnoreCase(modelStr, "a100"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorA100
-						case containsIgnoreCase(modelStr, "l40"):
-							criteria.Accelerator = recipe.CriteriaAcceleratorL40
+						if accelerator, found := detectAccelerator(model.String()); found {
+							criteria.Accelerator = accelerator
 						}
 					}
 				}
@@ -349,8 +382,8 @@ func extractCriteriaFromSnapshot(snap *snapshotter.Snapshot) *recipe.Criteria {
 				}
 			}
 
-		case measurement.TypeSystemD:
-			// SystemD measurements not used for criteria extraction
+		case measurement.TypeSystemD, measurement.TypeNetwork, measurement.TypeContainerRuntime:
+			// SystemD, Network and ContainerRuntime measurements not used for criteria extraction
 			continue
 		}
 	}
@@ -358,60 +391,42 @@ func extractCriteriaFromSnapshot(snap *snapshotter.Snapshot) *recipe.Criteria {
 	return criteria
 }
 
+// detectAccelerator maps a GPU model name (e.g. "NVIDIA H100 80GB HBM3") to
+// the declared accelerator type it contains. Longer types are tried first, so
+// "NVIDIA GB200" is gb200 rather than b200, and "NVIDIA L40S" is l40s rather
+// than l40 when both are declared.
+func detectAccelerator(model string) (recipe.CriteriaAcceleratorType, bool) {
+	types := recipe.GetCriteriaAcceleratorTypes()
+	slices.SortStableFunc(types, func(a, b string) int {
+		return len(b) - len(a)
+	})
+	for _, t := range types {
+		if containsIgnoreCase(model, t) {
+			return recipe.CriteriaAcceleratorType(t), true
+		}
+	}
+	return recipe.CriteriaAcceleratorAny, false
+}
+
 // applyCriteriaOverrides applies CLI flag overrides to criteria.
 // Logs a warning when a flag overrides a value detected from the snapshot.
 func applyCriteriaOverrides(cmd *cli.Command, criteria *recipe.Criteria) error {
-	if s := cmd.String("service"); s != "" {
-		parsed, err := recipe.ParseCriteriaServiceType(s)
-		if err != nil {
-			return err
-		}
-		if criteria.Service != "" && criteria.Service != parsed {
-			slog.Info("CLI flag overriding snapshot-detected value",
-				"field", "service",
-				"detected", criteria.Service,
-				"override", parsed)
-		}
-		criteria.Service = parsed
-	}
-	if s := cmd.String("accelerator"); s != "" {
-		parsed, err := recipe.ParseCriteriaAcceleratorType(s)
-		if err != nil {
-			return err
-		}
-		if criteria.Accelerator != "" && criteria.Accelerator != parsed {
-			slog.Info("CLI flag overriding snapshot-detected value",
-				"field", "accelerator",
-				"detected", criteria.Accelerator,
-				"override", parsed)
-		}
-		criteria.Accelerator = parsed
-	}
-	if s := cmd.String("intent"); s != "" {
-		parsed, err := recipe.ParseCriteriaIntentType(s)
-		if err != nil {
-			return err
-		}
-		if criteria.Intent != "" && criteria.Intent != parsed {
-			slog.Info("CLI flag overriding snapshot-detected value",
-				"field", "intent",
-				"detected", criteria.Intent,
-				"override", parsed)
-		}
-		criteria.Intent = parsed
+	values, err := criteriaFlagValues(cmd)
+	if err != nil {
+		return err
 	}
-	if s := cmd.String("os"); s != "" {
-		parsed, err := recipe.ParseCriteriaOSType(s)
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		parsed, err := recipe.GetCriteriaDimension(name).Parse(values[name])
 		if err != nil {
 			return err
 		}
-		if criteria.OS != "" && criteria.OS != parsed {
+		if detected := criteria.Get(name); detected != "" && detected != parsed {
 			slog.Info("CLI flag overriding snapshot-detected value",
-				"field", "os",
-				"detected", criteria.OS,
+				"field", name,
+				"detected", detected,
 				"override", parsed)
 		}
-		criteria.OS = parsed
+		criteria.Set(name, parsed)
 	}
 	if n := cmd.Int("nodes"); n > 0 {
 		if criteria.Nodes > 0 && criteria.Nodes != n {
@@ -427,8 +442,5 @@ func applyCriteriaOverrides(cmd *cli.Command, criteria *recipe.Criteria) error {
 
 // containsIgnoreCase checks if s contains substr (case-insensitive).
 func containsIgnoreCase(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr ||
-		len(s) > 0 && len(substr) > 0 &&
-			(s[0]|0x20 == substr[0]|0x20) && containsIgnoreCase(s[1:], substr[1:]) ||
-		len(s) > 0 && containsIgnoreCase(s[1:], substr))
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
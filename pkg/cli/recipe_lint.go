@@ -0,0 +1,110 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+func recipeLintCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "lint",
+		EnableShellCompletion: true,
+		Usage:                 "Check recipe data for errors without generating a recipe.",
+		Description: `Lint the recipe data set: the embedded data, layered with --data when set.
+
+Checks:
+  - every overlay parses, without unknown fields
+  - registry.yaml passes component registry validation
+  - spec.base chains resolve without cycles
+  - valuesFile and manifestFiles paths exist
+  - constraint names are valid measurement paths and their expressions parse
+  - criteria values are known
+  - dependencyRefs resolve without cycles along each inheritance chain
+  - overlays with identical criteria do not define a component or constraint differently
+
+Each diagnostic carries the file (relative to the data directory), line and
+column, severity and rule. Use --format json or yaml for machine-readable output.
+The command exits with a non-zero status when any error is found.
+
+Examples:
+
+Lint an external data directory:
+  cnsctl recipe lint --data ./my-data --format table
+
+Lint in CI and keep the diagnostics:
+  cnsctl recipe lint --data ./my-data --format json -o lint.json`,
+		Flags: []cli.Flag{
+			dataFlag,
+			outputFlag,
+			formatFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := initDataProvider(cmd); err != nil {
+				return fmt.Errorf("failed to initialize data provider: %w", err)
+			}
+
+			outFormat, err := parseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			report, err := recipe.Lint(recipe.GetDataProvider(),
+				recipe.WithConstraintPathParser(func(path string) error {
+					_, parseErr := validator.ParseConstraintPath(path)
+					return parseErr
+				}),
+				recipe.WithConstraintExpressionParser(func(expr string) error {
+					_, parseErr := validator.ParseConstraintExpression(expr)
+					return parseErr
+				}),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to lint recipe data: %w", err)
+			}
+
+			ser, err := serializer.NewFileWriterOrStdout(outFormat, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to create output writer: %w", err)
+			}
+			defer func() {
+				if closer, ok := ser.(interface{ Close() error }); ok {
+					if err := closer.Close(); err != nil {
+						slog.Warn("failed to close serializer", "error", err)
+					}
+				}
+			}()
+
+			if err := ser.Serialize(ctx, report); err != nil {
+				return fmt.Errorf("failed to serialize lint report: %w", err)
+			}
+
+			if report.HasErrors() {
+				return fmt.Errorf("recipe lint failed: %d error(s), %d warning(s)", report.Errors, report.Warnings)
+			}
+
+			slog.Info("recipe lint completed", "files", report.FilesChecked, "warnings", report.Warnings)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+)
+
+// formatMarkdown is the recipe matrix output format rendering a Markdown table.
+const formatMarkdown = "markdown"
+
+func recipeMatrixCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "matrix",
+		EnableShellCompletion: true,
+		Usage:                 "Report which criteria combinations have tuned overlays.",
+		Description: `Enumerate every service x accelerator x intent x OS combination and report,
+for each, the overlays that apply, the resulting component set and whether only
+the base recipe matched (a coverage gap).
+
+The data set is the embedded recipe data, layered with --data when set.
+Besides yaml, json and table (component counts), --format markdown renders a
+Markdown table listing the components of each combination, for files or stdout.
+
+Examples:
+
+Show coverage as a table:
+  cnsctl recipe matrix --format table
+
+Publish coverage of an external data directory as Markdown:
+  cnsctl recipe matrix --data ./my-data --format markdown -o coverage.md`,
+		Flags: []cli.Flag{
+			dataFlag,
+			outputFlag,
+			formatFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if err := initDataProvider(cmd); err != nil {
+				return fmt.Errorf("failed to initialize data provider: %w", err)
+			}
+
+			markdown := cmd.String("format") == formatMarkdown
+			var outFormat serializer.Format
+			if !markdown {
+				var err error
+				if outFormat, err = parseOutputFormat(cmd); err != nil {
+					return err
+				}
+			}
+
+			matrix, err := recipe.NewBuilder(recipe.WithVersion(version)).BuildCoverageMatrix(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to build coverage matrix: %w", err)
+			}
+
+			output := cmd.String("output")
+			if markdown {
+				if err := writeMarkdown(output, matrix); err != nil {
+					return err
+				}
+			} else {
+				ser, err := serializer.NewFileWriterOrStdout(outFormat, output)
+				if err != nil {
+					return fmt.Errorf("failed to create output writer: %w", err)
+				}
+				defer func() {
+					if closer, ok := ser.(interface{ Close() error }); ok {
+						if err := closer.Close(); err != nil {
+							slog.Warn("failed to close serializer", "error", err)
+						}
+					}
+				}()
+
+				if err := ser.Serialize(ctx, matrix); err != nil {
+					return fmt.Errorf("failed to serialize coverage matrix: %w", err)
+				}
+			}
+
+			slog.Info("coverage matrix completed",
+				"cells", matrix.Summary.Cells,
+				"tuned", matrix.Summary.Tuned,
+				"base_only", matrix.Summary.BaseOnly)
+			return nil
+		},
+	}
+}
+
+// writeMarkdown writes the Markdown rendering of the matrix to a file or stdout.
+func writeMarkdown(output string, matrix *recipe.CoverageMatrix) error {
+	path := strings.TrimSpace(output)
+	if strings.Contains(path, "://") {
+		return fmt.Errorf("markdown output supports files and stdout only, got %q", output)
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" && path != serializer.StdoutURI {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file %q: %w", path, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := matrix.WriteMarkdown(w); err != nil {
+		return fmt.Errorf("failed to write coverage matrix: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+func recipeGenerateOverlayCmd() *cli.Command {
+	return &cli.Command{
+		Name:                  "generate-overlay",
+		EnableShellCompletion: true,
+		Usage:                 "Generate a recipe overlay pinning the configuration observed in a snapshot.",
+		Description: `Generate a recipeMetadata overlay from a snapshot of a known-good cluster.
+
+The overlay criteria are detected from the snapshot the same way as with
+'cnsctl recipe --snapshot' and can be overridden with the criteria flags.
+Intent cannot be detected and should usually be set with --intent.
+
+The overlay pins, with exact-match constraints, the observed:
+  - Kubernetes version (K8s.server.version)
+  - GPU driver version (GPU.smi.driver)
+  - Kernel release (OS.sysctl./proc/sys/kernel/osrelease)
+  - OS release (OS.release.ID, OS.release.VERSION_ID)
+
+Component versions are taken from the container images running in the cluster
+(the K8s image subtype), for every registry component with versionImages.
+
+The overlay inherits from the most specific existing overlay matching its
+criteria, so that the overlays it refines do not replace its pins. Generation
+fails if another matching overlay would still replace a pinned value.
+
+Write the overlay to the overlays/ directory of an external data directory and
+pass it to recipe or bundle with --data.
+
+Examples:
+
+Generate an overlay from a snapshot file:
+  cnsctl recipe generate-overlay --snapshot snapshot.yaml --name my-cluster --intent training
+
+Generate an overlay into an external data directory:
+  cnsctl recipe generate-overlay --snapshot cm://gpu-operator/cns-snapshot \
+    --name my-cluster --intent training -o ./my-data/overlays/my-cluster.yaml
+  cnsctl recipe --data ./my-data --snapshot cm://gpu-operator/cns-snapshot --intent training`,
+		Flags: append(criteriaFlags(),
+			&cli.StringFlag{
+				Name:     "snapshot",
+				Aliases:  []string{"s"},
+				Required: true,
+				Usage: `Path/URI to the snapshot to generate the overlay from.
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
+			},
+			&cli.StringFlag{
+				Name:     "name",
+				Required: true,
+				Usage:    "Name of the overlay (metadata.name)",
+			},
+			&cli.StringFlag{
+				Name:  "base",
+				Usage: "Name of the recipe the overlay inherits from (default: the most specific overlay matching the criteria)",
+			},
+			dataFlag,
+			outputFlag,
+			formatFlag,
+			kubeconfigFlag,
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			// The registry maps observed images to components, so honor --data
+			if err := initDataProvider(cmd); err != nil {
+				return fmt.Errorf("failed to initialize data provider: %w", err)
+			}
+
+			outFormat, err := parseOutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			snapFilePath := cmd.String("snapshot")
+			slog.Info("loading snapshot from", "uri", snapFilePath)
+			snap, err := serializer.FromFileWithKubeconfig[snapshotter.Snapshot](snapFilePath, cmd.String("kubeconfig"))
+			if err != nil {
+				return fmt.Errorf("failed to load snapshot from %q: %w", snapFilePath, err)
+			}
+
+			criteria := extractCriteriaFromSnapshot(snap)
+			if err = applyCriteriaOverrides(cmd, criteria); err != nil {
+				return err
+			}
+
+			overlay, err := recipe.GenerateOverlay(recipe.OverlayConfig{
+				Name:     cmd.String("name"),
+				Base:     cmd.String("base"),
+				Criteria: criteria,
+			}, snap.Measurements)
+			if err != nil {
+				return fmt.Errorf("failed to generate overlay: %w", err)
+			}
+
+			output := cmd.String("output")
+			ser, err := serializer.NewFileWriterOrStdout(outFormat, output)
+			if err != nil {
+				return fmt.Errorf("failed to create output writer: %w", err)
+			}
+			defer func() {
+				if closer, ok := ser.(interface{ Close() error }); ok {
+					if err := closer.Close(); err != nil {
+						slog.Warn("failed to close serializer", "error", err)
+					}
+				}
+			}()
+
+			if err := ser.Serialize(ctx, overlay); err != nil {
+				return fmt.Errorf("failed to serialize overlay: %w", err)
+			}
+
+			slog.Info("overlay generation completed",
+				"output", output,
+				"criteria", criteria.String(),
+				"constraints", len(overlay.Spec.Constraints),
+				"components", len(overlay.Spec.ComponentRefs))
+
+			return nil
+		},
+	}
+}
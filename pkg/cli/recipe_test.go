@@ -16,10 +16,13 @@ package cli
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
@@ -478,6 +481,89 @@ func TestApplyCriteriaOverrides(t *testing.T) {
 	}
 }
 
+func TestCriteriaFlags(t *testing.T) {
+	flags := criteriaFlags()
+	for _, name := range []string{"service", "gpu", "intent", "os", "nodes", "dimension"} {
+		found := false
+		for _, flag := range flags {
+			if hasName(flag, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("criteria flag %q not found", name)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    *recipe.Criteria
+		wantErr bool
+	}{
+		{
+			name: "dimension flags and --dimension",
+			args: []string{"cmd", "--gpu", "h100", "--dimension", "intent=training"},
+			want: &recipe.Criteria{Service: "any", Accelerator: "h100", Intent: "training", OS: "any"},
+		},
+		{
+			name:    "undeclared dimension",
+			args:    []string{"cmd", "--dimension", "region=us-east"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed dimension",
+			args:    []string{"cmd", "--dimension", "region"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *recipe.Criteria
+			testCmd := &cli.Command{
+				Name:  "test",
+				Flags: criteriaFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					var err error
+					got, err = buildCriteriaFromCmd(cmd)
+					return err
+				},
+			}
+
+			err := testCmd.Run(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && got.String() != tt.want.String() {
+				t.Errorf("criteria = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectAccelerator(t *testing.T) {
+	tests := []struct {
+		model string
+		want  recipe.CriteriaAcceleratorType
+		found bool
+	}{
+		{"NVIDIA H100 80GB HBM3", recipe.CriteriaAcceleratorH100, true},
+		{"NVIDIA GB200", recipe.CriteriaAcceleratorGB200, true},
+		{"NVIDIA A100-SXM4-80GB", recipe.CriteriaAcceleratorA100, true},
+		{"NVIDIA L40S", recipe.CriteriaAcceleratorL40, true},
+		{"Tesla T4", recipe.CriteriaAcceleratorAny, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, found := detectAccelerator(tt.model)
+			if got != tt.want || found != tt.found {
+				t.Errorf("detectAccelerator(%q) = %q, %v, want %q, %v", tt.model, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
 func TestRecipeCmd_CommandStructure(t *testing.T) {
 	cmd := recipeCmd()
 
@@ -592,6 +678,7 @@ func TestContainsIgnoreCase(t *testing.T) {
 		{"NVIDIA A100-SXM4-80GB", "a100", true},
 		{"L40S", "l40", true},
 		{"H100", "gb200", false},
+		{"NVIDIA H100 80GB HBM3", "a100", false}, // characters must be contiguous
 		{"", "h100", false},
 		{"h100", "", true}, // empty substr matches anything
 		{"", "", true},     // empty matches empty
@@ -696,3 +783,57 @@ func TestInitDataProvider_MissingRegistry(t *testing.T) {
 		t.Errorf("error should mention registry.yaml, got: %v", err)
 	}
 }
+
+func TestRecipeGenerateOverlayCmd(t *testing.T) {
+	dir := t.TempDir()
+	snapPath := filepath.Join(dir, "snapshot.yaml")
+	snap := `kind: Snapshot
+apiVersion: cns.nvidia.com/v1alpha1
+measurements:
+  - type: K8s
+    subtypes:
+      - subtype: server
+        data:
+          version: v1.33.5-eks-3025e55
+      - subtype: image
+        data:
+          gpu-operator: v25.3.0
+  - type: OS
+    subtypes:
+      - subtype: release
+        data:
+          ID: ubuntu
+`
+	if err := os.WriteFile(snapPath, []byte(snap), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "overlays", "my-cluster.yaml")
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"generate-overlay", "--snapshot", snapPath, "--name", "my-cluster", "--intent", "training", "-o", out}
+	if err := recipeGenerateOverlayCmd().Run(context.Background(), args); err != nil {
+		t.Fatalf("generate-overlay error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var overlay recipe.RecipeMetadata
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		t.Fatalf("failed to parse overlay: %v", err)
+	}
+
+	c := overlay.Spec.Criteria
+	if c == nil || c.Service != recipe.CriteriaServiceEKS || c.OS != recipe.CriteriaOSUbuntu || c.Intent != recipe.CriteriaIntentTraining {
+		t.Errorf("Criteria = %+v", c)
+	}
+	if len(overlay.Spec.Constraints) != 2 {
+		t.Errorf("Constraints = %+v, want K8s version and OS ID", overlay.Spec.Constraints)
+	}
+	if len(overlay.Spec.ComponentRefs) != 1 || overlay.Spec.ComponentRefs[0].Version != "v25.3.0" {
+		t.Errorf("ComponentRefs = %+v", overlay.Spec.ComponentRefs)
+	}
+}
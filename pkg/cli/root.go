@@ -24,6 +24,7 @@ import (
 	"github.com/urfave/cli/v3"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/logging"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
 )
@@ -43,7 +44,7 @@ var (
 	outputFlag = &cli.StringFlag{
 		Name:    "output",
 		Aliases: []string{"o"},
-		Usage:   fmt.Sprintf("output destination: file path, ConfigMap URI (%snamespace/name), or stdout (default)", serializer.ConfigMapURIScheme),
+		Usage:   fmt.Sprintf("output destination: file path, ConfigMap URI (%snamespace/name), HTTP(S) URL (POST), or stdout (default)", serializer.ConfigMapURIScheme),
 	}
 
 	formatFlag = &cli.StringFlag{
@@ -71,6 +72,12 @@ var (
 // Execute starts the CLI application.
 // This is called by main.main().
 func Execute() {
+	// Let --recipe, --snapshot and similar flags read oci:// URIs
+	serializer.SetArtifactPuller(func(ctx context.Context, reference, dir string) error {
+		_, err := oci.PullURI(ctx, reference, dir)
+		return err
+	})
+
 	cmd := &cli.Command{
 		Name:                  name,
 		Usage:                 "Cloud Native Stack CLI",
@@ -130,6 +137,7 @@ func Execute() {
 			recipeCmd(),
 			bundleCmd(),
 			validateCmd(),
+			diffCmd(),
 		},
 		ShellComplete: commandLister,
 	}
@@ -40,7 +40,11 @@ func snapshotCmd() *cli.Command {
   - Sysctl kernel parameters
   - SystemD service configurations
 
-Note: All collection is done locally and no data is egressed out of the cluster.
+Note: All collection is done locally and no data is egressed out of the cluster,
+unless --output is an HTTP(S) URL. A URL receives the snapshot with a POST request,
+e.g. to report to a central API server:
+
+  cnsctl snapshot --output "https://cns.example.com/v1/snapshots?cluster=prod"
 
 Output can be in JSON or YAML format. 
 For a more complete snapshot use --deploy-agent to deploy a Kubernetes Job that captures the snapshot on a GPU node:
@@ -67,6 +71,12 @@ Override default tolerations (by default, all taints are tolerated):
   cnsctl snapshot --deploy-agent \
     --toleration dedicated=user-workload:NoSchedule
 
+Capture every matching node and merge the results into a ClusterSnapshot
+that groups identical nodes into pools and flags drift within each pool:
+  cnsctl snapshot --deploy-agent --all-nodes \
+    --node-selector nodeGroup=customer-gpu \
+    --output cluster-snapshot.yaml
+
 Combined node selector and custom tolerations:
   cnsctl snapshot --deploy-agent \
     --node-selector nodeGroup=customer-gpu \
@@ -123,11 +133,24 @@ Combined node selector and custom tolerations:
 				Value: true,
 				Usage: "Remove Job and RBAC resources on completion",
 			},
+			&cli.BoolFlag{
+				Name:  "all-nodes",
+				Usage: "Run the agent on every node matching --node-selector and produce a ClusterSnapshot",
+			},
+			&cli.StringSliceFlag{
+				Name:  "pool-key",
+				Usage: "Measurement path used to group nodes into pools with --all-nodes (format: Type.Subtype.Key, can be repeated)",
+			},
 			&cli.BoolFlag{
 				Name:  "privileged",
 				Value: true,
 				Usage: "Run agent in privileged mode (required for GPU/SystemD collectors). Set to false for PSS-restricted namespaces.",
 			},
+			&cli.StringFlag{
+				Name:    "host-root",
+				Usage:   "Directory the host filesystem is mounted at when running in a container; container runtime configuration is read below it",
+				Sources: cli.EnvVars("CNS_HOST_ROOT"),
+			},
 			outputFlag,
 			formatFlag,
 			kubeconfigFlag,
@@ -142,6 +165,7 @@ Combined node selector and custom tolerations:
 			// Create factory
 			factory := collector.NewDefaultFactory(
 				collector.WithVersion(version),
+				collector.WithHostRoot(cmd.String("host-root")),
 			)
 
 			// Create output serializer
@@ -187,6 +211,8 @@ Combined node selector and custom tolerations:
 					Output:             cmd.String("output"),
 					Debug:              cmd.Bool("debug"),
 					Privileged:         cmd.Bool("privileged"),
+					AllNodes:           cmd.Bool("all-nodes"),
+					PoolKeys:           cmd.StringSlice("pool-key"),
 				}
 			}
 
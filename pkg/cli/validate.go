@@ -16,11 +16,14 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
 	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
 
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
 	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
 	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
@@ -50,6 +53,9 @@ Load snapshot from ConfigMap (results to stdout):
 Output validation result to a file:
   cnsctl validate -r recipe.yaml -s snapshot.yaml -o result.yaml
 
+Validate each node pool of a multi-node snapshot (cnsctl snapshot --all-nodes):
+  cnsctl validate -r recipe.yaml -s cluster-snapshot.yaml
+
 Run validation without failing on constraint errors (informational mode):
   cnsctl validate -r recipe.yaml -s snapshot.yaml --fail-on-error=false
 `,
@@ -59,14 +65,14 @@ Run validation without failing on constraint errors (informational mode):
 				Aliases:  []string{"r"},
 				Required: true,
 				Usage: `Path/URI to recipe file containing constraints to validate.
-	Supports: file paths, HTTP/HTTPS URLs, or ConfigMap URIs (cm://namespace/name).`,
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
 			},
 			&cli.StringFlag{
 				Name:     "snapshot",
 				Aliases:  []string{"s"},
 				Required: true,
 				Usage: `Path/URI to snapshot file containing actual system measurements.
-	Supports: file paths, HTTP/HTTPS URLs, or ConfigMap URIs (cm://namespace/name).`,
+	Supports: file paths, HTTP/HTTPS URLs, ConfigMap URIs (cm://namespace/name), or OCI URIs (oci://registry/repo:tag[#file]).`,
 			},
 			&cli.BoolFlag{
 				Name:  "fail-on-error",
@@ -99,8 +105,8 @@ Run validation without failing on constraint errors (informational mode):
 
 			slog.Info("loading snapshot", "uri", snapshotFilePath)
 
-			// Load snapshot
-			snap, err := serializer.FromFileWithKubeconfig[snapshotter.Snapshot](snapshotFilePath, kubeconfig)
+			// Load snapshot, a Snapshot or a ClusterSnapshot
+			snap, err := serializer.FromFileWithKubeconfig[snapshotDocument](snapshotFilePath, kubeconfig)
 			if err != nil {
 				return fmt.Errorf("failed to load snapshot from %q: %w", snapshotFilePath, err)
 			}
@@ -115,8 +121,13 @@ Run validation without failing on constraint errors (informational mode):
 				validator.WithVersion(version),
 			)
 
-			// Validate
-			result, err := v.Validate(ctx, rec, snap)
+			// Validate, per node pool when given a ClusterSnapshot
+			var result *validator.ValidationResult
+			if snap.cluster != nil {
+				result, err = v.ValidateCluster(ctx, rec, snap.cluster)
+			} else {
+				result, err = v.Validate(ctx, rec, snap.snapshot)
+			}
 			if err != nil {
 				return fmt.Errorf("validation failed: %w", err)
 			}
@@ -143,6 +154,8 @@ Run validation without failing on constraint errors (informational mode):
 				return fmt.Errorf("failed to serialize validation result: %w", err)
 			}
 
+			logRemediations(result)
+
 			slog.Info("validation completed",
 				"status", result.Summary.Status,
 				"passed", result.Summary.Passed,
@@ -159,3 +172,71 @@ Run validation without failing on constraint errors (informational mode):
 		},
 	}
 }
+
+// snapshotDocument decodes a Snapshot or a ClusterSnapshot, depending on its
+// kind, so that the input is read only once.
+type snapshotDocument struct {
+	snapshot *snapshotter.Snapshot
+	cluster  *snapshotter.ClusterSnapshot
+}
+
+// UnmarshalYAML decodes the document into the type of its kind.
+func (d *snapshotDocument) UnmarshalYAML(node *yaml.Node) error {
+	var h header.Header
+	if err := node.Decode(&h); err != nil {
+		return err
+	}
+	if h.Kind == header.KindClusterSnapshot {
+		d.cluster = &snapshotter.ClusterSnapshot{}
+		return node.Decode(d.cluster)
+	}
+	d.snapshot = &snapshotter.Snapshot{}
+	return node.Decode(d.snapshot)
+}
+
+// UnmarshalJSON decodes the document into the type of its kind.
+func (d *snapshotDocument) UnmarshalJSON(data []byte) error {
+	var h header.Header
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+	if h.Kind == header.KindClusterSnapshot {
+		d.cluster = &snapshotter.ClusterSnapshot{}
+		return json.Unmarshal(data, d.cluster)
+	}
+	d.snapshot = &snapshotter.Snapshot{}
+	return json.Unmarshal(data, d.snapshot)
+}
+
+// logRemediations prints the remediation guidance of every failed constraint.
+func logRemediations(result *validator.ValidationResult) {
+	logResults := func(pool string, results []validator.ConstraintValidation) {
+		for _, r := range results {
+			if r.Status != validator.ConstraintStatusFailed || r.Remediation == nil {
+				continue
+			}
+			args := []any{"constraint", r.Name, "expected", r.Expected, "actual", r.Actual}
+			if pool != "" {
+				args = append(args, "pool", pool)
+			}
+			if r.Remediation.Description != "" {
+				args = append(args, "remediation", r.Remediation.Description)
+			}
+			if r.Remediation.Command != "" {
+				args = append(args, "command", r.Remediation.Command)
+			}
+			if len(r.Remediation.Values) > 0 {
+				args = append(args, "values", r.Remediation.Values)
+			}
+			if r.Remediation.DocsURL != "" {
+				args = append(args, "docs", r.Remediation.DocsURL)
+			}
+			slog.Warn("constraint failed", args...)
+		}
+	}
+
+	logResults("", result.Results)
+	for _, pv := range result.Pools {
+		logResults(pv.Pool, pv.Results)
+	}
+}
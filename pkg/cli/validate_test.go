@@ -0,0 +1,168 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+const validateTestRecipe = `kind: Recipe
+apiVersion: cns.nvidia.com/v1alpha1
+constraints:
+  - name: OS.release.VERSION_ID
+    value: "24.04"
+`
+
+// validateTestClusterSnapshot has two pools, of which only pool-0 runs the
+// OS release required by validateTestRecipe.
+const validateTestClusterSnapshot = `kind: ClusterSnapshot
+apiVersion: cns.nvidia.com/v1alpha1
+poolKeys:
+  - OS.release.VERSION_ID
+pools:
+  - name: pool-0
+    attributes:
+      OS.release.VERSION_ID: "24.04"
+    reference: node-a
+    nodes: [node-a, node-b]
+  - name: pool-1
+    attributes:
+      OS.release.VERSION_ID: "22.04"
+    reference: node-c
+    nodes: [node-c]
+nodes:
+  - name: node-a
+    pool: pool-0
+    measurements:
+      - type: OS
+        subtypes:
+          - subtype: release
+            data:
+              VERSION_ID: "24.04"
+  - name: node-b
+    pool: pool-0
+    measurements:
+      - type: OS
+        subtypes:
+          - subtype: release
+            data:
+              VERSION_ID: "24.04"
+  - name: node-c
+    pool: pool-1
+    measurements:
+      - type: OS
+        subtypes:
+          - subtype: release
+            data:
+              VERSION_ID: "22.04"
+`
+
+func TestValidateCmd_ClusterSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	recipePath := filepath.Join(dir, "recipe.yaml")
+	snapshotPath := filepath.Join(dir, "cluster-snapshot.yaml")
+	if err := os.WriteFile(recipePath, []byte(validateTestRecipe), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(snapshotPath, []byte(validateTestClusterSnapshot), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "result.json")
+	args := []string{"validate", "--recipe", recipePath, "--snapshot", snapshotPath, "--output", out, "--format", "json"}
+
+	err := validateCmd().Run(context.Background(), args)
+	if err == nil || !strings.Contains(err.Error(), "1 constraint(s) did not pass") {
+		t.Fatalf("error = %v, want failure of the pool-1 constraint", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	var result validator.ValidationResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("invalid result: %v", err)
+	}
+
+	if len(result.Pools) != 2 {
+		t.Fatalf("Pools = %+v, want 2 pools", result.Pools)
+	}
+	want := map[string]validator.ConstraintStatus{
+		"pool-0": validator.ConstraintStatusPassed,
+		"pool-1": validator.ConstraintStatusFailed,
+	}
+	for _, pool := range result.Pools {
+		if len(pool.Results) != 1 || pool.Results[0].Status != want[pool.Pool] {
+			t.Errorf("pool %s results = %+v, want %s", pool.Pool, pool.Results, want[pool.Pool])
+		}
+	}
+	if result.Summary.Passed != 1 || result.Summary.Failed != 1 {
+		t.Errorf("Summary = %+v, want 1 passed and 1 failed", result.Summary)
+	}
+	if result.SnapshotSource != snapshotPath {
+		t.Errorf("SnapshotSource = %q, want %q", result.SnapshotSource, snapshotPath)
+	}
+
+	// Without --fail-on-error the failed pool is only reported
+	if err := validateCmd().Run(context.Background(), append(args, "--fail-on-error=false")); err != nil {
+		t.Errorf("unexpected error with --fail-on-error=false: %v", err)
+	}
+}
+
+func TestSnapshotDocument(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cluster, err := serializer.FromFile[snapshotDocument](write("cluster.yaml", validateTestClusterSnapshot))
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if cluster.snapshot != nil || cluster.cluster == nil || len(cluster.cluster.Nodes) != 3 {
+		t.Errorf("cluster snapshot decoded as %+v", cluster)
+	}
+
+	jsonCluster := `{"kind": "ClusterSnapshot", "apiVersion": "cns.nvidia.com/v1alpha1", "nodes": [{"name": "node-a"}]}`
+	cluster, err = serializer.FromFile[snapshotDocument](write("cluster.json", jsonCluster))
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if cluster.cluster == nil || len(cluster.cluster.Nodes) != 1 {
+		t.Errorf("JSON cluster snapshot decoded as %+v", cluster)
+	}
+
+	single := "kind: Snapshot\napiVersion: cns.nvidia.com/v1alpha1\nmeasurements:\n  - type: OS\n    subtypes: []\n"
+	snap, err := serializer.FromFile[snapshotDocument](write("snapshot.yaml", single))
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if snap.cluster != nil || snap.snapshot == nil || len(snap.snapshot.Measurements) != 1 {
+		t.Errorf("snapshot decoded as %+v", snap)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+var (
+	filePathContainerdConfig = "/etc/containerd/config.toml"
+
+	// containerdRuntimeAttrs maps runtime handler readings to their configuration keys.
+	containerdRuntimeAttrs = map[string][]string{
+		"type":           {"runtime_type"},
+		"path":           {"runtime_path"},
+		"binary-name":    {"options", "BinaryName"},
+		"systemd-cgroup": {"options", "SystemdCgroup"},
+	}
+)
+
+// collectContainerd gathers the containerd CRI plugin configuration from
+// /etc/containerd/config.toml merged with its imports (e.g., drop-ins in
+// /etc/containerd/conf.d), and returns it as a subtype, e.g.:
+//
+//	version: 2
+//	default-runtime: nvidia
+//	runtimes: nvidia, runc
+//	runtime.nvidia.binary-name: /usr/bin/nvidia-container-runtime
+//	enable-cdi: true
+//	snapshotter: overlayfs
+//	systemd-cgroup: true
+func (c *Collector) collectContainerd(ctx context.Context) (*measurement.Subtype, error) {
+	// Check if context is canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data := readings{}
+	res := &measurement.Subtype{
+		Name: "containerd",
+		Data: data,
+	}
+
+	cfg, imports, err := c.loadContainerdConfig(ctx, filePathContainerdConfig, map[string]bool{})
+	if errors.Is(err, fs.ErrNotExist) {
+		data["configured"] = measurement.Bool(false)
+		return res, nil
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		slog.Warn("failed to load containerd configuration",
+			slog.String("path", filePathContainerdConfig),
+			slog.String("error", err.Error()))
+		data["configured"] = measurement.Bool(true)
+		data.set("config-path", filePathContainerdConfig)
+		return res, nil
+	}
+
+	data["configured"] = measurement.Bool(true)
+	data.set("config-path", filePathContainerdConfig)
+	data.set("imports", strings.Join(imports, ", "))
+
+	// The CRI plugin moved between configuration versions:
+	//   version 1: [plugins.cri]
+	//   version 2: [plugins."io.containerd.grpc.v1.cri"]
+	//   version 3: [plugins."io.containerd.cri.v1.runtime"] and [plugins."io.containerd.cri.v1.images"]
+	version, _ := cfg["version"].(int64)
+	if version == 0 {
+		version = 1
+	}
+	data["version"] = measurement.Int(int(version))
+
+	var runtimePlugin, imagesPlugin map[string]any
+	switch version {
+	case 1:
+		runtimePlugin = lookupTable(cfg, "plugins", "cri")
+		imagesPlugin = lookupTable(runtimePlugin, "containerd")
+	case 2:
+		runtimePlugin = lookupTable(cfg, "plugins", "io.containerd.grpc.v1.cri")
+		imagesPlugin = lookupTable(runtimePlugin, "containerd")
+	default:
+		runtimePlugin = lookupTable(cfg, "plugins", "io.containerd.cri.v1.runtime")
+		imagesPlugin = lookupTable(cfg, "plugins", "io.containerd.cri.v1.images")
+	}
+
+	defaultRuntime, _ := lookup(runtimePlugin, "containerd", "default_runtime_name").(string)
+	data.set("default-runtime", defaultRuntime)
+	data.set("snapshotter", lookup(imagesPlugin, "snapshotter"))
+	data.set("enable-cdi", lookup(runtimePlugin, "enable_cdi"))
+	data.set("cdi-spec-dirs", lookup(runtimePlugin, "cdi_spec_dirs"))
+
+	runtimes := lookupTable(runtimePlugin, "containerd", "runtimes")
+	data.setRuntimes(runtimes, containerdRuntimeAttrs)
+
+	// The cgroup driver is set per runtime; report the one of the default
+	// runtime (runc when unset), or the deprecated version 1 plugin setting.
+	if defaultRuntime == "" {
+		defaultRuntime = "runc"
+	}
+	data.set("systemd-cgroup", lookup(runtimes, defaultRuntime, "options", "SystemdCgroup"))
+	if _, ok := data["systemd-cgroup"]; !ok && version == 1 {
+		data.set("systemd-cgroup", lookup(runtimePlugin, "systemd_cgroup"))
+	}
+
+	return res, nil
+}
+
+// loadContainerdConfig loads the containerd configuration at name and merges
+// its imports over it in order, as containerd does. Relative import paths are
+// resolved against the directory of the importing file. It returns the merged
+// configuration and the imported files.
+func (c *Collector) loadContainerdConfig(ctx context.Context, name string, seen map[string]bool) (map[string]any, []string, error) {
+	seen[name] = true
+	cfg, err := c.loadTOML(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patterns, _ := cfg["imports"].([]any)
+	var imported []string
+	for _, p := range patterns {
+		// Check if context is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		pattern, ok := p.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid import %v in %s", p, name)
+		}
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(name), pattern)
+		}
+
+		paths, err := c.glob(pattern)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, path := range paths {
+			if seen[path] {
+				continue
+			}
+			sub, subImports, err := c.loadContainerdConfig(ctx, path, seen)
+			if err != nil {
+				return nil, nil, err
+			}
+			mergeTOML(cfg, sub)
+			imported = append(imported, path)
+			imported = append(imported, subImports...)
+		}
+	}
+
+	return cfg, imported, nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// DefaultRoot is the filesystem root configuration files are read from when
+// Collector.Root is empty.
+const DefaultRoot = "/"
+
+// Collector collects container runtime configuration including:
+// - containerd configuration from /etc/containerd/config.toml and its imports
+// - CRI-O configuration from /etc/crio/crio.conf and /etc/crio/crio.conf.d
+type Collector struct {
+	// Root is the filesystem root, DefaultRoot when empty. It allows reading
+	// the host configuration mounted into a container, e.g. at /host.
+	Root string
+}
+
+// Collect gathers the container runtime configurations and returns them as a
+// single measurement with two subtypes: containerd and crio.
+// Runtimes without configuration files report configured=false.
+func (c *Collector) Collect(ctx context.Context) (*measurement.Measurement, error) {
+	slog.Info("collecting container runtime configuration")
+
+	// Check if context is canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	containerd, err := c.collectContainerd(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	crio, err := c.collectCRIO(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &measurement.Measurement{
+		Type: measurement.TypeContainerRuntime,
+		Subtypes: []measurement.Subtype{
+			*containerd,
+			*crio,
+		},
+	}
+
+	return res, nil
+}
+
+// path returns the location of an absolute configuration path below Root.
+func (c *Collector) path(name string) string {
+	root := c.Root
+	if root == "" {
+		root = DefaultRoot
+	}
+	return filepath.Join(root, name)
+}
+
+// loadTOML reads and parses the configuration file at name (below Root).
+func (c *Collector) loadTOML(name string) (map[string]any, error) {
+	content, err := os.ReadFile(c.path(name))
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseTOML(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return cfg, nil
+}
+
+// glob returns the sorted configuration paths matching pattern (below Root).
+func (c *Collector) glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(c.path(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	paths := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if info, err := os.Stat(m); err != nil || info.IsDir() {
+			continue
+		}
+		rel, err := filepath.Rel(c.path("/"), m)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, "/"+filepath.ToSlash(rel))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// readings collects configuration values, omitting unset ones.
+type readings map[string]measurement.Reading
+
+// set stores a configuration value. Arrays are stored as a comma-separated list.
+func (r readings) set(key string, value any) {
+	switch v := value.(type) {
+	case nil, map[string]any:
+		return
+	case string:
+		if v == "" {
+			return
+		}
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		value = strings.Join(items, ", ")
+	}
+	r[key] = measurement.ToReading(value)
+}
+
+// setRuntimes stores the sorted handler names of a runtimes table and the
+// given attributes of each handler as runtime.<name>.<key>.
+func (r readings) setRuntimes(runtimes map[string]any, attrs map[string][]string) {
+	names := make([]string, 0, len(runtimes))
+	for name := range runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		handler, ok := runtimes[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		for key, path := range attrs {
+			r.set("runtime."+name+"."+key, lookup(handler, path...))
+		}
+	}
+	r.set("runtimes", strings.Join(names, ", "))
+	r["nvidia-runtime"] = measurement.Bool(runtimes[nvidiaRuntime] != nil)
+}
+
+// nvidiaRuntime is the runtime handler name configured by the NVIDIA Container Toolkit.
+const nvidiaRuntime = "nvidia"
@@ -0,0 +1,342 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// fakeRoot writes the given files below a temporary root directory.
+func fakeRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// checkReadings verifies the subtype has the expected readings and lacks the absent ones.
+func checkReadings(t *testing.T, st *measurement.Subtype, want map[string]string, absent ...string) {
+	t.Helper()
+	for key, value := range want {
+		got, ok := st.Data[key]
+		if !ok {
+			t.Errorf("missing reading %s", key)
+			continue
+		}
+		if got.String() != value {
+			t.Errorf("%s = %q, want %q", key, got.String(), value)
+		}
+	}
+	for _, key := range absent {
+		if got, ok := st.Data[key]; ok {
+			t.Errorf("unexpected reading %s = %v", key, got)
+		}
+	}
+}
+
+const containerdV2Config = `version = 2
+imports = ["/etc/containerd/conf.d/*.toml"]
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    enable_cdi = false
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      snapshotter = "overlayfs"
+      default_runtime_name = "runc"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+        runtime_type = "io.containerd.runc.v2"
+        [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+          SystemdCgroup = true
+`
+
+// nvidiaDropIn is the drop-in written by the NVIDIA Container Toolkit.
+const nvidiaDropIn = `version = 2
+
+[plugins."io.containerd.grpc.v1.cri"]
+  enable_cdi = true
+  cdi_spec_dirs = ["/etc/cdi", "/var/run/cdi"]
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "nvidia"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+  runtime_type = "io.containerd.runc.v2"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+  BinaryName = "/usr/bin/nvidia-container-runtime"
+  SystemdCgroup = true
+`
+
+func TestCollector_Containerd(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		want   map[string]string
+		absent []string
+	}{
+		{
+			name: "version 2 with toolkit drop-in",
+			files: map[string]string{
+				"etc/containerd/config.toml":            containerdV2Config,
+				"etc/containerd/conf.d/99-nvidia.toml":  nvidiaDropIn,
+				"etc/containerd/conf.d/00-mirror.toml":  "version = 2\n",
+				"etc/containerd/conf.d/README":          "not imported",
+				"etc/containerd/conf.d/subdir.toml/a.x": "directories are skipped",
+			},
+			want: map[string]string{
+				"configured":                  "true",
+				"config-path":                 "/etc/containerd/config.toml",
+				"imports":                     "/etc/containerd/conf.d/00-mirror.toml, /etc/containerd/conf.d/99-nvidia.toml",
+				"version":                     "2",
+				"default-runtime":             "nvidia",
+				"runtimes":                    "nvidia, runc",
+				"nvidia-runtime":              "true",
+				"runtime.nvidia.type":         "io.containerd.runc.v2",
+				"runtime.nvidia.binary-name":  "/usr/bin/nvidia-container-runtime",
+				"runtime.runc.systemd-cgroup": "true",
+				"enable-cdi":                  "true",
+				"cdi-spec-dirs":               "/etc/cdi, /var/run/cdi",
+				"snapshotter":                 "overlayfs",
+				"systemd-cgroup":              "true",
+			},
+			absent: []string{"runtime.runc.binary-name"},
+		},
+		{
+			name: "version 3 with relative import",
+			files: map[string]string{
+				"etc/containerd/config.toml": `version = 3
+imports = ["conf.d/*.toml"]
+
+[plugins.'io.containerd.cri.v1.images']
+  snapshotter = "native"
+
+[plugins.'io.containerd.cri.v1.runtime'.containerd.runtimes.runc.options]
+  SystemdCgroup = false
+`,
+				"etc/containerd/conf.d/nvidia.toml": `version = 3
+[plugins.'io.containerd.cri.v1.runtime'.containerd.runtimes.nvidia.options]
+  BinaryName = "/usr/local/nvidia/toolkit/nvidia-container-runtime"
+`,
+			},
+			want: map[string]string{
+				"version":                    "3",
+				"imports":                    "/etc/containerd/conf.d/nvidia.toml",
+				"snapshotter":                "native",
+				"runtimes":                   "nvidia, runc",
+				"nvidia-runtime":             "true",
+				"runtime.nvidia.binary-name": "/usr/local/nvidia/toolkit/nvidia-container-runtime",
+				"systemd-cgroup":             "false",
+			},
+			absent: []string{"default-runtime", "enable-cdi"},
+		},
+		{
+			name: "version 1",
+			files: map[string]string{
+				"etc/containerd/config.toml": `[plugins.cri]
+  systemd_cgroup = true
+  [plugins.cri.containerd]
+    snapshotter = "overlayfs"
+`,
+			},
+			want: map[string]string{
+				"version":        "1",
+				"snapshotter":    "overlayfs",
+				"systemd-cgroup": "true",
+				"nvidia-runtime": "false",
+			},
+			absent: []string{"runtimes", "default-runtime"},
+		},
+		{
+			name: "import cycle",
+			files: map[string]string{
+				"etc/containerd/config.toml": "version = 2\nimports = [\"/etc/containerd/a.toml\"]\n",
+				"etc/containerd/a.toml":      "version = 2\nimports = [\"/etc/containerd/config.toml\", \"/etc/containerd/b.toml\"]\n",
+				"etc/containerd/b.toml":      "[plugins.\"io.containerd.grpc.v1.cri\"]\nenable_cdi = true\n",
+			},
+			want: map[string]string{
+				"imports":    "/etc/containerd/a.toml, /etc/containerd/b.toml",
+				"enable-cdi": "true",
+			},
+		},
+		{
+			name:   "not configured",
+			files:  map[string]string{},
+			want:   map[string]string{"configured": "false"},
+			absent: []string{"config-path", "version", "nvidia-runtime"},
+		},
+		{
+			name: "invalid configuration",
+			files: map[string]string{
+				"etc/containerd/config.toml": "version = \n",
+			},
+			want:   map[string]string{"configured": "true", "config-path": "/etc/containerd/config.toml"},
+			absent: []string{"version"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collector{Root: fakeRoot(t, tt.files)}
+			st, err := c.collectContainerd(context.Background())
+			if err != nil {
+				t.Fatalf("collectContainerd() error = %v", err)
+			}
+			if st.Name != "containerd" {
+				t.Errorf("expected subtype containerd, got %q", st.Name)
+			}
+			checkReadings(t, st, tt.want, tt.absent...)
+		})
+	}
+}
+
+func TestCollector_CRIO(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string
+		want   map[string]string
+		absent []string
+	}{
+		{
+			name: "config with drop-ins",
+			files: map[string]string{
+				"etc/crio/crio.conf": `[crio]
+storage_driver = "overlay"
+
+[crio.runtime]
+default_runtime = "crun"
+cgroup_manager = "cgroupfs"
+
+[crio.runtime.runtimes.crun]
+runtime_path = "/usr/bin/crun"
+`,
+				"etc/crio/crio.conf.d/10-cgroup.conf": "[crio.runtime]\ncgroup_manager = \"systemd\"\n",
+				"etc/crio/crio.conf.d/99-nvidia.conf": `[crio.runtime]
+default_runtime = "nvidia"
+cdi_spec_dirs = ["/etc/cdi"]
+
+[crio.runtime.runtimes.nvidia]
+runtime_path = "/usr/bin/nvidia-container-runtime"
+runtime_type = "oci"
+`,
+			},
+			want: map[string]string{
+				"configured":          "true",
+				"config-path":         "/etc/crio/crio.conf",
+				"drop-ins":            "/etc/crio/crio.conf.d/10-cgroup.conf, /etc/crio/crio.conf.d/99-nvidia.conf",
+				"default-runtime":     "nvidia",
+				"runtimes":            "crun, nvidia",
+				"nvidia-runtime":      "true",
+				"runtime.nvidia.path": "/usr/bin/nvidia-container-runtime",
+				"runtime.nvidia.type": "oci",
+				"runtime.crun.path":   "/usr/bin/crun",
+				"cgroup-manager":      "systemd",
+				"systemd-cgroup":      "true",
+				"cdi-spec-dirs":       "/etc/cdi",
+				"storage-driver":      "overlay",
+			},
+			absent: []string{"runtime.crun.type"},
+		},
+		{
+			name: "drop-ins only",
+			files: map[string]string{
+				"etc/crio/crio.conf.d/01-runtime.conf": "[crio.runtime]\ncgroup_manager = \"cgroupfs\"\n",
+			},
+			want: map[string]string{
+				"configured":     "true",
+				"drop-ins":       "/etc/crio/crio.conf.d/01-runtime.conf",
+				"systemd-cgroup": "false",
+				"nvidia-runtime": "false",
+			},
+			absent: []string{"config-path", "default-runtime"},
+		},
+		{
+			name:   "not configured",
+			files:  map[string]string{},
+			want:   map[string]string{"configured": "false"},
+			absent: []string{"config-path", "drop-ins", "nvidia-runtime"},
+		},
+		{
+			name: "invalid drop-in",
+			files: map[string]string{
+				"etc/crio/crio.conf":            "[crio.runtime]\ndefault_runtime = \"crun\"\n",
+				"etc/crio/crio.conf.d/bad.conf": "[crio.runtime\n",
+			},
+			want:   map[string]string{"configured": "true"},
+			absent: []string{"default-runtime"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collector{Root: fakeRoot(t, tt.files)}
+			st, err := c.collectCRIO(context.Background())
+			if err != nil {
+				t.Fatalf("collectCRIO() error = %v", err)
+			}
+			if st.Name != "crio" {
+				t.Errorf("expected subtype crio, got %q", st.Name)
+			}
+			checkReadings(t, st, tt.want, tt.absent...)
+		})
+	}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	c := &Collector{Root: fakeRoot(t, map[string]string{
+		"etc/containerd/config.toml": nvidiaDropIn,
+	})}
+
+	m, err := c.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if m.Type != measurement.TypeContainerRuntime {
+		t.Errorf("expected type %q, got %q", measurement.TypeContainerRuntime, m.Type)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	for path, value := range map[string]string{
+		"containerd.default-runtime": "nvidia",
+		"containerd.enable-cdi":      "true",
+		"crio.configured":            "false",
+	} {
+		if got, ok := m.Lookup(path); !ok || got.String() != value {
+			t.Errorf("%s = %v, want %s", path, got, value)
+		}
+	}
+}
+
+func TestCollector_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &Collector{Root: fakeRoot(t, nil)}
+	if _, err := c.Collect(ctx); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+var (
+	filePathCRIOConfig = "/etc/crio/crio.conf"
+	dirPathCRIODropIns = "/etc/crio/crio.conf.d"
+
+	// crioRuntimeAttrs maps runtime handler readings to their configuration keys.
+	crioRuntimeAttrs = map[string][]string{
+		"type": {"runtime_type"},
+		"path": {"runtime_path"},
+	}
+)
+
+// collectCRIO gathers the CRI-O configuration from /etc/crio/crio.conf merged
+// with the drop-ins in /etc/crio/crio.conf.d (in lexical order, later files
+// win), and returns it as a subtype, e.g.:
+//
+//	default-runtime: nvidia
+//	runtimes: crun, nvidia
+//	runtime.nvidia.path: /usr/bin/nvidia-container-runtime
+//	cgroup-manager: systemd
+//	systemd-cgroup: true
+//	storage-driver: overlay
+func (c *Collector) collectCRIO(ctx context.Context) (*measurement.Subtype, error) {
+	// Check if context is canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data := readings{}
+	res := &measurement.Subtype{
+		Name: "crio",
+		Data: data,
+	}
+
+	dropIns, err := c.glob(path.Join(dirPathCRIODropIns, "*"))
+	if err != nil {
+		return nil, err
+	}
+	files := dropIns
+	if _, statErr := os.Stat(c.path(filePathCRIOConfig)); !errors.Is(statErr, fs.ErrNotExist) {
+		files = append([]string{filePathCRIOConfig}, dropIns...)
+		data.set("config-path", filePathCRIOConfig)
+	}
+	data["configured"] = measurement.Bool(len(files) > 0)
+	data.set("drop-ins", strings.Join(dropIns, ", "))
+	if len(files) == 0 {
+		return res, nil
+	}
+
+	cfg := make(map[string]any)
+	for _, file := range files {
+		// Check if context is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		fileCfg, err := c.loadTOML(file)
+		if err != nil {
+			slog.Warn("failed to load CRI-O configuration",
+				slog.String("path", file),
+				slog.String("error", err.Error()))
+			return res, nil
+		}
+		mergeTOML(cfg, fileCfg)
+	}
+
+	runtime := lookupTable(cfg, "crio", "runtime")
+	data.set("default-runtime", lookup(runtime, "default_runtime"))
+	data.set("cdi-spec-dirs", lookup(runtime, "cdi_spec_dirs"))
+	data.set("storage-driver", lookup(cfg, "crio", "storage_driver"))
+	data.setRuntimes(lookupTable(runtime, "runtimes"), crioRuntimeAttrs)
+
+	if manager, ok := lookup(runtime, "cgroup_manager").(string); ok {
+		data.set("cgroup-manager", manager)
+		data["systemd-cgroup"] = measurement.Bool(manager == "systemd")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cri collects container runtime configuration from containerd and CRI-O.
+//
+// The systemd collector reports the state of the container runtime services,
+// but not whether they are configured for GPUs. This collector parses the
+// runtime configuration files to report the default runtime, the nvidia
+// runtime handler installed by the NVIDIA Container Toolkit, CDI settings,
+// and the snapshotter and cgroup driver.
+//
+// # Collected Data
+//
+// The collector returns a ContainerRuntime measurement with 2 subtypes:
+//
+// 1. containerd - /etc/containerd/config.toml merged with its imports
+// (e.g., /etc/containerd/conf.d/99-nvidia.toml), later imports winning:
+//   - configured: Whether a configuration file exists
+//   - config-path, imports: Loaded files
+//   - version: Configuration schema version (1, 2 or 3)
+//   - default-runtime: Default runtime handler (e.g., "nvidia")
+//   - runtimes: Sorted, comma-separated runtime handler names
+//   - nvidia-runtime: Whether the nvidia runtime handler is configured
+//   - runtime.<name>.type, path, binary-name, systemd-cgroup: Handler settings
+//   - enable-cdi, cdi-spec-dirs: Container Device Interface settings
+//   - snapshotter: Image snapshotter (e.g., "overlayfs")
+//   - systemd-cgroup: SystemdCgroup of the default runtime
+//
+// 2. crio - /etc/crio/crio.conf merged with /etc/crio/crio.conf.d/* in
+// lexical order:
+//   - configured, config-path, drop-ins: Loaded files
+//   - default-runtime, runtimes, nvidia-runtime: Runtime handlers
+//   - runtime.<name>.type, path: Handler settings
+//   - cgroup-manager, systemd-cgroup: Cgroup driver
+//   - cdi-spec-dirs: Container Device Interface spec directories
+//   - storage-driver: containers/storage driver (e.g., "overlay")
+//
+// Only settings present in the configuration files are reported; runtime
+// built-in defaults are not filled in. Files that cannot be parsed are logged
+// and their settings omitted.
+//
+// # Usage
+//
+//	collector := &cri.Collector{}
+//	m, err := collector.Collect(ctx)
+//
+// Root reads the configuration of another filesystem, such as the host
+// filesystem mounted into a container:
+//
+//	collector := &cri.Collector{Root: "/host"}
+//
+// The snapshot agent mounts the host /etc/containerd and /etc/crio read-only
+// below /host and passes it through CNS_HOST_ROOT (collector.WithHostRoot).
+//
+// # Use in Recipes
+//
+// Constraints can require the NVIDIA runtime before deploying GPU workloads:
+//
+//	constraints:
+//	  - name: ContainerRuntime.containerd.default-runtime
+//	    value: nvidia
+//	  - name: ContainerRuntime.containerd.systemd-cgroup
+//	    value: "true"
+package cri
@@ -0,0 +1,62 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML decodes a container runtime configuration file into nested maps.
+// Integers decode as int64, arrays as []any and tables as map[string]any.
+func parseTOML(content string) (map[string]any, error) {
+	cfg := make(map[string]any)
+	if _, err := toml.Decode(content, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// mergeTOML merges src into dst: tables are merged recursively and all other
+// values in src replace those in dst.
+func mergeTOML(dst, src map[string]any) {
+	for key, value := range src {
+		srcTable, srcOK := value.(map[string]any)
+		dstTable, dstOK := dst[key].(map[string]any)
+		if srcOK && dstOK {
+			mergeTOML(dstTable, srcTable)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// lookup returns the value at path below table, or nil when absent.
+func lookup(table map[string]any, path ...string) any {
+	var cur any = table
+	for _, key := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	return cur
+}
+
+// lookupTable returns the table at path below table, or nil when absent.
+func lookupTable(table map[string]any, path ...string) map[string]any {
+	m, _ := lookup(table, path...).(map[string]any)
+	return m
+}
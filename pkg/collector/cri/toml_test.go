@@ -0,0 +1,165 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "# only a comment\n\n",
+			want:  map[string]any{},
+		},
+		{
+			name:  "scalars",
+			input: "version = 2\nratio = 0.5\nenabled = true\ndisabled = false # comment\nname = \"a \\\"b\\\" \\u00e9\"\npath = 'C:\\dir'\nbig = 1_000\nhex = 0x1f\n",
+			want: map[string]any{
+				"version":  int64(2),
+				"ratio":    0.5,
+				"enabled":  true,
+				"disabled": false,
+				"name":     `a "b" é`,
+				"path":     `C:\dir`,
+				"big":      int64(1000),
+				"hex":      int64(31),
+			},
+		},
+		{
+			name: "tables and quoted keys",
+			input: `[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "nvidia"
+
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+    BinaryName = "/usr/bin/nvidia-container-runtime"
+`,
+			want: map[string]any{
+				"plugins": map[string]any{
+					"io.containerd.grpc.v1.cri": map[string]any{
+						"containerd": map[string]any{
+							"default_runtime_name": "nvidia",
+							"runtimes": map[string]any{
+								"nvidia": map[string]any{
+									"options": map[string]any{
+										"BinaryName": "/usr/bin/nvidia-container-runtime",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "dotted keys",
+			input: "[crio]\nruntime.default_runtime = \"crun\"\n",
+			want: map[string]any{
+				"crio": map[string]any{
+					"runtime": map[string]any{"default_runtime": "crun"},
+				},
+			},
+		},
+		{
+			name:  "multi-line array",
+			input: "imports = [\n  \"/etc/containerd/conf.d/*.toml\", # drop-ins\n  '/etc/other.toml',\n]\nempty = []\nnested = [[1, 2], [\"a\"]]\n",
+			want: map[string]any{
+				"imports": []any{"/etc/containerd/conf.d/*.toml", "/etc/other.toml"},
+				"empty":   []any{},
+				"nested":  []any{[]any{int64(1), int64(2)}, []any{"a"}},
+			},
+		},
+		{
+			name:  "inline table",
+			input: "options = { BinaryName = \"/bin/rt\", SystemdCgroup = true }\n",
+			want: map[string]any{
+				"options": map[string]any{"BinaryName": "/bin/rt", "SystemdCgroup": true},
+			},
+		},
+		{
+			name:  "multi-line strings",
+			input: "a = \"\"\"\nline1\nline2\"\"\"\nb = '''\nraw \\n'''\n",
+			want: map[string]any{
+				"a": "line1\nline2",
+				"b": `raw \n`,
+			},
+		},
+		{
+			name:  "array of tables",
+			input: "[[mirror]]\nhost = \"a\"\n[[mirror]]\nhost = \"b\"\n[mirror.auth]\nuser = \"x\"\n",
+			want: map[string]any{
+				"mirror": []map[string]any{
+					{"host": "a"},
+					{"host": "b", "auth": map[string]any{"user": "x"}},
+				},
+			},
+		},
+		{name: "missing value", input: "a =\n", wantErr: true},
+		{name: "missing equals", input: "a 1\n", wantErr: true},
+		{name: "unterminated string", input: "a = \"abc\n", wantErr: true},
+		{name: "unterminated array", input: "a = [1, 2\n", wantErr: true},
+		{name: "unterminated header", input: "[a\n", wantErr: true},
+		{name: "trailing garbage", input: "a = 1 2\n", wantErr: true},
+		{name: "value used as table", input: "a = 1\n[a.b]\n", wantErr: true},
+		{name: "invalid escape", input: "a = \"\\q\"\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTOML(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTOML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTOML() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeTOML(t *testing.T) {
+	dst := map[string]any{
+		"version": int64(2),
+		"plugins": map[string]any{
+			"cri": map[string]any{"snapshotter": "overlayfs", "enable_cdi": false},
+		},
+		"imports": []any{"a.toml"},
+	}
+	src := map[string]any{
+		"plugins": map[string]any{
+			"cri": map[string]any{"enable_cdi": true},
+		},
+		"imports": []any{"b.toml"},
+	}
+
+	mergeTOML(dst, src)
+
+	want := map[string]any{
+		"version": int64(2),
+		"plugins": map[string]any{
+			"cri": map[string]any{"snapshotter": "overlayfs", "enable_cdi": true},
+		},
+		"imports": []any{"b.toml"},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("mergeTOML() = %#v, want %#v", dst, want)
+	}
+}
@@ -17,9 +17,10 @@
 // # Overview
 //
 // This package defines a unified interface for gathering measurements from various system
-// sources including Kubernetes clusters, GPU hardware, operating system configuration, and
-// systemd services. Collectors run concurrently and return structured measurement data that
-// can be serialized for analysis or recommendation generation.
+// sources including Kubernetes clusters, GPU hardware, operating system configuration,
+// systemd services, RDMA/network devices, and container runtimes. Collectors run
+// concurrently and return structured measurement data that can be serialized for
+// analysis or recommendation generation.
 //
 // # Core Interface
 //
@@ -40,6 +41,8 @@
 //	    CreateOSCollector() Collector
 //	    CreateKubernetesCollector() Collector
 //	    CreateGPUCollector() Collector
+//	    CreateNetworkCollector() Collector
+//	    CreateContainerRuntimeCollector() Collector
 //	}
 //
 // The DefaultFactory provides production implementations with configurable options:
@@ -73,6 +76,16 @@
 //   - Active state and startup settings
 //   - Resource limits and dependencies
 //
+// Network: Reads RDMA and network interface attributes from sysfs:
+//   - RDMA devices and ports (link layer, rate, state, firmware, GUIDs)
+//   - Physical network interfaces (MTU, driver, link state)
+//   - SR-IOV virtual function counts
+//
+// ContainerRuntime: Parses containerd and CRI-O configuration files:
+//   - Default runtime and runtime handlers (e.g., nvidia)
+//   - CDI enablement and spec directories
+//   - Snapshotter/storage driver and systemd cgroup settings
+//
 // # Usage Example
 //
 // Using the default factory:
@@ -103,6 +116,8 @@
 //	    {"gpu", factory.CreateGPUCollector()},
 //	    {"os", factory.CreateOSCollector()},
 //	    {"systemd", factory.CreateSystemDCollector()},
+//	    {"network", factory.CreateNetworkCollector()},
+//	    {"cri", factory.CreateContainerRuntimeCollector()},
 //	}
 //
 //	for _, col := range collectors {
@@ -130,6 +145,8 @@
 //   - collector/gpu - GPU hardware collectors
 //   - collector/os - Operating system collectors
 //   - collector/systemd - SystemD service collectors
+//   - collector/network - RDMA and network interface collectors
+//   - collector/cri - Container runtime configuration collectors
 //   - collector/file - File-based configuration collectors
 //
 // # Error Handling
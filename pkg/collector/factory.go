@@ -15,8 +15,10 @@
 package collector
 
 import (
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector/cri"
 	"github.com/NVIDIA/cloud-native-stack/pkg/collector/gpu"
 	"github.com/NVIDIA/cloud-native-stack/pkg/collector/k8s"
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector/network"
 	"github.com/NVIDIA/cloud-native-stack/pkg/collector/os"
 	"github.com/NVIDIA/cloud-native-stack/pkg/collector/systemd"
 )
@@ -29,6 +31,8 @@ type Factory interface {
 	CreateOSCollector() Collector
 	CreateKubernetesCollector() Collector
 	CreateGPUCollector() Collector
+	CreateNetworkCollector() Collector
+	CreateContainerRuntimeCollector() Collector
 }
 
 // Option defines a configuration option for DefaultFactory.
@@ -50,12 +54,22 @@ func WithVersion(version string) Option {
 	}
 }
 
+// WithHostRoot sets the directory the host filesystem is mounted at when
+// collecting from a container (e.g., "/host"). Collectors that read host
+// configuration files resolve them below it.
+func WithHostRoot(root string) Option {
+	return func(f *DefaultFactory) {
+		f.HostRoot = root
+	}
+}
+
 // DefaultFactory is the standard implementation of Factory that creates collectors
 // with production dependencies. It configures default systemd services to monitor
 // and supports version tracking.
 type DefaultFactory struct {
 	SystemDServices []string
 	Version         string
+	HostRoot        string
 }
 
 // NewDefaultFactory creates a new DefaultFactory with default configuration.
@@ -99,3 +113,13 @@ func (f *DefaultFactory) CreateOSCollector() Collector {
 func (f *DefaultFactory) CreateKubernetesCollector() Collector {
 	return &k8s.Collector{}
 }
+
+// CreateNetworkCollector creates a collector for RDMA devices and network interfaces.
+func (f *DefaultFactory) CreateNetworkCollector() Collector {
+	return &network.Collector{}
+}
+
+// CreateContainerRuntimeCollector creates a collector for containerd and CRI-O configuration.
+func (f *DefaultFactory) CreateContainerRuntimeCollector() Collector {
+	return &cri.Collector{Root: f.HostRoot}
+}
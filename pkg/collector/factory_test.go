@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector/cri"
 	"github.com/NVIDIA/cloud-native-stack/pkg/collector/systemd"
 )
 
@@ -64,6 +65,8 @@ func TestDefaultCollectorFactory_AllCollectors(t *testing.T) {
 		factory.CreateOSCollector,
 		factory.CreateGPUCollector,
 		factory.CreateKubernetesCollector,
+		factory.CreateNetworkCollector,
+		factory.CreateContainerRuntimeCollector,
 	}
 
 	for i, createFunc := range collectorFuncs {
@@ -95,6 +98,18 @@ func TestWithVersion(t *testing.T) {
 	}
 }
 
+func TestWithHostRoot(t *testing.T) {
+	factory := NewDefaultFactory(WithHostRoot("/host"))
+
+	runtime, ok := factory.CreateContainerRuntimeCollector().(*cri.Collector)
+	if !ok {
+		t.Fatal("Expected *cri.Collector")
+	}
+	if runtime.Root != "/host" {
+		t.Errorf("expected root /host, got %q", runtime.Root)
+	}
+}
+
 func TestNewDefaultFactory_Defaults(t *testing.T) {
 	factory := NewDefaultFactory()
 
@@ -20,28 +20,60 @@
 //
 // # Collected Data
 //
-// The collector returns a measurement with multiple subtypes, one per GPU:
-//
-// GPU Hardware:
-//   - model: GPU model name (H100, A100, L40, etc.)
-//   - uuid: GPU UUID for unique identification
-//   - architecture: GPU architecture (Hopper, Ampere, Ada, etc.)
-//   - computeCapability: CUDA compute capability (9.0, 8.0, etc.)
-//   - memory: Total GPU memory in MB
-//   - bandwidth: Memory bandwidth in GB/s
-//
-// Driver Information:
-//   - driverVersion: NVIDIA driver version (570.158.01, etc.)
-//   - cudaVersion: Maximum supported CUDA version
-//   - vbios: GPU firmware/VBIOS version
-//
-// Runtime Settings:
-//   - persistenceMode: Whether persistence mode is enabled
-//   - computeMode: Compute mode (Default, Exclusive, Prohibited)
-//   - migMode: MIG mode (Enabled, Disabled) for supported GPUs
-//   - addressingMode: GPU addressing mode
-//   - powerLimit: Current power limit in watts
-//   - powerState: Current power state (P0-P12)
+// The collector returns a GPU measurement with an "smi" subtype followed by
+// one subtype per GPU, named by its index (gpu.0, gpu.1, etc.).
+//
+// smi - driver details, the first GPU and aggregates over all GPUs:
+//   - driver, cuda-version, gpu-count
+//   - gpu.model, gpu.product-architecture, gpu.vbios-version, etc. (first GPU)
+//   - gpu.models: Sorted, comma-separated set of GPU models
+//   - gpu.mixed-models: Whether the node has more than one GPU model
+//   - gpu.mig-enabled-count, gpu.ecc-enabled-count: GPUs with MIG/ECC enabled
+//   - gpu.ecc.{volatile,aggregate}-{correctable,uncorrectable}: ECC error totals
+//   - gpu.fabric.states: Sorted, comma-separated set of NVLink fabric states
+//   - gpu.unhealthy-count, gpu.healthy: GPUs failing the health checks below
+//
+// gpu.<index> - a single GPU:
+//   - model, uuid, serial, product-architecture, pci-bus-id, memory
+//   - vbios-version, persistence-mode, compute-mode
+//   - mig-mode, mig-mode-pending, ecc-mode, ecc-mode-pending
+//   - ecc.{volatile,aggregate}-{correctable,uncorrectable}: ECC error counts
+//   - retired-pages.pending, remapped-rows.pending, remapped-rows.failure
+//   - power-limit, max-clocks.{graphics,sm,memory}
+//   - pcie.max-link-gen, pcie.max-link-width
+//   - fabric.state, fabric.status, fabric.clique-id: NVLink fabric registration
+//   - healthy: Health check result
+//
+// topology - GPU/NIC topology from nvidia-smi topo -m and NVLink status from
+// nvidia-smi nvlink -s:
+//   - gpu-count, nic-count
+//   - <device>.<device>: Connection of each device pair (gpu0.gpu1: NV18, gpu0.nic0: PXB)
+//   - gpu<N>.cpu-affinity, gpu<N>.numa-affinity, nic<N>.device (e.g., mlx5_0)
+//   - gpu.link-types, gpu.all-nvlink, gpu.min-nvlinks: GPU-to-GPU connectivity
+//   - gpu.nic-local-count: GPUs with a NIC behind the same PCIe switch (PIX/PXB)
+//   - gpu.numa-nodes: NUMA nodes the GPUs are attached to
+//   - gpu<N>.nvlink.{active,inactive}, nvlink.{active,inactive,speeds}: NVLink states
+//
+// The topology subtype is omitted, with a warning, when nvidia-smi cannot
+// report the topology. NVLink readings are omitted when NVLink status is not
+// available.
+//
+// Readings nvidia-smi does not report for a GPU are omitted from its subtype.
+// A GPU is healthy unless it has uncorrectable ECC errors since the driver was
+// loaded, pending page retirement or row remapping, a failed row remapping, a
+// required reset, or a fabric status other than Success.
+//
+// Constraints can assert that all GPUs of a node are homogeneous and healthy:
+//
+//	constraints:
+//	  - name: GPU.smi.gpu.mixed-models
+//	    value: "false"
+//	  - name: GPU.smi.gpu.unhealthy-count
+//	    value: "== 0"
+//	  - name: GPU.gpu.0.fabric.state
+//	    value: Completed
+//	  - name: GPU.topology.gpu.min-nvlinks
+//	    value: ">= 18"
 //
 // # Usage
 //
@@ -79,14 +111,16 @@
 //
 // # Multi-GPU Support
 //
-// The collector automatically detects and collects data from all available GPUs:
+// Every GPU reported by nvidia-smi becomes a gpu.<index> subtype:
 //
-//	measurements, _ := collector.Collect(ctx)
-//	for i, subtype := range measurements[0].Subtypes {
-//	    fmt.Printf("GPU %d: %s\n", i, subtype.Data["model"])
+//	m, _ := collector.Collect(ctx)
+//	for _, st := range m.Subtypes[1:] {
+//	    fmt.Printf("%s: %s\n", st.Name, st.Data["model"])
 //	}
 //
-// Each GPU becomes a separate subtype named by its index (0, 1, 2, etc.).
+// tools/fake-nvidia-smi prints the XML, topology matrix and NVLink status of
+// an 8-GPU node and can be placed in PATH as nvidia-smi to exercise the
+// collector without GPUs.
 //
 // # Context Support
 //
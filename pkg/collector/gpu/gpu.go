@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
@@ -70,19 +73,24 @@ func (s *Collector) Collect(ctx context.Context) (*measurement.Measurement, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute nvidia-smi command: %w", err)
 	}
-	smiReadings, err := getSMIReadings(data)
+	subtypes, err := getSMISubtypes(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse nvidia-smi output: %w", err)
 	}
 
+	// Topology is best effort: older drivers and vGPUs may not support it
+	if len(subtypes) > 1 {
+		topology, topoErr := collectTopology(ctx)
+		if topoErr != nil {
+			slog.Warn("failed to collect GPU topology", slog.String("error", topoErr.Error()))
+		} else {
+			subtypes = append(subtypes, *topology)
+		}
+	}
+
 	res := &measurement.Measurement{
-		Type: measurement.TypeGPU,
-		Subtypes: []measurement.Subtype{
-			{
-				Name: "smi",
-				Data: smiReadings, // no need for filtering here since we control the fields in getSMIReadings
-			},
-		},
+		Type:     measurement.TypeGPU,
+		Subtypes: subtypes, // no need for filtering here since we control the fields in the readings
 	}
 
 	return res, nil
@@ -104,12 +112,29 @@ func noGPUMeasurement() *measurement.Measurement {
 	}
 }
 
-func getSMIReadings(data []byte) (map[string]measurement.Reading, error) {
+// getSMISubtypes parses nvidia-smi XML output into the "smi" subtype, holding
+// driver details and aggregates over all GPUs, followed by one "gpu.<index>"
+// subtype per GPU.
+func getSMISubtypes(data []byte) ([]measurement.Subtype, error) {
 	smiDevice, err := parseSMIDevice(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse nvidia-smi output: %w", err)
 	}
 
+	subtypes := []measurement.Subtype{{Name: "smi", Data: getSMIReadings(smiDevice)}}
+	for i := range smiDevice.GPUs {
+		subtypes = append(subtypes, measurement.Subtype{
+			Name: fmt.Sprintf("gpu.%d", i),
+			Data: getGPUReadings(&smiDevice.GPUs[i]),
+		})
+	}
+	return subtypes, nil
+}
+
+// getSMIReadings returns the driver readings, the details of the first GPU
+// under "gpu.*" keys, and aggregates over all GPUs, so constraints can assert
+// that the GPUs of a node are homogeneous and healthy.
+func getSMIReadings(smiDevice *NVSMIDevice) map[string]measurement.Reading {
 	smiData := make(map[string]measurement.Reading)
 
 	smiData[measurement.KeyGPUDriver] = measurement.Str(smiDevice.DriverVersion)
@@ -120,10 +145,10 @@ func getSMIReadings(data []byte) (map[string]measurement.Reading, error) {
 
 	if gpuCount < 1 {
 		slog.Warn("No GPUs found in nvidia-smi output")
-		return smiData, nil
+		return smiData
 	}
 
-	// Only include details for the first GPU to keep output concise
+	// Details of the first GPU; per-GPU details are in the gpu.<index> subtypes
 	gpu := smiDevice.GPUs[0]
 	prefix := "gpu"
 	key := func(field string) string {
@@ -138,7 +163,178 @@ func getSMIReadings(data []byte) (map[string]measurement.Reading, error) {
 	smiData[key("vbios-version")] = measurement.Str(gpu.VbiosVersion)
 	smiData[key("gsp-firmware-version")] = measurement.Str(gpu.GspFirmwareVersion)
 
-	return smiData, nil
+	// Aggregates over all GPUs
+	models := make(map[string]bool)
+	fabricStates := make(map[string]bool)
+	var migEnabled, eccEnabled, unhealthy int
+	var ecc eccCounts
+	for i := range smiDevice.GPUs {
+		g := &smiDevice.GPUs[i]
+		models[g.ProductName] = true
+		if g.Fabric.State != "" {
+			fabricStates[g.Fabric.State] = true
+		}
+		if isEnabled(g.MigMode.CurrentMig) {
+			migEnabled++
+		}
+		if isEnabled(g.EccMode.CurrentEcc) {
+			eccEnabled++
+		}
+		if !isHealthy(g) {
+			unhealthy++
+		}
+		ecc.add(newECCCounts(&g.EccErrors))
+	}
+
+	smiData[key("models")] = measurement.Str(joinSet(models))
+	smiData[key("mixed-models")] = measurement.Bool(len(models) > 1)
+	smiData[key("mig-enabled-count")] = measurement.Int(migEnabled)
+	smiData[key("ecc-enabled-count")] = measurement.Int(eccEnabled)
+	smiData[key("unhealthy-count")] = measurement.Int(unhealthy)
+	smiData[key("healthy")] = measurement.Bool(unhealthy == 0)
+	if len(fabricStates) > 0 {
+		smiData[key("fabric.states")] = measurement.Str(joinSet(fabricStates))
+	}
+	ecc.setReadings(smiData, key("ecc."))
+
+	return smiData
+}
+
+// getGPUReadings returns the readings of a single GPU. Readings that are
+// absent from the nvidia-smi output are omitted.
+func getGPUReadings(gpu *GPU) map[string]measurement.Reading {
+	data := make(map[string]measurement.Reading)
+	setStr := func(key, value string) {
+		if value != "" {
+			data[key] = measurement.Str(value)
+		}
+	}
+
+	setStr(measurement.KeyGPUModel, gpu.ProductName)
+	setStr(measurement.KeyGPUUUID, gpu.UUID)
+	setStr("serial", gpu.Serial)
+	setStr("product-architecture", gpu.ProductArchitecture)
+	if gpu.Pci.PciBusID != "" {
+		setStr("pci-bus-id", gpu.Pci.PciBusID)
+	} else {
+		setStr("pci-bus-id", gpu.ID)
+	}
+	setStr(measurement.KeyGPUMemory, gpu.FbMemoryUsage.Total)
+	setStr("vbios-version", gpu.VbiosVersion)
+	setStr("persistence-mode", gpu.PersistenceMode)
+	setStr("compute-mode", gpu.ComputeMode)
+	setStr("mig-mode", gpu.MigMode.CurrentMig)
+	setStr("mig-mode-pending", gpu.MigMode.PendingMig)
+	setStr("ecc-mode", gpu.EccMode.CurrentEcc)
+	setStr("ecc-mode-pending", gpu.EccMode.PendingEcc)
+	setStr("retired-pages.pending", gpu.RetiredPages.PendingRetirement)
+	setStr("remapped-rows.pending", gpu.RemappedRows.RemappedRowPending)
+	setStr("remapped-rows.failure", gpu.RemappedRows.RemappedRowFailure)
+	setStr("power-limit", gpu.GpuPowerReadings.CurrentPowerLimit)
+	setStr("max-clocks.graphics", gpu.MaxClocks.GraphicsClock)
+	setStr("max-clocks.sm", gpu.MaxClocks.SmClock)
+	setStr("max-clocks.memory", gpu.MaxClocks.MemClock)
+	setStr("pcie.max-link-gen", gpu.Pci.PciGpuLinkInfo.PcieGen.MaxLinkGen)
+	setStr("pcie.max-link-width", gpu.Pci.PciGpuLinkInfo.LinkWidths.MaxLinkWidth)
+	setStr("fabric.state", gpu.Fabric.State)
+	setStr("fabric.status", gpu.Fabric.Status)
+	setStr("fabric.clique-id", gpu.Fabric.Cliqueid)
+
+	newECCCounts(&gpu.EccErrors).setReadings(data, "ecc.")
+	data["healthy"] = measurement.Bool(isHealthy(gpu))
+
+	return data
+}
+
+// isHealthy reports whether the GPU has no uncorrectable ECC errors since the
+// last driver load, no pending page retirement or row remapping, no failed row
+// remapping, does not require a reset, and has not failed to join its NVLink
+// fabric. Lifetime (aggregate) ECC counters are reported but not considered.
+func isHealthy(gpu *GPU) bool {
+	if newECCCounts(&gpu.EccErrors).volatileUncorrectable > 0 {
+		return false
+	}
+	if isYes(gpu.RetiredPages.PendingRetirement) ||
+		isYes(gpu.RemappedRows.RemappedRowPending) ||
+		isYes(gpu.RemappedRows.RemappedRowFailure) ||
+		isYes(gpu.GpuResetStatus.ResetRequired) {
+		return false
+	}
+	switch gpu.Fabric.Status {
+	case "", "N/A", "Success":
+		return true
+	default:
+		return false
+	}
+}
+
+// eccCounts holds ECC error counters summed over SRAM and DRAM.
+type eccCounts struct {
+	volatileCorrectable    int
+	volatileUncorrectable  int
+	aggregateCorrectable   int
+	aggregateUncorrectable int
+
+	// reported is false when nvidia-smi reports no counters (e.g., N/A when ECC is unsupported)
+	reported bool
+}
+
+func newECCCounts(e *EccErrors) eccCounts {
+	var c eccCounts
+	sum := func(values ...string) int {
+		total := 0
+		for _, v := range values {
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				total += n
+				c.reported = true
+			}
+		}
+		return total
+	}
+	c.volatileCorrectable = sum(e.Volatile.SramCorrectable, e.Volatile.DramCorrectable)
+	c.volatileUncorrectable = sum(e.Volatile.SramUncorrectableParity, e.Volatile.SramUncorrectableSecded,
+		e.Volatile.DramUncorrectable)
+	c.aggregateCorrectable = sum(e.Aggregate.SramCorrectable, e.Aggregate.DramCorrectable)
+	c.aggregateUncorrectable = sum(e.Aggregate.SramUncorrectableParity, e.Aggregate.SramUncorrectableSecded,
+		e.Aggregate.DramUncorrectable)
+	return c
+}
+
+func (c *eccCounts) add(o eccCounts) {
+	c.volatileCorrectable += o.volatileCorrectable
+	c.volatileUncorrectable += o.volatileUncorrectable
+	c.aggregateCorrectable += o.aggregateCorrectable
+	c.aggregateUncorrectable += o.aggregateUncorrectable
+	c.reported = c.reported || o.reported
+}
+
+// setReadings adds the counters under the given key prefix, if any were reported.
+func (c eccCounts) setReadings(data map[string]measurement.Reading, prefix string) {
+	if !c.reported {
+		return
+	}
+	data[prefix+"volatile-correctable"] = measurement.Int(c.volatileCorrectable)
+	data[prefix+"volatile-uncorrectable"] = measurement.Int(c.volatileUncorrectable)
+	data[prefix+"aggregate-correctable"] = measurement.Int(c.aggregateCorrectable)
+	data[prefix+"aggregate-uncorrectable"] = measurement.Int(c.aggregateUncorrectable)
+}
+
+func isEnabled(v string) bool {
+	return strings.EqualFold(strings.TrimSpace(v), "Enabled")
+}
+
+func isYes(v string) bool {
+	return strings.EqualFold(strings.TrimSpace(v), "Yes")
+}
+
+// joinSet returns the sorted members of set separated by ", ".
+func joinSet(set map[string]bool) string {
+	members := make([]string, 0, len(set))
+	for m := range set {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return strings.Join(members, ", ")
 }
 
 func executeCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
@@ -173,6 +369,7 @@ type NVSMIDevice struct {
 }
 
 type GPU struct {
+	ID                        string                    `xml:"id,attr" json:"id" yaml:"id"`
 	ProductName               string                    `xml:"product_name" json:"productName" yaml:"productName"`
 	ProductBrand              string                    `xml:"product_brand" json:"productBrand" yaml:"productBrand"`
 	ProductArchitecture       string                    `xml:"product_architecture" json:"productArchitecture" yaml:"productArchitecture"`
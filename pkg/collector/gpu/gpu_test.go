@@ -18,8 +18,11 @@ import (
 	"context"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -154,10 +157,7 @@ func TestGetSMIReadings(t *testing.T) {
 		t.Skipf("gpu.xml not available: %v", err)
 	}
 
-	readings, err := getSMIReadings(data)
-	if err != nil {
-		t.Fatalf("getSMIReadings failed: %v", err)
-	}
+	readings := smiReadings(t, data)
 
 	// Validate expected keys exist
 	expectedKeys := []string{
@@ -223,10 +223,7 @@ func TestGetSMIReadings_NoGPUs(t *testing.T) {
 	<attached_gpus>0</attached_gpus>
 </nvidia_smi_log>`)
 
-	readings, err := getSMIReadings(xmlData)
-	if err != nil {
-		t.Fatalf("getSMIReadings failed: %v", err)
-	}
+	readings := smiReadings(t, xmlData)
 
 	// Should have driver and CUDA version
 	if _, ok := readings[measurement.KeyGPUDriver]; !ok {
@@ -410,3 +407,256 @@ func TestNVSMIDevice_XMLUnmarshal(t *testing.T) {
 		}
 	}
 }
+
+// fakeSMIScript is the fake nvidia-smi used by the e2e tests.
+const fakeSMIScript = "../../../tools/fake-nvidia-smi"
+
+// smiReadings returns the readings of the "smi" subtype parsed from data.
+func smiReadings(t *testing.T, data []byte) map[string]measurement.Reading {
+	t.Helper()
+	subtypes, err := getSMISubtypes(data)
+	if err != nil {
+		t.Fatalf("getSMISubtypes failed: %v", err)
+	}
+	if len(subtypes) == 0 || subtypes[0].Name != "smi" {
+		t.Fatalf("expected smi as first subtype, got %v", subtypes)
+	}
+	return subtypes[0].Data
+}
+
+// fakeSMIXML returns the XML document printed by the fake nvidia-smi for -q -x.
+func fakeSMIXML(t *testing.T) string {
+	t.Helper()
+	script, err := os.ReadFile(fakeSMIScript)
+	if err != nil {
+		t.Skipf("fake-nvidia-smi not available: %v", err)
+	}
+	_, rest, ok := strings.Cut(string(script), "<< 'XML'\n")
+	if !ok {
+		t.Fatal("XML document not found in fake-nvidia-smi")
+	}
+	doc, _, ok := strings.Cut(rest, "\nXML\n")
+	if !ok {
+		t.Fatal("unterminated XML document in fake-nvidia-smi")
+	}
+	return doc
+}
+
+func TestGetSMISubtypes(t *testing.T) {
+	data, err := os.ReadFile("gpu.xml")
+	if err != nil {
+		t.Skipf("gpu.xml not available: %v", err)
+	}
+
+	subtypes, err := getSMISubtypes(data)
+	if err != nil {
+		t.Fatalf("getSMISubtypes failed: %v", err)
+	}
+	m := &measurement.Measurement{Type: measurement.TypeGPU, Subtypes: subtypes}
+
+	if len(subtypes) != 9 {
+		t.Fatalf("expected smi and 8 per-GPU subtypes, got %v", m.SubtypeNames())
+	}
+
+	want := map[string]string{
+		"smi.gpu.models":                      "NVIDIA H100 80GB HBM3",
+		"smi.gpu.mixed-models":                "false",
+		"smi.gpu.mig-enabled-count":           "0",
+		"smi.gpu.ecc-enabled-count":           "8",
+		"smi.gpu.unhealthy-count":             "0",
+		"smi.gpu.healthy":                     "true",
+		"smi.gpu.fabric.states":               "Completed",
+		"smi.gpu.ecc.volatile-uncorrectable":  "0",
+		"smi.gpu.ecc.aggregate-uncorrectable": "0",
+		"gpu.0.pci-bus-id":                    "00000000:04:00.0",
+		"gpu.0.product-architecture":          "Hopper",
+		"gpu.0.mig-mode":                      "Disabled",
+		"gpu.0.ecc-mode":                      "Enabled",
+		"gpu.0.fabric.state":                  "Completed",
+		"gpu.0.fabric.status":                 "Success",
+		"gpu.0.pcie.max-link-gen":             "4",
+		"gpu.0.power-limit":                   "700.00 W",
+		"gpu.0.remapped-rows.pending":         "No",
+		"gpu.0.healthy":                       "true",
+		"gpu.7." + measurement.KeyGPUModel:    "NVIDIA H100 80GB HBM3",
+		"gpu.7.ecc.volatile-correctable":      "0",
+		"gpu.7.ecc.aggregate-uncorrectable":   "0",
+		"smi.gpu." + measurement.KeyGPUModel:  "NVIDIA H100 80GB HBM3",
+		"smi." + measurement.KeyGPUCount:      "8",
+		"gpu.1." + measurement.KeyGPUMemory:   "81559 MiB",
+	}
+	for path, value := range want {
+		got, ok := m.Lookup(path)
+		if !ok {
+			t.Errorf("missing reading %s", path)
+			continue
+		}
+		if got.String() != value {
+			t.Errorf("%s = %q, want %q", path, got.String(), value)
+		}
+	}
+
+	// UUIDs identify each GPU
+	if m.Subtypes[1].Data[measurement.KeyGPUUUID].String() == m.Subtypes[2].Data[measurement.KeyGPUUUID].String() {
+		t.Error("expected distinct GPU UUIDs")
+	}
+}
+
+func TestGetSMISubtypes_FakeNvidiaSMI(t *testing.T) {
+	fixture := fakeSMIXML(t)
+
+	// inject adds elements to the first GPU of the fixture
+	inject := func(elements string) func(string) string {
+		return func(doc string) string {
+			return strings.Replace(doc, "<product_brand>NVIDIA</product_brand>",
+				"<product_brand>NVIDIA</product_brand>"+elements, 1)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(string) string
+		want   map[string]string
+		absent []string
+	}{
+		{
+			name: "fixture",
+			want: map[string]string{
+				measurement.KeyGPUCount: "8",
+				"gpu.models":            "NVIDIA B200",
+				"gpu.mixed-models":      "false",
+				"gpu.mig-enabled-count": "0",
+				"gpu.unhealthy-count":   "0",
+				"gpu.healthy":           "true",
+			},
+			absent: []string{"gpu.fabric.states", "gpu.ecc.volatile-uncorrectable"},
+		},
+		{
+			name: "mixed models",
+			mutate: func(doc string) string {
+				return strings.Replace(doc, "<product_name>NVIDIA B200</product_name>",
+					"<product_name>NVIDIA H200</product_name>", 1)
+			},
+			want: map[string]string{
+				"gpu.models":       "NVIDIA B200, NVIDIA H200",
+				"gpu.mixed-models": "true",
+				"gpu.healthy":      "true",
+			},
+		},
+		{
+			name:   "mig enabled",
+			mutate: inject("<mig_mode><current_mig>Enabled</current_mig></mig_mode>"),
+			want: map[string]string{
+				"gpu.mig-enabled-count": "1",
+			},
+		},
+		{
+			name: "uncorrectable ecc errors",
+			mutate: inject("<ecc_errors><volatile><dram_correctable>5</dram_correctable>" +
+				"<dram_uncorrectable>2</dram_uncorrectable></volatile></ecc_errors>"),
+			want: map[string]string{
+				"gpu.ecc.volatile-correctable":   "5",
+				"gpu.ecc.volatile-uncorrectable": "2",
+				"gpu.unhealthy-count":            "1",
+				"gpu.healthy":                    "false",
+			},
+		},
+		{
+			name:   "fabric failure",
+			mutate: inject("<fabric><state>Completed</state><status>Failure</status></fabric>"),
+			want: map[string]string{
+				"gpu.fabric.states":   "Completed",
+				"gpu.unhealthy-count": "1",
+				"gpu.healthy":         "false",
+			},
+		},
+		{
+			name:   "row remapping failure",
+			mutate: inject("<remapped_rows><remapped_row_failure>Yes</remapped_row_failure></remapped_rows>"),
+			want: map[string]string{
+				"gpu.unhealthy-count": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := fixture
+			if tt.mutate != nil {
+				doc = tt.mutate(doc)
+			}
+			readings := smiReadings(t, []byte(doc))
+			for key, value := range tt.want {
+				got, ok := readings[key]
+				if !ok {
+					t.Errorf("missing reading %s", key)
+					continue
+				}
+				if got.String() != value {
+					t.Errorf("%s = %q, want %q", key, got.String(), value)
+				}
+			}
+			for _, key := range tt.absent {
+				if _, ok := readings[key]; ok {
+					t.Errorf("unexpected reading %s", key)
+				}
+			}
+		})
+	}
+}
+
+// useFakeSMI puts the fake nvidia-smi first in PATH for the duration of the test.
+func useFakeSMI(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+	script, err := filepath.Abs(fakeSMIScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(script); err != nil {
+		t.Skipf("fake-nvidia-smi not available: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Symlink(script, filepath.Join(dir, nvidiaSMICommand)); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestCollector_FakeNvidiaSMI(t *testing.T) {
+	useFakeSMI(t)
+
+	m, err := (&Collector{}).Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if len(m.Subtypes) != 10 {
+		t.Fatalf("expected smi, 8 per-GPU and topology subtypes, got %v", m.SubtypeNames())
+	}
+	for i := range 8 {
+		name := fmt.Sprintf("gpu.%d", i)
+		st := m.GetSubtype(name)
+		if st == nil {
+			t.Fatalf("missing subtype %s", name)
+		}
+		if got := st.Data[measurement.KeyGPUModel].String(); got != "NVIDIA B200" {
+			t.Errorf("%s model = %q", name, got)
+		}
+		if got := st.Data[measurement.KeyGPUUUID].String(); got != fmt.Sprintf("GPU-fake-0000-0000-0000-00000000000%d", i) {
+			t.Errorf("%s uuid = %q", name, got)
+		}
+	}
+	if got, _ := m.Lookup("gpu.0.pci-bus-id"); got == nil || got.String() != "00000000:00:04.0" {
+		t.Errorf("gpu.0.pci-bus-id = %v", got)
+	}
+	if got, _ := m.Lookup("smi.gpu.healthy"); got == nil || got.String() != "true" {
+		t.Errorf("smi.gpu.healthy = %v", got)
+	}
+	if got, _ := m.Lookup("topology.gpu.all-nvlink"); got == nil || got.String() != "true" {
+		t.Errorf("topology.gpu.all-nvlink = %v", got)
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpu
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+const (
+	// topologySubtype is the GPU measurement subtype holding the GPU/NIC
+	// topology and NVLink status.
+	topologySubtype = "topology"
+
+	// topologySelf marks the diagonal of the topology matrix.
+	topologySelf = "X"
+)
+
+var (
+	// ansiEscape matches the terminal formatting nvidia-smi adds to the matrix header.
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	// nicLegend matches NIC legend lines such as "NIC0: mlx5_0".
+	nicLegend = regexp.MustCompile(`^(NIC\d+):\s*(\S+)$`)
+
+	// nvlinkGPU matches the GPU header lines of nvidia-smi nvlink -s.
+	nvlinkGPU = regexp.MustCompile(`^GPU (\d+):`)
+
+	// nvlinkLink matches the link lines of nvidia-smi nvlink -s.
+	nvlinkLink = regexp.MustCompile(`^Link (\d+):\s*(.*)$`)
+)
+
+// TopologyMatrix is the parsed output of nvidia-smi topo -m.
+type TopologyMatrix struct {
+	// Devices are the GPUs and NICs of the matrix in column order (GPU0, ..., NIC0, ...).
+	Devices []string `json:"devices" yaml:"devices"`
+
+	// Links maps each device to the connection type (NV18, PIX, PXB, PHB, NODE, SYS)
+	// towards every other device.
+	Links map[string]map[string]string `json:"links" yaml:"links"`
+
+	// CPUAffinity maps each GPU to the CPUs local to it.
+	CPUAffinity map[string]string `json:"cpuAffinity,omitempty" yaml:"cpuAffinity,omitempty"`
+
+	// NUMAAffinity maps each GPU to the NUMA node local to it.
+	NUMAAffinity map[string]string `json:"numaAffinity,omitempty" yaml:"numaAffinity,omitempty"`
+
+	// NICs maps each NIC to its device name (e.g., mlx5_0) from the NIC legend.
+	NICs map[string]string `json:"nics,omitempty" yaml:"nics,omitempty"`
+}
+
+// NVLinkStatus is the status of the NVLinks of one GPU, parsed from nvidia-smi nvlink -s.
+type NVLinkStatus struct {
+	// GPU is the GPU index.
+	GPU int `json:"gpu" yaml:"gpu"`
+
+	// Active is the number of active links.
+	Active int `json:"active" yaml:"active"`
+
+	// Inactive is the number of inactive links.
+	Inactive int `json:"inactive" yaml:"inactive"`
+
+	// Speeds are the reported speeds of the active links (e.g., "50 GB/s").
+	Speeds []string `json:"speeds,omitempty" yaml:"speeds,omitempty"`
+}
+
+// collectTopology runs nvidia-smi topo -m and nvidia-smi nvlink -s and returns
+// the topology subtype. NVLink status is optional since GPUs without NVLink
+// make nvidia-smi nvlink fail on some driver versions.
+func collectTopology(ctx context.Context) (*measurement.Subtype, error) {
+	matrixData, err := executeCommand(ctx, nvidiaSMICommand, "topo", "-m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute nvidia-smi topo command: %w", err)
+	}
+	matrix, err := parseTopologyMatrix(matrixData)
+	if err != nil {
+		return nil, err
+	}
+
+	var nvlinks []NVLinkStatus
+	nvlinkData, err := executeCommand(ctx, nvidiaSMICommand, "nvlink", "-s")
+	if err == nil {
+		nvlinks = parseNVLinkStatus(nvlinkData)
+	}
+
+	return &measurement.Subtype{
+		Name: topologySubtype,
+		Data: getTopologyReadings(matrix, nvlinks),
+	}, nil
+}
+
+// parseTopologyMatrix parses the tab-separated matrix and NIC legend printed by
+// nvidia-smi topo -m.
+func parseTopologyMatrix(data []byte) (*TopologyMatrix, error) {
+	m := &TopologyMatrix{
+		Links:        make(map[string]map[string]string),
+		CPUAffinity:  make(map[string]string),
+		NUMAAffinity: make(map[string]string),
+		NICs:         make(map[string]string),
+	}
+
+	var columns []string
+	scanner := bufio.NewScanner(bytes.NewReader(ansiEscape.ReplaceAll(data, nil)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := splitTopologyFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// The header is the first indented line starting with GPU0
+		if columns == nil {
+			if line != strings.TrimLeft(line, " \t") && fields[0] == "GPU0" {
+				columns = fields
+			}
+			continue
+		}
+
+		if matches := nicLegend.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			m.NICs[matches[1]] = matches[2]
+			continue
+		}
+
+		device := fields[0]
+		if _, seen := m.Links[device]; seen || !isTopologyDevice(device) {
+			// Legend lines
+			continue
+		}
+		m.Devices = append(m.Devices, device)
+		m.Links[device] = make(map[string]string)
+		for i, value := range fields[1:] {
+			if i >= len(columns) {
+				break
+			}
+			switch col := columns[i]; {
+			case isTopologyDevice(col):
+				if value != topologySelf {
+					m.Links[device][col] = value
+				}
+			case col == "CPU Affinity":
+				m.CPUAffinity[device] = value
+			case col == "NUMA Affinity":
+				m.NUMAAffinity[device] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read nvidia-smi topology: %w", err)
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("no topology matrix found in nvidia-smi output")
+	}
+	return m, nil
+}
+
+// splitTopologyFields splits a matrix line on tabs, dropping empty cells.
+func splitTopologyFields(line string) []string {
+	var fields []string
+	for _, f := range strings.Split(line, "\t") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func isTopologyDevice(name string) bool {
+	for _, prefix := range []string{"GPU", "NIC"} {
+		if n, ok := strings.CutPrefix(name, prefix); ok {
+			if _, err := strconv.Atoi(n); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseNVLinkStatus parses the output of nvidia-smi nvlink -s. GPUs without
+// NVLink are reported with no links.
+func parseNVLinkStatus(data []byte) []NVLinkStatus {
+	var statuses []NVLinkStatus
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if matches := nvlinkGPU.FindStringSubmatch(line); matches != nil {
+			index, _ := strconv.Atoi(matches[1])
+			statuses = append(statuses, NVLinkStatus{GPU: index})
+			continue
+		}
+		matches := nvlinkLink.FindStringSubmatch(line)
+		if matches == nil || len(statuses) == 0 {
+			continue
+		}
+		status := &statuses[len(statuses)-1]
+		if state := strings.TrimSpace(matches[2]); strings.Contains(strings.ToLower(state), "inactive") {
+			status.Inactive++
+		} else {
+			status.Active++
+			status.Speeds = append(status.Speeds, state)
+		}
+	}
+	return statuses
+}
+
+// getTopologyReadings flattens the topology into readings:
+//   - gpu-count, nic-count: Devices in the matrix
+//   - <device>.<device>: Connection type of every device pair (e.g., gpu0.gpu1: NV18)
+//   - gpu<N>.cpu-affinity, gpu<N>.numa-affinity: CPU and NUMA affinity of each GPU
+//   - nic<N>.device: Device name of each NIC
+//   - gpu.link-types: Sorted set of GPU-to-GPU connection types
+//   - gpu.all-nvlink: Whether every GPU pair is connected through NVLink
+//   - gpu.min-nvlinks: Fewest bonded NVLinks between any GPU pair (0 without NVLink)
+//   - gpu.nic-local-count: GPUs with a NIC behind the same PCIe switch (PIX or PXB)
+//   - gpu.numa-nodes: Number of distinct NUMA nodes the GPUs are attached to
+//   - gpu<N>.nvlink.active, gpu<N>.nvlink.inactive: NVLink states of each GPU
+//   - nvlink.active, nvlink.inactive, nvlink.speeds: NVLink states of all GPUs
+func getTopologyReadings(m *TopologyMatrix, nvlinks []NVLinkStatus) map[string]measurement.Reading {
+	data := make(map[string]measurement.Reading)
+
+	var gpus, nics []string
+	for _, d := range m.Devices {
+		if strings.HasPrefix(d, "GPU") {
+			gpus = append(gpus, d)
+		} else {
+			nics = append(nics, d)
+		}
+	}
+	data[measurement.KeyGPUCount] = measurement.Int(len(gpus))
+	data["nic-count"] = measurement.Int(len(nics))
+
+	// Each pair once, in matrix order
+	for i, a := range m.Devices {
+		for _, b := range m.Devices[i+1:] {
+			if link, ok := m.Links[a][b]; ok {
+				data[strings.ToLower(a)+"."+strings.ToLower(b)] = measurement.Str(link)
+			}
+		}
+	}
+	for _, gpu := range gpus {
+		if v := m.CPUAffinity[gpu]; v != "" {
+			data[strings.ToLower(gpu)+".cpu-affinity"] = measurement.Str(v)
+		}
+		if v := m.NUMAAffinity[gpu]; v != "" {
+			data[strings.ToLower(gpu)+".numa-affinity"] = measurement.Str(v)
+		}
+	}
+	for _, nic := range nics {
+		if v := m.NICs[nic]; v != "" {
+			data[strings.ToLower(nic)+".device"] = measurement.Str(v)
+		}
+	}
+
+	// GPU-to-GPU connectivity
+	if len(gpus) > 1 {
+		linkTypes := make(map[string]bool)
+		minNVLinks := -1
+		for i, a := range gpus {
+			for _, b := range gpus[i+1:] {
+				link := m.Links[a][b]
+				linkTypes[link] = true
+				n := nvlinkCount(link)
+				if minNVLinks < 0 || n < minNVLinks {
+					minNVLinks = n
+				}
+			}
+		}
+		data["gpu.link-types"] = measurement.Str(joinSet(linkTypes))
+		data["gpu.all-nvlink"] = measurement.Bool(minNVLinks > 0)
+		data["gpu.min-nvlinks"] = measurement.Int(minNVLinks)
+	}
+
+	// GPU-to-NIC and NUMA affinity
+	nicLocal := 0
+	numaNodes := make(map[string]bool)
+	for _, gpu := range gpus {
+		for _, nic := range nics {
+			if link := m.Links[gpu][nic]; link == "PIX" || link == "PXB" {
+				nicLocal++
+				break
+			}
+		}
+		if v := m.NUMAAffinity[gpu]; v != "" && v != "N/A" {
+			numaNodes[v] = true
+		}
+	}
+	data["gpu.nic-local-count"] = measurement.Int(nicLocal)
+	data["gpu.numa-nodes"] = measurement.Int(len(numaNodes))
+
+	// NVLink states
+	if len(nvlinks) > 0 {
+		var active, inactive int
+		speeds := make(map[string]bool)
+		for _, s := range nvlinks {
+			prefix := fmt.Sprintf("gpu%d.nvlink.", s.GPU)
+			data[prefix+"active"] = measurement.Int(s.Active)
+			data[prefix+"inactive"] = measurement.Int(s.Inactive)
+			active += s.Active
+			inactive += s.Inactive
+			for _, speed := range s.Speeds {
+				speeds[speed] = true
+			}
+		}
+		data["nvlink.active"] = measurement.Int(active)
+		data["nvlink.inactive"] = measurement.Int(inactive)
+		if len(speeds) > 0 {
+			data["nvlink.speeds"] = measurement.Str(joinSet(speeds))
+		}
+	}
+
+	return data
+}
+
+// nvlinkCount returns the number of bonded NVLinks of an NV# connection, or 0.
+func nvlinkCount(link string) int {
+	if n, ok := strings.CutPrefix(link, "NV"); ok {
+		if count, err := strconv.Atoi(n); err == nil {
+			return count
+		}
+	}
+	return 0
+}
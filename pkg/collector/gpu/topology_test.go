@@ -0,0 +1,201 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+func TestCollectTopology_FakeNvidiaSMI(t *testing.T) {
+	useFakeSMI(t)
+
+	st, err := collectTopology(context.Background())
+	if err != nil {
+		t.Fatalf("collectTopology() error = %v", err)
+	}
+	if st.Name != topologySubtype {
+		t.Errorf("expected subtype %q, got %q", topologySubtype, st.Name)
+	}
+
+	want := map[string]string{
+		measurement.KeyGPUCount: "8",
+		"nic-count":             "4",
+		"gpu0.gpu7":             "NV18",
+		"gpu0.nic0":             "PXB",
+		"gpu0.nic2":             "SYS",
+		"gpu5.nic2":             "PXB",
+		"nic0.nic1":             "NODE",
+		"gpu0.cpu-affinity":     "0-55,112-167",
+		"gpu7.numa-affinity":    "1",
+		"nic3.device":           "mlx5_3",
+		"gpu.link-types":        "NV18",
+		"gpu.all-nvlink":        "true",
+		"gpu.min-nvlinks":       "18",
+		"gpu.nic-local-count":   "8",
+		"gpu.numa-nodes":        "2",
+		"gpu3.nvlink.active":    "18",
+		"gpu3.nvlink.inactive":  "0",
+		"nvlink.active":         "144",
+		"nvlink.inactive":       "0",
+		"nvlink.speeds":         "50 GB/s",
+	}
+	for key, value := range want {
+		got, ok := st.Data[key]
+		if !ok {
+			t.Errorf("missing reading %s", key)
+			continue
+		}
+		if got.String() != value {
+			t.Errorf("%s = %q, want %q", key, got.String(), value)
+		}
+	}
+
+	// Pairs are reported once
+	if _, ok := st.Data["gpu7.gpu0"]; ok {
+		t.Error("unexpected reading for reversed pair gpu7.gpu0")
+	}
+}
+
+func TestParseTopologyMatrix(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "pcie only with formatted header",
+			data: "\x1b[4m\tGPU0\tGPU1\tNIC0\tCPU Affinity\tNUMA Affinity\tGPU NUMA ID\x1b[0m\n" +
+				"GPU0\t X \tPIX\tPHB\t0-31\t0\t\tN/A\n" +
+				"GPU1\tPIX\t X \tPHB\t0-31\t0\t\tN/A\n" +
+				"NIC0\tPHB\tPHB\t X \n\n" +
+				"Legend:\n\n  X    = Self\n  PIX  = Connection traversing at most a single PCIe bridge\n\n" +
+				"NIC Legend:\n\n  NIC0: mlx5_0\n",
+			want: map[string]string{
+				measurement.KeyGPUCount: "2",
+				"nic-count":             "1",
+				"gpu0.gpu1":             "PIX",
+				"gpu1.nic0":             "PHB",
+				"nic0.device":           "mlx5_0",
+				"gpu.link-types":        "PIX",
+				"gpu.all-nvlink":        "false",
+				"gpu.min-nvlinks":       "0",
+				"gpu.nic-local-count":   "0",
+				"gpu.numa-nodes":        "1",
+			},
+		},
+		{
+			name: "partial nvlink",
+			data: "\tGPU0\tGPU1\tGPU2\tCPU Affinity\tNUMA Affinity\n" +
+				"GPU0\t X \tNV12\tSYS\t0-15\t0\n" +
+				"GPU1\tNV12\t X \tNV4\t0-15\t0\n" +
+				"GPU2\tSYS\tNV4\t X \t16-31\t1\n",
+			want: map[string]string{
+				measurement.KeyGPUCount: "3",
+				"nic-count":             "0",
+				"gpu.link-types":        "NV12, NV4, SYS",
+				"gpu.all-nvlink":        "false",
+				"gpu.min-nvlinks":       "0",
+				"gpu.numa-nodes":        "2",
+			},
+		},
+		{
+			name: "single gpu",
+			data: "\tGPU0\tCPU Affinity\tNUMA Affinity\tGPU NUMA ID\n" +
+				"GPU0\t X \t0-7\t0\t\tN/A\n",
+			want: map[string]string{
+				measurement.KeyGPUCount: "1",
+				"gpu0.cpu-affinity":     "0-7",
+			},
+		},
+		{
+			name:    "no matrix",
+			data:    "NVML: Unable to get topology\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := parseTopologyMatrix([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTopologyMatrix() error = %v", err)
+			}
+			readings := getTopologyReadings(m, nil)
+			for key, value := range tt.want {
+				got, ok := readings[key]
+				if !ok {
+					t.Errorf("missing reading %s", key)
+					continue
+				}
+				if got.String() != value {
+					t.Errorf("%s = %q, want %q", key, got.String(), value)
+				}
+			}
+			if _, ok := readings["nvlink.active"]; ok {
+				t.Error("unexpected NVLink readings without NVLink status")
+			}
+		})
+	}
+}
+
+func TestParseNVLinkStatus(t *testing.T) {
+	data := []byte("GPU 0: NVIDIA H100 80GB HBM3 (UUID: GPU-0)\n" +
+		"\t Link 0: 26.562 GB/s\n" +
+		"\t Link 1: <inactive>\n" +
+		"GPU 1: NVIDIA H100 80GB HBM3 (UUID: GPU-1)\n" +
+		"\t Link 0: 26.562 GB/s\n" +
+		"\t Link 1: 26.562 GB/s\n" +
+		"GPU 2: NVIDIA L40S (UUID: GPU-2)\n")
+
+	statuses := parseNVLinkStatus(data)
+	if len(statuses) != 3 {
+		t.Fatalf("expected 3 GPUs, got %+v", statuses)
+	}
+	if statuses[0].Active != 1 || statuses[0].Inactive != 1 {
+		t.Errorf("GPU 0 = %+v, want 1 active and 1 inactive link", statuses[0])
+	}
+	if statuses[2].Active != 0 || statuses[2].Inactive != 0 {
+		t.Errorf("GPU 2 = %+v, want no links", statuses[2])
+	}
+
+	m := &TopologyMatrix{Devices: []string{"GPU0", "GPU1", "GPU2"}}
+	readings := getTopologyReadings(m, statuses)
+	want := map[string]string{
+		"gpu0.nvlink.inactive": "1",
+		"gpu2.nvlink.active":   "0",
+		"nvlink.active":        "3",
+		"nvlink.inactive":      "1",
+		"nvlink.speeds":        "26.562 GB/s",
+	}
+	for key, value := range want {
+		if got, ok := readings[key]; !ok || got.String() != value {
+			t.Errorf("%s = %v, want %q", key, got, value)
+		}
+	}
+
+	if got := parseNVLinkStatus([]byte("NVML: Unable to retrieve NVLink information as all links are inActive\n")); len(got) != 0 {
+		t.Errorf("expected no GPUs, got %+v", got)
+	}
+}
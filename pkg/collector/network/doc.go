@@ -0,0 +1,65 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package network collects RDMA and network interface configuration data.
+//
+// This collector captures the fabric side of GPU nodes, complementing the
+// NVIDIA Network Operator: InfiniBand/RoCE devices, their ports, and the
+// physical network interfaces with their SR-IOV configuration. All data is
+// read from sysfs, so no tools need to be installed on the node.
+//
+// # Collected Data
+//
+// The collector returns a Network measurement with 2 subtypes:
+//
+// 1. rdma - RDMA devices from /sys/class/infiniband:
+//   - <device>.fw-version, hca-type, board-id, node-type
+//   - <device>.node-guid, sys-image-guid: Device GUIDs
+//   - <device>.netdev: Network interfaces of the device
+//   - <device>.sriov-numvfs, sriov-totalvfs: SR-IOV virtual functions
+//   - <device>.port<N>.state, phys-state: Port state (ACTIVE, LinkUp)
+//   - <device>.port<N>.rate: Port rate (e.g., "400 Gb/sec (4X NDR)")
+//   - <device>.port<N>.link-layer: InfiniBand or Ethernet (RoCE)
+//   - device-count, port-count, active-port-count, sriov-vf-count
+//   - link-layers, rates, fw-versions: Sorted, comma-separated value sets
+//
+// 2. net - Physical network interfaces from /sys/class/net:
+//   - <interface>.mtu, operstate, speed, link-layer, driver
+//   - <interface>.sriov-numvfs, sriov-totalvfs
+//   - interface-count, sriov-vf-count, mtus
+//
+// Virtual interfaces (loopback, bridges, veth pairs, CNI devices) are skipped.
+// Attributes that cannot be read are omitted. Nodes without RDMA devices
+// report device-count=0.
+//
+// # Usage
+//
+//	collector := &network.Collector{}
+//	m, err := collector.Collect(ctx)
+//
+// SysfsRoot reads from another sysfs mount, such as the host's sysfs mounted
+// into a container or a fake tree in tests:
+//
+//	collector := &network.Collector{SysfsRoot: "/host/sys"}
+//
+// # Use in Recipes
+//
+// Constraints can require a healthy fabric before deploying training workloads:
+//
+//	constraints:
+//	  - name: Network.rdma.active-port-count
+//	    value: ">= 8"
+//	  - name: Network.rdma.rates
+//	    value: "400 Gb/sec (4X NDR)"
+package network
@@ -0,0 +1,90 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// linkTypes maps ARPHRD_* values of /sys/class/net/<iface>/type to link layers.
+var linkTypes = map[string]string{
+	"1":  "Ethernet",
+	"32": "InfiniBand",
+}
+
+// collectNet gathers the attributes of physical network interfaces from
+// /sys/class/net and returns them as a subtype keyed by interface, e.g.:
+//
+//	ens1f0np0.mtu: 9000
+//	ens1f0np0.driver: mlx5_core
+//	ens1f0np0.sriov-numvfs: 8
+//
+// Virtual interfaces (loopback, bridges, veth pairs, CNI devices) have no
+// backing device and are skipped, since they vary with the pods on the node.
+func (c *Collector) collectNet(ctx context.Context) (*measurement.Subtype, error) {
+	dir := c.root("class", "net")
+	ifaces, err := listDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	data := readings{}
+	mtus := make(map[string]bool)
+	var count, vfs int
+
+	for _, iface := range ifaces {
+		// Check if context is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := os.Stat(c.root("class", "net", iface, "device")); err != nil {
+			continue
+		}
+		count++
+
+		key := func(field string) string { return iface + "." + field }
+		mtu := readAttr(dir, iface, "mtu")
+		mtus[mtu] = true
+		data.setInt(key("mtu"), mtu)
+		data.setStr(key("operstate"), readAttr(dir, iface, "operstate"))
+		data.setInt(key("speed"), readAttr(dir, iface, "speed"))
+		data.setStr(key("link-layer"), linkTypes[readAttr(dir, iface, "type")])
+		data.setStr(key("driver"), readLinkBase(dir, iface, "device", "driver"))
+
+		numVFs := readAttr(dir, iface, "device", "sriov_numvfs")
+		data.setInt(key("sriov-numvfs"), numVFs)
+		data.setInt(key("sriov-totalvfs"), readAttr(dir, iface, "device", "sriov_totalvfs"))
+		if n, err := strconv.Atoi(numVFs); err == nil {
+			vfs += n
+		}
+	}
+
+	data["interface-count"] = measurement.Int(count)
+	data["sriov-vf-count"] = measurement.Int(vfs)
+	data.setStr("mtus", joinSet(mtus))
+
+	res := &measurement.Subtype{
+		Name: "net",
+		Data: data,
+	}
+
+	return res, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// DefaultSysfsRoot is the sysfs mount point read when Collector.SysfsRoot is empty.
+const DefaultSysfsRoot = "/sys"
+
+// Collector collects RDMA and network interface configuration from sysfs:
+// - RDMA devices and ports from /sys/class/infiniband
+// - Physical network interfaces from /sys/class/net
+type Collector struct {
+	// SysfsRoot is the sysfs mount point, DefaultSysfsRoot when empty.
+	// Tests point it at a fake sysfs tree.
+	SysfsRoot string
+}
+
+// Collect gathers RDMA and network interface configuration and returns them as
+// a single measurement with two subtypes: rdma and net.
+// Nodes without RDMA devices report device-count=0 (graceful degradation).
+func (c *Collector) Collect(ctx context.Context) (*measurement.Measurement, error) {
+	slog.Info("collecting network configuration")
+
+	// Check if context is canceled
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rdma, err := c.collectRDMA(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	net, err := c.collectNet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &measurement.Measurement{
+		Type: measurement.TypeNetwork,
+		Subtypes: []measurement.Subtype{
+			*rdma,
+			*net,
+		},
+	}
+
+	return res, nil
+}
+
+// root returns the sysfs root joined with elem.
+func (c *Collector) root(elem ...string) string {
+	root := c.SysfsRoot
+	if root == "" {
+		root = DefaultSysfsRoot
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
+
+// listDir returns the sorted entry names of dir, or none when it does not exist.
+func listDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readAttr returns the trimmed content of a sysfs attribute, or "" when it
+// cannot be read (absent, or invalid in the current state such as the speed
+// of a down interface).
+func readAttr(path ...string) string {
+	data, err := os.ReadFile(filepath.Join(path...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// readLinkBase returns the base name of a sysfs symlink target, e.g. the
+// driver name of device/driver, or "" when it is not a link.
+func readLinkBase(path ...string) string {
+	target, err := os.Readlink(filepath.Join(path...))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
+
+// readings collects string readings, omitting empty values.
+type readings map[string]measurement.Reading
+
+func (r readings) setStr(key, value string) {
+	if value != "" {
+		r[key] = measurement.Str(value)
+	}
+}
+
+// setInt stores an integer attribute, falling back to the raw value.
+func (r readings) setInt(key, value string) {
+	if n, err := strconv.Atoi(value); err == nil {
+		r[key] = measurement.Int(n)
+		return
+	}
+	r.setStr(key, value)
+}
+
+// joinSet returns the sorted members of set separated by ", ".
+func joinSet(set map[string]bool) string {
+	members := make([]string, 0, len(set))
+	for m := range set {
+		if m != "" {
+			members = append(members, m)
+		}
+	}
+	sort.Strings(members)
+	return strings.Join(members, ", ")
+}
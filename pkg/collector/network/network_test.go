@@ -0,0 +1,228 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// fakeSysfs creates a sysfs tree under a temporary directory from a map of
+// attribute paths to contents. Values starting with "->" create symlinks.
+func fakeSysfs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for path, content := range files {
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if target, ok := cutLink(content); ok {
+			if err := os.Symlink(target, full); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.WriteFile(full, []byte(content+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func cutLink(content string) (string, bool) {
+	if len(content) > 2 && content[:2] == "->" {
+		return content[2:], true
+	}
+	return "", false
+}
+
+// dgxSysfs is a node with two InfiniBand HCAs, one RoCE NIC with SR-IOV, and
+// virtual interfaces that must be skipped.
+var dgxSysfs = map[string]string{
+	"class/infiniband/mlx5_0/fw_ver":                   "28.39.1002",
+	"class/infiniband/mlx5_0/hca_type":                 "MT4129",
+	"class/infiniband/mlx5_0/board_id":                 "MT_0000000838",
+	"class/infiniband/mlx5_0/node_type":                "1: CA",
+	"class/infiniband/mlx5_0/node_guid":                "a088:c203:0012:3456",
+	"class/infiniband/mlx5_0/sys_image_guid":           "a088:c203:0012:3456",
+	"class/infiniband/mlx5_0/device/net/ibp24s0/mtu":   "4092",
+	"class/infiniband/mlx5_0/ports/1/state":            "4: ACTIVE",
+	"class/infiniband/mlx5_0/ports/1/phys_state":       "5: LinkUp",
+	"class/infiniband/mlx5_0/ports/1/rate":             "400 Gb/sec (4X NDR)",
+	"class/infiniband/mlx5_0/ports/1/link_layer":       "InfiniBand",
+	"class/infiniband/mlx5_1/fw_ver":                   "28.39.1002",
+	"class/infiniband/mlx5_1/node_guid":                "a088:c203:0012:3457",
+	"class/infiniband/mlx5_1/ports/1/state":            "1: DOWN",
+	"class/infiniband/mlx5_1/ports/1/phys_state":       "3: Disabled",
+	"class/infiniband/mlx5_1/ports/1/rate":             "400 Gb/sec (4X NDR)",
+	"class/infiniband/mlx5_1/ports/1/link_layer":       "InfiniBand",
+	"class/infiniband/mlx5_2/fw_ver":                   "22.41.1000",
+	"class/infiniband/mlx5_2/device/sriov_numvfs":      "8",
+	"class/infiniband/mlx5_2/device/sriov_totalvfs":    "16",
+	"class/infiniband/mlx5_2/device/net/ens1f0np0/mtu": "9000",
+	"class/infiniband/mlx5_2/ports/1/state":            "4: ACTIVE",
+	"class/infiniband/mlx5_2/ports/1/phys_state":       "5: LinkUp",
+	"class/infiniband/mlx5_2/ports/1/rate":             "200 Gb/sec (4X HDR)",
+	"class/infiniband/mlx5_2/ports/1/link_layer":       "Ethernet",
+	"class/net/ens1f0np0/mtu":                          "9000",
+	"class/net/ens1f0np0/operstate":                    "up",
+	"class/net/ens1f0np0/speed":                        "200000",
+	"class/net/ens1f0np0/type":                         "1",
+	"class/net/ens1f0np0/device/sriov_numvfs":          "8",
+	"class/net/ens1f0np0/device/sriov_totalvfs":        "16",
+	"class/net/ens1f0np0/device/driver":                "->../../../bus/pci/drivers/mlx5_core",
+	"class/net/ibp24s0/mtu":                            "4092",
+	"class/net/ibp24s0/operstate":                      "up",
+	"class/net/ibp24s0/type":                           "32",
+	"class/net/ibp24s0/device/driver":                  "->../../../bus/pci/drivers/mlx5_core",
+	"class/net/lo/mtu":                                 "65536",
+	"class/net/cni0/mtu":                               "1450",
+	"class/net/veth1234/mtu":                           "1450",
+}
+
+func TestCollector_Collect(t *testing.T) {
+	c := &Collector{SysfsRoot: fakeSysfs(t, dgxSysfs)}
+
+	m, err := c.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if m.Type != measurement.TypeNetwork {
+		t.Errorf("expected type %q, got %q", measurement.TypeNetwork, m.Type)
+	}
+	if len(m.Subtypes) != 2 || m.Subtypes[0].Name != "rdma" || m.Subtypes[1].Name != "net" {
+		t.Fatalf("expected rdma and net subtypes, got %v", m.SubtypeNames())
+	}
+
+	want := map[string]string{
+		"rdma.device-count":            "3",
+		"rdma.port-count":              "3",
+		"rdma.active-port-count":       "2",
+		"rdma.sriov-vf-count":          "8",
+		"rdma.link-layers":             "Ethernet, InfiniBand",
+		"rdma.rates":                   "200 Gb/sec (4X HDR), 400 Gb/sec (4X NDR)",
+		"rdma.fw-versions":             "22.41.1000, 28.39.1002",
+		"rdma.mlx5_0.fw-version":       "28.39.1002",
+		"rdma.mlx5_0.hca-type":         "MT4129",
+		"rdma.mlx5_0.node-type":        "CA",
+		"rdma.mlx5_0.node-guid":        "a088:c203:0012:3456",
+		"rdma.mlx5_0.netdev":           "ibp24s0",
+		"rdma.mlx5_0.port1.state":      "ACTIVE",
+		"rdma.mlx5_0.port1.phys-state": "LinkUp",
+		"rdma.mlx5_0.port1.rate":       "400 Gb/sec (4X NDR)",
+		"rdma.mlx5_0.port1.link-layer": "InfiniBand",
+		"rdma.mlx5_1.port1.state":      "DOWN",
+		"rdma.mlx5_2.sriov-numvfs":     "8",
+		"rdma.mlx5_2.sriov-totalvfs":   "16",
+		"rdma.mlx5_2.port1.link-layer": "Ethernet",
+		"net.interface-count":          "2",
+		"net.sriov-vf-count":           "8",
+		"net.mtus":                     "4092, 9000",
+		"net.ens1f0np0.mtu":            "9000",
+		"net.ens1f0np0.operstate":      "up",
+		"net.ens1f0np0.speed":          "200000",
+		"net.ens1f0np0.link-layer":     "Ethernet",
+		"net.ens1f0np0.driver":         "mlx5_core",
+		"net.ens1f0np0.sriov-numvfs":   "8",
+		"net.ibp24s0.link-layer":       "InfiniBand",
+		"net.ibp24s0.driver":           "mlx5_core",
+	}
+	for path, value := range want {
+		got, ok := m.Lookup(path)
+		if !ok {
+			t.Errorf("missing reading %s", path)
+			continue
+		}
+		if got.String() != value {
+			t.Errorf("%s = %q, want %q", path, got.String(), value)
+		}
+	}
+
+	absent := []string{
+		"rdma.mlx5_1.hca-type", // unreadable attributes are omitted
+		"net.ibp24s0.speed",    // not reported by the fake tree
+		"net.lo.mtu",           // virtual interfaces are skipped
+		"net.veth1234.mtu",
+		"net.cni0.mtu",
+	}
+	for _, path := range absent {
+		if _, ok := m.Lookup(path); ok {
+			t.Errorf("unexpected reading %s", path)
+		}
+	}
+
+	// Integer attributes keep their type
+	if v := m.GetSubtype("net").Data["ens1f0np0.mtu"].Any(); v != 9000 {
+		t.Errorf("expected int MTU, got %T %v", v, v)
+	}
+}
+
+func TestCollector_NoRDMA(t *testing.T) {
+	c := &Collector{SysfsRoot: fakeSysfs(t, map[string]string{
+		"class/net/lo/mtu": "65536",
+	})}
+
+	m, err := c.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	for path, value := range map[string]string{
+		"rdma.device-count":   "0",
+		"rdma.port-count":     "0",
+		"net.interface-count": "0",
+	} {
+		if got, ok := m.Lookup(path); !ok || got.String() != value {
+			t.Errorf("%s = %v, want %s", path, got, value)
+		}
+	}
+	if _, ok := m.Lookup("rdma.link-layers"); ok {
+		t.Error("expected no link-layers reading without RDMA devices")
+	}
+}
+
+func TestCollector_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &Collector{SysfsRoot: fakeSysfs(t, dgxSysfs)}
+	if _, err := c.Collect(ctx); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}
+
+func TestStripPrefix(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"4: ACTIVE", "ACTIVE"},
+		{"5: LinkUp", "LinkUp"},
+		{"1: CA", "CA"},
+		{"ACTIVE", "ACTIVE"},
+		{"a088:c203:0012:3456", "a088:c203:0012:3456"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := stripPrefix(tt.in); got != tt.want {
+			t.Errorf("stripPrefix(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+const portStateActive = "ACTIVE"
+
+// collectRDMA gathers RDMA device and port attributes from /sys/class/infiniband
+// and returns them as a subtype keyed by device, e.g.:
+//
+//	mlx5_0.fw-version: 28.39.1002
+//	mlx5_0.port1.state: ACTIVE
+//	mlx5_0.port1.rate: 400 Gb/sec (4X NDR)
+//	mlx5_0.port1.link-layer: InfiniBand
+func (c *Collector) collectRDMA(ctx context.Context) (*measurement.Subtype, error) {
+	dir := c.root("class", "infiniband")
+	devices, err := listDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RDMA devices: %w", err)
+	}
+
+	data := readings{}
+	linkLayers := make(map[string]bool)
+	rates := make(map[string]bool)
+	firmware := make(map[string]bool)
+	var ports, activePorts, vfs int
+
+	for _, dev := range devices {
+		// Check if context is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		key := func(field string) string { return dev + "." + field }
+		fw := readAttr(dir, dev, "fw_ver")
+		firmware[fw] = true
+		data.setStr(key("fw-version"), fw)
+		data.setStr(key("hca-type"), readAttr(dir, dev, "hca_type"))
+		data.setStr(key("board-id"), readAttr(dir, dev, "board_id"))
+		data.setStr(key("node-type"), stripPrefix(readAttr(dir, dev, "node_type")))
+		data.setStr(key("node-guid"), readAttr(dir, dev, "node_guid"))
+		data.setStr(key("sys-image-guid"), readAttr(dir, dev, "sys_image_guid"))
+
+		netdevs, err := listDir(c.root("class", "infiniband", dev, "device", "net"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list network interfaces of RDMA device %s: %w", dev, err)
+		}
+		data.setStr(key("netdev"), strings.Join(netdevs, ","))
+
+		numVFs := readAttr(dir, dev, "device", "sriov_numvfs")
+		data.setInt(key("sriov-numvfs"), numVFs)
+		data.setInt(key("sriov-totalvfs"), readAttr(dir, dev, "device", "sriov_totalvfs"))
+		if n, err := strconv.Atoi(numVFs); err == nil {
+			vfs += n
+		}
+
+		portNums, err := listDir(c.root("class", "infiniband", dev, "ports"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ports of RDMA device %s: %w", dev, err)
+		}
+		for _, port := range portNums {
+			portKey := func(field string) string { return key("port" + port + "." + field) }
+			portDir := c.root("class", "infiniband", dev, "ports", port)
+
+			state := stripPrefix(readAttr(portDir, "state"))
+			linkLayer := readAttr(portDir, "link_layer")
+			rate := readAttr(portDir, "rate")
+			data.setStr(portKey("state"), state)
+			data.setStr(portKey("phys-state"), stripPrefix(readAttr(portDir, "phys_state")))
+			data.setStr(portKey("rate"), rate)
+			data.setStr(portKey("link-layer"), linkLayer)
+
+			ports++
+			if state == portStateActive {
+				activePorts++
+			}
+			linkLayers[linkLayer] = true
+			rates[rate] = true
+		}
+	}
+
+	data["device-count"] = measurement.Int(len(devices))
+	data["port-count"] = measurement.Int(ports)
+	data["active-port-count"] = measurement.Int(activePorts)
+	data["sriov-vf-count"] = measurement.Int(vfs)
+	data.setStr("link-layers", joinSet(linkLayers))
+	data.setStr("rates", joinSet(rates))
+	data.setStr("fw-versions", joinSet(firmware))
+
+	res := &measurement.Subtype{
+		Name: "rdma",
+		Data: data,
+	}
+
+	return res, nil
+}
+
+// stripPrefix removes the numeric code sysfs prefixes to enumerated values,
+// e.g. "4: ACTIVE" becomes "ACTIVE" and "5: LinkUp" becomes "LinkUp".
+func stripPrefix(value string) string {
+	if code, name, ok := strings.Cut(value, ":"); ok {
+		if _, err := strconv.Atoi(strings.TrimSpace(code)); err == nil {
+			return strings.TrimSpace(name)
+		}
+	}
+	return value
+}
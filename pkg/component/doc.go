@@ -123,7 +123,9 @@
 //   - GetBundlerVersion: Returns bundler version from config
 //   - GetRecipeBundlerVersion: Returns recipe version from config
 //   - MarshalYAMLWithHeader: Serializes values with component header
-//   - ApplyMapOverrides: Applies dot-notation overrides to nested maps
+//   - ApplyMapOverrides: Applies dot-notation overrides to nested maps, with list indices (a[0].b)
+//   - ApplyTypedMapOverrides: Applies structured overrides, nil deletes the value
+//   - MergeMapValues: Deep merges a values file into nested maps
 //   - ApplyNodeSelectorOverrides: Applies node selectors to Helm paths
 //   - ApplyTolerationsOverrides: Applies tolerations to Helm paths
 //   - GenerateDefaultBundleMetadata: Creates default BundleMetadata struct
@@ -228,6 +228,19 @@ func MakeBundle(ctx context.Context, b *BaseBundler, input recipe.RecipeInput, o
 			"failed to get values for "+cfg.Name, err)
 	}
 
+	// Apply user values files from --values flags, then structured
+	// overrides from --set-json flags
+	if files, ok := componentOverrides(b.Config.ValuesFiles(), cfg); ok {
+		for _, file := range files {
+			MergeMapValues(values, file)
+		}
+	}
+	if overrides, ok := componentOverrides(b.Config.JSONValueOverrides(), cfg); ok {
+		if applyErr := ApplyTypedMapOverrides(values, overrides); applyErr != nil {
+			slog.Warn("failed to apply some structured value overrides to values map", "error", applyErr)
+		}
+	}
+
 	// Apply user value overrides from --set flags
 	if overrides := getValueOverridesForComponent(b, cfg); len(overrides) > 0 {
 		if applyErr := ApplyMapOverrides(values, overrides); applyErr != nil {
@@ -348,22 +361,25 @@ func MakeBundle(ctx context.Context, b *BaseBundler, input recipe.RecipeInput, o
 // getValueOverridesForComponent retrieves value overrides for a component from config.
 // It checks the component name first, then any alternative keys specified in the config.
 func getValueOverridesForComponent(b *BaseBundler, cfg ComponentConfig) map[string]string {
-	allOverrides := b.Config.ValueOverrides()
-	if allOverrides == nil {
-		return nil
-	}
+	overrides, _ := componentOverrides(b.Config.ValueOverrides(), cfg)
+	return overrides
+}
 
+// componentOverrides returns the entry of a per-component overrides map for a
+// component, matching its name or one of its alternative override keys.
+func componentOverrides[T any](all map[string]T, cfg ComponentConfig) (T, bool) {
 	// Check the component name first
-	if overrides, ok := allOverrides[cfg.Name]; ok {
-		return overrides
+	if overrides, ok := all[cfg.Name]; ok {
+		return overrides, true
 	}
 
 	// Check alternative keys
 	for _, key := range cfg.ValueOverrideKeys {
-		if overrides, ok := allOverrides[key]; ok {
-			return overrides
+		if overrides, ok := all[key]; ok {
+			return overrides, true
 		}
 	}
 
-	return nil
+	var zero T
+	return zero, false
 }
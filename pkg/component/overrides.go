@@ -17,6 +17,7 @@ package component
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -68,6 +69,7 @@ func ApplyValueOverrides(target any, overrides map[string]string) error {
 
 // ApplyMapOverrides applies overrides to a map[string]any using dot-notation paths.
 // Handles nested maps by traversing the path segments and creating nested maps as needed.
+// Paths may address list elements by index (e.g., "tolerations[0].key").
 // Useful for applying --set flag overrides to values.yaml content.
 func ApplyMapOverrides(target map[string]any, overrides map[string]string) error {
 	if target == nil {
@@ -79,8 +81,9 @@ func ApplyMapOverrides(target map[string]any, overrides map[string]string) error
 	}
 
 	var errors []string
-	for path, value := range overrides {
-		if err := setMapValueByPath(target, path, value); err != nil {
+	for _, path := range sortedValuePaths(overrides) {
+		value := overrides[path]
+		if err := setValueAtPath(target, path, convertMapValue(value)); err != nil {
 			errors = append(errors, fmt.Sprintf("%s=%s: %v", path, value, err))
 		}
 	}
@@ -92,39 +95,110 @@ func ApplyMapOverrides(target map[string]any, overrides map[string]string) error
 	return nil
 }
 
-// setMapValueByPath sets a value in a nested map using dot-notation path.
-// Creates nested maps as needed. Converts string values to bools when appropriate.
-func setMapValueByPath(target map[string]any, path, value string) error {
-	parts := strings.Split(path, ".")
-	current := target
+// ApplyTypedMapOverrides applies structured overrides (e.g., decoded from --set-json)
+// to a map[string]any using the same paths as ApplyMapOverrides. Values replace
+// the value at their path as-is; a nil value deletes the key or list element.
+// List indices refer to the list before any element is deleted.
+func ApplyTypedMapOverrides(target map[string]any, overrides map[string]any) error {
+	if target == nil {
+		return fmt.Errorf("target map cannot be nil")
+	}
 
-	// Traverse/create the path up to the last segment
-	for i := 0; i < len(parts)-1; i++ {
-		part := parts[i]
-		if next, ok := current[part]; ok {
-			// If the value exists, it must be a map
-			if nextMap, ok := next.(map[string]any); ok {
-				current = nextMap
-			} else {
-				return fmt.Errorf("path segment %q exists but is not a map (type: %T)", part, next)
-			}
-		} else {
-			// Create a new nested map
-			newMap := make(map[string]any)
-			current[part] = newMap
-			current = newMap
+	// Deletions run after the sets, in reverse order, so that removing a list
+	// element does not shift the elements later paths refer to.
+	paths := sortedValuePaths(overrides)
+	var deletions []string
+	var errors []string
+	for _, path := range paths {
+		if overrides[path] == nil {
+			deletions = append(deletions, path)
+			continue
+		}
+		value := normalizeNumbers(copyValues(overrides[path]))
+		if err := setValueAtPath(target, path, value); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+	for i := len(deletions) - 1; i >= 0; i-- {
+		if err := setValueAtPath(target, deletions[i], nil); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", deletions[i], err))
 		}
 	}
 
-	// Set the final value
-	lastPart := parts[len(parts)-1]
-
-	// Try to convert value to appropriate type
-	current[lastPart] = convertMapValue(value)
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to apply typed map overrides: %s", strings.Join(errors, "; "))
+	}
 
 	return nil
 }
 
+// MergeMapValues deep merges src (e.g., a --values file) into dst. Maps are
+// merged recursively, other values in src replace those in dst, and a nil
+// value in src deletes the key from dst.
+func MergeMapValues(dst, src map[string]any) {
+	for key, srcVal := range src {
+		if srcVal == nil {
+			delete(dst, key)
+			continue
+		}
+		if dstMap, ok := dst[key].(map[string]any); ok {
+			if srcMap, ok := srcVal.(map[string]any); ok {
+				MergeMapValues(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = normalizeNumbers(copyValues(srcVal))
+	}
+}
+
+// copyValues returns a deep copy of the maps and lists in a values tree.
+func copyValues(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, item := range t {
+			out[k] = copyValues(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, item := range t {
+			out[i] = copyValues(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// sortedValuePaths returns the paths of overrides in an order where parents
+// precede their children and list indices ascend, so that overrides of the
+// same list append elements in order.
+func sortedValuePaths[T any](overrides map[string]T) []string {
+	paths := make([]string, 0, len(overrides))
+	parsed := make(map[string][]pathSegment, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+		parsed[path], _ = parseValuePath(path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		a, b := parsed[paths[i]], parsed[paths[j]]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			switch {
+			case a[k].isIndex && b[k].isIndex && a[k].index != b[k].index:
+				return a[k].index < b[k].index
+			case a[k].String() != b[k].String():
+				return a[k].String() < b[k].String()
+			}
+		}
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
 // convertMapValue converts a string value to an appropriate Go type.
 // Handles bools ("true"/"false") and numbers.
 func convertMapValue(value string) any {
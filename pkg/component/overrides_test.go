@@ -15,6 +15,7 @@
 package component
 
 import (
+	"reflect"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -837,6 +838,101 @@ func TestApplyMapOverrides(t *testing.T) {
 	}
 }
 
+func TestApplyTypedMapOverrides(t *testing.T) {
+	target := map[string]any{
+		"driver": map[string]any{"enabled": true, "version": "550.0.0"},
+		"dcgm":   map[string]any{"enabled": true},
+	}
+	tolerations := []any{map[string]any{"operator": "Exists"}}
+	overrides := map[string]any{
+		"daemonsets.tolerations":      tolerations,
+		"daemonsets.tolerations[1]":   map[string]any{"key": "gpu", "effect": "NoSchedule"},
+		"driver.version":              nil,
+		"dcgm":                        nil,
+		"devicePlugin.config.maxSize": float64(1048576),
+	}
+
+	if err := ApplyTypedMapOverrides(target, overrides); err != nil {
+		t.Fatalf("ApplyTypedMapOverrides() error = %v", err)
+	}
+
+	want := map[string]any{
+		"driver": map[string]any{"enabled": true},
+		"daemonsets": map[string]any{"tolerations": []any{
+			map[string]any{"operator": "Exists"},
+			map[string]any{"key": "gpu", "effect": "NoSchedule"},
+		}},
+		"devicePlugin": map[string]any{"config": map[string]any{"maxSize": int64(1048576)}},
+	}
+	if !reflect.DeepEqual(target, want) {
+		t.Errorf("target = %v, want %v", target, want)
+	}
+
+	// Overrides must not share structure with the target
+	if len(tolerations) != 1 {
+		t.Errorf("override value was modified: %v", tolerations)
+	}
+
+	if err := ApplyTypedMapOverrides(nil, overrides); err == nil {
+		t.Error("expected error for nil target")
+	}
+	if err := ApplyTypedMapOverrides(map[string]any{}, map[string]any{"a[5]": "x"}); err == nil {
+		t.Error("expected error for out of range index")
+	}
+}
+
+func TestApplyTypedMapOverrides_ListDeletions(t *testing.T) {
+	target := map[string]any{"tolerations": []any{"a", "b", "c", "d"}}
+	overrides := map[string]any{
+		"tolerations[0]": nil,
+		"tolerations[1]": nil,
+		"tolerations[3]": "D",
+	}
+
+	if err := ApplyTypedMapOverrides(target, overrides); err != nil {
+		t.Fatalf("ApplyTypedMapOverrides() error = %v", err)
+	}
+
+	// Indices refer to the original list
+	want := []any{"c", "D"}
+	if got := target["tolerations"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("tolerations = %v, want %v", got, want)
+	}
+}
+
+func TestMergeMapValues(t *testing.T) {
+	dst := map[string]any{
+		"driver": map[string]any{"enabled": true, "version": "550.0.0"},
+		"args":   []any{"--a"},
+		"dcgm":   map[string]any{"enabled": true},
+	}
+	src := map[string]any{
+		"driver": map[string]any{"version": "570.86.16", "rdma": map[string]any{"enabled": true}},
+		"args":   []any{"--b"},
+		"dcgm":   nil,
+	}
+
+	MergeMapValues(dst, src)
+
+	want := map[string]any{
+		"driver": map[string]any{
+			"enabled": true,
+			"version": "570.86.16",
+			"rdma":    map[string]any{"enabled": true},
+		},
+		"args": []any{"--b"},
+	}
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("dst = %v, want %v", dst, want)
+	}
+
+	// Merged maps must be copies of src
+	dst["driver"].(map[string]any)["rdma"].(map[string]any)["enabled"] = false
+	if src["driver"].(map[string]any)["rdma"].(map[string]any)["enabled"] != true {
+		t.Error("modifying dst affected src")
+	}
+}
+
 func TestConvertMapValue(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,235 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a values path: a map key or a list index.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s pathSegment) String() string {
+	if s.isIndex {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return s.key
+}
+
+// parseValuePath splits a values path into map keys and list indices.
+// Keys are separated by dots, indices follow a key in brackets, and a dot
+// that is part of a key is escaped with a backslash:
+//
+//	driver.version                      -> driver, version
+//	tolerations[0].key                  -> tolerations, [0], key
+//	nodeSelector.nvidia\.com/gpu\.present -> nodeSelector, nvidia.com/gpu.present
+func parseValuePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+
+	var segments []pathSegment
+	var key strings.Builder
+	expectKey := true // a key must follow the start of the path and each dot
+
+	flushKey := func() error {
+		if key.Len() == 0 {
+			if expectKey {
+				return fmt.Errorf("invalid path %q: empty key", path)
+			}
+			return nil
+		}
+		segments = append(segments, pathSegment{key: key.String()})
+		key.Reset()
+		expectKey = false
+		return nil
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; c {
+		case '\\':
+			if i+1 < len(path) && path[i+1] == '.' {
+				key.WriteByte('.')
+				i++
+				continue
+			}
+			key.WriteByte(c)
+		case '.':
+			if err := flushKey(); err != nil {
+				return nil, err
+			}
+			expectKey = true
+		case '[':
+			if err := flushKey(); err != nil {
+				return nil, err
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated index", path)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, path[i+1:i+end])
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			i += end
+			if i+1 < len(path) && path[i+1] != '.' && path[i+1] != '[' {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q after index", path, path[i+1])
+			}
+		default:
+			key.WriteByte(c)
+		}
+	}
+	if err := flushKey(); err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+// setValueAtPath sets value at path below target, creating the maps and list
+// elements along the way. A list index may address an existing element or
+// append one (index equal to the list length). A nil value deletes the key or
+// list element at path instead.
+func setValueAtPath(target map[string]any, path string, value any) error {
+	segments, err := parseValuePath(path)
+	if err != nil {
+		return err
+	}
+	if segments[0].isIndex {
+		return fmt.Errorf("invalid path %q: must start with a key", path)
+	}
+
+	_, err = setSegments(target, segments, value)
+	return err
+}
+
+// setSegments sets value at segments below node and returns the updated node,
+// which differs from node when a list grows or shrinks.
+func setSegments(node any, segments []pathSegment, value any) (any, error) {
+	seg := segments[0]
+	last := len(segments) == 1
+
+	if !seg.isIndex {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("path segment %q exists but is not a map (type: %T)", seg, node)
+		}
+		if last {
+			if value == nil {
+				delete(m, seg.key)
+			} else {
+				m[seg.key] = value
+			}
+			return m, nil
+		}
+
+		child, exists := m[seg.key]
+		if !exists || child == nil {
+			if value == nil {
+				return m, nil // nothing to delete
+			}
+			child = newContainer(segments[1])
+		}
+		updated, err := setSegments(child, segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = updated
+		return m, nil
+	}
+
+	list, ok := node.([]any)
+	if !ok {
+		return nil, fmt.Errorf("path segment %s exists but is not a list (type: %T)", seg, node)
+	}
+	if seg.index > len(list) || (seg.index == len(list) && value == nil) {
+		if value == nil {
+			return list, nil // nothing to delete
+		}
+		return nil, fmt.Errorf("index %s out of range (list length: %d)", seg, len(list))
+	}
+
+	if last {
+		switch {
+		case value == nil:
+			return append(list[:seg.index:seg.index], list[seg.index+1:]...), nil
+		case seg.index == len(list):
+			return append(list, value), nil
+		default:
+			list[seg.index] = value
+			return list, nil
+		}
+	}
+
+	var child any
+	if seg.index < len(list) && list[seg.index] != nil {
+		child = list[seg.index]
+	} else {
+		if value == nil {
+			return list, nil // nothing to delete
+		}
+		child = newContainer(segments[1])
+	}
+	updated, err := setSegments(child, segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	if seg.index == len(list) {
+		return append(list, updated), nil
+	}
+	list[seg.index] = updated
+	return list, nil
+}
+
+// newContainer returns an empty map or list to hold the next path segment.
+func newContainer(next pathSegment) any {
+	if next.isIndex {
+		return []any{}
+	}
+	return make(map[string]any)
+}
+
+// normalizeNumbers converts whole float64 numbers, as produced by JSON
+// decoding, to int64 so that they render as integers (e.g., 1048576 instead
+// of 1.048576e+06) in generated values files.
+func normalizeNumbers(v any) any {
+	switch t := v.(type) {
+	case float64:
+		if t == math.Trunc(t) && math.Abs(t) < 1<<53 {
+			return int64(t)
+		}
+		return t
+	case map[string]any:
+		for k, item := range t {
+			t[k] = normalizeNumbers(item)
+		}
+		return t
+	case []any:
+		for i, item := range t {
+			t[i] = normalizeNumbers(item)
+		}
+		return t
+	default:
+		return v
+	}
+}
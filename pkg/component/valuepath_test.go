@@ -0,0 +1,198 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseValuePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []pathSegment
+		wantErr bool
+	}{
+		{
+			name: "single key",
+			path: "enabled",
+			want: []pathSegment{{key: "enabled"}},
+		},
+		{
+			name: "nested keys",
+			path: "driver.version",
+			want: []pathSegment{{key: "driver"}, {key: "version"}},
+		},
+		{
+			name: "list index",
+			path: "tolerations[0].key",
+			want: []pathSegment{{key: "tolerations"}, {index: 0, isIndex: true}, {key: "key"}},
+		},
+		{
+			name: "nested list indices",
+			path: "matrix[1][2]",
+			want: []pathSegment{{key: "matrix"}, {index: 1, isIndex: true}, {index: 2, isIndex: true}},
+		},
+		{
+			name: "escaped dots",
+			path: `nodeSelector.nvidia\.com/gpu\.present`,
+			want: []pathSegment{{key: "nodeSelector"}, {key: "nvidia.com/gpu.present"}},
+		},
+		{name: "empty path", path: "", wantErr: true},
+		{name: "empty key", path: "driver..version", wantErr: true},
+		{name: "trailing dot", path: "driver.", wantErr: true},
+		{name: "unterminated index", path: "tolerations[0", wantErr: true},
+		{name: "negative index", path: "tolerations[-1]", wantErr: true},
+		{name: "non-numeric index", path: "tolerations[a]", wantErr: true},
+		{name: "text after index", path: "tolerations[0]key", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseValuePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseValuePath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseValuePath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetValueAtPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  map[string]any
+		path    string
+		value   any
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			name:   "creates nested maps",
+			target: map[string]any{},
+			path:   "driver.version",
+			value:  "570.86.16",
+			want:   map[string]any{"driver": map[string]any{"version": "570.86.16"}},
+		},
+		{
+			name:   "sets existing list element field",
+			target: map[string]any{"tolerations": []any{map[string]any{"key": "a"}}},
+			path:   "tolerations[0].key",
+			value:  "b",
+			want:   map[string]any{"tolerations": []any{map[string]any{"key": "b"}}},
+		},
+		{
+			name:   "appends list element",
+			target: map[string]any{"args": []any{"--a"}},
+			path:   "args[1]",
+			value:  "--b",
+			want:   map[string]any{"args": []any{"--a", "--b"}},
+		},
+		{
+			name:   "creates list",
+			target: map[string]any{},
+			path:   "tolerations[0].operator",
+			value:  "Exists",
+			want:   map[string]any{"tolerations": []any{map[string]any{"operator": "Exists"}}},
+		},
+		{
+			name:   "replaces with structured value",
+			target: map[string]any{"driver": map[string]any{"enabled": true}},
+			path:   "driver",
+			value:  map[string]any{"version": "570"},
+			want:   map[string]any{"driver": map[string]any{"version": "570"}},
+		},
+		{
+			name:   "nil deletes key",
+			target: map[string]any{"driver": map[string]any{"enabled": true, "version": "570"}},
+			path:   "driver.version",
+			value:  nil,
+			want:   map[string]any{"driver": map[string]any{"enabled": true}},
+		},
+		{
+			name:   "nil deletes list element",
+			target: map[string]any{"args": []any{"--a", "--b", "--c"}},
+			path:   "args[1]",
+			value:  nil,
+			want:   map[string]any{"args": []any{"--a", "--c"}},
+		},
+		{
+			name:   "nil on missing path is no-op",
+			target: map[string]any{},
+			path:   "driver.version",
+			value:  nil,
+			want:   map[string]any{},
+		},
+		{
+			name:    "index out of range",
+			target:  map[string]any{"args": []any{"--a"}},
+			path:    "args[3]",
+			value:   "--b",
+			wantErr: true,
+		},
+		{
+			name:    "index into map",
+			target:  map[string]any{"driver": map[string]any{}},
+			path:    "driver[0]",
+			value:   "x",
+			wantErr: true,
+		},
+		{
+			name:    "key into scalar",
+			target:  map[string]any{"driver": "x"},
+			path:    "driver.version",
+			value:   "570",
+			wantErr: true,
+		},
+		{
+			name:    "path starts with index",
+			target:  map[string]any{},
+			path:    "[0]",
+			value:   "x",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setValueAtPath(tt.target, tt.path, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setValueAtPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.target, tt.want) {
+				t.Errorf("setValueAtPath(%q) target = %v, want %v", tt.path, tt.target, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	got := normalizeNumbers(map[string]any{
+		"size":  float64(1048576),
+		"ratio": 0.5,
+		"list":  []any{float64(3), "x"},
+	})
+	want := map[string]any{
+		"size":  int64(1048576),
+		"ratio": 0.5,
+		"list":  []any{int64(3), "x"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeNumbers() = %v, want %v", got, want)
+	}
+}
@@ -39,6 +39,12 @@ const (
 	// Longer than recipe due to file I/O operations.
 	BundleHandlerTimeout = 60 * time.Second
 
+	// ValidateHandlerTimeout is the timeout for validation requests.
+	ValidateHandlerTimeout = 30 * time.Second
+
+	// SnapshotHandlerTimeout is the timeout for snapshot ingestion and history requests.
+	SnapshotHandlerTimeout = 30 * time.Second
+
 	// RecipeCacheTTL is the default cache duration for recipe responses.
 	RecipeCacheTTL = 10 * time.Minute
 )
@@ -111,3 +117,12 @@ const (
 	// CLISnapshotTimeout is the default timeout for snapshot operations.
 	CLISnapshotTimeout = 5 * time.Minute
 )
+
+// Drift detection timeouts for the cnsd drift controller.
+const (
+	// DriftInterval is the default interval between drift checks.
+	DriftInterval = 15 * time.Minute
+
+	// DriftCheckTimeout is the timeout for a single drift check.
+	DriftCheckTimeout = 5 * time.Minute
+)
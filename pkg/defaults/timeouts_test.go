@@ -34,6 +34,8 @@ func TestTimeoutConstants(t *testing.T) {
 		{"RecipeHandlerTimeout", RecipeHandlerTimeout, 10 * time.Second, 60 * time.Second},
 		{"RecipeBuildTimeout", RecipeBuildTimeout, 10 * time.Second, 30 * time.Second},
 		{"BundleHandlerTimeout", BundleHandlerTimeout, 30 * time.Second, 120 * time.Second},
+		{"ValidateHandlerTimeout", ValidateHandlerTimeout, 10 * time.Second, 60 * time.Second},
+		{"SnapshotHandlerTimeout", SnapshotHandlerTimeout, 10 * time.Second, 60 * time.Second},
 
 		// Server timeouts
 		{"ServerReadTimeout", ServerReadTimeout, 5 * time.Second, 30 * time.Second},
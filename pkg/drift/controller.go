@@ -0,0 +1,380 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector"
+	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+// maxEventPaths is the number of changed reading paths listed in a drift event.
+const maxEventPaths = 5
+
+// Controller periodically snapshots the node, validates the snapshot against a
+// reference recipe and diffs it against the previous snapshot.
+type Controller struct {
+	version  string
+	interval time.Duration
+	factory  collector.Factory
+	hostRoot string
+
+	// Reference recipe: loaded from recipeSource, or built from criteria
+	recipeSource string
+	criteria     *recipe.Criteria
+
+	diffFilter     snapshotter.DiffFilter
+	reportWriter   serializer.Serializer
+	snapshotWriter serializer.Serializer
+	baselineSource string
+	events         EventRecorder
+
+	// State of the previous check
+	previous *snapshotter.Snapshot
+	statuses map[string]validator.ConstraintStatus
+}
+
+// Option is a functional option for configuring Controller instances.
+type Option func(*Controller)
+
+// WithVersion sets the version reported in snapshots and reports.
+func WithVersion(version string) Option {
+	return func(c *Controller) {
+		c.version = version
+	}
+}
+
+// WithInterval sets the interval between drift checks (default: defaults.DriftInterval).
+func WithInterval(interval time.Duration) Option {
+	return func(c *Controller) {
+		c.interval = interval
+	}
+}
+
+// WithFactory sets the collector factory used to take snapshots.
+func WithFactory(factory collector.Factory) Option {
+	return func(c *Controller) {
+		c.factory = factory
+	}
+}
+
+// WithHostRoot sets the directory the host filesystem is mounted at, used by
+// the default collector factory to read host configuration files.
+func WithHostRoot(root string) Option {
+	return func(c *Controller) {
+		c.hostRoot = root
+	}
+}
+
+// WithRecipe loads the reference recipe from a file path, URL, ConfigMap URI
+// (cm://namespace/name) or OCI reference before every check.
+func WithRecipe(source string) Option {
+	return func(c *Controller) {
+		c.recipeSource = source
+	}
+}
+
+// WithCriteria builds the reference recipe from criteria before every check.
+func WithCriteria(criteria *recipe.Criteria) Option {
+	return func(c *Controller) {
+		c.criteria = criteria
+	}
+}
+
+// WithDiffFilter restricts the readings compared between snapshots.
+// snapshotter.VolatileReadings are always excluded.
+func WithDiffFilter(filter snapshotter.DiffFilter) Option {
+	return func(c *Controller) {
+		c.diffFilter = filter
+	}
+}
+
+// WithConfigMap publishes reports to the ConfigMap namespace/name and keeps the
+// last snapshot in namespace/name-snapshot, which is used as the baseline for
+// the first diff after a restart.
+func WithConfigMap(namespace, name string) Option {
+	return func(c *Controller) {
+		snapshotName := name + "-snapshot"
+		c.reportWriter = serializer.NewConfigMapWriter(namespace, name, serializer.FormatYAML)
+		c.snapshotWriter = serializer.NewConfigMapWriter(namespace, snapshotName, serializer.FormatYAML)
+		c.baselineSource = serializer.ConfigMapURIScheme + namespace + "/" + snapshotName
+	}
+}
+
+// WithReportWriter sets the serializer reports are published with.
+func WithReportWriter(w serializer.Serializer) Option {
+	return func(c *Controller) {
+		c.reportWriter = w
+	}
+}
+
+// WithSnapshotWriter sets the serializer the last snapshot is stored with.
+func WithSnapshotWriter(w serializer.Serializer) Option {
+	return func(c *Controller) {
+		c.snapshotWriter = w
+	}
+}
+
+// WithEventRecorder sets the recorder for Kubernetes Events.
+func WithEventRecorder(r EventRecorder) Option {
+	return func(c *Controller) {
+		c.events = r
+	}
+}
+
+// New creates a Controller. Exactly one of WithRecipe and WithCriteria is required.
+func New(opts ...Option) (*Controller, error) {
+	c := &Controller{
+		interval: defaults.DriftInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.diffFilter.Exclude = append(slices.Clone(snapshotter.VolatileReadings), c.diffFilter.Exclude...)
+
+	if (c.recipeSource == "") == (c.criteria == nil) {
+		return nil, errors.New(errors.ErrCodeInvalidRequest,
+			"exactly one of a recipe source or criteria is required")
+	}
+	if c.interval <= 0 {
+		return nil, errors.New(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("drift interval must be positive, got %s", c.interval))
+	}
+	if c.factory == nil {
+		c.factory = collector.NewDefaultFactory(
+			collector.WithVersion(c.version),
+			collector.WithHostRoot(c.hostRoot),
+		)
+	}
+
+	return c, nil
+}
+
+// Run checks for drift immediately and then on every interval until ctx is
+// canceled. Failed checks are logged and retried on the next interval.
+func (c *Controller) Run(ctx context.Context) error {
+	c.loadBaseline()
+
+	slog.Info("drift controller started",
+		"interval", c.interval,
+		"recipe", c.recipeDescription())
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Check(ctx); err != nil {
+			slog.Error("drift check failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("drift controller stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs a single drift check and publishes its report.
+func (c *Controller) Check(ctx context.Context) (*Report, error) {
+	checkCtx, cancel := context.WithTimeout(ctx, defaults.DriftCheckTimeout)
+	defer cancel()
+
+	report, snap, err := c.check(checkCtx)
+	if err != nil {
+		driftChecksTotal.WithLabelValues("error").Inc()
+		return nil, err
+	}
+
+	recordMetrics(report)
+	c.recordEvents(checkCtx, report)
+
+	if err := c.publish(checkCtx, report, snap); err != nil {
+		driftChecksTotal.WithLabelValues("error").Inc()
+		return report, err
+	}
+
+	c.previous = snap
+	c.statuses = constraintStatuses(report.Validation)
+
+	driftChecksTotal.WithLabelValues("success").Inc()
+	driftLastCheckTimestamp.SetToCurrentTime()
+
+	slog.Info("drift check complete",
+		"passed", report.Validation.Summary.Passed,
+		"failed", report.Validation.Summary.Failed,
+		"skipped", report.Validation.Summary.Skipped,
+		"status_changes", len(report.StatusChanges),
+		"changed_readings", changedReadings(report))
+
+	return report, nil
+}
+
+// check takes a snapshot and builds the report without publishing it.
+func (c *Controller) check(ctx context.Context) (*Report, *snapshotter.Snapshot, error) {
+	ns := &snapshotter.NodeSnapshotter{
+		Version: c.version,
+		Factory: c.factory,
+	}
+	snap, err := ns.Collect(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeInternal, "failed to collect snapshot", err)
+	}
+
+	rec, err := c.referenceRecipe(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validation, err := validator.New(validator.WithVersion(c.version)).Validate(ctx, rec, snap)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeInternal, "failed to validate snapshot", err)
+	}
+
+	report := &Report{
+		Recipe:        c.recipeDescription(),
+		Validation:    validation,
+		StatusChanges: statusChanges(c.statuses, validation),
+	}
+	report.Init(header.KindDriftReport, APIVersion, c.version)
+
+	if c.previous != nil {
+		report.Drift, err = snapshotter.NewSnapshotDiff(c.version, c.previous, snap, c.diffFilter)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return report, snap, nil
+}
+
+// referenceRecipe loads or builds the recipe the snapshot is validated against.
+func (c *Controller) referenceRecipe(ctx context.Context) (*recipe.RecipeResult, error) {
+	if c.criteria != nil {
+		rec, err := recipe.NewBuilder(recipe.WithVersion(c.version)).BuildFromCriteria(ctx, c.criteria)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to build reference recipe", err)
+		}
+		return rec, nil
+	}
+
+	rec, err := serializer.FromFile[recipe.RecipeResult](c.recipeSource)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeNotFound, "failed to load reference recipe", err,
+			map[string]any{"source": c.recipeSource})
+	}
+	return rec, nil
+}
+
+// publish writes the report and the snapshot used as the next baseline.
+func (c *Controller) publish(ctx context.Context, report *Report, snap *snapshotter.Snapshot) error {
+	if c.reportWriter != nil {
+		if err := c.reportWriter.Serialize(ctx, report); err != nil {
+			return errors.Wrap(errors.ErrCodeInternal, "failed to publish drift report", err)
+		}
+	}
+	if c.snapshotWriter != nil {
+		if err := c.snapshotWriter.Serialize(ctx, snap); err != nil {
+			return errors.Wrap(errors.ErrCodeInternal, "failed to store snapshot", err)
+		}
+	}
+	return nil
+}
+
+// recordEvents emits an Event for every constraint that started failing or
+// recovered, and one for changed readings. Failures are logged, not returned.
+func (c *Controller) recordEvents(ctx context.Context, report *Report) {
+	if c.events == nil {
+		return
+	}
+
+	emit := func(eventType, reason, message string) {
+		if err := c.events.Event(ctx, eventType, reason, message); err != nil {
+			slog.Warn("failed to record event", "reason", reason, "error", err)
+		}
+	}
+
+	for _, change := range report.StatusChanges {
+		switch {
+		case change.After == validator.ConstraintStatusFailed:
+			emit(corev1.EventTypeWarning, ReasonConstraintFailed,
+				fmt.Sprintf("constraint %s failed: expected %s, actual %s", change.Name, change.Expected, change.Actual))
+		case change.Before == validator.ConstraintStatusFailed && change.After == validator.ConstraintStatusPassed:
+			emit(corev1.EventTypeNormal, ReasonConstraintRecovered,
+				fmt.Sprintf("constraint %s passed: actual %s", change.Name, change.Actual))
+		}
+	}
+
+	if report.Drift != nil && report.Drift.HasDrift() {
+		paths := make([]string, 0, maxEventPaths)
+		for _, ch := range report.Drift.Changes {
+			if len(paths) == maxEventPaths {
+				paths = append(paths, "...")
+				break
+			}
+			paths = append(paths, ch.Path())
+		}
+		emit(corev1.EventTypeWarning, ReasonConfigurationDrift,
+			fmt.Sprintf("%d added, %d removed, %d changed since the previous snapshot: %s",
+				report.Drift.Summary.Added, report.Drift.Summary.Removed, report.Drift.Summary.Changed,
+				strings.Join(paths, ", ")))
+	}
+}
+
+// loadBaseline loads the snapshot stored by a previous run, if any.
+func (c *Controller) loadBaseline() {
+	if c.baselineSource == "" || c.previous != nil {
+		return
+	}
+
+	snap, err := serializer.FromFile[snapshotter.Snapshot](c.baselineSource)
+	if err != nil {
+		slog.Info("no previous snapshot, first check sets the baseline",
+			"source", c.baselineSource, "reason", err)
+		return
+	}
+	c.previous = snap
+}
+
+// recipeDescription describes the reference recipe for reports and logs.
+func (c *Controller) recipeDescription() string {
+	if c.criteria != nil {
+		return c.criteria.String()
+	}
+	return c.recipeSource
+}
+
+// changedReadings returns the number of readings changed since the previous snapshot.
+func changedReadings(report *Report) int {
+	if report.Drift == nil {
+		return 0
+	}
+	return report.Drift.Summary.Total
+}
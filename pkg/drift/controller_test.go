@@ -0,0 +1,375 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/collector"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+const testRecipe = `kind: RecipeResult
+apiVersion: cns.nvidia.com/v1alpha1
+metadata:
+  version: v0.1.0
+constraints:
+  - name: K8s.server.version
+    value: ">= 1.32"
+componentRefs: []
+deploymentOrder: []
+`
+
+// fakeFactory returns collectors reporting a configurable Kubernetes version.
+// OS and SystemD readings that change on every read (random UUID, open file
+// count, unit memory usage) are reported like on a real node.
+type fakeFactory struct {
+	k8sVersion  string
+	err         error
+	collections int
+}
+
+func (f *fakeFactory) CreateKubernetesCollector() collector.Collector {
+	return &fakeCollector{err: f.err, m: &measurement.Measurement{
+		Type: measurement.TypeK8s,
+		Subtypes: []measurement.Subtype{{
+			Name: "server",
+			Data: map[string]measurement.Reading{"version": measurement.Str(f.k8sVersion)},
+		}},
+	}}
+}
+
+func (f *fakeFactory) CreateSystemDCollector() collector.Collector {
+	return &fakeCollector{m: &measurement.Measurement{
+		Type: measurement.TypeSystemD,
+		Subtypes: []measurement.Subtype{{
+			Name: "containerd.service",
+			Data: map[string]measurement.Reading{
+				"ActiveState":                   measurement.Str("active"),
+				"MemoryCurrent":                 measurement.Uint64(uint64(48_000_000 + f.collections*4096)),
+				"CPUUsageNSec":                  measurement.Uint64(uint64(9_000_000_000 + f.collections*1_000_000)),
+				"TasksCurrent":                  measurement.Uint64(uint64(30 + f.collections%3)),
+				"StateChangeTimestampMonotonic": measurement.Uint64(uint64(f.collections)),
+			},
+		}},
+	}}
+}
+
+func (f *fakeFactory) CreateOSCollector() collector.Collector {
+	f.collections++
+	return &fakeCollector{m: &measurement.Measurement{
+		Type: measurement.TypeOS,
+		Subtypes: []measurement.Subtype{{
+			Name: "sysctl",
+			Data: map[string]measurement.Reading{
+				"/proc/sys/kernel/osrelease":            measurement.Str("6.8.0-1028-aws"),
+				"/proc/sys/kernel/random/uuid":          measurement.Str(fmt.Sprintf("3f0c9a1e-%04d-4b6e-9d2a-5c7f8e1b2a3d", f.collections)),
+				"/proc/sys/kernel/random/entropy_avail": measurement.Str(fmt.Sprint(256 - f.collections)),
+				"/proc/sys/fs/file-nr":                  measurement.Str(fmt.Sprintf("%d\t0\t9223372036854775807", 2304+f.collections*32)),
+				"/proc/sys/fs/dentry-state":             measurement.Str(fmt.Sprintf("%d\t%d\t45\t0\t0\t0", 81234+f.collections, 60321)),
+			},
+		}},
+	}}
+}
+
+func (f *fakeFactory) CreateGPUCollector() collector.Collector {
+	return &fakeCollector{m: &measurement.Measurement{Type: measurement.TypeGPU}}
+}
+
+func (f *fakeFactory) CreateNetworkCollector() collector.Collector {
+	return &fakeCollector{m: &measurement.Measurement{Type: measurement.TypeNetwork}}
+}
+
+func (f *fakeFactory) CreateContainerRuntimeCollector() collector.Collector {
+	return &fakeCollector{m: &measurement.Measurement{Type: measurement.TypeContainerRuntime}}
+}
+
+type fakeCollector struct {
+	m   *measurement.Measurement
+	err error
+}
+
+func (c *fakeCollector) Collect(context.Context) (*measurement.Measurement, error) {
+	return c.m, c.err
+}
+
+// fakeSerializer records the serialized objects.
+type fakeSerializer struct {
+	objects []any
+	err     error
+}
+
+func (s *fakeSerializer) Serialize(_ context.Context, v any) error {
+	s.objects = append(s.objects, v)
+	return s.err
+}
+
+// fakeRecorder records event reasons.
+type fakeRecorder struct {
+	reasons  []string
+	messages []string
+}
+
+func (r *fakeRecorder) Event(_ context.Context, _, reason, message string) error {
+	r.reasons = append(r.reasons, reason)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func writeTestRecipe(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "recipe.yaml")
+	if err := os.WriteFile(path, []byte(testRecipe), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNew(t *testing.T) {
+	recipePath := writeTestRecipe(t)
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{"recipe", []Option{WithRecipe(recipePath)}, false},
+		{"neither recipe nor criteria", nil, true},
+		{"invalid interval", []Option{WithRecipe(recipePath), WithInterval(-time.Second)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && c.interval <= 0 {
+				t.Errorf("interval = %v, want default", c.interval)
+			}
+		})
+	}
+}
+
+func TestController_Check(t *testing.T) {
+	factory := &fakeFactory{k8sVersion: "v1.33.5"}
+	reports := &fakeSerializer{}
+	snapshots := &fakeSerializer{}
+	events := &fakeRecorder{}
+
+	c, err := New(
+		WithVersion("v1.0.0"),
+		WithRecipe(writeTestRecipe(t)),
+		WithFactory(factory),
+		WithReportWriter(reports),
+		WithSnapshotWriter(snapshots),
+		WithEventRecorder(events),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// First check: passing, no baseline yet
+	report, err := c.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if report.Kind != header.KindDriftReport {
+		t.Errorf("Kind = %q, want %q", report.Kind, header.KindDriftReport)
+	}
+	if report.Validation.Summary.Passed != 1 || report.Drift != nil || report.HasDrift() {
+		t.Errorf("first check: summary = %+v, drift = %v", report.Validation.Summary, report.Drift)
+	}
+	if len(reports.objects) != 1 || len(snapshots.objects) != 1 {
+		t.Errorf("published %d reports and %d snapshots, want 1 each", len(reports.objects), len(snapshots.objects))
+	}
+	if len(events.reasons) != 0 {
+		t.Errorf("unexpected events: %v", events.reasons)
+	}
+
+	// Second check: the Kubernetes version was downgraded
+	factory.k8sVersion = "v1.31.0"
+	report, err = c.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !report.HasDrift() || report.Drift == nil || report.Drift.Summary.Changed != 1 {
+		t.Fatalf("second check: drift = %+v", report.Drift)
+	}
+	if len(report.StatusChanges) != 1 || report.StatusChanges[0].After != validator.ConstraintStatusFailed {
+		t.Errorf("StatusChanges = %+v", report.StatusChanges)
+	}
+	if strings.Join(events.reasons, ",") != ReasonConstraintFailed+","+ReasonConfigurationDrift {
+		t.Errorf("events = %v", events.reasons)
+	}
+	if !strings.Contains(events.messages[1], "K8s.server.version") {
+		t.Errorf("drift event does not name the changed reading: %q", events.messages[1])
+	}
+
+	// Third check: the version was restored
+	factory.k8sVersion = "v1.33.5"
+	events.reasons = nil
+	if _, err = c.Check(ctx); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if strings.Join(events.reasons, ",") != ReasonConstraintRecovered+","+ReasonConfigurationDrift {
+		t.Errorf("events = %v", events.reasons)
+	}
+}
+
+func TestController_CheckErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name:    "collector failure",
+			opts:    []Option{WithRecipe(writeTestRecipe(t)), WithFactory(&fakeFactory{err: fmt.Errorf("boom")})},
+			wantErr: "failed to collect snapshot",
+		},
+		{
+			name:    "missing recipe",
+			opts:    []Option{WithRecipe(filepath.Join(t.TempDir(), "missing.yaml")), WithFactory(&fakeFactory{})},
+			wantErr: "failed to load reference recipe",
+		},
+		{
+			name: "publish failure",
+			opts: []Option{
+				WithRecipe(writeTestRecipe(t)),
+				WithFactory(&fakeFactory{k8sVersion: "v1.33.0"}),
+				WithReportWriter(&fakeSerializer{err: fmt.Errorf("forbidden")}),
+			},
+			wantErr: "failed to publish drift report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			_, err = c.Check(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if c.previous != nil {
+				t.Error("failed check must not replace the baseline")
+			}
+		})
+	}
+}
+
+func TestController_DiffFilter(t *testing.T) {
+	factory := &fakeFactory{k8sVersion: "v1.33.5"}
+	c, err := New(
+		WithRecipe(writeTestRecipe(t)),
+		WithFactory(factory),
+		WithDiffFilter(snapshotter.DiffFilter{Exclude: []string{"K8s.server.*"}}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err = c.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	factory.k8sVersion = "v1.34.0"
+	report, err := c.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Drift == nil || report.Drift.HasDrift() {
+		t.Errorf("excluded reading reported as drift: %+v", report.Drift)
+	}
+}
+
+func TestController_VolatileReadingsIgnored(t *testing.T) {
+	factory := &fakeFactory{k8sVersion: "v1.33.5"}
+	recorder := &fakeRecorder{}
+	c, err := New(
+		WithRecipe(writeTestRecipe(t)),
+		WithFactory(factory),
+		WithEventRecorder(recorder),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err = c.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	report, err := c.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Drift == nil || report.Drift.HasDrift() {
+		t.Errorf("back-to-back collections reported drift: %+v", report.Drift)
+	}
+	for _, reason := range recorder.reasons {
+		if reason == ReasonConfigurationDrift {
+			t.Errorf("unexpected %s event: %v", reason, recorder.messages)
+		}
+	}
+}
+
+// signalSerializer signals every serialized object on a channel.
+type signalSerializer struct {
+	ch chan any
+}
+
+func (s *signalSerializer) Serialize(_ context.Context, v any) error {
+	s.ch <- v
+	return nil
+}
+
+func TestController_Run(t *testing.T) {
+	reports := &signalSerializer{ch: make(chan any, 1)}
+	c, err := New(
+		WithRecipe(writeTestRecipe(t)),
+		WithFactory(&fakeFactory{k8sVersion: "v1.33.5"}),
+		WithReportWriter(reports),
+		WithInterval(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	select {
+	case <-reports.ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first check did not run")
+	}
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drift provides scheduled configuration drift detection for a node.
+//
+// # Overview
+//
+// A Controller runs in-cluster (started by "cnsd drift") and, on every interval:
+//
+//  1. Takes a snapshot of the node with the collector factory
+//  2. Loads the reference recipe, or builds it from criteria
+//  3. Validates the snapshot against the recipe constraints
+//  4. Diffs the snapshot against the previous one
+//
+// # Outputs
+//
+// Each check produces a Report (kind DriftReport) that is published in three ways:
+//
+//   - ConfigMap: the report is applied to the configured ConfigMap, and the
+//     snapshot to <name>-snapshot, which is the diff baseline after a restart.
+//     In cnsd, the name is suffixed with the node name (see EnvConfig.ReportName)
+//     so that every node of the DaemonSet keeps its own baseline
+//   - Prometheus: cns_drift_constraint_status{constraint,status} is 1 for the
+//     current status of every constraint; cns_drift_constraints{status} and
+//     cns_drift_changes{change} hold the totals
+//   - Kubernetes Events: ConstraintFailed and ConstraintRecovered when a
+//     constraint status changes, ConfigurationDrift when readings changed
+//
+// # Usage
+//
+//	c, err := drift.New(
+//	    drift.WithVersion(version),
+//	    drift.WithInterval(15*time.Minute),
+//	    drift.WithRecipe("cm://gpu-operator/cns-recipe"),
+//	    drift.WithConfigMap("gpu-operator", "cns-drift"),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	return c.Run(ctx)
+//
+// In cnsd, the controller is configured from the environment (see ParseEnvConfig):
+// CNS_DRIFT_RECIPE or CNS_DRIFT_CRITERIA, CNS_DRIFT_INTERVAL, CNS_DRIFT_NAMESPACE,
+// CNS_DRIFT_CONFIGMAP, CNS_DRIFT_EXCLUDE, CNS_HOST_ROOT and NODE_NAME.
+package drift
@@ -0,0 +1,155 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+// Environment variables configuring the drift controller in cnsd.
+const (
+	EnvInterval  = "CNS_DRIFT_INTERVAL"
+	EnvRecipe    = "CNS_DRIFT_RECIPE"
+	EnvCriteria  = "CNS_DRIFT_CRITERIA"
+	EnvNamespace = "CNS_DRIFT_NAMESPACE"
+	EnvConfigMap = "CNS_DRIFT_CONFIGMAP"
+	EnvExclude   = "CNS_DRIFT_EXCLUDE"
+	EnvHostRoot  = "CNS_HOST_ROOT"
+
+	// envPodNamespace is the pod namespace set via the Downward API.
+	envPodNamespace = "POD_NAMESPACE"
+
+	// envNodeName is the node name set via the Downward API.
+	envNodeName = "NODE_NAME"
+
+	// DefaultConfigMap is the default name of the drift report ConfigMap.
+	DefaultConfigMap = "cns-drift"
+)
+
+// EnvConfig is the drift controller configuration read from the environment.
+type EnvConfig struct {
+	// Interval is the interval between checks.
+	Interval time.Duration
+
+	// Recipe is the reference recipe source (file, URL, cm:// or oci://).
+	Recipe string
+
+	// Criteria is the path of a criteria file to build the reference recipe from.
+	Criteria string
+
+	// Namespace is the namespace of the report ConfigMap and Events.
+	Namespace string
+
+	// ConfigMap is the name of the report ConfigMap.
+	ConfigMap string
+
+	// Node is the name of the node checked by this controller. When set, the
+	// report and baseline ConfigMaps are keyed by it (see ReportName).
+	Node string
+
+	// Exclude lists reading patterns ignored when diffing snapshots, in
+	// addition to snapshotter.VolatileReadings.
+	Exclude []string
+
+	// HostRoot is the directory the host filesystem is mounted at.
+	HostRoot string
+}
+
+// ParseEnvConfig reads the drift controller configuration from the environment.
+// The namespace defaults to POD_NAMESPACE, then "default". The node is read from
+// NODE_NAME.
+func ParseEnvConfig() (*EnvConfig, error) {
+	cfg := &EnvConfig{
+		Interval:  defaults.DriftInterval,
+		Recipe:    os.Getenv(EnvRecipe),
+		Criteria:  os.Getenv(EnvCriteria),
+		Namespace: os.Getenv(EnvNamespace),
+		ConfigMap: os.Getenv(EnvConfigMap),
+		Node:      os.Getenv(envNodeName),
+		HostRoot:  os.Getenv(EnvHostRoot),
+	}
+
+	if v := os.Getenv(EnvInterval); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return nil, errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("invalid %s %q: must be a positive duration (e.g. 15m)", EnvInterval, v))
+		}
+		cfg.Interval = interval
+	}
+
+	if (cfg.Recipe == "") == (cfg.Criteria == "") {
+		return nil, errors.New(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("exactly one of %s or %s must be set", EnvRecipe, EnvCriteria))
+	}
+
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv(envPodNamespace)
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = "default"
+	}
+	if cfg.ConfigMap == "" {
+		cfg.ConfigMap = DefaultConfigMap
+	}
+
+	for _, p := range strings.Split(os.Getenv(EnvExclude), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			cfg.Exclude = append(cfg.Exclude, p)
+		}
+	}
+
+	return cfg, nil
+}
+
+// ReportName returns the name of the report ConfigMap: the configured name
+// suffixed with the node name when known, so that every node of a DaemonSet
+// keeps its own report and baseline snapshot.
+func (e *EnvConfig) ReportName() string {
+	if e.Node == "" {
+		return e.ConfigMap
+	}
+	return e.ConfigMap + "-" + e.Node
+}
+
+// Options returns the controller options for the configuration. The criteria
+// file, if any, is loaded once here.
+func (e *EnvConfig) Options() ([]Option, error) {
+	opts := []Option{
+		WithInterval(e.Interval),
+		WithConfigMap(e.Namespace, e.ReportName()),
+		WithDiffFilter(snapshotter.DiffFilter{Exclude: e.Exclude}),
+		WithHostRoot(e.HostRoot),
+	}
+
+	if e.Criteria != "" {
+		criteria, err := recipe.LoadCriteriaFromFile(e.Criteria)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("failed to load criteria from %q", e.Criteria), err)
+		}
+		return append(opts, WithCriteria(criteria)), nil
+	}
+
+	return append(opts, WithRecipe(e.Recipe)), nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import "testing"
+
+func TestParseEnvConfig_ReportName(t *testing.T) {
+	tests := []struct {
+		name      string
+		configMap string
+		node      string
+		want      string
+	}{
+		{name: "default", want: DefaultConfigMap},
+		{name: "custom", configMap: "gpu-drift", want: "gpu-drift"},
+		{name: "per node", node: "gpu-node-1", want: "cns-drift-gpu-node-1"},
+		{name: "custom per node", configMap: "gpu-drift", node: "gpu-node-1", want: "gpu-drift-gpu-node-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvRecipe, "cm://gpu-operator/cns-recipe")
+			t.Setenv(EnvConfigMap, tt.configMap)
+			t.Setenv(envNodeName, tt.node)
+
+			cfg, err := ParseEnvConfig()
+			if err != nil {
+				t.Fatalf("ParseEnvConfig() error = %v", err)
+			}
+			if got := cfg.ReportName(); got != tt.want {
+				t.Errorf("ReportName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
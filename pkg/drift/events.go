@@ -0,0 +1,96 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/k8s/client"
+)
+
+// Event reasons emitted by the drift controller.
+const (
+	// ReasonConstraintFailed is emitted when a constraint starts failing.
+	ReasonConstraintFailed = "ConstraintFailed"
+
+	// ReasonConstraintRecovered is emitted when a failed constraint passes again.
+	ReasonConstraintRecovered = "ConstraintRecovered"
+
+	// ReasonConfigurationDrift is emitted when readings changed since the previous snapshot.
+	ReasonConfigurationDrift = "ConfigurationDrift"
+
+	// eventSource is the component reported as the source of events.
+	eventSource = "cnsd-drift"
+)
+
+// EventRecorder records Kubernetes Events for drift check results.
+type EventRecorder interface {
+	// Event records an event of the given type (corev1.EventTypeNormal or
+	// corev1.EventTypeWarning) with a reason and a message.
+	Event(ctx context.Context, eventType, reason, message string) error
+}
+
+// KubeEventRecorder creates core/v1 Events attached to the drift report ConfigMap.
+type KubeEventRecorder struct {
+	client    client.Interface
+	namespace string
+	name      string
+	host      string
+}
+
+// NewKubeEventRecorder returns a recorder whose events reference the ConfigMap
+// namespace/name. host is reported as the event source host (typically the node name).
+func NewKubeEventRecorder(c client.Interface, namespace, name, host string) *KubeEventRecorder {
+	return &KubeEventRecorder{
+		client:    c,
+		namespace: namespace,
+		name:      name,
+		host:      host,
+	}
+}
+
+// Event creates a Kubernetes Event.
+func (r *KubeEventRecorder) Event(ctx context.Context, eventType, reason, message string) error {
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: r.name + ".",
+			Namespace:    r.namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  r.namespace,
+			Name:       r.name,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: eventSource, Host: r.host},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	if _, err := r.client.CoreV1().Events(r.namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create event %s: %w", reason, err)
+	}
+	return nil
+}
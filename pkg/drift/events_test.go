@@ -0,0 +1,112 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubeEventRecorder(t *testing.T) {
+	c := fake.NewClientset()
+	r := NewKubeEventRecorder(c, "gpu-operator", "cns-drift", "node-1")
+
+	ctx := context.Background()
+	if err := r.Event(ctx, corev1.EventTypeWarning, ReasonConstraintFailed, "constraint failed"); err != nil {
+		t.Fatalf("Event() error = %v", err)
+	}
+
+	events, err := c.CoreV1().Events("gpu-operator").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("got %d events, want 1", len(events.Items))
+	}
+
+	e := events.Items[0]
+	if e.Reason != ReasonConstraintFailed || e.Type != corev1.EventTypeWarning || e.Message != "constraint failed" {
+		t.Errorf("event = %s/%s %q", e.Type, e.Reason, e.Message)
+	}
+	if e.InvolvedObject.Kind != "ConfigMap" || e.InvolvedObject.Name != "cns-drift" {
+		t.Errorf("InvolvedObject = %+v", e.InvolvedObject)
+	}
+	if e.Source.Host != "node-1" {
+		t.Errorf("Source.Host = %q, want node-1", e.Source.Host)
+	}
+}
+
+func TestParseEnvConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    EnvConfig
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{EnvRecipe: "cm://gpu-operator/cns-recipe"},
+			want: EnvConfig{Interval: 15 * time.Minute, Recipe: "cm://gpu-operator/cns-recipe", Namespace: "default", ConfigMap: DefaultConfigMap},
+		},
+		{
+			name: "all set",
+			env: map[string]string{
+				EnvCriteria:     "/etc/cns/criteria.yaml",
+				EnvInterval:     "1h",
+				EnvConfigMap:    "drift",
+				EnvExclude:      "OS.sysctl.*, K8s.node.*",
+				envPodNamespace: "cns",
+			},
+			want: EnvConfig{Interval: time.Hour, Criteria: "/etc/cns/criteria.yaml", Namespace: "cns", ConfigMap: "drift",
+				Exclude: []string{"OS.sysctl.*", "K8s.node.*"}},
+		},
+		{
+			name:    "neither recipe nor criteria",
+			env:     map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid interval",
+			env:     map[string]string{EnvRecipe: "recipe.yaml", EnvInterval: "often"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvInterval, EnvRecipe, EnvCriteria, EnvNamespace, EnvConfigMap, EnvExclude, envPodNamespace} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			got, err := ParseEnvConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEnvConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Interval != tt.want.Interval || got.Recipe != tt.want.Recipe || got.Criteria != tt.want.Criteria ||
+				got.Namespace != tt.want.Namespace || got.ConfigMap != tt.want.ConfigMap ||
+				len(got.Exclude) != len(tt.want.Exclude) {
+				t.Errorf("ParseEnvConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
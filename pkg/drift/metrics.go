@@ -0,0 +1,101 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+// allConstraintStatuses lists the statuses exported for every constraint.
+var allConstraintStatuses = []validator.ConstraintStatus{
+	validator.ConstraintStatusPassed,
+	validator.ConstraintStatusFailed,
+	validator.ConstraintStatusSkipped,
+}
+
+var (
+	// Constraint metrics
+	driftConstraintStatus = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cns_drift_constraint_status",
+			Help: "Status of each recipe constraint in the last drift check (1 for the current status, 0 otherwise)",
+		},
+		[]string{"constraint", "status"}, // passed, failed or skipped
+	)
+
+	driftConstraints = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cns_drift_constraints",
+			Help: "Number of recipe constraints by status in the last drift check",
+		},
+		[]string{"status"},
+	)
+
+	// Snapshot diff metrics
+	driftChanges = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cns_drift_changes",
+			Help: "Number of readings that changed since the previous snapshot, by change type",
+		},
+		[]string{"change"}, // added, removed or changed
+	)
+
+	// Check metrics
+	driftChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cns_drift_checks_total",
+			Help: "Total number of drift checks",
+		},
+		[]string{"status"}, // success or error
+	)
+
+	driftLastCheckTimestamp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cns_drift_last_check_timestamp_seconds",
+			Help: "Unix time of the last successful drift check",
+		},
+	)
+)
+
+// recordMetrics exports the results of a drift check.
+func recordMetrics(report *Report) {
+	driftConstraintStatus.Reset()
+	for _, cv := range report.Validation.Results {
+		for _, status := range allConstraintStatuses {
+			value := 0.0
+			if cv.Status == status {
+				value = 1
+			}
+			driftConstraintStatus.WithLabelValues(cv.Name, string(status)).Set(value)
+		}
+	}
+
+	summary := report.Validation.Summary
+	driftConstraints.WithLabelValues(string(validator.ConstraintStatusPassed)).Set(float64(summary.Passed))
+	driftConstraints.WithLabelValues(string(validator.ConstraintStatusFailed)).Set(float64(summary.Failed))
+	driftConstraints.WithLabelValues(string(validator.ConstraintStatusSkipped)).Set(float64(summary.Skipped))
+
+	var diff snapshotter.DiffSummary
+	if report.Drift != nil {
+		diff = report.Drift.Summary
+	}
+	driftChanges.WithLabelValues(string(snapshotter.ChangeAdded)).Set(float64(diff.Added))
+	driftChanges.WithLabelValues(string(snapshotter.ChangeRemoved)).Set(float64(diff.Removed))
+	driftChanges.WithLabelValues(string(snapshotter.ChangeModified)).Set(float64(diff.Changed))
+}
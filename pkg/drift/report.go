@@ -0,0 +1,97 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+	"github.com/NVIDIA/cloud-native-stack/pkg/validator"
+)
+
+// APIVersion is the API version of drift reports.
+const APIVersion = "cns.nvidia.com/v1alpha1"
+
+// Report is the outcome of a single drift check.
+type Report struct {
+	header.Header `json:",inline" yaml:",inline"`
+
+	// Recipe describes the reference recipe (its source, or the criteria it was built from).
+	Recipe string `json:"recipe" yaml:"recipe"`
+
+	// Validation contains the results of validating the snapshot against the recipe.
+	Validation *validator.ValidationResult `json:"validation" yaml:"validation"`
+
+	// StatusChanges lists the constraints whose status differs from the previous check.
+	StatusChanges []StatusChange `json:"statusChanges,omitempty" yaml:"statusChanges,omitempty"`
+
+	// Drift lists the readings that changed since the previous snapshot.
+	// Nil on the first check, when there is no previous snapshot.
+	Drift *snapshotter.SnapshotDiff `json:"drift,omitempty" yaml:"drift,omitempty"`
+}
+
+// StatusChange describes a constraint whose status changed between two checks.
+type StatusChange struct {
+	// Name is the fully qualified constraint name.
+	Name string `json:"name" yaml:"name"`
+
+	// Before is the status in the previous check (empty if the constraint is new).
+	Before validator.ConstraintStatus `json:"before,omitempty" yaml:"before,omitempty"`
+
+	// After is the status in the current check.
+	After validator.ConstraintStatus `json:"after" yaml:"after"`
+
+	// Actual is the value found in the current snapshot.
+	Actual string `json:"actual,omitempty" yaml:"actual,omitempty"`
+
+	// Expected is the constraint expression from the recipe.
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+}
+
+// HasDrift reports whether the check found failed constraints or changed readings.
+func (r *Report) HasDrift() bool {
+	if r.Validation != nil && r.Validation.Summary.Failed > 0 {
+		return true
+	}
+	return r.Drift != nil && r.Drift.HasDrift()
+}
+
+// statusChanges compares the constraint results of two checks. Constraints
+// that are new and passing are not reported.
+func statusChanges(previous map[string]validator.ConstraintStatus, current *validator.ValidationResult) []StatusChange {
+	changes := make([]StatusChange, 0)
+	for _, cv := range current.Results {
+		before, seen := previous[cv.Name]
+		if before == cv.Status || (!seen && cv.Status == validator.ConstraintStatusPassed) {
+			continue
+		}
+		changes = append(changes, StatusChange{
+			Name:     cv.Name,
+			Before:   before,
+			After:    cv.Status,
+			Actual:   cv.Actual,
+			Expected: cv.Expected,
+		})
+	}
+	return changes
+}
+
+// constraintStatuses indexes the constraint results of a check by name.
+func constraintStatuses(result *validator.ValidationResult) map[string]validator.ConstraintStatus {
+	statuses := make(map[string]validator.ConstraintStatus, len(result.Results))
+	for _, cv := range result.Results {
+		statuses[cv.Name] = cv.Status
+	}
+	return statuses
+}
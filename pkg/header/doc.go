@@ -93,6 +93,8 @@
 // The Kind field identifies the resource type:
 //   - Recipe: Configuration recommendations
 //   - Snapshot: System configuration capture
+//   - ClusterSnapshot: Per-node snapshots grouped into node pools
+//   - SnapshotDiff: Reading changes between two snapshots
 //   - Bundle: Deployment artifact metadata
 //
 // # Custom Metadata
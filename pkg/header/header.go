@@ -25,9 +25,12 @@ type Kind string
 // Valid Kind constants for all CNS resource types.
 const (
 	KindSnapshot         Kind = "Snapshot"
+	KindClusterSnapshot  Kind = "ClusterSnapshot"
+	KindSnapshotDiff     Kind = "SnapshotDiff"
 	KindRecipe           Kind = "Recipe"
 	KindRecipeResult     Kind = "RecipeResult"
 	KindValidationResult Kind = "ValidationResult"
+	KindDriftReport      Kind = "DriftReport"
 )
 
 // String returns the string representation of the Kind.
@@ -38,7 +41,7 @@ func (k Kind) String() string {
 // IsValid checks if the Kind is one of the recognized kinds.
 func (k *Kind) IsValid() bool {
 	switch *k {
-	case KindSnapshot, KindRecipe, KindRecipeResult, KindValidationResult:
+	case KindSnapshot, KindClusterSnapshot, KindSnapshotDiff, KindRecipe, KindRecipeResult, KindValidationResult, KindDriftReport:
 		return true
 	default:
 		return false
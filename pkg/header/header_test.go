@@ -65,6 +65,16 @@ func TestKind_IsValid(t *testing.T) {
 			kind: KindSnapshot,
 			want: true,
 		},
+		{
+			name: "ClusterSnapshot is valid",
+			kind: KindClusterSnapshot,
+			want: true,
+		},
+		{
+			name: "SnapshotDiff is valid",
+			kind: KindSnapshotDiff,
+			want: true,
+		},
 		{
 			name: "Recipe is valid",
 			kind: KindRecipe,
@@ -80,6 +90,11 @@ func TestKind_IsValid(t *testing.T) {
 			kind: KindValidationResult,
 			want: true,
 		},
+		{
+			name: "DriftReport is valid",
+			kind: KindDriftReport,
+			want: true,
+		},
 		{
 			name: "Empty kind is invalid",
 			kind: Kind(""),
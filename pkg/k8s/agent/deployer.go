@@ -54,7 +54,19 @@ func (d *Deployer) Deploy(ctx context.Context) error {
 		return fmt.Errorf("failed to create ClusterRoleBinding: %w", err)
 	}
 
-	// Step 2: Ensure Job (delete existing + recreate)
+	// Step 2: List target nodes so every node gets one indexed Pod
+	if d.config.AllNodes {
+		nodes, err := d.listTargetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list target nodes: %w", err)
+		}
+		if len(nodes) == 0 {
+			return fmt.Errorf("no schedulable, ready nodes with tolerated taints match node selector %v", d.config.NodeSelector)
+		}
+		d.targetNodes = nodes
+	}
+
+	// Step 3: Ensure Job (delete existing + recreate)
 	if err := d.ensureJob(ctx); err != nil {
 		return fmt.Errorf("failed to create Job: %w", err)
 	}
@@ -74,7 +86,21 @@ func (d *Deployer) GetSnapshot(ctx context.Context) ([]byte, error) {
 	return d.getSnapshotFromConfigMap(ctx)
 }
 
-// Cleanup removes the agent Job and RBAC resources.
+// GetSnapshots retrieves the per-node snapshots written by an AllNodes Job.
+// Returns one snapshot YAML document per Job completion index.
+func (d *Deployer) GetSnapshots(ctx context.Context) ([][]byte, error) {
+	if !d.config.AllNodes {
+		snapshot, err := d.GetSnapshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{snapshot}, nil
+	}
+	return d.getNodeSnapshotsFromConfigMaps(ctx)
+}
+
+// Cleanup removes the agent Job, the per-node snapshot ConfigMaps of an
+// AllNodes Job and RBAC resources.
 // If opts.Enabled is false, no cleanup is performed (resources are kept for debugging).
 // All resources are attempted for deletion even if some fail, and a combined error is returned.
 func (d *Deployer) Cleanup(ctx context.Context, opts CleanupOptions) error {
@@ -93,6 +119,15 @@ func (d *Deployer) Cleanup(ctx context.Context, opts CleanupOptions) error {
 		deleted = append(deleted, fmt.Sprintf("Job %q", d.config.JobName))
 	}
 
+	// Delete the per-node snapshot ConfigMaps of an AllNodes Job
+	if d.config.AllNodes {
+		if err := d.deleteNodeConfigMaps(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("per-node ConfigMaps of %q: %v", d.config.Output, err))
+		} else {
+			deleted = append(deleted, fmt.Sprintf("per-node ConfigMaps of %q", d.config.Output))
+		}
+	}
+
 	// Delete RBAC resources - attempt all even if some fail
 	if err := d.deleteServiceAccount(ctx); err != nil {
 		errs = append(errs, fmt.Sprintf("ServiceAccount %q: %v", d.config.ServiceAccountName, err))
@@ -256,9 +256,27 @@ func TestDeployer_EnsureJob(t *testing.T) {
 			t.Errorf("expected image %q, got %q", config.Image, container.Image)
 		}
 
-		// Verify volumes
-		if len(job.Spec.Template.Spec.Volumes) != 2 {
-			t.Errorf("expected 2 volumes, got %d", len(job.Spec.Template.Spec.Volumes))
+		// Verify volumes: tmp, /run/systemd and the container runtime configuration
+		if len(job.Spec.Template.Spec.Volumes) != 4 {
+			t.Errorf("expected 4 volumes, got %d", len(job.Spec.Template.Spec.Volumes))
+		}
+		mounts := make(map[string]bool)
+		for _, m := range container.VolumeMounts {
+			mounts[m.MountPath] = m.ReadOnly
+		}
+		for _, path := range []string{"/host/etc/containerd", "/host/etc/crio"} {
+			if readOnly, ok := mounts[path]; !ok || !readOnly {
+				t.Errorf("expected read-only mount at %s, got %v", path, container.VolumeMounts)
+			}
+		}
+		hostRootSet := false
+		for _, env := range container.Env {
+			if env.Name == "CNS_HOST_ROOT" && env.Value == "/host" {
+				hostRootSet = true
+			}
+		}
+		if !hostRootSet {
+			t.Errorf("expected CNS_HOST_ROOT=/host, got %v", container.Env)
 		}
 	})
 
@@ -28,6 +28,17 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// hostRoot is the directory host configuration directories are mounted below
+// in privileged mode, passed to the snapshot command as CNS_HOST_ROOT.
+const hostRoot = "/host"
+
+// hostConfigDirs are the host directories holding container runtime
+// configuration, mounted read-only below hostRoot in privileged mode.
+var hostConfigDirs = []struct{ name, path string }{
+	{name: "containerd-config", path: "/etc/containerd"},
+	{name: "crio-config", path: "/etc/crio"},
+}
+
 // ensureJob deletes any existing Job and creates a fresh one.
 func (d *Deployer) ensureJob(ctx context.Context) error {
 	// Delete existing Job if present
@@ -64,16 +75,23 @@ func (d *Deployer) ensureJob(ctx context.Context) error {
 
 // buildJob constructs the Job specification.
 func (d *Deployer) buildJob() *batchv1.Job {
+	// Each indexed Pod writes to its own ConfigMap; the Job controller sets
+	// JOB_COMPLETION_INDEX and Kubernetes expands $(VAR) in args
+	output := d.config.Output
+	if d.config.AllNodes {
+		output = d.nodeOutput(fmt.Sprintf("$(%s)", completionIndexEnv))
+	}
+
 	// Build command arguments (directly invoke binary without shell)
-	args := []string{"snapshot", "-o", d.config.Output}
+	args := []string{"snapshot", "-o", output}
 	if d.config.Debug {
-		args = []string{"--debug", "--log-json", "snapshot", "-o", d.config.Output}
+		args = []string{"--debug", "--log-json", "snapshot", "-o", output}
 	}
 
 	// Build pod spec based on privileged mode
 	podSpec := d.buildPodSpec(args)
 
-	return &batchv1.Job{
+	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      d.config.JobName,
 			Namespace: d.config.Namespace,
@@ -98,6 +116,16 @@ func (d *Deployer) buildJob() *batchv1.Job {
 			},
 		},
 	}
+
+	if d.config.AllNodes {
+		count := int32(len(d.targetNodes)) //nolint:gosec // node count is bounded by cluster size
+		job.Spec.Completions = ptr.To(count)
+		job.Spec.Parallelism = ptr.To(count)
+		job.Spec.CompletionMode = ptr.To(batchv1.IndexedCompletion)
+		d.applyAllNodesSettings(&job.Spec.Template.Spec)
+	}
+
+	return job
 }
 
 // buildPodSpec constructs the pod specification.
@@ -206,6 +234,29 @@ func (d *Deployer) applyPrivilegedSettings(spec *corev1.PodSpec) {
 			},
 		},
 	})
+
+	// Container runtime configuration is read from the host. Only one runtime
+	// is installed per node, so the directories are not required to exist.
+	for _, dir := range hostConfigDirs {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      dir.name,
+			MountPath: hostRoot + dir.path,
+			ReadOnly:  true,
+		})
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: dir.name,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: dir.path,
+					Type: ptr.To(corev1.HostPathUnset),
+				},
+			},
+		})
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "CNS_HOST_ROOT",
+		Value: hostRoot,
+	})
 }
 
 // applyRestrictedSettings configures the pod for PSS-restricted namespaces (K8s collector only).
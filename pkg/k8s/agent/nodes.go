@@ -0,0 +1,178 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// completionIndexEnv is the environment variable the Job controller sets to
+	// the Pod's completion index in Indexed Jobs.
+	completionIndexEnv = "JOB_COMPLETION_INDEX"
+
+	// jobNameLabel is the label the Job controller sets on every Pod it creates.
+	jobNameLabel = "batch.kubernetes.io/job-name"
+
+	// hostnameTopologyKey spreads Pods so that each node runs at most one.
+	hostnameTopologyKey = "kubernetes.io/hostname"
+
+	// nodeNameField is the node field the Pods are pinned to the target nodes by.
+	nodeNameField = "metadata.name"
+)
+
+// listTargetNodes returns the names of the nodes matching the node selector
+// that can run an agent Pod: schedulable, Ready, and with every NoSchedule and
+// NoExecute taint tolerated. Nodes the Pods cannot be scheduled on would leave
+// the indexed Job waiting for completions that never happen.
+func (d *Deployer) listTargetNodes(ctx context.Context) ([]string, error) {
+	nodes, err := d.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(d.config.NodeSelector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable || !nodeReady(node) || !d.toleratesTaints(node) {
+			slog.Debug("skipping node that cannot run the agent", "node", node.Name)
+			continue
+		}
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// nodeReady reports whether the node has a Ready condition with status True.
+func nodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// toleratesTaints reports whether the configured tolerations tolerate every
+// taint of the node that prevents scheduling or running Pods.
+func (d *Deployer) toleratesTaints(node *corev1.Node) bool {
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range d.config.Tolerations {
+			// Lt/Gt tolerations are matched only when the scheduler feature gate is
+			// enabled, which is off by default
+			if d.config.Tolerations[j].ToleratesTaint(logr.Discard(), taint, false) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeOutput returns the per-node output URI for the given completion index.
+func (d *Deployer) nodeOutput(index string) string {
+	return d.config.Output + "-" + index
+}
+
+// getNodeSnapshotsFromConfigMaps retrieves the snapshot written by each indexed Pod.
+func (d *Deployer) getNodeSnapshotsFromConfigMaps(ctx context.Context) ([][]byte, error) {
+	snapshots := make([][]byte, 0, len(d.targetNodes))
+	for i := range d.targetNodes {
+		data, err := d.readSnapshotConfigMap(ctx, d.nodeOutput(strconv.Itoa(i)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot for index %d: %w", i, err)
+		}
+		snapshots = append(snapshots, data)
+	}
+
+	// The per-node snapshots are merged by the caller and not kept
+	if err := d.deleteNodeConfigMaps(ctx); err != nil {
+		slog.Warn("failed to delete per-node snapshot ConfigMaps", "error", err)
+	}
+	return snapshots, nil
+}
+
+// deleteNodeConfigMaps deletes the per-node snapshot ConfigMaps of the target
+// nodes, followed by those left behind by earlier runs on more nodes.
+func (d *Deployer) deleteNodeConfigMaps(ctx context.Context) error {
+	namespace, name, err := parseConfigMapName(d.config.Output)
+	if err != nil {
+		return fmt.Errorf("failed to parse ConfigMap URI: %w", err)
+	}
+
+	configMaps := d.clientset.CoreV1().ConfigMaps(namespace)
+	for i := 0; ; i++ {
+		err := configMaps.Delete(ctx, name+"-"+strconv.Itoa(i), metav1.DeleteOptions{})
+		switch {
+		case errors.IsNotFound(err) && i >= len(d.targetNodes):
+			return nil
+		case ignoreNotFound(err) != nil:
+			return fmt.Errorf("failed to delete ConfigMap %s/%s-%d: %w", namespace, name, i, err)
+		}
+	}
+}
+
+// applyAllNodesSettings pins the Pods of an indexed Job to the target nodes
+// and spreads them so that every target node runs exactly one.
+func (d *Deployer) applyAllNodesSettings(spec *corev1.PodSpec) {
+	spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{
+								Key:      nodeNameField,
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   d.targetNodes,
+							},
+						},
+					},
+				},
+			},
+		},
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{jobNameLabel: d.config.JobName},
+					},
+					TopologyKey: hostnameTopologyKey,
+				},
+			},
+		},
+	}
+}
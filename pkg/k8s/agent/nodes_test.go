@@ -0,0 +1,228 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestNode(name string, labels map[string]string, unschedulable bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func allowAllPermissions(clientset *fake.Clientset) {
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authv1.SelfSubjectAccessReview{
+			Status: authv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+}
+
+func TestDeployer_Deploy_AllNodes(t *testing.T) {
+	gpu := map[string]string{"nodeGroup": "gpu"}
+	clientset := fake.NewClientset(
+		newTestNode("gpu-1", gpu, false),
+		newTestNode("gpu-2", gpu, false),
+		newTestNode("gpu-cordoned", gpu, true),
+		newTestNode("cpu-1", map[string]string{"nodeGroup": "cpu"}, false),
+	)
+	notReady := newTestNode("gpu-not-ready", gpu, false)
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+	tainted := newTestNode("gpu-tainted", gpu, false)
+	tainted.Spec.Taints = []corev1.Taint{{Key: "maintenance", Effect: corev1.TaintEffectNoSchedule}}
+	tolerated := newTestNode("gpu-tolerated", gpu, false)
+	tolerated.Spec.Taints = []corev1.Taint{
+		{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "preferred", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	for _, node := range []*corev1.Node{notReady, tainted, tolerated} {
+		if _, err := clientset.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	allowAllPermissions(clientset)
+
+	config := Config{
+		Namespace:          "test-namespace",
+		ServiceAccountName: testName,
+		JobName:            testName,
+		Image:              "ghcr.io/nvidia/cns:latest",
+		Output:             "cm://test-namespace/cns-snapshot",
+		NodeSelector:       gpu,
+		Tolerations:        []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}},
+		AllNodes:           true,
+	}
+	deployer := NewDeployer(clientset, config)
+	ctx := context.Background()
+
+	if err := deployer.Deploy(ctx); err != nil {
+		t.Fatalf("Deploy() failed: %v", err)
+	}
+
+	job, err := clientset.BatchV1().Jobs(config.Namespace).Get(ctx, config.JobName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Job not created: %v", err)
+	}
+
+	if got := *job.Spec.Completions; got != 3 {
+		t.Errorf("Completions = %d, want 3", got)
+	}
+	if got := *job.Spec.Parallelism; got != 3 {
+		t.Errorf("Parallelism = %d, want 3", got)
+	}
+	if got := *job.Spec.CompletionMode; got != batchv1.IndexedCompletion {
+		t.Errorf("CompletionMode = %s, want %s", got, batchv1.IndexedCompletion)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	args := strings.Join(podSpec.Containers[0].Args, " ")
+	if !strings.Contains(args, "cm://test-namespace/cns-snapshot-$(JOB_COMPLETION_INDEX)") {
+		t.Errorf("args %q do not use per-index output", args)
+	}
+
+	if podSpec.Affinity == nil || podSpec.Affinity.PodAntiAffinity == nil || podSpec.Affinity.NodeAffinity == nil {
+		t.Fatal("expected node affinity and pod anti-affinity to place one Pod on each target node")
+	}
+	terms := podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].TopologyKey != hostnameTopologyKey {
+		t.Errorf("unexpected anti-affinity terms: %+v", terms)
+	}
+	nodeTerms := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	want := []string{"gpu-1", "gpu-2", "gpu-tolerated"}
+	if len(nodeTerms) != 1 || len(nodeTerms[0].MatchFields) != 1 ||
+		strings.Join(nodeTerms[0].MatchFields[0].Values, ",") != strings.Join(want, ",") {
+		t.Errorf("node affinity terms = %+v, want nodes %v", nodeTerms, want)
+	}
+
+	// The Job controller sets the completion index itself
+	for _, env := range podSpec.Containers[0].Env {
+		if env.Name == completionIndexEnv {
+			t.Errorf("container sets %s, which the Job controller provides", completionIndexEnv)
+		}
+	}
+}
+
+func TestDeployer_Deploy_AllNodes_NoMatchingNodes(t *testing.T) {
+	clientset := fake.NewClientset(newTestNode("cpu-1", map[string]string{"nodeGroup": "cpu"}, false))
+	allowAllPermissions(clientset)
+
+	deployer := NewDeployer(clientset, Config{
+		Namespace:          "test-namespace",
+		ServiceAccountName: testName,
+		JobName:            testName,
+		Output:             "cm://test-namespace/cns-snapshot",
+		NodeSelector:       map[string]string{"nodeGroup": "gpu"},
+		AllNodes:           true,
+	})
+
+	if err := deployer.Deploy(context.Background()); err == nil {
+		t.Error("Deploy() expected error when no nodes match")
+	}
+}
+
+func TestDeployer_GetSnapshots_AllNodes(t *testing.T) {
+	cms := []runtime.Object{}
+	for _, idx := range []string{"0", "1"} {
+		cms = append(cms, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cns-snapshot-" + idx, Namespace: "test-namespace"},
+			Data:       map[string]string{"snapshot.yaml": "kind: Snapshot # " + idx},
+		})
+	}
+	clientset := fake.NewClientset(cms...)
+
+	deployer := NewDeployer(clientset, Config{
+		Namespace: "test-namespace",
+		JobName:   testName,
+		Output:    "cm://test-namespace/cns-snapshot",
+		AllNodes:  true,
+	})
+	deployer.targetNodes = []string{"gpu-1", "gpu-2"}
+
+	snapshots, err := deployer.GetSnapshots(context.Background())
+	if err != nil {
+		t.Fatalf("GetSnapshots() failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	if string(snapshots[1]) != "kind: Snapshot # 1" {
+		t.Errorf("snapshot[1] = %q", snapshots[1])
+	}
+
+	// The per-node ConfigMaps are deleted once read
+	remaining, err := clientset.CoreV1().ConfigMaps("test-namespace").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining.Items) != 0 {
+		t.Errorf("expected per-node ConfigMaps to be deleted, %d remain", len(remaining.Items))
+	}
+
+	// A missing per-node ConfigMap is an error
+	deployer.targetNodes = append(deployer.targetNodes, "gpu-3")
+	if _, err := deployer.GetSnapshots(context.Background()); err == nil {
+		t.Error("GetSnapshots() expected error for missing ConfigMap")
+	}
+}
+
+func TestDeployer_Cleanup_AllNodes(t *testing.T) {
+	cms := []runtime.Object{}
+	// Index 2 is left behind by an earlier run on three nodes
+	for _, name := range []string{"cns-snapshot", "cns-snapshot-0", "cns-snapshot-1", "cns-snapshot-2"} {
+		cms = append(cms, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+			Data:       map[string]string{"snapshot.yaml": "kind: Snapshot"},
+		})
+	}
+	clientset := fake.NewClientset(cms...)
+
+	deployer := NewDeployer(clientset, Config{
+		Namespace:          "test-namespace",
+		ServiceAccountName: testName,
+		JobName:            testName,
+		Output:             "cm://test-namespace/cns-snapshot",
+		AllNodes:           true,
+	})
+	deployer.targetNodes = []string{"gpu-1", "gpu-2"}
+
+	if err := deployer.Cleanup(context.Background(), CleanupOptions{Enabled: true}); err != nil {
+		t.Fatalf("Cleanup() failed: %v", err)
+	}
+
+	remaining, err := clientset.CoreV1().ConfigMaps("test-namespace").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the merged snapshot ConfigMap is kept
+	if len(remaining.Items) != 1 || remaining.Items[0].Name != "cns-snapshot" {
+		t.Errorf("remaining ConfigMaps = %v, want only cns-snapshot", remaining.Items)
+	}
+}
@@ -34,12 +34,16 @@ type Config struct {
 	Output             string
 	Debug              bool
 	Privileged         bool // If true, run with privileged security context (required for GPU/SystemD collectors)
+	AllNodes           bool // If true, run one Pod on every schedulable, Ready node matching NodeSelector whose taints are tolerated
 }
 
 // Deployer manages the deployment and lifecycle of the agent Job.
 type Deployer struct {
 	clientset kubernetes.Interface
 	config    Config
+
+	// targetNodes are the names of the nodes targeted in AllNodes mode (set by Deploy).
+	targetNodes []string
 }
 
 // NewDeployer creates a new agent Deployer with the given configuration.
@@ -83,8 +83,13 @@ func (d *Deployer) waitForJobCompletion(ctx context.Context, timeout time.Durati
 
 // getSnapshotFromConfigMap retrieves the snapshot data from ConfigMap.
 func (d *Deployer) getSnapshotFromConfigMap(ctx context.Context) ([]byte, error) {
+	return d.readSnapshotConfigMap(ctx, d.config.Output)
+}
+
+// readSnapshotConfigMap retrieves the snapshot data from the ConfigMap at the given URI.
+func (d *Deployer) readSnapshotConfigMap(ctx context.Context, uri string) ([]byte, error) {
 	// Parse ConfigMap name from output URI
-	namespace, name, err := parseConfigMapName(d.config.Output)
+	namespace, name, err := parseConfigMapName(uri)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse ConfigMap URI: %w", err)
 	}
@@ -13,12 +13,13 @@
 // limitations under the License.
 
 // Package measurement provides types and utilities for collecting, comparing, and filtering
-// system measurements from various sources (Kubernetes, GPU, OS, SystemD).
+// system measurements from various sources (Kubernetes, GPU, OS, SystemD, Network,
+// ContainerRuntime).
 //
 // # Core Types
 //
 // The package defines a hierarchical structure for measurements:
-//   - Type: Enum identifying the measurement source (K8s, GPU, OS, SystemD)
+//   - Type: Enum identifying the measurement source (K8s, GPU, OS, SystemD, Network, ContainerRuntime)
 //   - Measurement: Contains a Type and a slice of Subtypes
 //   - Subtype: Named collection of key-value data (e.g., "cluster", "node")
 //   - Reading: Interface for type-safe scalar values (int, float64, string, bool, etc.)
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -56,7 +57,7 @@ const (
 	KeyActive        = "active"
 )
 
-// Type represents the category of a measurement (e.g., Kubernetes, GPU, OS, SystemD).
+// Type represents the category of a measurement (e.g., Kubernetes, GPU, OS, SystemD, Network, ContainerRuntime).
 type Type string
 
 // String returns the string representation of the measurement Type.
@@ -65,10 +66,12 @@ func (mt Type) String() string {
 }
 
 const (
-	TypeK8s     Type = "K8s"
-	TypeGPU     Type = "GPU"
-	TypeOS      Type = "OS"
-	TypeSystemD Type = "SystemD"
+	TypeK8s              Type = "K8s"
+	TypeGPU              Type = "GPU"
+	TypeOS               Type = "OS"
+	TypeSystemD          Type = "SystemD"
+	TypeNetwork          Type = "Network"
+	TypeContainerRuntime Type = "ContainerRuntime"
 )
 
 // Types is the list of all supported measurement types.
@@ -77,6 +80,8 @@ var Types = []Type{
 	TypeGPU,
 	TypeOS,
 	TypeSystemD,
+	TypeNetwork,
+	TypeContainerRuntime,
 }
 
 // ParseType parses a string into a measurement Type.
@@ -294,6 +299,25 @@ func (m *Measurement) GetSubtype(name string) *Subtype {
 	return nil
 }
 
+// Lookup returns the reading at a "{Subtype}.{Key}" path. Both subtype names
+// (e.g., "gpu.0") and keys (e.g., "gpu.model") may contain dots, so every
+// subtype whose name prefixes the path is tried, shortest name first.
+func (m *Measurement) Lookup(path string) (Reading, bool) {
+	var found Reading
+	matched := -1
+	for i := range m.Subtypes {
+		st := &m.Subtypes[i]
+		key, ok := strings.CutPrefix(path, st.Name+".")
+		if !ok || (matched >= 0 && len(st.Name) >= matched) {
+			continue
+		}
+		if r, exists := st.Data[key]; exists && r != nil {
+			found, matched = r, len(st.Name)
+		}
+	}
+	return found, matched >= 0
+}
+
 // HasSubtype checks if a subtype with the given name exists.
 func (m *Measurement) HasSubtype(name string) bool {
 	return m.GetSubtype(name) != nil
@@ -38,6 +38,8 @@ func TestType_String(t *testing.T) {
 		{"SMI", TypeGPU, "GPU"},
 		{"OS", TypeOS, "OS"},
 		{"SystemD", TypeSystemD, "SystemD"},
+		{"Network", TypeNetwork, "Network"},
+		{"ContainerRuntime", TypeContainerRuntime, "ContainerRuntime"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -57,6 +59,8 @@ func TestParseType(t *testing.T) {
 	}{
 		{"valid k8s", "K8s", TypeK8s, true},
 		{"valid os", "OS", TypeOS, true},
+		{"valid network", "Network", TypeNetwork, true},
+		{"valid container runtime", "ContainerRuntime", TypeContainerRuntime, true},
 		{"invalid", "Invalid", "", false},
 		{"empty", "", "", false},
 		{"lowercase", "grub", "", false},
@@ -274,6 +278,42 @@ func TestMeasurement_GetSubtype(t *testing.T) {
 	})
 }
 
+func TestMeasurement_Lookup(t *testing.T) {
+	m := &Measurement{
+		Type: TypeGPU,
+		Subtypes: []Subtype{
+			{Name: "smi", Data: map[string]Reading{"gpu.model": Str("H100"), "driver": Str("570.86.15")}},
+			{Name: "gpu.0", Data: map[string]Reading{"model": Str("H100"), "fabric.state": Str("Completed")}},
+			{Name: "gpu", Data: map[string]Reading{"0.model": Str("shadowed")}},
+		},
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"smi.driver", "570.86.15", true},
+		{"smi.gpu.model", "H100", true},
+		{"gpu.0.fabric.state", "Completed", true},
+		{"gpu.0.model", "shadowed", true}, // shortest subtype name wins
+		{"gpu.0.missing", "", false},
+		{"gpu.1.model", "", false},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := m.Lookup(tt.path)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMeasurement_HasSubtype(t *testing.T) {
 	m := &Measurement{
 		Type: TypeK8s,
@@ -25,6 +25,9 @@
 //   - Package: Creates a local OCI artifact in OCI Image Layout format
 //   - PushFromStore: Pushes a previously packaged artifact to a remote registry
 //   - PackageAndPush: High-level workflow combining Package and PushFromStore
+//   - Pull: Fetches an artifact by tag or digest and unpacks it into a directory
+//   - Sign: Signs a pushed artifact and attaches a bundle attestation
+//   - VerifySignature: Verifies the signature and attestation of an artifact
 //
 // The Reference type encapsulates parsed output target information, making it easy to
 // determine if output is destined for the local filesystem or an OCI registry.
@@ -46,6 +49,7 @@
 //	oci://registry/repository:tag
 //	oci://ghcr.io/nvidia/bundles:v1.0.0
 //	oci://localhost:5000/test/bundle:latest
+//	oci://ghcr.io/nvidia/bundles@sha256:4f5c...
 //
 // Local file paths are detected by absence of the oci:// scheme.
 //
@@ -92,6 +96,7 @@
 //   - Registry: OCI registry hostname (e.g., "ghcr.io")
 //   - Repository: Image repository path (e.g., "nvidia/bundle")
 //   - Tag: Image tag (e.g., "v1.0.0")
+//   - Digest: Manifest digest pinned with "@sha256:..." (pull only)
 //   - LocalPath: File system path for non-OCI targets
 //
 // The Reference.WithTag() method returns a copy with the tag modified, useful for
@@ -116,6 +121,29 @@
 // Credentials are loaded from the standard Docker configuration (~/.docker/config.json)
 // using the ORAS credentials package.
 //
+// # Signing and Attestation
+//
+// Sign stores a cosign-compatible signature manifest under the tag
+// "sha256-<hex>.sig". Its single layer holds the cosign simple signing payload
+// and the signature in the "dev.cosignproject.cosign/signature" annotation.
+// A BundleAttestation (recipe, snapshot digest and CLI version) is wrapped in an
+// in-toto statement, signed as a DSSE envelope and pushed as an OCI referrer of
+// the artifact with artifact type "application/vnd.in-toto+json".
+//
+//	key, err := oci.LoadPrivateKey("cosign.key")
+//	_, err = oci.Sign(ctx, oci.SignOptions{
+//	    Registry:    "ghcr.io",
+//	    Repository:  "nvidia/bundle",
+//	    Digest:      pushResult.Digest,
+//	    Key:         key,
+//	    Attestation: &oci.BundleAttestation{CLIVersion: "v1.0.0"},
+//	})
+//
+// VerifySignature resolves a tag to its digest, checks the signature and any
+// bundle attestation with a public key, and returns the verified digest to pull.
+// Keys are unencrypted PEM ECDSA, RSA or Ed25519 keys. The ocitest package
+// provides an in-process registry for testing these flows.
+//
 // # Artifact Type
 //
 // Artifacts are pushed with the media type "application/vnd.nvidia.cns.artifact".
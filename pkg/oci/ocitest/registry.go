@@ -0,0 +1,285 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ocitest provides an in-process OCI distribution registry for tests.
+//
+// The registry keeps blobs and manifests in memory and implements the subset of
+// the OCI distribution API used by ORAS: blob upload and fetch, manifest push,
+// fetch and tagging, and the referrers API.
+//
+//	reg := ocitest.NewRegistry(t)
+//	ref := "oci://" + reg.Host() + "/test/bundle:v1"
+//
+// Clients must connect with plain HTTP.
+package ocitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Registry is an in-memory OCI registry served over HTTP.
+type Registry struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	blobs     map[string][]byte            // repository@digest -> content
+	manifests map[string]storedManifest    // repository@digest -> manifest
+	tags      map[string]map[string]string // repository -> tag -> digest
+}
+
+type storedManifest struct {
+	mediaType string
+	content   []byte
+}
+
+// NewRegistry starts an in-memory registry that is shut down when the test ends.
+func NewRegistry(t testing.TB) *Registry {
+	t.Helper()
+
+	r := &Registry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string]storedManifest),
+		tags:      make(map[string]map[string]string),
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+// Host returns the host:port of the registry.
+func (r *Registry) Host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// Tag points a tag of the repository at an existing manifest digest.
+func (r *Registry) Tag(repository, tag, dgst string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tagLocked(repository, tag, dgst)
+}
+
+// Resolve returns the manifest digest a tag points at, or "" when unknown.
+func (r *Registry) Resolve(repository, tag string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tags[repository][tag]
+}
+
+func (r *Registry) tagLocked(repository, tag, dgst string) {
+	if r.tags[repository] == nil {
+		r.tags[repository] = make(map[string]string)
+	}
+	r.tags[repository][tag] = dgst
+}
+
+func (r *Registry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.Path
+	if path == "/v2/" || path == "/v2" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !strings.HasPrefix(path, "/v2/") {
+		http.NotFound(w, req)
+		return
+	}
+	path = strings.TrimPrefix(path, "/v2/")
+
+	for _, route := range []struct {
+		sep    string
+		handle func(http.ResponseWriter, *http.Request, string, string)
+	}{
+		{"/blobs/uploads/", r.handleUpload},
+		{"/blobs/", r.handleBlob},
+		{"/manifests/", r.handleManifest},
+		{"/referrers/", r.handleReferrers},
+	} {
+		if i := strings.LastIndex(path, route.sep); i > 0 {
+			route.handle(w, req, path[:i], path[i+len(route.sep):])
+			return
+		}
+	}
+	// Upload initiation has no trailing segment
+	if strings.HasSuffix(path, "/blobs/uploads") {
+		r.handleUpload(w, req, strings.TrimSuffix(path, "/blobs/uploads"), "")
+		return
+	}
+	http.NotFound(w, req)
+}
+
+func (r *Registry) handleUpload(w http.ResponseWriter, req *http.Request, repo, id string) {
+	switch req.Method {
+	case http.MethodPost:
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/"+hex.EncodeToString(buf))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		if id == "" {
+			http.Error(w, "missing upload id", http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dgst, err := digest.Parse(req.URL.Query().Get("digest"))
+		if err != nil || dgst != digest.FromBytes(data) {
+			http.Error(w, "digest mismatch", http.StatusBadRequest)
+			return
+		}
+		r.mu.Lock()
+		r.blobs[repo+"@"+dgst.String()] = data
+		r.mu.Unlock()
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/"+dgst.String())
+		w.Header().Set("Docker-Content-Digest", dgst.String())
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Registry) handleBlob(w http.ResponseWriter, req *http.Request, repo, dgst string) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	r.mu.Lock()
+	data, ok := r.blobs[repo+"@"+dgst]
+	r.mu.Unlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	writeContent(w, req, "application/octet-stream", dgst, data)
+}
+
+func (r *Registry) handleManifest(w http.ResponseWriter, req *http.Request, repo, ref string) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		r.mu.Lock()
+		dgst := ref
+		if _, err := digest.Parse(ref); err != nil {
+			dgst = r.tags[repo][ref]
+		}
+		m, ok := r.manifests[repo+"@"+dgst]
+		r.mu.Unlock()
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		writeContent(w, req, m.mediaType, dgst, m.content)
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dgst := digest.FromBytes(data).String()
+
+		var subject struct {
+			Subject *ociv1.Descriptor `json:"subject"`
+		}
+		if err := json.Unmarshal(data, &subject); err != nil {
+			http.Error(w, "invalid manifest", http.StatusBadRequest)
+			return
+		}
+
+		r.mu.Lock()
+		r.manifests[repo+"@"+dgst] = storedManifest{mediaType: req.Header.Get("Content-Type"), content: data}
+		if _, err := digest.Parse(ref); err != nil {
+			r.tagLocked(repo, ref, dgst)
+		}
+		r.mu.Unlock()
+
+		if subject.Subject != nil {
+			w.Header().Set("OCI-Subject", subject.Subject.Digest.String())
+		}
+		w.Header().Set("Location", "/v2/"+repo+"/manifests/"+dgst)
+		w.Header().Set("Docker-Content-Digest", dgst)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *Registry) handleReferrers(w http.ResponseWriter, req *http.Request, repo, dgst string) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	index := ociv1.Index{
+		MediaType: ociv1.MediaTypeImageIndex,
+		Manifests: []ociv1.Descriptor{},
+	}
+	index.SchemaVersion = 2
+
+	r.mu.Lock()
+	for key, m := range r.manifests {
+		if !strings.HasPrefix(key, repo+"@") {
+			continue
+		}
+		var manifest ociv1.Manifest
+		if err := json.Unmarshal(m.content, &manifest); err != nil || manifest.Subject == nil {
+			continue
+		}
+		if manifest.Subject.Digest.String() != dgst {
+			continue
+		}
+		artifactType := manifest.ArtifactType
+		if artifactType == "" {
+			artifactType = manifest.Config.MediaType
+		}
+		index.Manifests = append(index.Manifests, ociv1.Descriptor{
+			MediaType:    m.mediaType,
+			ArtifactType: artifactType,
+			Digest:       digest.FromBytes(m.content),
+			Size:         int64(len(m.content)),
+			Annotations:  manifest.Annotations,
+		})
+	}
+	r.mu.Unlock()
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ociv1.MediaTypeImageIndex)
+	_, _ = w.Write(data)
+}
+
+func writeContent(w http.ResponseWriter, req *http.Request, mediaType, dgst string, data []byte) {
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Docker-Content-Digest", dgst)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+}
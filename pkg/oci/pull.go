@@ -0,0 +1,194 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	oras "oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/file"
+
+	apperrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+)
+
+// PullOptions configures the OCI pull operation.
+type PullOptions struct {
+	// Registry is the OCI registry host (e.g., "ghcr.io", "localhost:5000").
+	Registry string
+	// Repository is the image repository path (e.g., "nvidia/eidos").
+	Repository string
+	// Tag is the image tag (e.g., "v1.0.0", "latest").
+	Tag string
+	// Digest is the expected manifest digest (e.g., "sha256:..."). When set, the
+	// artifact is pulled by digest, and Tag, if also set, must resolve to it.
+	Digest string
+	// OutputDir is the directory the artifact contents are unpacked into.
+	OutputDir string
+	// PlainHTTP uses HTTP instead of HTTPS for the registry connection.
+	PlainHTTP bool
+	// InsecureTLS skips TLS certificate verification.
+	InsecureTLS bool
+}
+
+// PullResult contains the result of a successful OCI pull.
+type PullResult struct {
+	// Digest is the SHA256 digest of the pulled artifact manifest.
+	Digest string
+	// Reference is the full image reference (registry/repository:tag or
+	// registry/repository@digest).
+	Reference string
+	// OutputDir is the directory the artifact contents were unpacked into.
+	OutputDir string
+}
+
+// Pull fetches an artifact from a remote registry and unpacks its contents
+// into the output directory. Artifacts created by Package contain the bundle
+// directory as a single layer, so the output directory mirrors the original
+// bundle layout.
+//
+// The manifest and every blob are verified against their digests while they
+// are copied. When an expected digest is given, the pulled manifest must match it.
+func Pull(ctx context.Context, opts PullOptions) (*PullResult, error) {
+	if opts.Tag == "" && opts.Digest == "" {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidRequest, "tag or digest is required to pull OCI artifact")
+	}
+	srcRef := opts.Tag
+	if opts.Digest != "" {
+		if _, err := digest.Parse(opts.Digest); err != nil {
+			return nil, apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid artifact digest", err)
+		}
+		srcRef = opts.Digest
+	}
+	if opts.OutputDir == "" {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidRequest, "output directory is required to pull OCI artifact")
+	}
+
+	// Validate registry and repository format
+	if err := ValidateRegistryReference(opts.Registry, opts.Repository); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeUnavailable, "operation canceled", err)
+	}
+
+	registryHost := stripProtocol(opts.Registry)
+	refString := fmt.Sprintf("%s/%s:%s", registryHost, opts.Repository, opts.Tag)
+	if opts.Digest != "" {
+		refString = fmt.Sprintf("%s/%s@%s", registryHost, opts.Repository, opts.Digest)
+	}
+
+	absOutputDir, err := filepath.Abs(opts.OutputDir)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to resolve output directory", err)
+	}
+	if mkdirErr := os.MkdirAll(absOutputDir, 0o755); mkdirErr != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to create output directory", mkdirErr)
+	}
+
+	repo, err := newRemoteRepository(opts.Registry, opts.Repository, opts.PlainHTTP, opts.InsecureTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	// A tag pinned to a digest must still point at that digest
+	if opts.Tag != "" && opts.Digest != "" {
+		tagged, resolveErr := repo.Resolve(ctx, opts.Tag)
+		if resolveErr != nil {
+			return nil, apperrors.Wrap(apperrors.ErrCodeNotFound, "failed to resolve artifact tag", resolveErr)
+		}
+		if tagged.Digest.String() != opts.Digest {
+			return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+				"artifact tag does not match the expected digest", map[string]any{
+					"tag":      opts.Tag,
+					"digest":   tagged.Digest.String(),
+					"expected": opts.Digest,
+				})
+		}
+	}
+
+	// File store unpacks directory layers into the output directory
+	fs, err := file.New(absOutputDir)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to create file store", err)
+	}
+	defer func() { _ = fs.Close() }()
+
+	desc, err := oras.Copy(ctx, repo, srcRef, fs, srcRef, oras.DefaultCopyOptions)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to pull artifact from registry", err)
+	}
+	if opts.Digest != "" && desc.Digest.String() != opts.Digest {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"pulled artifact does not match the expected digest", map[string]any{
+				"digest":   desc.Digest.String(),
+				"expected": opts.Digest,
+			})
+	}
+
+	slog.Debug("pulled OCI artifact",
+		"reference", refString,
+		"digest", desc.Digest.String(),
+		"output_dir", absOutputDir)
+
+	return &PullResult{
+		Digest:    desc.Digest.String(),
+		Reference: refString,
+		OutputDir: absOutputDir,
+	}, nil
+}
+
+// PullURI pulls the artifact at an oci:// URI (oci://registry/repository:tag or
+// oci://registry/repository@digest) into the output directory. Registries on
+// loopback addresses (localhost, 127.0.0.1, ::1) are accessed over plain HTTP;
+// credentials are read from the Docker configuration.
+func PullURI(ctx context.Context, uri, outputDir string) (*PullResult, error) {
+	ref, err := ParseOutputTarget(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !ref.IsOCI {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"not an OCI reference", map[string]any{"uri": uri})
+	}
+
+	return Pull(ctx, PullOptions{
+		Registry:   ref.Registry,
+		Repository: ref.Repository,
+		Tag:        ref.Tag,
+		Digest:     ref.Digest,
+		OutputDir:  outputDir,
+		PlainHTTP:  isLoopbackRegistry(ref.Registry),
+	})
+}
+
+// isLoopbackRegistry reports whether a registry host refers to the local machine.
+func isLoopbackRegistry(registry string) bool {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci/ocitest"
+)
+
+func TestPull_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    PullOptions
+		wantErr string
+	}{
+		{
+			name:    "empty tag and digest",
+			opts:    PullOptions{Registry: "localhost:5000", Repository: "test/repo", OutputDir: t.TempDir()},
+			wantErr: "tag or digest is required to pull OCI artifact",
+		},
+		{
+			name:    "invalid digest",
+			opts:    PullOptions{Registry: "localhost:5000", Repository: "test/repo", Digest: "sha256:zz", OutputDir: t.TempDir()},
+			wantErr: "invalid artifact digest",
+		},
+		{
+			name:    "empty output directory",
+			opts:    PullOptions{Registry: "localhost:5000", Repository: "test/repo", Tag: "v1.0.0"},
+			wantErr: "output directory is required",
+		},
+		{
+			name:    "invalid registry",
+			opts:    PullOptions{Registry: "invalid registry", Repository: "test/repo", Tag: "v1.0.0", OutputDir: t.TempDir()},
+			wantErr: "invalid registry host format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Pull(context.Background(), tt.opts)
+			if err == nil {
+				t.Fatal("Pull() expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Pull() error = %q, want to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPull_FromRegistry(t *testing.T) {
+	reg := ocitest.NewRegistry(t)
+	pushed := pushTestBundle(t, reg, "v1", "driver: 570\n")
+	other := pushTestBundle(t, reg, "v2", "driver: 580\n")
+
+	tests := []struct {
+		name    string
+		tag     string
+		digest  string
+		wantRef string
+		wantErr string
+	}{
+		{
+			name:    "by tag",
+			tag:     "v1",
+			wantRef: ":v1",
+		},
+		{
+			name:    "by digest",
+			digest:  pushed.Digest,
+			wantRef: "@" + pushed.Digest,
+		},
+		{
+			name:    "tag pinned to digest",
+			tag:     "v1",
+			digest:  pushed.Digest,
+			wantRef: "@" + pushed.Digest,
+		},
+		{
+			name:    "tag moved away from digest",
+			tag:     "v2",
+			digest:  pushed.Digest,
+			wantErr: "does not match the expected digest",
+		},
+		{
+			name:    "unknown tag",
+			tag:     "missing",
+			wantErr: "failed to pull artifact",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := t.TempDir()
+			result, err := Pull(context.Background(), PullOptions{
+				Registry:   reg.Host(),
+				Repository: "test/bundle",
+				Tag:        tt.tag,
+				Digest:     tt.digest,
+				OutputDir:  out,
+				PlainHTTP:  true,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Pull() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Pull() error = %v", err)
+			}
+			if result.Digest != pushed.Digest || result.Digest == other.Digest {
+				t.Errorf("Digest = %s, want %s", result.Digest, pushed.Digest)
+			}
+			if !strings.HasSuffix(result.Reference, tt.wantRef) {
+				t.Errorf("Reference = %s, want suffix %s", result.Reference, tt.wantRef)
+			}
+
+			data, err := os.ReadFile(filepath.Join(out, "values.yaml"))
+			if err != nil {
+				t.Fatalf("pulled bundle missing values.yaml: %v", err)
+			}
+			if string(data) != "driver: 570\n" {
+				t.Errorf("values.yaml = %q", data)
+			}
+		})
+	}
+}
+
+func TestIsLoopbackRegistry(t *testing.T) {
+	tests := []struct {
+		registry string
+		want     bool
+	}{
+		{"localhost", true},
+		{"localhost:5000", true},
+		{"127.0.0.1:5000", true},
+		{"[::1]:5000", true},
+		{"ghcr.io", false},
+		{"registry.example.com:443", false},
+		{"10.0.0.1:5000", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			if got := isLoopbackRegistry(tt.registry); got != tt.want {
+				t.Errorf("isLoopbackRegistry(%q) = %v, want %v", tt.registry, got, tt.want)
+			}
+		})
+	}
+}
@@ -387,6 +387,26 @@ func createAuthClient(plainHTTP, insecureTLS bool) (*auth.Client, error) {
 	return client, credErr
 }
 
+// newRemoteRepository returns a client for a remote repository configured with
+// Docker credentials and the requested transport security.
+func newRemoteRepository(registry, repository string, plainHTTP, insecureTLS bool) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(fmt.Sprintf("%s/%s", stripProtocol(registry), repository))
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to initialize remote repository", err)
+	}
+	repo.PlainHTTP = plainHTTP
+
+	// Configure auth client using Docker credentials if available
+	authClient, err := createAuthClient(plainHTTP, insecureTLS)
+	if err != nil {
+		slog.Warn("failed to initialize Docker credential store, continuing without authentication",
+			"error", err)
+	}
+	repo.Client = authClient
+
+	return repo, nil
+}
+
 // hardLinkDir recursively creates hard links from src to dst.
 // This is much faster than copying and uses no additional disk space.
 //
@@ -44,6 +44,9 @@ type Reference struct {
 	// Empty string means no tag was specified; caller should apply a default.
 	// Only populated when IsOCI is true.
 	Tag string
+	// Digest is the manifest digest pinned in the URI (e.g., "sha256:..."), if any.
+	// Only populated when IsOCI is true.
+	Digest string
 	// LocalPath is the local directory path for non-OCI output.
 	// Only populated when IsOCI is false.
 	LocalPath string
@@ -73,10 +76,13 @@ func ParseOutputTarget(target string) (*Reference, error) {
 	registry := reference.Domain(ref)
 	repository := reference.Path(ref)
 
-	var tag string
+	var tag, dgst string
 	if tagged, ok := ref.(reference.Tagged); ok {
 		tag = tagged.Tag()
 	}
+	if digested, ok := ref.(reference.Digested); ok {
+		dgst = digested.Digest().String()
+	}
 	// If no tag specified, return empty string; caller will apply default
 
 	// Validate registry and repository format
@@ -89,20 +95,19 @@ func ParseOutputTarget(target string) (*Reference, error) {
 		Registry:   registry,
 		Repository: repository,
 		Tag:        tag,
+		Digest:     dgst,
 	}, nil
 }
 
 // String returns the full reference string.
-// For OCI references: "oci://registry/repository:tag" (or without tag if empty).
+// For OCI references: "oci://registry/repository:tag" (or without tag if empty),
+// followed by "@digest" when a digest is pinned.
 // For local paths: the local path.
 func (r *Reference) String() string {
 	if !r.IsOCI {
 		return r.LocalPath
 	}
-	if r.Tag == "" {
-		return fmt.Sprintf("%s%s/%s", URIScheme, r.Registry, r.Repository)
-	}
-	return fmt.Sprintf("%s%s/%s:%s", URIScheme, r.Registry, r.Repository, r.Tag)
+	return URIScheme + r.ImageReference()
 }
 
 // ImageReference returns the Docker-style image reference (without oci:// scheme).
@@ -111,10 +116,14 @@ func (r *Reference) ImageReference() string {
 	if !r.IsOCI {
 		return ""
 	}
-	if r.Tag == "" {
-		return fmt.Sprintf("%s/%s", r.Registry, r.Repository)
+	ref := fmt.Sprintf("%s/%s", r.Registry, r.Repository)
+	if r.Tag != "" {
+		ref += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		ref += "@" + r.Digest
 	}
-	return fmt.Sprintf("%s/%s:%s", r.Registry, r.Repository, r.Tag)
+	return ref
 }
 
 // WithTag returns a copy of the reference with the specified tag.
@@ -128,6 +137,7 @@ func (r *Reference) WithTag(tag string) *Reference {
 		Registry:   r.Registry,
 		Repository: r.Repository,
 		Tag:        tag,
+		Digest:     r.Digest,
 	}
 }
 
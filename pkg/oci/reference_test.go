@@ -26,6 +26,7 @@ func TestParseOutputTarget(t *testing.T) {
 		wantReg   string
 		wantRepo  string
 		wantTag   string
+		wantDig   string
 		wantDir   string
 		wantErr   bool
 	}{
@@ -87,6 +88,23 @@ func TestParseOutputTarget(t *testing.T) {
 			wantRepo:  "org/team/project/bundle",
 			wantTag:   "latest",
 		},
+		{
+			name:      "OCI with digest",
+			input:     "oci://ghcr.io/nvidia/bundle@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			wantIsOCI: true,
+			wantReg:   "ghcr.io",
+			wantRepo:  "nvidia/bundle",
+			wantDig:   "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
+		{
+			name:      "OCI with tag and digest",
+			input:     "oci://ghcr.io/nvidia/bundle:v1@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			wantIsOCI: true,
+			wantReg:   "ghcr.io",
+			wantRepo:  "nvidia/bundle",
+			wantTag:   "v1",
+			wantDig:   "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
 		{
 			name:    "OCI invalid reference",
 			input:   "oci://",
@@ -124,6 +142,9 @@ func TestParseOutputTarget(t *testing.T) {
 			if ref.Tag != tt.wantTag {
 				t.Errorf("ParseOutputTarget() Tag = %v, want %v", ref.Tag, tt.wantTag)
 			}
+			if ref.Digest != tt.wantDig {
+				t.Errorf("ParseOutputTarget() Digest = %v, want %v", ref.Digest, tt.wantDig)
+			}
 			if ref.LocalPath != tt.wantDir {
 				t.Errorf("ParseOutputTarget() LocalPath = %v, want %v", ref.LocalPath, tt.wantDir)
 			}
@@ -165,6 +186,17 @@ func TestReference_String(t *testing.T) {
 			},
 			want: "oci://ghcr.io/nvidia/bundle",
 		},
+		{
+			name: "OCI with tag and digest",
+			ref: &Reference{
+				IsOCI:      true,
+				Registry:   "ghcr.io",
+				Repository: "nvidia/bundle",
+				Tag:        "v1.0.0",
+				Digest:     "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+			want: "oci://ghcr.io/nvidia/bundle:v1.0.0@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,684 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	oras "oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+
+	apperrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+)
+
+const (
+	// SimpleSigningMediaType is the media type of the cosign signature payload layer.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// SignatureAnnotation is the layer annotation holding the base64 encoded
+	// signature of the payload, as written by cosign.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// InTotoArtifactType is the artifact type of attestation referrers.
+	InTotoArtifactType = "application/vnd.in-toto+json"
+
+	// DSSEMediaType is the media type of the DSSE envelope holding an attestation.
+	DSSEMediaType = "application/vnd.dsse.envelope.v1+json"
+
+	// AttestationPredicateType identifies the CNS bundle provenance predicate.
+	AttestationPredicateType = "https://github.com/NVIDIA/cloud-native-stack/bundle/v1"
+
+	// signatureTagSuffix is appended to "sha256-<hex>" to form the cosign signature tag.
+	signatureTagSuffix = ".sig"
+
+	// simpleSigningType is the critical.type value of cosign signature payloads.
+	simpleSigningType = "cosign container image signature"
+
+	// inTotoStatementType is the in-toto statement type of attestations.
+	inTotoStatementType = "https://in-toto.io/Statement/v1"
+
+	// maxSignatureBlobSize bounds signature payloads and attestations fetched during verification.
+	maxSignatureBlobSize = 4 << 20
+)
+
+// BundleAttestation is the predicate attached to a signed bundle artifact. It
+// records the inputs the bundle was generated from.
+type BundleAttestation struct {
+	// CLIVersion is the version of the CLI that generated the bundle.
+	CLIVersion string `json:"cliVersion"`
+
+	// SnapshotDigest is the digest of the snapshot the recipe was generated from, if any.
+	SnapshotDigest string `json:"snapshotDigest,omitempty"`
+
+	// Recipe is the recipe the bundle was generated from.
+	Recipe json.RawMessage `json:"recipe,omitempty"`
+}
+
+// SignOptions configures signing of a pushed artifact.
+type SignOptions struct {
+	// Registry is the OCI registry host (e.g., "ghcr.io", "localhost:5000").
+	Registry string
+	// Repository is the image repository path (e.g., "nvidia/eidos").
+	Repository string
+	// Digest is the manifest digest of the artifact to sign.
+	Digest string
+	// Key signs the artifact. Use LoadPrivateKey to read a PEM encoded key.
+	Key crypto.Signer
+	// Attestation is attached as a signed in-toto attestation referrer when set.
+	Attestation *BundleAttestation
+	// PlainHTTP uses HTTP instead of HTTPS for the registry connection.
+	PlainHTTP bool
+	// InsecureTLS skips TLS certificate verification.
+	InsecureTLS bool
+}
+
+// SignResult contains the result of signing an artifact.
+type SignResult struct {
+	// SignatureReference is the reference of the cosign signature manifest.
+	SignatureReference string
+	// AttestationDigest is the digest of the attestation referrer manifest, if attached.
+	AttestationDigest string
+}
+
+// VerifySignatureOptions configures verification of a signed artifact.
+type VerifySignatureOptions struct {
+	// Registry is the OCI registry host (e.g., "ghcr.io", "localhost:5000").
+	Registry string
+	// Repository is the image repository path (e.g., "nvidia/eidos").
+	Repository string
+	// Reference is the tag or manifest digest of the artifact to verify.
+	Reference string
+	// PublicKey verifies the signatures. Use LoadPublicKey to read a PEM encoded key.
+	PublicKey crypto.PublicKey
+	// PlainHTTP uses HTTP instead of HTTPS for the registry connection.
+	PlainHTTP bool
+	// InsecureTLS skips TLS certificate verification.
+	InsecureTLS bool
+}
+
+// VerifySignatureResult contains the result of a successful signature verification.
+type VerifySignatureResult struct {
+	// Digest is the verified manifest digest. Pull by this digest to fetch
+	// exactly the content that was verified.
+	Digest string
+	// Attestation is the verified bundle attestation, nil when none is attached.
+	Attestation *BundleAttestation
+}
+
+// simpleSigningPayload is the cosign "simple signing" payload.
+type simpleSigningPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+// inTotoSubject identifies the artifact an attestation statement is about.
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// inTotoStatement is an in-toto v1 statement carrying a BundleAttestation.
+type inTotoStatement struct {
+	Type          string             `json:"_type"`
+	Subject       []inTotoSubject    `json:"subject"`
+	PredicateType string             `json:"predicateType"`
+	Predicate     *BundleAttestation `json:"predicate"`
+}
+
+// dsseEnvelope is a DSSE envelope as produced by cosign attest.
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// LoadPrivateKey reads a PEM encoded private key (PKCS#8, SEC 1 EC or PKCS#1 RSA).
+// Encrypted cosign keys are not supported; export them unencrypted first.
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			fmt.Sprintf("unsupported private key type %q (encrypted keys are not supported)", block.Type),
+			map[string]any{"path": path})
+	}
+	if err != nil {
+		return nil, apperrors.WrapWithContext(apperrors.ErrCodeInvalidRequest,
+			"failed to parse private key", err, map[string]any{"path": path})
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"private key cannot be used for signing", map[string]any{"path": path})
+	}
+	return signer, nil
+}
+
+// LoadPublicKey reads a PEM encoded PKIX public key, such as cosign.pub.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			fmt.Sprintf("unsupported public key type %q", block.Type), map[string]any{"path": path})
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, apperrors.WrapWithContext(apperrors.ErrCodeInvalidRequest,
+			"failed to parse public key", err, map[string]any{"path": path})
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperrors.WrapWithContext(apperrors.ErrCodeNotFound,
+			"failed to read key file", err, map[string]any{"path": path})
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"key file is not PEM encoded", map[string]any{"path": path})
+	}
+	return block, nil
+}
+
+// Sign signs a pushed artifact with a local key.
+//
+// The signature is stored as a cosign-compatible signature manifest tagged
+// "sha256-<hex>.sig", so it can be checked with
+// "cosign verify --key cosign.pub --insecure-ignore-tlog". When an attestation
+// is given, it is wrapped in a signed in-toto statement and attached to the
+// artifact as an OCI referrer.
+func Sign(ctx context.Context, opts SignOptions) (*SignResult, error) {
+	if opts.Key == nil {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidRequest, "signing key is required")
+	}
+	dgst, err := digest.Parse(opts.Digest)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid artifact digest", err)
+	}
+	if err := ValidateRegistryReference(opts.Registry, opts.Repository); err != nil {
+		return nil, err
+	}
+
+	repo, err := newRemoteRepository(opts.Registry, opts.Repository, opts.PlainHTTP, opts.InsecureTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := repo.Resolve(ctx, dgst.String())
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeNotFound, "failed to resolve artifact to sign", err)
+	}
+
+	identity := fmt.Sprintf("%s/%s", stripProtocol(opts.Registry), opts.Repository)
+	sigTag, err := pushSignature(ctx, repo, identity, subject, opts.Key)
+	if err != nil {
+		return nil, err
+	}
+	result := &SignResult{SignatureReference: fmt.Sprintf("%s:%s", identity, sigTag)}
+
+	slog.Debug("pushed artifact signature", "reference", result.SignatureReference)
+
+	if opts.Attestation != nil {
+		attDesc, attErr := pushAttestation(ctx, repo, identity, subject, opts.Key, opts.Attestation)
+		if attErr != nil {
+			return nil, attErr
+		}
+		result.AttestationDigest = attDesc.Digest.String()
+
+		slog.Debug("attached bundle attestation", "digest", result.AttestationDigest)
+	}
+
+	return result, nil
+}
+
+// pushSignature pushes the cosign signature manifest of subject and returns its tag.
+func pushSignature(ctx context.Context, repo *remote.Repository, identity string, subject ociv1.Descriptor, key crypto.Signer) (string, error) {
+	var payload simpleSigningPayload
+	payload.Critical.Identity.DockerReference = identity
+	payload.Critical.Image.DockerManifestDigest = subject.Digest.String()
+	payload.Critical.Type = simpleSigningType
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", apperrors.Wrap(apperrors.ErrCodeInternal, "failed to serialize signature payload", err)
+	}
+	sig, err := signBytes(key, payloadBytes)
+	if err != nil {
+		return "", err
+	}
+
+	layer, err := oras.PushBytes(ctx, repo, SimpleSigningMediaType, payloadBytes)
+	if err != nil {
+		return "", apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to push signature payload", err)
+	}
+	layer.Annotations = map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(sig)}
+
+	config, err := oras.PushBytes(ctx, repo, ociv1.MediaTypeImageConfig, []byte("{}"))
+	if err != nil {
+		return "", apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to push signature config", err)
+	}
+
+	manifest := ociv1.Manifest{
+		MediaType: ociv1.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ociv1.Descriptor{layer},
+	}
+	manifest.SchemaVersion = 2
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return "", apperrors.Wrap(apperrors.ErrCodeInternal, "failed to serialize signature manifest", err)
+	}
+
+	tag := signatureTag(subject.Digest)
+	if _, err := oras.TagBytes(ctx, repo, ociv1.MediaTypeImageManifest, manifestBytes, tag); err != nil {
+		return "", apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to push signature manifest", err)
+	}
+	return tag, nil
+}
+
+// pushAttestation pushes a signed in-toto attestation as a referrer of subject.
+func pushAttestation(ctx context.Context, repo *remote.Repository, identity string, subject ociv1.Descriptor,
+	key crypto.Signer, attestation *BundleAttestation) (ociv1.Descriptor, error) {
+
+	statement := inTotoStatement{
+		Type: inTotoStatementType,
+		Subject: []inTotoSubject{{
+			Name:   identity,
+			Digest: map[string]string{subject.Digest.Algorithm().String(): subject.Digest.Encoded()},
+		}},
+		PredicateType: AttestationPredicateType,
+		Predicate:     attestation,
+	}
+	statementBytes, err := json.Marshal(statement)
+	if err != nil {
+		return ociv1.Descriptor{}, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to serialize attestation", err)
+	}
+	sig, err := signBytes(key, dssePAE(InTotoArtifactType, statementBytes))
+	if err != nil {
+		return ociv1.Descriptor{}, err
+	}
+
+	envelope, err := json.Marshal(dsseEnvelope{
+		PayloadType: InTotoArtifactType,
+		Payload:     base64.StdEncoding.EncodeToString(statementBytes),
+		Signatures:  []dsseSignature{{Sig: base64.StdEncoding.EncodeToString(sig)}},
+	})
+	if err != nil {
+		return ociv1.Descriptor{}, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to serialize attestation envelope", err)
+	}
+
+	layer, err := oras.PushBytes(ctx, repo, DSSEMediaType, envelope)
+	if err != nil {
+		return ociv1.Descriptor{}, apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to push attestation", err)
+	}
+
+	desc, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, InTotoArtifactType, oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ociv1.Descriptor{layer},
+		ManifestAnnotations: map[string]string{
+			ociv1.AnnotationCreated:     ReproducibleTimestamp,
+			"in-toto.io/predicate-type": AttestationPredicateType,
+		},
+	})
+	if err != nil {
+		return ociv1.Descriptor{}, apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to push attestation manifest", err)
+	}
+	return desc, nil
+}
+
+// VerifySignature verifies the cosign signature of an artifact and, when
+// present, its signed bundle attestation.
+//
+// Verification fails when no signature made with the public key is found, or
+// when bundle attestations are attached but none of them is signed with the
+// key and refers to the artifact.
+func VerifySignature(ctx context.Context, opts VerifySignatureOptions) (*VerifySignatureResult, error) {
+	if opts.PublicKey == nil {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidRequest, "public key is required")
+	}
+	if opts.Reference == "" {
+		return nil, apperrors.New(apperrors.ErrCodeInvalidRequest, "tag or digest is required to verify OCI artifact")
+	}
+	if err := ValidateRegistryReference(opts.Registry, opts.Repository); err != nil {
+		return nil, err
+	}
+
+	repo, err := newRemoteRepository(opts.Registry, opts.Repository, opts.PlainHTTP, opts.InsecureTLS)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := repo.Resolve(ctx, opts.Reference)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeNotFound, "failed to resolve artifact", err)
+	}
+
+	if err := verifyCosignSignature(ctx, repo, subject, opts.PublicKey); err != nil {
+		return nil, err
+	}
+
+	attestation, err := verifyAttestations(ctx, repo, subject, opts.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifySignatureResult{
+		Digest:      subject.Digest.String(),
+		Attestation: attestation,
+	}, nil
+}
+
+// verifyCosignSignature checks that the signature manifest of subject holds at
+// least one payload signed with key that names the subject digest. Payloads
+// that are not signed with key or name another digest are skipped, so that
+// signatures appended by other parties cannot mask a valid one.
+func verifyCosignSignature(ctx context.Context, repo *remote.Repository, subject ociv1.Descriptor, key crypto.PublicKey) error {
+	tag := signatureTag(subject.Digest)
+	_, manifestBytes, err := oras.FetchBytes(ctx, repo, tag, oras.FetchBytesOptions{MaxBytes: maxSignatureBlobSize})
+	if err != nil {
+		return apperrors.WrapWithContext(apperrors.ErrCodeNotFound, "no signature found for artifact", err,
+			map[string]any{"digest": subject.Digest.String()})
+	}
+
+	var manifest ociv1.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid signature manifest", err)
+	}
+
+	var mismatched string
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != SimpleSigningMediaType {
+			continue
+		}
+		sig, decodeErr := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+		if decodeErr != nil || len(sig) == 0 {
+			continue
+		}
+		payloadBytes, fetchErr := fetchBlob(ctx, repo, layer)
+		if fetchErr != nil {
+			return fetchErr
+		}
+		if verifyBytes(key, payloadBytes, sig) != nil {
+			continue
+		}
+
+		var payload simpleSigningPayload
+		if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest != subject.Digest.String() {
+			mismatched = payload.Critical.Image.DockerManifestDigest
+			continue
+		}
+		return nil
+	}
+
+	if mismatched != "" {
+		return apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"signature does not match artifact digest", map[string]any{
+				"digest": subject.Digest.String(),
+				"signed": mismatched,
+			})
+	}
+	return apperrors.NewWithContext(apperrors.ErrCodeUnauthorized,
+		"no signature of the artifact verifies with the provided public key",
+		map[string]any{"digest": subject.Digest.String()})
+}
+
+// verifyAttestations verifies the bundle attestations attached to subject and
+// returns the predicate, or nil when no bundle attestation is attached.
+//
+// Anyone with push access can attach referrers, so envelopes that are not
+// signed with key or do not refer to subject are skipped. Verification fails
+// only when bundle attestations are attached but none of them verifies.
+func verifyAttestations(ctx context.Context, repo *remote.Repository, subject ociv1.Descriptor, key crypto.PublicKey) (*BundleAttestation, error) {
+	var referrers []ociv1.Descriptor
+	err := repo.Referrers(ctx, subject, InTotoArtifactType, func(page []ociv1.Descriptor) error {
+		referrers = append(referrers, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to list artifact referrers", err)
+	}
+
+	var attached, signed bool
+	for _, ref := range referrers {
+		manifestBytes, fetchErr := fetchManifest(ctx, repo, ref)
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		var manifest ociv1.Manifest
+		if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+			continue
+		}
+
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != DSSEMediaType {
+				continue
+			}
+			envelopeBytes, blobErr := fetchBlob(ctx, repo, layer)
+			if blobErr != nil {
+				return nil, blobErr
+			}
+			envelope, statement, parseErr := parseEnvelope(envelopeBytes)
+			if parseErr != nil || statement.PredicateType != AttestationPredicateType {
+				continue
+			}
+			attached = true
+			if !envelope.verifies(key) {
+				continue
+			}
+			signed = true
+			if !statementRefersTo(statement, subject.Digest) {
+				continue
+			}
+			return statement.Predicate, nil
+		}
+	}
+
+	switch {
+	case signed:
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"attestation subject does not match artifact digest",
+			map[string]any{"digest": subject.Digest.String()})
+	case attached:
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeUnauthorized,
+			"no bundle attestation verifies with the provided public key",
+			map[string]any{"digest": subject.Digest.String()})
+	default:
+		return nil, nil
+	}
+}
+
+// parseEnvelope decodes a DSSE envelope and its in-toto statement without
+// checking the signature.
+func parseEnvelope(data []byte) (*dsseEnvelope, *inTotoStatement, error) {
+	var envelope dsseEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid attestation envelope", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid attestation payload", err)
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.ErrCodeInvalidRequest, "invalid attestation statement", err)
+	}
+	return &envelope, &statement, nil
+}
+
+// verifies reports whether any signature of the envelope verifies with key.
+func (e *dsseEnvelope) verifies(key crypto.PublicKey) bool {
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return false
+	}
+	pae := dssePAE(e.PayloadType, payload)
+	for _, s := range e.Signatures {
+		sig, decodeErr := base64.StdEncoding.DecodeString(s.Sig)
+		if decodeErr == nil && verifyBytes(key, pae, sig) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func statementRefersTo(statement *inTotoStatement, dgst digest.Digest) bool {
+	for _, s := range statement.Subject {
+		if s.Digest[dgst.Algorithm().String()] == dgst.Encoded() {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchBlob(ctx context.Context, repo *remote.Repository, desc ociv1.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureBlobSize {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"signature blob too large", map[string]any{"digest": desc.Digest.String(), "size": desc.Size})
+	}
+	data, err := content.FetchAll(ctx, repo.Blobs(), desc)
+	if err != nil {
+		return nil, apperrors.WrapWithContext(apperrors.ErrCodeUnavailable, "failed to fetch blob", err,
+			map[string]any{"digest": desc.Digest.String()})
+	}
+	return data, nil
+}
+
+func fetchManifest(ctx context.Context, repo *remote.Repository, desc ociv1.Descriptor) ([]byte, error) {
+	rc, err := repo.Manifests().Fetch(ctx, desc)
+	if err != nil {
+		return nil, apperrors.WrapWithContext(apperrors.ErrCodeUnavailable, "failed to fetch manifest", err,
+			map[string]any{"digest": desc.Digest.String()})
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxSignatureBlobSize))
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeUnavailable, "failed to read manifest", err)
+	}
+	if digest.FromBytes(data) != desc.Digest {
+		return nil, apperrors.NewWithContext(apperrors.ErrCodeInvalidRequest,
+			"manifest digest mismatch", map[string]any{"digest": desc.Digest.String()})
+	}
+	return data, nil
+}
+
+// signatureTag returns the cosign signature tag of a manifest digest.
+func signatureTag(dgst digest.Digest) string {
+	return strings.Replace(dgst.String(), ":", "-", 1) + signatureTagSuffix
+}
+
+// dssePAE returns the DSSE pre-authentication encoding of a payload.
+func dssePAE(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// signBytes signs data the way cosign does: the SHA-256 digest for ECDSA
+// (ASN.1) and RSA (PKCS#1 v1.5) keys, the raw message for Ed25519 keys.
+func signBytes(key crypto.Signer, data []byte) ([]byte, error) {
+	var (
+		sig []byte
+		err error
+	)
+	if _, ok := key.Public().(ed25519.PublicKey); ok {
+		sig, err = key.Sign(rand.Reader, data, crypto.Hash(0))
+	} else {
+		sum := sha256.Sum256(data)
+		sig, err = key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrCodeInternal, "failed to sign payload", err)
+	}
+	return sig, nil
+}
+
+// verifyBytes verifies a signature produced by signBytes.
+func verifyBytes(key crypto.PublicKey, data, sig []byte) error {
+	sum := sha256.Sum256(data)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(k, sum[:], sig) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], sig) == nil {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(k, data, sig) {
+			return nil
+		}
+	default:
+		return apperrors.New(apperrors.ErrCodeInvalidRequest, fmt.Sprintf("unsupported public key type %T", key))
+	}
+	return apperrors.New(apperrors.ErrCodeUnauthorized, "signature verification failed")
+}
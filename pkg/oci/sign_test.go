@@ -0,0 +1,428 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci/ocitest"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	oras "oras.land/oras-go/v2"
+)
+
+// pushTestBundle packages a small bundle directory and pushes it to the registry.
+func pushTestBundle(t *testing.T, reg *ocitest.Registry, tag, content string) *PackageAndPushResult {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := PackageAndPush(context.Background(), OutputConfig{
+		SourceDir: dir,
+		OutputDir: t.TempDir(),
+		Reference: &Reference{IsOCI: true, Registry: reg.Host(), Repository: "test/bundle", Tag: tag},
+		Version:   "v1.0.0",
+		PlainHTTP: true,
+	})
+	if err != nil {
+		t.Fatalf("PackageAndPush() error = %v", err)
+	}
+	return result
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSignAndVerifySignature(t *testing.T) {
+	ctx := context.Background()
+	reg := ocitest.NewRegistry(t)
+	pushed := pushTestBundle(t, reg, "v1", "driver: 570\n")
+	key := newECDSAKey(t)
+
+	attestation := &BundleAttestation{
+		CLIVersion:     "v1.0.0",
+		SnapshotDigest: "sha256:0123",
+		Recipe:         json.RawMessage(`{"kind":"Recipe"}`),
+	}
+	signed, err := Sign(ctx, SignOptions{
+		Registry:    reg.Host(),
+		Repository:  "test/bundle",
+		Digest:      pushed.Digest,
+		Key:         key,
+		Attestation: attestation,
+		PlainHTTP:   true,
+	})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	wantTag := strings.Replace(pushed.Digest, ":", "-", 1) + ".sig"
+	if !strings.HasSuffix(signed.SignatureReference, ":"+wantTag) {
+		t.Errorf("SignatureReference = %s, want tag %s", signed.SignatureReference, wantTag)
+	}
+	if signed.AttestationDigest == "" {
+		t.Error("expected attestation to be attached")
+	}
+
+	result, err := VerifySignature(ctx, VerifySignatureOptions{
+		Registry:   reg.Host(),
+		Repository: "test/bundle",
+		Reference:  "v1",
+		PublicKey:  key.Public(),
+		PlainHTTP:  true,
+	})
+	if err != nil {
+		t.Fatalf("VerifySignature() error = %v", err)
+	}
+	if result.Digest != pushed.Digest {
+		t.Errorf("Digest = %s, want %s", result.Digest, pushed.Digest)
+	}
+	if result.Attestation == nil {
+		t.Fatal("expected verified attestation")
+	}
+	if result.Attestation.CLIVersion != "v1.0.0" || result.Attestation.SnapshotDigest != "sha256:0123" {
+		t.Errorf("Attestation = %+v", result.Attestation)
+	}
+	if string(result.Attestation.Recipe) != `{"kind":"Recipe"}` {
+		t.Errorf("Recipe = %s", result.Attestation.Recipe)
+	}
+}
+
+func TestVerifySignature_Failures(t *testing.T) {
+	ctx := context.Background()
+	key := newECDSAKey(t)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, reg *ocitest.Registry)
+		key     crypto.PublicKey
+		wantErr string
+	}{
+		{
+			name: "unsigned artifact",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				pushTestBundle(t, reg, "v1", "a: 1\n")
+			},
+			key:     key.Public(),
+			wantErr: "no signature found",
+		},
+		{
+			name: "wrong key",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+				signTestBundle(t, reg, pushed.Digest, newECDSAKey(t))
+			},
+			key:     key.Public(),
+			wantErr: "no signature of the artifact verifies",
+		},
+		{
+			name: "tag moved to unsigned artifact",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+				signTestBundle(t, reg, pushed.Digest, key)
+				pushTestBundle(t, reg, "v1", "a: 2\n")
+			},
+			key:     key.Public(),
+			wantErr: "no signature found",
+		},
+		{
+			name: "signature copied to another artifact",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				signed := pushTestBundle(t, reg, "signed", "a: 1\n")
+				signTestBundle(t, reg, signed.Digest, key)
+				other := pushTestBundle(t, reg, "v1", "a: 2\n")
+				sig := reg.Resolve("test/bundle", strings.Replace(signed.Digest, ":", "-", 1)+".sig")
+				reg.Tag("test/bundle", strings.Replace(other.Digest, ":", "-", 1)+".sig", sig)
+			},
+			key:     key.Public(),
+			wantErr: "signature does not match artifact digest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := ocitest.NewRegistry(t)
+			tt.setup(t, reg)
+
+			_, err := VerifySignature(ctx, VerifySignatureOptions{
+				Registry:   reg.Host(),
+				Repository: "test/bundle",
+				Reference:  "v1",
+				PublicKey:  tt.key,
+				PlainHTTP:  true,
+			})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifySignature() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifySignature_SkipsForeignEntries(t *testing.T) {
+	ctx := context.Background()
+	key := newECDSAKey(t)
+	attestation := &BundleAttestation{CLIVersion: "v1.0.0"}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, reg *ocitest.Registry)
+		wantErr string
+	}{
+		{
+			name: "foreign attestation next to signed attestation",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+				signTestBundleWithAttestation(t, reg, pushed.Digest, newECDSAKey(t), &BundleAttestation{CLIVersion: "v6.6.6"})
+				signTestBundleWithAttestation(t, reg, pushed.Digest, key, attestation)
+			},
+		},
+		{
+			name: "only foreign attestation",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+				signTestBundleWithAttestation(t, reg, pushed.Digest, newECDSAKey(t), attestation)
+				signTestBundle(t, reg, pushed.Digest, key)
+			},
+			wantErr: "no bundle attestation verifies",
+		},
+		{
+			name: "signature for another artifact before matching signature",
+			setup: func(t *testing.T, reg *ocitest.Registry) {
+				other := pushTestBundle(t, reg, "other", "a: 2\n")
+				signTestBundle(t, reg, other.Digest, key)
+				pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+				signTestBundleWithAttestation(t, reg, pushed.Digest, key, attestation)
+				mergeSignatureLayers(t, reg, pushed.Digest, other.Digest)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := ocitest.NewRegistry(t)
+			tt.setup(t, reg)
+
+			result, err := VerifySignature(ctx, VerifySignatureOptions{
+				Registry:   reg.Host(),
+				Repository: "test/bundle",
+				Reference:  "v1",
+				PublicKey:  key.Public(),
+				PlainHTTP:  true,
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("VerifySignature() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifySignature() error = %v", err)
+			}
+			if result.Attestation == nil || result.Attestation.CLIVersion != "v1.0.0" {
+				t.Errorf("Attestation = %+v, want the one signed with the key", result.Attestation)
+			}
+		})
+	}
+}
+
+func TestSign_Ed25519WithoutAttestation(t *testing.T) {
+	ctx := context.Background()
+	reg := ocitest.NewRegistry(t)
+	pushed := pushTestBundle(t, reg, "v1", "a: 1\n")
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := signTestBundle(t, reg, pushed.Digest, priv)
+	if signed.AttestationDigest != "" {
+		t.Errorf("unexpected attestation %s", signed.AttestationDigest)
+	}
+
+	result, err := VerifySignature(ctx, VerifySignatureOptions{
+		Registry:   reg.Host(),
+		Repository: "test/bundle",
+		Reference:  pushed.Digest,
+		PublicKey:  pub,
+		PlainHTTP:  true,
+	})
+	if err != nil {
+		t.Fatalf("VerifySignature() error = %v", err)
+	}
+	if result.Attestation != nil {
+		t.Errorf("Attestation = %+v, want nil", result.Attestation)
+	}
+}
+
+func TestSign_Validation(t *testing.T) {
+	key := newECDSAKey(t)
+	tests := []struct {
+		name string
+		opts SignOptions
+	}{
+		{"missing key", SignOptions{Registry: "localhost:5000", Repository: "test", Digest: "sha256:" + strings.Repeat("a", 64)}},
+		{"invalid digest", SignOptions{Registry: "localhost:5000", Repository: "test", Digest: "latest", Key: key}},
+		{"invalid repository", SignOptions{Registry: "localhost:5000", Repository: "UPPER", Digest: "sha256:" + strings.Repeat("a", 64), Key: key}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Sign(context.Background(), tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+	key := newECDSAKey(t)
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPath := filepath.Join(dir, "cosign.key")
+	pubPath := filepath.Join(dir, "cosign.pub")
+	encPath := filepath.Join(dir, "encrypted.key")
+	writePEM(t, privPath, "PRIVATE KEY", privDER)
+	writePEM(t, pubPath, "PUBLIC KEY", pubDER)
+	writePEM(t, encPath, "ENCRYPTED SIGSTORE PRIVATE KEY", []byte("x"))
+
+	signer, err := LoadPrivateKey(privPath)
+	if err != nil {
+		t.Fatalf("LoadPrivateKey() error = %v", err)
+	}
+	pub, err := LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey() error = %v", err)
+	}
+
+	sig, err := signBytes(signer, []byte("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyBytes(pub, []byte("payload"), sig); err != nil {
+		t.Errorf("verifyBytes() error = %v", err)
+	}
+	if err := verifyBytes(pub, []byte("tampered"), sig); err == nil {
+		t.Error("expected verification of tampered payload to fail")
+	}
+
+	if _, err := LoadPrivateKey(encPath); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("LoadPrivateKey(encrypted) error = %v", err)
+	}
+	if _, err := LoadPublicKey(privPath); err == nil {
+		t.Error("expected error loading private key as public key")
+	}
+	if _, err := LoadPrivateKey(filepath.Join(dir, "missing.key")); err == nil {
+		t.Error("expected error for missing key file")
+	}
+}
+
+func signTestBundle(t *testing.T, reg *ocitest.Registry, dgst string, key crypto.Signer) *SignResult {
+	t.Helper()
+	result, err := Sign(context.Background(), SignOptions{
+		Registry:   reg.Host(),
+		Repository: "test/bundle",
+		Digest:     dgst,
+		Key:        key,
+		PlainHTTP:  true,
+	})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return result
+}
+
+func signTestBundleWithAttestation(t *testing.T, reg *ocitest.Registry, dgst string, key crypto.Signer, attestation *BundleAttestation) {
+	t.Helper()
+	_, err := Sign(context.Background(), SignOptions{
+		Registry:    reg.Host(),
+		Repository:  "test/bundle",
+		Digest:      dgst,
+		Key:         key,
+		Attestation: attestation,
+		PlainHTTP:   true,
+	})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+}
+
+// mergeSignatureLayers rewrites the signature manifest of dgst so that the
+// signature layers of from precede its own, as when several signatures are
+// appended to the same tag.
+func mergeSignatureLayers(t *testing.T, reg *ocitest.Registry, dgst, from string) {
+	t.Helper()
+	ctx := context.Background()
+	repo, err := newRemoteRepository(reg.Host(), "test/bundle", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetch := func(subject string) ociv1.Manifest {
+		_, data, fetchErr := oras.FetchBytes(ctx, repo, signatureTag(digest.Digest(subject)), oras.DefaultFetchBytesOptions)
+		if fetchErr != nil {
+			t.Fatal(fetchErr)
+		}
+		var m ociv1.Manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	manifest := fetch(dgst)
+	manifest.Layers = append(fetch(from).Layers, manifest.Layers...)
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oras.TagBytes(ctx, repo, ociv1.MediaTypeImageManifest, data, signatureTag(digest.Digest(dgst))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -22,7 +22,7 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-//go:embed data/overlays/*.yaml data/registry.yaml data/components/*/*.yaml data/components/*/manifests/*.yaml
+//go:embed data/overlays/*.yaml data/registry.yaml data/remediations.yaml data/components/*/*.yaml data/components/*/manifests/*.yaml data/components/*/schemas/*/values.schema.json
 var dataFS embed.FS
 
 // GetEmbeddedFS returns the embedded data filesystem.
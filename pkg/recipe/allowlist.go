@@ -16,6 +16,7 @@ package recipe
 
 import (
 	"log/slog"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -31,6 +32,15 @@ const (
 	EnvAllowedOSTypes      = "CNS_ALLOWED_OS"
 )
 
+// EnvAllowedDimension returns the environment variable name holding the
+// allowlist of a criteria dimension declared beyond the built-in ones, e.g.
+// CNS_ALLOWED_REGION for "region" and CNS_ALLOWED_K8S_DISTRIBUTION for
+// "k8s-distribution".
+func EnvAllowedDimension(name string) string {
+	name = strings.NewReplacer("-", "_", ".", "_").Replace(name)
+	return "CNS_ALLOWED_" + strings.ToUpper(name)
+}
+
 // AllowLists defines which criteria values are permitted for API requests.
 // An empty or nil slice means all values are allowed for that criteria type.
 // This is used by the API server to restrict which values can be requested,
@@ -51,6 +61,10 @@ type AllowLists struct {
 	// OSTypes is the list of allowed OS types (e.g., "ubuntu", "rhel").
 	// If empty, all OS types are allowed.
 	OSTypes []CriteriaOSType
+
+	// Dimensions maps the other declared criteria dimensions (e.g., "region")
+	// to their allowed values. If a dimension has no values, all are allowed.
+	Dimensions map[string][]string
 }
 
 // IsEmpty returns true if no allowlists are configured (all values allowed).
@@ -58,12 +72,37 @@ func (a *AllowLists) IsEmpty() bool {
 	if a == nil {
 		return true
 	}
+	for _, values := range a.Dimensions {
+		if len(values) > 0 {
+			return false
+		}
+	}
 	return len(a.Accelerators) == 0 &&
 		len(a.Services) == 0 &&
 		len(a.Intents) == 0 &&
 		len(a.OSTypes) == 0
 }
 
+// Values returns the allowed values of the named criteria dimension as
+// strings, or nil if all values are allowed.
+func (a *AllowLists) Values(name string) []string {
+	if a == nil {
+		return nil
+	}
+	switch name {
+	case CriteriaDimensionAccelerator:
+		return a.AcceleratorStrings()
+	case CriteriaDimensionService:
+		return a.ServiceStrings()
+	case CriteriaDimensionIntent:
+		return a.IntentStrings()
+	case CriteriaDimensionOS:
+		return a.OSTypeStrings()
+	default:
+		return a.Dimensions[name]
+	}
+}
+
 // AcceleratorStrings returns the allowed accelerator types as strings.
 func (a *AllowLists) AcceleratorStrings() []string {
 	if a == nil {
@@ -113,6 +152,8 @@ func (a *AllowLists) ValidateCriteria(c *Criteria) error {
 		"allowed_services", a.ServiceStrings(),
 		"allowed_intents", a.IntentStrings(),
 		"allowed_os_types", a.OSTypeStrings(),
+		"criteria_dimensions", c.Dimensions,
+		"allowed_dimensions", a.Dimensions,
 	)
 
 	// Check accelerator
@@ -175,6 +216,26 @@ func (a *AllowLists) ValidateCriteria(c *Criteria) error {
 		}
 	}
 
+	// Check other declared dimensions
+	for _, name := range slices.Sorted(maps.Keys(a.Dimensions)) {
+		allowed := a.Dimensions[name]
+		value := c.Dimensions[name]
+		if len(allowed) == 0 || value == "" || value == criteriaAnyValue {
+			continue
+		}
+		if !slices.Contains(allowed, value) {
+			return cnserrors.WrapWithContext(
+				cnserrors.ErrCodeInvalidRequest,
+				name+" not allowed",
+				nil,
+				map[string]any{
+					"requested": value,
+					"allowed":   allowed,
+				},
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -185,6 +246,8 @@ func (a *AllowLists) ValidateCriteria(c *Criteria) error {
 //   - CNS_ALLOWED_SERVICES: comma-separated list of service types (e.g., "eks,gke")
 //   - CNS_ALLOWED_INTENTS: comma-separated list of intent types (e.g., "training,inference")
 //   - CNS_ALLOWED_OS: comma-separated list of OS types (e.g., "ubuntu,rhel")
+//   - CNS_ALLOWED_<DIMENSION>: comma-separated list of values of another
+//     dimension declared in the registry (see EnvAllowedDimension)
 //
 // Invalid values in the environment variables are skipped with a warning logged.
 func ParseAllowListsFromEnv() (*AllowLists, error) {
@@ -246,6 +309,31 @@ func ParseAllowListsFromEnv() (*AllowLists, error) {
 		al.OSTypes = osTypes
 	}
 
+	// Parse other declared dimensions
+	for _, d := range GetCriteriaDimensions() {
+		if isBuiltinCriteriaDimension(d.Name) {
+			continue
+		}
+		env := EnvAllowedDimension(d.Name)
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		values, err := parseDimensionList(&d, v)
+		if err != nil {
+			return nil, cnserrors.WrapWithContext(
+				cnserrors.ErrCodeInvalidRequest,
+				"invalid "+env,
+				err,
+				map[string]any{"value": v},
+			)
+		}
+		if al.Dimensions == nil {
+			al.Dimensions = make(map[string][]string)
+		}
+		al.Dimensions[d.Name] = values
+	}
+
 	// Return nil if no allowlists configured (empty struct means all allowed)
 	if al.IsEmpty() {
 		return nil, nil //nolint:nilnil // nil allowlist means all values allowed, not an error
@@ -331,6 +419,25 @@ func parseOSList(s string) ([]CriteriaOSType, error) {
 	return result, nil
 }
 
+// parseDimensionList parses a comma-separated list of values of a dimension.
+func parseDimensionList(d *CriteriaDimension, s string) ([]string, error) {
+	var result []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		parsed, err := d.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		if parsed != criteriaAnyValue {
+			result = append(result, parsed)
+		}
+	}
+	return result, nil
+}
+
 // Helper functions to convert typed slices to string slices for error messages.
 
 func acceleratorTypesToStrings(types []CriteriaAcceleratorType) []string {
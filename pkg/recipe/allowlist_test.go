@@ -15,6 +15,7 @@
 package recipe
 
 import (
+	"slices"
 	"strings"
 	"testing"
 )
@@ -45,6 +46,27 @@ func TestAllowLists_ValidateCriteria(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "allowed dimension value passes",
+			allowLists: &AllowLists{
+				Dimensions: map[string][]string{"region": {"us-east"}},
+			},
+			criteria: &Criteria{
+				Dimensions: map[string]string{"region": "us-east"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "disallowed dimension value fails",
+			allowLists: &AllowLists{
+				Dimensions: map[string][]string{"region": {"us-east"}},
+			},
+			criteria: &Criteria{
+				Dimensions: map[string]string{"region": "eu-west"},
+			},
+			wantErr:    true,
+			errContain: "region not allowed",
+		},
 		{
 			name: "allowed accelerator passes",
 			allowLists: &AllowLists{
@@ -350,3 +372,28 @@ func TestParseAllowListsFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAllowListsFromEnv_Dimensions(t *testing.T) {
+	useTestDimensions(t)
+
+	if got := EnvAllowedDimension("k8s-distribution"); got != "CNS_ALLOWED_K8S_DISTRIBUTION" {
+		t.Errorf("EnvAllowedDimension() = %q", got)
+	}
+
+	t.Setenv(EnvAllowedDimension("region"), "us-east-1, any")
+	al, err := ParseAllowListsFromEnv()
+	if err != nil {
+		t.Fatalf("ParseAllowListsFromEnv() error = %v", err)
+	}
+	if al == nil || !slices.Equal(al.Values("region"), []string{"us-east"}) {
+		t.Fatalf("allowlists = %+v, want region [us-east]", al)
+	}
+	if al.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+
+	t.Setenv(EnvAllowedDimension("region"), "mars")
+	if _, err := ParseAllowListsFromEnv(); err == nil {
+		t.Error("expected error for undeclared region value")
+	}
+}
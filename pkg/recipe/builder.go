@@ -62,6 +62,15 @@ func WithAllowLists(al *AllowLists) Option {
 	}
 }
 
+// WithExplain returns an Option that enables explain mode for the Builder.
+// In explain mode, results include the overlay provenance of every component
+// field, constraint and values key, along with the rejected overlays.
+func WithExplain(explain bool) Option {
+	return func(b *Builder) {
+		b.Explain = explain
+	}
+}
+
 // NewBuilder creates a new Builder instance with the provided functional options.
 func NewBuilder(opts ...Option) *Builder {
 	b := &Builder{}
@@ -79,6 +88,7 @@ func NewBuilder(opts ...Option) *Builder {
 type Builder struct {
 	Version    string
 	AllowLists *AllowLists
+	Explain    bool
 }
 
 // BuildFromCriteria creates a RecipeResult payload for the provided criteria.
@@ -131,6 +141,12 @@ func (b *Builder) BuildFromCriteria(ctx context.Context, c *Criteria) (*RecipeRe
 		return nil, err
 	}
 
+	if b.Explain {
+		if result.Explanation, err = store.Explain(result); err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeInternal, "failed to explain recipe", err)
+		}
+	}
+
 	// Set recipe version from builder configuration
 	if b.Version != "" {
 		result.Metadata.Version = b.Version
@@ -195,6 +211,12 @@ func (b *Builder) BuildFromCriteriaWithEvaluator(ctx context.Context, c *Criteri
 		return nil, err
 	}
 
+	if b.Explain {
+		if result.Explanation, err = store.Explain(result); err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeInternal, "failed to explain recipe", err)
+		}
+	}
+
 	// Set recipe version from builder configuration
 	if b.Version != "" {
 		result.Metadata.Version = b.Version
@@ -17,6 +17,7 @@ package recipe
 import (
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -25,9 +26,10 @@ import (
 // ComponentRegistry holds the declarative configuration for all components.
 // This is loaded from data/registry.yaml at startup.
 type ComponentRegistry struct {
-	APIVersion string            `yaml:"apiVersion"`
-	Kind       string            `yaml:"kind"`
-	Components []ComponentConfig `yaml:"components"`
+	APIVersion string              `yaml:"apiVersion"`
+	Kind       string              `yaml:"kind"`
+	Criteria   []CriteriaDimension `yaml:"criteria,omitempty"`
+	Components []ComponentConfig   `yaml:"components"`
 
 	// Index for fast lookup by name (populated after loading)
 	byName map[string]*ComponentConfig
@@ -42,6 +44,10 @@ type ComponentConfig struct {
 	// DisplayName is the human-readable name used in templates and output.
 	DisplayName string `yaml:"displayName"`
 
+	// Namespace is the namespace the component is installed into by the
+	// deployers. Defaults to the component name.
+	Namespace string `yaml:"namespace,omitempty"`
+
 	// ValueOverrideKeys are alternative keys for --set flag matching.
 	// Example: ["gpuoperator"] allows --set gpuoperator:key=value
 	ValueOverrideKeys []string `yaml:"valueOverrideKeys,omitempty"`
@@ -54,6 +60,27 @@ type ComponentConfig struct {
 
 	// NodeScheduling defines paths for injecting node selectors and tolerations.
 	NodeScheduling NodeSchedulingConfig `yaml:"nodeScheduling,omitempty"`
+
+	// Mirror defines paths for rewriting image registries in air-gapped bundles.
+	Mirror MirrorConfig `yaml:"mirror,omitempty"`
+
+	// VersionImages are container image names (without registry) whose tag is
+	// the component version. Used to pin component versions from a snapshot.
+	VersionImages []string `yaml:"versionImages,omitempty"`
+
+	// ValuesSchemas reference the values.schema.json of chart versions, used
+	// to validate rendered component values before bundling.
+	ValuesSchemas []ValuesSchemaConfig `yaml:"valuesSchemas,omitempty"`
+}
+
+// ValuesSchemaConfig references the values schema of a chart version.
+type ValuesSchemaConfig struct {
+	// Version is the chart version the schema belongs to (e.g., "v25.10.1").
+	Version string `yaml:"version"`
+
+	// File is the schema path relative to the data directory
+	// (e.g., "components/gpu-operator/schemas/v25.10.1/values.schema.json").
+	File string `yaml:"file"`
 }
 
 // HelmConfig contains default Helm chart settings for a component.
@@ -98,20 +125,36 @@ type SchedulingPaths struct {
 	TolerationPaths []string `yaml:"tolerationPaths,omitempty"`
 }
 
-// Global component registry (loaded once, thread-safe access)
+// MirrorConfig defines paths for image registry rewriting.
+type MirrorConfig struct {
+	// RegistryPaths are paths of image registry or repository values that are
+	// pointed at the mirror registry.
+	RegistryPaths []string `yaml:"registryPaths,omitempty"`
+}
+
+// Global component registry (loaded once per data provider, thread-safe access)
 var (
-	globalRegistry     *ComponentRegistry
-	globalRegistryOnce sync.Once
-	globalRegistryErr  error
+	globalRegistryMu         sync.Mutex
+	globalRegistry           *ComponentRegistry
+	globalRegistryErr        error
+	globalRegistryLoaded     bool
+	globalRegistryGeneration int
 )
 
 // GetComponentRegistry returns the global component registry.
-// The registry is loaded once from embedded data and cached.
+// The registry is loaded from the data provider and cached until the data
+// provider changes (see SetDataProvider).
 // Returns an error if the registry file cannot be loaded or parsed.
 func GetComponentRegistry() (*ComponentRegistry, error) {
-	globalRegistryOnce.Do(func() {
+	globalRegistryMu.Lock()
+	defer globalRegistryMu.Unlock()
+
+	generation := GetDataProviderGeneration()
+	if !globalRegistryLoaded || globalRegistryGeneration != generation {
 		globalRegistry, globalRegistryErr = loadComponentRegistry()
-	})
+		globalRegistryLoaded = true
+		globalRegistryGeneration = generation
+	}
 	return globalRegistry, globalRegistryErr
 }
 
@@ -239,6 +282,25 @@ func (r *ComponentRegistry) Validate() []error {
 		}
 	}
 
+	// Check values schema references
+	for i, comp := range r.Components {
+		versions := make(map[string]bool)
+		for j, vs := range comp.ValuesSchemas {
+			if vs.Version == "" || vs.File == "" {
+				errs = append(errs, fmt.Errorf("component[%d] (%s): valuesSchemas[%d]: version and file are required", i, comp.Name, j))
+				continue
+			}
+			version := strings.TrimPrefix(vs.Version, "v")
+			if versions[version] {
+				errs = append(errs, fmt.Errorf("component[%d] (%s): duplicate values schema for version %s", i, comp.Name, vs.Version))
+			}
+			versions[version] = true
+		}
+	}
+
+	// Check criteria dimension declarations
+	errs = append(errs, validateCriteriaDimensions(r.Criteria)...)
+
 	// Check for mutually exclusive helm/kustomize configuration
 	for i, comp := range r.Components {
 		hasHelm := comp.Helm.DefaultRepository != "" || comp.Helm.DefaultChart != ""
@@ -284,6 +346,37 @@ func (c *ComponentConfig) GetAcceleratedTolerationPaths() []string {
 	return c.NodeScheduling.Accelerated.TolerationPaths
 }
 
+// GetNamespace returns the namespace declared for a component, or "" if none.
+func (c *ComponentConfig) GetNamespace() string {
+	if c == nil {
+		return ""
+	}
+	return c.Namespace
+}
+
+// GetMirrorRegistryPaths returns all mirror registry paths for a component.
+func (c *ComponentConfig) GetMirrorRegistryPaths() []string {
+	if c == nil {
+		return nil
+	}
+	return c.Mirror.RegistryPaths
+}
+
+// GetValuesSchemaFile returns the values schema file of a chart version, or ""
+// when the registry has none. Versions match with or without a "v" prefix.
+func (c *ComponentConfig) GetValuesSchemaFile(version string) string {
+	if c == nil || version == "" {
+		return ""
+	}
+	version = strings.TrimPrefix(version, "v")
+	for _, vs := range c.ValuesSchemas {
+		if strings.TrimPrefix(vs.Version, "v") == version {
+			return vs.File
+		}
+	}
+	return ""
+}
+
 // GetType returns the component deployment type based on which config is present.
 // Returns ComponentTypeKustomize if Kustomize.DefaultSource is set,
 // otherwise returns ComponentTypeHelm (the default).
@@ -208,6 +208,10 @@ func TestComponentRegistry_NodeSchedulingPaths(t *testing.T) {
 	if !slices.Contains(sysSelectors, "operator.nodeSelector") {
 		t.Error("gpu-operator should have 'operator.nodeSelector' in system node selector paths")
 	}
+
+	if !slices.Contains(gpuOp.GetMirrorRegistryPaths(), "operator.repository") {
+		t.Error("gpu-operator should have 'operator.repository' in mirror registry paths")
+	}
 }
 
 func TestComponentRegistry_PathSyntax(t *testing.T) {
@@ -223,6 +227,7 @@ func TestComponentRegistry_PathSyntax(t *testing.T) {
 		allPaths = append(allPaths, comp.GetSystemTolerationPaths()...)
 		allPaths = append(allPaths, comp.GetAcceleratedNodeSelectorPaths()...)
 		allPaths = append(allPaths, comp.GetAcceleratedTolerationPaths()...)
+		allPaths = append(allPaths, comp.GetMirrorRegistryPaths()...)
 
 		for _, path := range allPaths {
 			// Paths should not be empty
@@ -311,6 +316,54 @@ func TestComponentConfig_NilSafety(t *testing.T) {
 	if nilComp.GetAcceleratedTolerationPaths() != nil {
 		t.Error("expected nil for nil component")
 	}
+	if nilComp.GetMirrorRegistryPaths() != nil {
+		t.Error("expected nil for nil component")
+	}
+	if nilComp.GetValuesSchemaFile("v1") != "" {
+		t.Error("expected empty schema file for nil component")
+	}
+}
+
+func TestComponentConfig_GetValuesSchemaFile(t *testing.T) {
+	comp := &ComponentConfig{
+		Name: "test",
+		ValuesSchemas: []ValuesSchemaConfig{
+			{Version: "v25.10.1", File: "components/test/schemas/v25.10.1/values.schema.json"},
+			{Version: "4.14.0", File: "components/test/schemas/4.14.0/values.schema.json"},
+		},
+	}
+
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v25.10.1", "components/test/schemas/v25.10.1/values.schema.json"},
+		{"25.10.1", "components/test/schemas/v25.10.1/values.schema.json"},
+		{"v4.14.0", "components/test/schemas/4.14.0/values.schema.json"},
+		{"v25.3.3", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := comp.GetValuesSchemaFile(tt.version); got != tt.want {
+			t.Errorf("GetValuesSchemaFile(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestComponentRegistry_ValuesSchemasExist(t *testing.T) {
+	registry, err := GetComponentRegistry()
+	if err != nil {
+		t.Fatalf("GetComponentRegistry() error = %v", err)
+	}
+
+	provider := GetDataProvider()
+	for _, comp := range registry.Components {
+		for _, vs := range comp.ValuesSchemas {
+			if _, err := provider.ReadFile(vs.File); err != nil {
+				t.Errorf("component %s: values schema %s for version %s not found: %v", comp.Name, vs.File, vs.Version, err)
+			}
+		}
+	}
 }
 
 func TestComponentRegistry_NilSafety(t *testing.T) {
@@ -340,6 +393,32 @@ func TestComponentRegistry_Validate_EdgeCases(t *testing.T) {
 		}
 	})
 
+	t.Run("values schema validation", func(t *testing.T) {
+		registry := &ComponentRegistry{
+			Components: []ComponentConfig{
+				{
+					Name:        "test",
+					DisplayName: "Test",
+					ValuesSchemas: []ValuesSchemaConfig{
+						{Version: "v1.0.0", File: "a.json"},
+						{Version: "1.0.0", File: "b.json"},
+						{Version: "v2.0.0"},
+					},
+				},
+			},
+		}
+		errs := registry.Validate()
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 validation errors, got %v", errs)
+		}
+		if !strings.Contains(errs[0].Error(), "duplicate values schema") {
+			t.Errorf("expected duplicate values schema error, got %v", errs[0])
+		}
+		if !strings.Contains(errs[1].Error(), "version and file are required") {
+			t.Errorf("expected required fields error, got %v", errs[1])
+		}
+	})
+
 	t.Run("empty name validation", func(t *testing.T) {
 		registry := &ComponentRegistry{
 			Components: []ComponentConfig{
@@ -19,8 +19,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
@@ -33,7 +36,8 @@ const criteriaAnyValue = "any"
 // CriteriaServiceType represents the Kubernetes service/platform type for criteria.
 type CriteriaServiceType string
 
-// CriteriaServiceType constants for supported Kubernetes services.
+// CriteriaServiceType constants for the Kubernetes services of the embedded
+// registry. External registries may declare more.
 const (
 	CriteriaServiceAny CriteriaServiceType = "any"
 	CriteriaServiceEKS CriteriaServiceType = "eks"
@@ -43,32 +47,22 @@ const (
 )
 
 // ParseCriteriaServiceType parses a string into a CriteriaServiceType.
+// Valid values are declared by the service dimension of the registry.
 func ParseCriteriaServiceType(s string) (CriteriaServiceType, error) {
-	switch strings.ToLower(strings.TrimSpace(s)) {
-	case "", criteriaAnyValue, "self-managed", "self", "vanilla":
-		return CriteriaServiceAny, nil
-	case "eks":
-		return CriteriaServiceEKS, nil
-	case "gke":
-		return CriteriaServiceGKE, nil
-	case "aks":
-		return CriteriaServiceAKS, nil
-	case "oke":
-		return CriteriaServiceOKE, nil
-	default:
-		return CriteriaServiceAny, fmt.Errorf("invalid service type: %s", s)
-	}
+	v, err := parseCriteriaDimension(CriteriaDimensionService, s)
+	return CriteriaServiceType(v), err
 }
 
 // GetCriteriaServiceTypes returns all supported service types sorted alphabetically.
 func GetCriteriaServiceTypes() []string {
-	return []string{"aks", "eks", "gke", "oke"}
+	return criteriaDimensionValues(CriteriaDimensionService)
 }
 
 // CriteriaAcceleratorType represents the GPU/accelerator type.
 type CriteriaAcceleratorType string
 
-// CriteriaAcceleratorType constants for supported accelerators.
+// CriteriaAcceleratorType constants for the accelerators of the embedded
+// registry. External registries may declare more.
 const (
 	CriteriaAcceleratorAny   CriteriaAcceleratorType = "any"
 	CriteriaAcceleratorH100  CriteriaAcceleratorType = "h100"
@@ -78,32 +72,22 @@ const (
 )
 
 // ParseCriteriaAcceleratorType parses a string into a CriteriaAcceleratorType.
+// Valid values are declared by the accelerator dimension of the registry.
 func ParseCriteriaAcceleratorType(s string) (CriteriaAcceleratorType, error) {
-	switch strings.ToLower(strings.TrimSpace(s)) {
-	case "", criteriaAnyValue:
-		return CriteriaAcceleratorAny, nil
-	case "h100":
-		return CriteriaAcceleratorH100, nil
-	case "gb200":
-		return CriteriaAcceleratorGB200, nil
-	case "a100":
-		return CriteriaAcceleratorA100, nil
-	case "l40":
-		return CriteriaAcceleratorL40, nil
-	default:
-		return CriteriaAcceleratorAny, fmt.Errorf("invalid accelerator type: %s", s)
-	}
+	v, err := parseCriteriaDimension(CriteriaDimensionAccelerator, s)
+	return CriteriaAcceleratorType(v), err
 }
 
 // GetCriteriaAcceleratorTypes returns all supported accelerator types sorted alphabetically.
 func GetCriteriaAcceleratorTypes() []string {
-	return []string{"a100", "gb200", "h100", "l40"}
+	return criteriaDimensionValues(CriteriaDimensionAccelerator)
 }
 
 // CriteriaIntentType represents the workload intent.
 type CriteriaIntentType string
 
-// CriteriaIntentType constants for supported workload intents.
+// CriteriaIntentType constants for the workload intents of the embedded
+// registry. External registries may declare more.
 const (
 	CriteriaIntentAny       CriteriaIntentType = "any"
 	CriteriaIntentTraining  CriteriaIntentType = "training"
@@ -111,28 +95,22 @@ const (
 )
 
 // ParseCriteriaIntentType parses a string into a CriteriaIntentType.
+// Valid values are declared by the intent dimension of the registry.
 func ParseCriteriaIntentType(s string) (CriteriaIntentType, error) {
-	switch strings.ToLower(strings.TrimSpace(s)) {
-	case "", criteriaAnyValue:
-		return CriteriaIntentAny, nil
-	case "training":
-		return CriteriaIntentTraining, nil
-	case "inference":
-		return CriteriaIntentInference, nil
-	default:
-		return CriteriaIntentAny, fmt.Errorf("invalid intent type: %s", s)
-	}
+	v, err := parseCriteriaDimension(CriteriaDimensionIntent, s)
+	return CriteriaIntentType(v), err
 }
 
 // GetCriteriaIntentTypes returns all supported intent types sorted alphabetically.
 func GetCriteriaIntentTypes() []string {
-	return []string{"inference", "training"}
+	return criteriaDimensionValues(CriteriaDimensionIntent)
 }
 
 // CriteriaOSType represents an operating system type.
 type CriteriaOSType string
 
-// CriteriaOSType constants for supported operating systems.
+// CriteriaOSType constants for the operating systems of the embedded
+// registry. External registries may declare more.
 const (
 	CriteriaOSAny         CriteriaOSType = "any"
 	CriteriaOSUbuntu      CriteriaOSType = "ubuntu"
@@ -142,30 +120,21 @@ const (
 )
 
 // ParseCriteriaOSType parses a string into a CriteriaOSType.
+// Valid values are declared by the os dimension of the registry.
 func ParseCriteriaOSType(s string) (CriteriaOSType, error) {
-	switch strings.ToLower(strings.TrimSpace(s)) {
-	case "", criteriaAnyValue:
-		return CriteriaOSAny, nil
-	case "ubuntu":
-		return CriteriaOSUbuntu, nil
-	case "rhel":
-		return CriteriaOSRHEL, nil
-	case "cos":
-		return CriteriaOSCOS, nil
-	case "amazonlinux", "al2", "al2023":
-		return CriteriaOSAmazonLinux, nil
-	default:
-		return CriteriaOSAny, fmt.Errorf("invalid os type: %s", s)
-	}
+	v, err := parseCriteriaDimension(CriteriaDimensionOS, s)
+	return CriteriaOSType(v), err
 }
 
 // GetCriteriaOSTypes returns all supported OS types sorted alphabetically.
 func GetCriteriaOSTypes() []string {
-	return []string{"amazonlinux", "cos", "rhel", "ubuntu"}
+	return criteriaDimensionValues(CriteriaDimensionOS)
 }
 
 // Criteria represents the input parameters for recipe matching.
 // All fields are optional and default to "any" if not specified.
+// Dimensions declared in the registry beyond the typed fields are held in
+// Dimensions and serialized inline with them.
 type Criteria struct {
 	// Service is the Kubernetes service type (eks, gke, aks, oke, self-managed).
 	Service CriteriaServiceType `json:"service,omitempty" yaml:"service,omitempty"`
@@ -181,6 +150,111 @@ type Criteria struct {
 
 	// Nodes is the number of worker nodes (0 means any/unspecified).
 	Nodes int `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+
+	// Dimensions holds the values of the other declared criteria dimensions
+	// (e.g., "region"), keyed by dimension name.
+	Dimensions map[string]string `json:"-" yaml:",inline"`
+}
+
+// criteriaFields has the fields of Criteria without its JSON methods.
+type criteriaFields Criteria
+
+// MarshalJSON serializes the criteria with Dimensions inline.
+func (c Criteria) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(criteriaFields(c))
+	if err != nil || len(c.Dimensions) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]any, len(c.Dimensions)+5)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Dimensions {
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON parses criteria, collecting fields other than the typed ones
+// into Dimensions.
+func (c *Criteria) UnmarshalJSON(data []byte) error {
+	var fields criteriaFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for name, value := range raw {
+		if isBuiltinCriteriaDimension(name) || name == criteriaNodesField {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return fmt.Errorf("invalid %s value: %s", name, value)
+		}
+		if fields.Dimensions == nil {
+			fields.Dimensions = make(map[string]string)
+		}
+		fields.Dimensions[name] = s
+	}
+
+	*c = Criteria(fields)
+	return nil
+}
+
+// Get returns the value of the named criteria dimension ("" if unset).
+func (c *Criteria) Get(name string) string {
+	switch name {
+	case CriteriaDimensionService:
+		return string(c.Service)
+	case CriteriaDimensionAccelerator:
+		return string(c.Accelerator)
+	case CriteriaDimensionIntent:
+		return string(c.Intent)
+	case CriteriaDimensionOS:
+		return string(c.OS)
+	default:
+		return c.Dimensions[name]
+	}
+}
+
+// Set sets the value of the named criteria dimension without validating it.
+// Use WithCriteriaDimension to parse the value against the declaration.
+func (c *Criteria) Set(name, value string) {
+	switch name {
+	case CriteriaDimensionService:
+		c.Service = CriteriaServiceType(value)
+	case CriteriaDimensionAccelerator:
+		c.Accelerator = CriteriaAcceleratorType(value)
+	case CriteriaDimensionIntent:
+		c.Intent = CriteriaIntentType(value)
+	case CriteriaDimensionOS:
+		c.OS = CriteriaOSType(value)
+	default:
+		if c.Dimensions == nil {
+			c.Dimensions = make(map[string]string)
+		}
+		c.Dimensions[name] = value
+	}
+}
+
+// dimensionNames returns the sorted names of the Dimensions set in any of the
+// given criteria.
+func dimensionNames(criteria ...*Criteria) []string {
+	seen := make(map[string]bool)
+	for _, c := range criteria {
+		if c == nil {
+			continue
+		}
+		for name := range c.Dimensions {
+			seen[name] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
 }
 
 // NewCriteria creates a new Criteria with all fields set to "any".
@@ -236,6 +310,13 @@ func (c *Criteria) Matches(other *Criteria) bool {
 		return false
 	}
 
+	// Declared dimensions beyond the typed fields
+	for _, name := range dimensionNames(c, other) {
+		if !matchesCriteriaField(c.Dimensions[name], other.Dimensions[name]) {
+			return false
+		}
+	}
+
 	// Nodes: 0 means any - apply same asymmetric logic
 	// Query 0 (any) → only match if recipe is also 0 (generic)
 	// Recipe 0 (any) → match any query value
@@ -298,9 +379,42 @@ func (c *Criteria) Specificity() int {
 	if c.Nodes != 0 {
 		score++
 	}
+	for _, value := range c.Dimensions {
+		if value != "" && value != criteriaAnyValue {
+			score++
+		}
+	}
 	return score
 }
 
+// normalizeCriteria returns a copy of c with empty fields set to "any",
+// the way matching treats them.
+func normalizeCriteria(c *Criteria) *Criteria {
+	n := *c
+	if n.Service == "" {
+		n.Service = CriteriaServiceAny
+	}
+	if n.Accelerator == "" {
+		n.Accelerator = CriteriaAcceleratorAny
+	}
+	if n.Intent == "" {
+		n.Intent = CriteriaIntentAny
+	}
+	if n.OS == "" {
+		n.OS = CriteriaOSAny
+	}
+	if c.Dimensions != nil {
+		n.Dimensions = make(map[string]string, len(c.Dimensions))
+		for name, value := range c.Dimensions {
+			if value == "" {
+				value = criteriaAnyValue
+			}
+			n.Dimensions[name] = value
+		}
+	}
+	return &n
+}
+
 // String returns a human-readable representation of the criteria.
 func (c *Criteria) String() string {
 	parts := []string{}
@@ -316,6 +430,11 @@ func (c *Criteria) String() string {
 	if c.OS != CriteriaOSAny {
 		parts = append(parts, fmt.Sprintf("os=%s", c.OS))
 	}
+	for _, name := range dimensionNames(c) {
+		if value := c.Dimensions[name]; value != "" && value != criteriaAnyValue {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
 	if c.Nodes != 0 {
 		parts = append(parts, fmt.Sprintf("nodes=%d", c.Nodes))
 	}
@@ -376,6 +495,23 @@ func WithCriteriaOS(s string) CriteriaOption {
 	}
 }
 
+// WithCriteriaDimension sets the value of a criteria dimension declared in the
+// registry, by name or alias.
+func WithCriteriaDimension(name, value string) CriteriaOption {
+	return func(c *Criteria) error {
+		d := GetCriteriaDimension(name)
+		if d == nil {
+			return fmt.Errorf("unknown criteria dimension: %s", name)
+		}
+		v, err := d.Parse(value)
+		if err != nil {
+			return err
+		}
+		c.Set(d.Name, v)
+		return nil
+	}
+}
+
 // WithCriteriaNodes sets the number of nodes.
 func WithCriteriaNodes(n int) CriteriaOption {
 	return func(c *Criteria) error {
@@ -400,7 +536,8 @@ func BuildCriteria(opts ...CriteriaOption) (*Criteria, error) {
 
 // ParseCriteriaFromRequest parses recipe criteria from HTTP query parameters.
 // All parameters are optional and default to "any" if not specified.
-// Supported parameters: service, accelerator (alias: gpu), intent, os, nodes.
+// Supported parameters: the criteria dimensions declared in the registry by
+// name or alias (service, accelerator or gpu, intent, os, ...) and nodes.
 func ParseCriteriaFromRequest(r *http.Request) (*Criteria, error) {
 	if r == nil {
 		return nil, fmt.Errorf("request cannot be nil")
@@ -412,52 +549,31 @@ func ParseCriteriaFromRequest(r *http.Request) (*Criteria, error) {
 
 // ParseCriteriaFromValues parses recipe criteria from URL values.
 // All parameters are optional and default to "any" if not specified.
-// Supported parameters: service, accelerator (alias: gpu), intent, os, nodes.
+// Supported parameters: the criteria dimensions declared in the registry by
+// name or alias (service, accelerator or gpu, intent, os, ...) and nodes.
 func ParseCriteriaFromValues(values url.Values) (*Criteria, error) {
 	c := NewCriteria()
 
-	// Parse service
-	if s := values.Get("service"); s != "" {
-		st, err := ParseCriteriaServiceType(s)
-		if err != nil {
-			return nil, err
-		}
-		c.Service = st
-	}
-
-	// Parse accelerator (also accept "gpu" as alias for backwards compatibility)
-	accelParam := values.Get("accelerator")
-	if accelParam == "" {
-		accelParam = values.Get("gpu")
-	}
-	if accelParam != "" {
-		at, err := ParseCriteriaAcceleratorType(accelParam)
-		if err != nil {
-			return nil, err
+	// Parse declared dimensions, trying the name before the aliases
+	for _, d := range GetCriteriaDimensions() {
+		var param string
+		for _, name := range append([]string{d.Name}, d.Aliases...) {
+			if param = values.Get(name); param != "" {
+				break
+			}
 		}
-		c.Accelerator = at
-	}
-
-	// Parse intent
-	if s := values.Get("intent"); s != "" {
-		it, err := ParseCriteriaIntentType(s)
-		if err != nil {
-			return nil, err
+		if param == "" {
+			continue
 		}
-		c.Intent = it
-	}
-
-	// Parse OS
-	if s := values.Get("os"); s != "" {
-		ot, err := ParseCriteriaOSType(s)
+		v, err := d.Parse(param)
 		if err != nil {
 			return nil, err
 		}
-		c.OS = ot
+		c.Set(d.Name, v)
 	}
 
 	// Parse nodes count
-	if s := values.Get("nodes"); s != "" {
+	if s := values.Get(criteriaNodesField); s != "" {
 		var n int
 		if _, err := fmt.Sscanf(s, "%d", &n); err != nil {
 			return nil, fmt.Errorf("invalid nodes value: %s", s)
@@ -508,17 +624,11 @@ type RecipeCriteria struct {
 	Spec *Criteria `json:"spec" yaml:"spec"`
 }
 
-// rawCriteriaSpec is an intermediate struct for parsing criteria spec with string enum values.
-// This allows validation through Parse* functions before creating the typed Criteria.
-type rawCriteriaSpec struct {
-	Service     string `json:"service,omitempty" yaml:"service,omitempty"`
-	Accelerator string `json:"accelerator,omitempty" yaml:"accelerator,omitempty"`
-	Intent      string `json:"intent,omitempty" yaml:"intent,omitempty"`
-	OS          string `json:"os,omitempty" yaml:"os,omitempty"`
-	Nodes       int    `json:"nodes,omitempty" yaml:"nodes,omitempty"`
-}
+// rawCriteriaSpec is an intermediate map for parsing criteria spec with string values.
+// This allows validation against the declared dimensions before creating the Criteria.
+type rawCriteriaSpec map[string]any
 
-// rawRecipeCriteria is for parsing RecipeCriteria with string enum values in spec.
+// rawRecipeCriteria is for parsing RecipeCriteria with string values in spec.
 type rawRecipeCriteria struct {
 	Kind       string `json:"kind" yaml:"kind"`
 	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
@@ -528,50 +638,66 @@ type rawRecipeCriteria struct {
 	Spec rawCriteriaSpec `json:"spec" yaml:"spec"`
 }
 
-// validateAndConvertRawSpec validates raw string values and converts to typed Criteria.
-func validateAndConvertRawSpec(raw *rawCriteriaSpec) (*Criteria, error) {
+// validateAndConvertRawSpec validates raw values against the declared
+// dimensions and converts them to Criteria. Unknown fields are logged and
+// skipped; recipe lint reports them as errors.
+func validateAndConvertRawSpec(raw rawCriteriaSpec) (*Criteria, error) {
 	c := NewCriteria()
 
-	if raw.Service != "" {
-		st, err := ParseCriteriaServiceType(raw.Service)
-		if err != nil {
-			return nil, err
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		value := raw[name]
+		if name == criteriaNodesField {
+			n, ok := rawCriteriaNodes(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid nodes value: %v", value)
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("invalid nodes count: %d (must be >= 0)", n)
+			}
+			c.Nodes = n
+			continue
 		}
-		c.Service = st
-	}
 
-	if raw.Accelerator != "" {
-		at, err := ParseCriteriaAcceleratorType(raw.Accelerator)
-		if err != nil {
-			return nil, err
+		d := GetCriteriaDimension(name)
+		if d == nil {
+			slog.Warn("ignoring unknown criteria field", "field", name)
+			continue
 		}
-		c.Accelerator = at
-	}
-
-	if raw.Intent != "" {
-		it, err := ParseCriteriaIntentType(raw.Intent)
-		if err != nil {
-			return nil, err
+		if value == nil {
+			continue
 		}
-		c.Intent = it
-	}
-
-	if raw.OS != "" {
-		ot, err := ParseCriteriaOSType(raw.OS)
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s value: %v", name, value)
+		}
+		v, err := d.Parse(s)
 		if err != nil {
 			return nil, err
 		}
-		c.OS = ot
+		c.Set(d.Name, v)
 	}
 
-	if raw.Nodes < 0 {
-		return nil, fmt.Errorf("invalid nodes count: %d (must be >= 0)", raw.Nodes)
-	}
-	c.Nodes = raw.Nodes
-
 	return c, nil
 }
 
+// rawCriteriaNodes converts a decoded nodes value to an int. JSON decodes
+// numbers as float64 and YAML as int.
+func rawCriteriaNodes(value any) (int, bool) {
+	switch n := value.(type) {
+	case nil:
+		return 0, true
+	case int:
+		return n, true
+	case float64:
+		if n != float64(int(n)) {
+			return 0, false
+		}
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
 // LoadCriteriaFromFile loads criteria from a YAML or JSON file.
 // The file format is auto-detected from the file extension.
 // All fields are optional and default to "any" if not specified.
@@ -601,7 +727,7 @@ func LoadCriteriaFromFile(path string) (*Criteria, error) {
 		return nil, fmt.Errorf("invalid apiVersion %q, expected %q", raw.APIVersion, RecipeCriteriaAPIVersion)
 	}
 
-	return validateAndConvertRawSpec(&raw.Spec)
+	return validateAndConvertRawSpec(raw.Spec)
 }
 
 // ParseCriteriaFromBody parses criteria from an io.Reader (HTTP request body).
@@ -670,5 +796,5 @@ func ParseCriteriaFromBody(body io.Reader, contentType string) (*Criteria, error
 		return nil, fmt.Errorf("invalid apiVersion %q, expected %q", raw.APIVersion, RecipeCriteriaAPIVersion)
 	}
 
-	return validateAndConvertRawSpec(&raw.Spec)
+	return validateAndConvertRawSpec(raw.Spec)
 }
@@ -15,11 +15,14 @@
 package recipe
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestParseCriteriaServiceType(t *testing.T) {
@@ -849,6 +852,31 @@ spec:
   nodes: -5`,
 			wantErr: true,
 		},
+		{
+			name:     "non-numeric nodes count",
+			filename: "string_nodes.yaml",
+			content: `kind: recipeCriteria
+apiVersion: cns.nvidia.com/v1alpha1
+spec:
+  nodes: many`,
+			wantErr: true,
+		},
+		{
+			name:     "undeclared criteria dimension is skipped",
+			filename: "undeclared.yaml",
+			content: `kind: recipeCriteria
+apiVersion: cns.nvidia.com/v1alpha1
+spec:
+  service: eks
+  region: us-east`,
+			want: &Criteria{
+				Service:     CriteriaServiceEKS,
+				Accelerator: CriteriaAcceleratorAny,
+				Intent:      CriteriaIntentAny,
+				OS:          CriteriaOSAny,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1071,3 +1099,81 @@ func TestParseCriteriaFromBody_NilBody(t *testing.T) {
 func writeTestFile(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0o644)
 }
+
+func TestCriteria_Dimensions(t *testing.T) {
+	recipe := &Criteria{Service: CriteriaServiceEKS, Dimensions: map[string]string{"region": "us-east"}}
+
+	tests := []struct {
+		name  string
+		query *Criteria
+		want  bool
+	}{
+		{"same value", &Criteria{Service: CriteriaServiceEKS, Dimensions: map[string]string{"region": "us-east"}}, true},
+		{"other value", &Criteria{Service: CriteriaServiceEKS, Dimensions: map[string]string{"region": "eu-west"}}, false},
+		{"query any", &Criteria{Service: CriteriaServiceEKS, Dimensions: map[string]string{"region": "any"}}, false},
+		{"query unset", &Criteria{Service: CriteriaServiceEKS}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recipe.Matches(tt.query); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A recipe without the dimension is generic for it
+	generic := &Criteria{Service: CriteriaServiceEKS}
+	if !generic.Matches(tests[0].query) {
+		t.Error("recipe without region should match a query with region")
+	}
+
+	if got, want := recipe.Specificity(), generic.Specificity()+1; got != want {
+		t.Errorf("Specificity() = %d, want %d", got, want)
+	}
+	if got := recipe.String(); !strings.Contains(got, "region=us-east") {
+		t.Errorf("String() = %q, want region=us-east", got)
+	}
+}
+
+func TestCriteria_DimensionsSerialization(t *testing.T) {
+	c := &Criteria{Service: CriteriaServiceEKS, Nodes: 4, Dimensions: map[string]string{"region": "us-east"}}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got := string(data); got != `{"nodes":4,"region":"us-east","service":"eks"}` {
+		t.Errorf("json.Marshal() = %s", got)
+	}
+	var fromJSON Criteria
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if fromJSON.Service != CriteriaServiceEKS || fromJSON.Nodes != 4 || fromJSON.Get("region") != "us-east" {
+		t.Errorf("json round trip = %+v", fromJSON)
+	}
+
+	// Without dimensions the JSON output is unchanged
+	data, err = json.Marshal(&Criteria{Service: CriteriaServiceEKS, Accelerator: CriteriaAcceleratorH100})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if got := string(data); got != `{"service":"eks","accelerator":"h100"}` {
+		t.Errorf("json.Marshal() = %s", got)
+	}
+
+	data, err = yaml.Marshal(c)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), "region: us-east") {
+		t.Errorf("yaml.Marshal() = %s, want inline region", data)
+	}
+	var fromYAML Criteria
+	if err := yaml.Unmarshal(data, &fromYAML); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	if fromYAML.Service != CriteriaServiceEKS || fromYAML.Nodes != 4 || fromYAML.Get("region") != "us-east" {
+		t.Errorf("yaml round trip = %+v", fromYAML)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Names of the built-in criteria dimensions, which have typed Criteria fields.
+const (
+	CriteriaDimensionService     = "service"
+	CriteriaDimensionAccelerator = "accelerator"
+	CriteriaDimensionIntent      = "intent"
+	CriteriaDimensionOS          = "os"
+)
+
+// criteriaNodesField is the criteria field holding the node count. It is not a
+// declared dimension since its value is a number rather than an enumeration.
+const criteriaNodesField = "nodes"
+
+// CriteriaDimension declares a criteria dimension in the component registry.
+// The declaration drives criteria parsing, matching, allowlists, API query
+// parameters and CLI flags, so dimensions and values can be added without
+// code changes.
+//
+// Example:
+//
+//	criteria:
+//	  - name: accelerator
+//	    description: Accelerator/GPU type
+//	    aliases: [gpu]
+//	    values: [a100, gb200, h100, l40]
+type CriteriaDimension struct {
+	// Name is the criteria field name (e.g., "accelerator").
+	Name string `yaml:"name"`
+
+	// Description is the human-readable description used in CLI help.
+	Description string `yaml:"description,omitempty"`
+
+	// Aliases are alternative query parameter and CLI flag names (e.g., "gpu").
+	Aliases []string `yaml:"aliases,omitempty"`
+
+	// Values are the allowed values. "any" is always allowed.
+	Values []string `yaml:"values"`
+
+	// ValueAliases map alternative inputs to a value or to "any"
+	// (e.g., al2023: amazonlinux).
+	ValueAliases map[string]string `yaml:"valueAliases,omitempty"`
+}
+
+// isBuiltinCriteriaDimension reports whether name is a dimension with a typed
+// Criteria field.
+func isBuiltinCriteriaDimension(name string) bool {
+	switch name {
+	case CriteriaDimensionService, CriteriaDimensionAccelerator, CriteriaDimensionIntent, CriteriaDimensionOS:
+		return true
+	default:
+		return false
+	}
+}
+
+// Parse normalizes s to a value of the dimension. Empty input and "any" parse
+// to "any", value aliases resolve to their target, and anything else that is
+// not a declared value is an error.
+func (d *CriteriaDimension) Parse(s string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" || v == criteriaAnyValue {
+		return criteriaAnyValue, nil
+	}
+	if target, ok := d.ValueAliases[v]; ok {
+		return target, nil
+	}
+	if slices.Contains(d.Values, v) {
+		return v, nil
+	}
+	return criteriaAnyValue, fmt.Errorf("invalid %s type: %s", d.Name, s)
+}
+
+// SortedValues returns the allowed values sorted alphabetically.
+func (d *CriteriaDimension) SortedValues() []string {
+	values := slices.Clone(d.Values)
+	slices.Sort(values)
+	return values
+}
+
+// HasName reports whether name is the name or one of the aliases of the dimension.
+func (d *CriteriaDimension) HasName(name string) bool {
+	return d.Name == name || slices.Contains(d.Aliases, name)
+}
+
+// GetCriteriaDimension returns the dimension with the given name or alias.
+// Returns nil if no such dimension is declared.
+func (r *ComponentRegistry) GetCriteriaDimension(name string) *CriteriaDimension {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Criteria {
+		if r.Criteria[i].HasName(name) {
+			return &r.Criteria[i]
+		}
+	}
+	return nil
+}
+
+// GetCriteriaDimensions returns the criteria dimensions declared in the global
+// component registry, in declaration order.
+func GetCriteriaDimensions() []CriteriaDimension {
+	reg, err := GetComponentRegistry()
+	if err != nil {
+		slog.Warn("failed to load criteria dimensions", "error", err)
+		return nil
+	}
+	return reg.Criteria
+}
+
+// GetCriteriaDimension returns the dimension with the given name or alias
+// from the global component registry, or nil if it is not declared.
+func GetCriteriaDimension(name string) *CriteriaDimension {
+	reg, err := GetComponentRegistry()
+	if err != nil {
+		slog.Warn("failed to load criteria dimensions", "error", err)
+		return nil
+	}
+	return reg.GetCriteriaDimension(name)
+}
+
+// parseCriteriaDimension parses s as a value of the named dimension.
+// Only "any" parses when the dimension is not declared.
+func parseCriteriaDimension(name, s string) (string, error) {
+	d := GetCriteriaDimension(name)
+	if d == nil {
+		d = &CriteriaDimension{Name: name}
+	}
+	return d.Parse(s)
+}
+
+// criteriaDimensionValues returns the sorted values of the named dimension.
+func criteriaDimensionValues(name string) []string {
+	d := GetCriteriaDimension(name)
+	if d == nil {
+		return nil
+	}
+	return d.SortedValues()
+}
+
+// validateCriteriaDimensions checks criteria dimension declarations for errors.
+func validateCriteriaDimensions(dims []CriteriaDimension) []error {
+	var errs []error
+	names := make(map[string]string) // name or alias -> dimension name
+
+	for i, d := range dims {
+		if d.Name == "" {
+			errs = append(errs, fmt.Errorf("criteria[%d]: name is required", i))
+			continue
+		}
+		for _, name := range append([]string{d.Name}, d.Aliases...) {
+			if name == criteriaNodesField {
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): %q is reserved for the node count", i, d.Name, name))
+				continue
+			}
+			if existing, ok := names[name]; ok {
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): name or alias %q already used by %s", i, d.Name, name, existing))
+				continue
+			}
+			names[name] = d.Name
+		}
+
+		if len(d.Values) == 0 {
+			errs = append(errs, fmt.Errorf("criteria[%d] (%s): values are required", i, d.Name))
+		}
+		values := make(map[string]bool, len(d.Values))
+		for _, v := range d.Values {
+			switch {
+			case v == "" || v == criteriaAnyValue:
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): invalid value %q", i, d.Name, v))
+			case v != strings.ToLower(strings.TrimSpace(v)):
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): value %q must be lower case", i, d.Name, v))
+			case values[v]:
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): duplicate value %q", i, d.Name, v))
+			}
+			values[v] = true
+		}
+
+		for _, alias := range slices.Sorted(maps.Keys(d.ValueAliases)) {
+			target := d.ValueAliases[alias]
+			if alias != strings.ToLower(strings.TrimSpace(alias)) {
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): value alias %q must be lower case", i, d.Name, alias))
+			}
+			if values[alias] {
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): value alias %q shadows a value", i, d.Name, alias))
+			}
+			if target != criteriaAnyValue && !values[target] {
+				errs = append(errs, fmt.Errorf("criteria[%d] (%s): value alias %q targets undeclared value %q", i, d.Name, alias, target))
+			}
+		}
+	}
+
+	return errs
+}
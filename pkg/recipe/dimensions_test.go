@@ -0,0 +1,216 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testDimensionsRegistry extends the accelerator dimension and adds a region
+// dimension to the embedded registry.
+const testDimensionsRegistry = `apiVersion: cns.nvidia.com/v1alpha1
+kind: ComponentRegistry
+criteria:
+  - name: accelerator
+    description: Accelerator/GPU type
+    aliases: [gpu]
+    values: [a100, b200, gb200, h100, l40, l40s]
+  - name: region
+    description: Cloud region
+    values: [us-east, eu-west]
+    valueAliases:
+      us-east-1: us-east
+components: []
+`
+
+// useTestDimensions serves testDimensionsRegistry as an external data
+// directory for the duration of the test.
+func useTestDimensions(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "registry.yaml"), []byte(testDimensionsRegistry), 0600); err != nil {
+		t.Fatalf("failed to write registry.yaml: %v", err)
+	}
+	provider, err := NewLayeredDataProvider(NewEmbeddedDataProvider(dataFS, "data"), LayeredProviderConfig{ExternalDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create layered provider: %v", err)
+	}
+
+	original := globalDataProvider
+	SetDataProvider(provider)
+	t.Cleanup(func() { SetDataProvider(original) })
+}
+
+func TestCriteriaDimension_Parse(t *testing.T) {
+	d := &CriteriaDimension{
+		Name:         "os",
+		Values:       []string{"ubuntu", "amazonlinux"},
+		ValueAliases: map[string]string{"al2023": "amazonlinux", "linux": "any"},
+	}
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: "any"},
+		{input: "ANY", want: "any"},
+		{input: " Ubuntu ", want: "ubuntu"},
+		{input: "al2023", want: "amazonlinux"},
+		{input: "linux", want: "any"},
+		{input: "windows", want: "any", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := d.Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if err != nil && err.Error() != "invalid os type: windows" {
+				t.Errorf("Parse(%q) error = %q", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateCriteriaDimensions(t *testing.T) {
+	valid := CriteriaDimension{Name: "region", Values: []string{"us-east"}}
+
+	tests := []struct {
+		name    string
+		dims    []CriteriaDimension
+		wantErr string
+	}{
+		{name: "valid", dims: []CriteriaDimension{valid}},
+		{name: "missing name", dims: []CriteriaDimension{{Values: []string{"x"}}}, wantErr: "name is required"},
+		{name: "reserved name", dims: []CriteriaDimension{{Name: "nodes", Values: []string{"x"}}}, wantErr: "reserved"},
+		{
+			name:    "duplicate alias",
+			dims:    []CriteriaDimension{valid, {Name: "zone", Aliases: []string{"region"}, Values: []string{"a"}}},
+			wantErr: `alias "region" already used by region`,
+		},
+		{name: "no values", dims: []CriteriaDimension{{Name: "region"}}, wantErr: "values are required"},
+		{name: "any value", dims: []CriteriaDimension{{Name: "region", Values: []string{"any"}}}, wantErr: `invalid value "any"`},
+		{name: "upper case value", dims: []CriteriaDimension{{Name: "region", Values: []string{"US"}}}, wantErr: "lower case"},
+		{name: "duplicate value", dims: []CriteriaDimension{{Name: "region", Values: []string{"us", "us"}}}, wantErr: "duplicate value"},
+		{
+			name:    "alias to undeclared value",
+			dims:    []CriteriaDimension{{Name: "region", Values: []string{"us"}, ValueAliases: map[string]string{"usa": "na"}}},
+			wantErr: "undeclared value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateCriteriaDimensions(tt.dims)
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("errors = %v, want one containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeCriteriaDimensions(t *testing.T) {
+	embedded := []CriteriaDimension{
+		{Name: "service", Values: []string{"eks"}},
+		{Name: "accelerator", Values: []string{"h100"}},
+	}
+	external := []CriteriaDimension{
+		{Name: "region", Values: []string{"us-east"}},
+		{Name: "accelerator", Values: []string{"h100", "b200"}},
+	}
+
+	merged := mergeCriteriaDimensions(embedded, external)
+
+	var names []string
+	for _, d := range merged {
+		names = append(names, d.Name)
+	}
+	if !slices.Equal(names, []string{"service", "accelerator", "region"}) {
+		t.Errorf("merged dimensions = %v, want [service accelerator region]", names)
+	}
+	if !slices.Equal(merged[1].Values, []string{"h100", "b200"}) {
+		t.Errorf("accelerator values = %v, want external values", merged[1].Values)
+	}
+}
+
+func TestEmbeddedCriteriaDimensions(t *testing.T) {
+	reg, err := GetComponentRegistry()
+	if err != nil {
+		t.Fatalf("GetComponentRegistry() error = %v", err)
+	}
+	if errs := validateCriteriaDimensions(reg.Criteria); len(errs) > 0 {
+		t.Errorf("embedded criteria dimensions invalid: %v", errs)
+	}
+	for _, name := range []string{CriteriaDimensionService, CriteriaDimensionAccelerator, CriteriaDimensionIntent, CriteriaDimensionOS} {
+		if reg.GetCriteriaDimension(name) == nil {
+			t.Errorf("embedded registry does not declare %s", name)
+		}
+	}
+	if d := reg.GetCriteriaDimension("gpu"); d == nil || d.Name != CriteriaDimensionAccelerator {
+		t.Errorf("gpu alias resolves to %v, want accelerator", d)
+	}
+}
+
+func TestExternalCriteriaDimensions(t *testing.T) {
+	t.Run("declared values and dimensions", func(t *testing.T) {
+		useTestDimensions(t)
+
+		if at, err := ParseCriteriaAcceleratorType("L40S"); err != nil || at != "l40s" {
+			t.Errorf("ParseCriteriaAcceleratorType(L40S) = %q, %v", at, err)
+		}
+		if types := GetCriteriaAcceleratorTypes(); !slices.Contains(types, "b200") || !slices.IsSorted(types) {
+			t.Errorf("GetCriteriaAcceleratorTypes() = %v, want sorted with b200", types)
+		}
+
+		criteria, err := ParseCriteriaFromValues(url.Values{"gpu": {"b200"}, "region": {"us-east-1"}})
+		if err != nil {
+			t.Fatalf("ParseCriteriaFromValues() error = %v", err)
+		}
+		if criteria.Accelerator != "b200" || criteria.Get("region") != "us-east" {
+			t.Errorf("criteria = %s, want accelerator=b200, region=us-east", criteria)
+		}
+
+		if _, err := ParseCriteriaFromValues(url.Values{"region": {"mars"}}); err == nil ||
+			err.Error() != "invalid region type: mars" {
+			t.Errorf("ParseCriteriaFromValues(region=mars) error = %v", err)
+		}
+		if _, err := BuildCriteria(WithCriteriaDimension("zone", "a")); err == nil {
+			t.Error("expected error for undeclared dimension")
+		}
+	})
+
+	t.Run("reloaded after data provider change", func(t *testing.T) {
+		if _, err := ParseCriteriaAcceleratorType("l40s"); err == nil {
+			t.Error("l40s should not be declared by the embedded registry")
+		}
+		if GetCriteriaDimension("region") != nil {
+			t.Error("region should not be declared by the embedded registry")
+		}
+	})
+}
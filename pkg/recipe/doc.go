@@ -30,6 +30,7 @@
 //	    Intent      CriteriaIntentType      // training, inference, any
 //	    OS          CriteriaOSType          // ubuntu, cos, rhel, any
 //	    Nodes       int                     // node count (0 = any)
+//	    Dimensions  map[string]string       // other declared dimensions (e.g. region)
 //	}
 //
 // RecipeResult: Generated configuration result
@@ -77,6 +78,23 @@
 //   - CriteriaIntentInference: Inference workloads
 //   - CriteriaIntentAny: Generic workloads
 //
+// The constants above are the values of the embedded registry. Criteria
+// dimensions and their values are declared in the criteria section of
+// registry.yaml, which drives parsing, matching, allowlists, query parameters
+// and CLI flags. An external data directory can redeclare a dimension with
+// more values (e.g. an l40s accelerator) or declare a new one (e.g. region),
+// whose values are held in Criteria.Dimensions:
+//
+//	criteria:
+//	  - name: region
+//	    description: Cloud region
+//	    values: [us-east, eu-west]
+//	    valueAliases:
+//	      us-east-1: us-east
+//
+// GetCriteriaDimensions returns the declarations and WithCriteriaDimension
+// sets any declared dimension by name or alias.
+//
 // # Usage
 //
 // Basic recipe generation with criteria:
@@ -102,6 +120,11 @@
 //
 //	builder := recipe.NewBuilder()
 //	http.HandleFunc("/v1/recipe", builder.HandleRecipes)
+//	http.HandleFunc("/v1/recipe/matrix", builder.HandleMatrix)
+//
+// BuildCoverageMatrix reports the overlays and components of every service,
+// accelerator, intent and OS combination, flagging combinations without a
+// criteria-specific overlay as base only.
 //
 // Parse criteria from HTTP request:
 //
@@ -120,6 +143,35 @@
 //   - intent: training, inference, any (default: any)
 //   - os: ubuntu, cos, rhel, any (default: any)
 //   - nodes: integer node count (default: 0 = any)
+//   - any other declared criteria dimension by name (default: any)
+//   - explain: true to include the overlay provenance (default: false)
+//
+// # Explain Mode
+//
+// A Builder created with WithExplain(true) attaches a RecipeExplanation to the
+// result. It lists every overlay that was considered with its file, status
+// (applied or rejected) and reason, and records the overlay and file that last
+// set each constraint, component ref field and merged values key:
+//
+//	builder := recipe.NewBuilder(recipe.WithExplain(true))
+//	result, _ := builder.BuildFromCriteria(ctx, criteria)
+//	for _, o := range result.Explanation.Overlays {
+//	    fmt.Printf("%s %s: %s\n", o.Name, o.Status, o.Reason)
+//	}
+//
+// # Linting
+//
+// Lint checks a data set without building recipes and returns a LintReport of
+// diagnostics positioned by file, line and column. Constraint names and
+// expressions are checked with the parsers passed as options, as the validator
+// package depends on this one:
+//
+//	report, _ := recipe.Lint(recipe.GetDataProvider(),
+//	    recipe.WithConstraintPathParser(checkPath),
+//	    recipe.WithConstraintExpressionParser(checkExpression))
+//	for _, d := range report.Diagnostics {
+//	    fmt.Println(d)
+//	}
 //
 // # Criteria Files (CLI and HTTP API - POST)
 //
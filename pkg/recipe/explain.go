@@ -0,0 +1,358 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cnserrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// OverlayStatus describes whether an overlay contributed to a recipe result.
+type OverlayStatus string
+
+const (
+	// OverlayApplied indicates the overlay was merged into the result.
+	OverlayApplied OverlayStatus = "applied"
+
+	// OverlayRejected indicates the overlay was considered but not merged.
+	OverlayRejected OverlayStatus = "rejected"
+)
+
+// registrySource is the overlay name reported for fields filled in from
+// component registry defaults.
+const registrySource = "registry"
+
+// RecipeExplanation describes where every part of a recipe result came from.
+// It is attached to the result when explain mode is requested.
+type RecipeExplanation struct {
+	// Overlays lists the applied overlays in merge order, followed by the
+	// rejected overlays sorted by name.
+	Overlays []OverlayDecision `json:"overlays" yaml:"overlays"`
+
+	// Constraints maps each constraint name to the overlay that last set it.
+	Constraints map[string]FieldSource `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+
+	// Components maps each component name to the provenance of its fields and values.
+	Components map[string]*ComponentExplanation `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// OverlayDecision records whether an overlay was applied and why.
+type OverlayDecision struct {
+	// Name is the overlay name (metadata.name).
+	Name string `json:"name" yaml:"name"`
+
+	// File is the data file the overlay was loaded from.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+
+	// Status is either "applied" or "rejected".
+	Status OverlayStatus `json:"status" yaml:"status"`
+
+	// Reason explains the decision (e.g., criteria mismatch, failed constraints).
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// FieldSource identifies the overlay and file that last set a value.
+type FieldSource struct {
+	// Overlay is the name of the overlay that set the value, "registry" for
+	// component registry defaults, or empty for implicit component values.
+	Overlay string `json:"overlay,omitempty" yaml:"overlay,omitempty"`
+
+	// File is the data file containing the value.
+	File string `json:"file" yaml:"file"`
+}
+
+// ComponentExplanation describes the provenance of a single component.
+type ComponentExplanation struct {
+	// Fields maps component ref fields (e.g., "version", "overrides.driver")
+	// to the overlay that last set them.
+	Fields map[string]FieldSource `json:"fields" yaml:"fields"`
+
+	// Values maps merged values keys in dot notation (e.g., "driver.version")
+	// to the values file or overlay override that last set them.
+	Values map[string]FieldSource `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// Explain builds the provenance of a recipe result produced by this store.
+// It replays the applied overlays in order using the same precedence rules as
+// RecipeMetadataSpec.Merge and GetValuesForComponent, and lists every other
+// overlay with the reason it was rejected.
+func (s *MetadataStore) Explain(result *RecipeResult) (*RecipeExplanation, error) {
+	if result == nil {
+		return nil, cnserrors.New(cnserrors.ErrCodeInvalidRequest, "recipe result cannot be nil")
+	}
+
+	exp := &RecipeExplanation{
+		Overlays:    make([]OverlayDecision, 0, len(s.Overlays)+1),
+		Constraints: make(map[string]FieldSource),
+		Components:  make(map[string]*ComponentExplanation),
+	}
+
+	// Component ref fields and override keys, replayed in merge order
+	applied := make(map[string]bool, len(result.Metadata.AppliedOverlays))
+	for _, name := range result.Metadata.AppliedOverlays {
+		recipe, ok := s.GetRecipeByName(name)
+		if !ok {
+			return nil, cnserrors.NewWithContext(cnserrors.ErrCodeNotFound,
+				"applied overlay not found", map[string]any{"overlay": name})
+		}
+		applied[name] = true
+
+		exp.Overlays = append(exp.Overlays, OverlayDecision{
+			Name:   name,
+			File:   s.RecipeFiles[name],
+			Status: OverlayApplied,
+			Reason: s.appliedReason(name, recipe, result),
+		})
+
+		src := FieldSource{Overlay: name, File: s.RecipeFiles[name]}
+		for _, c := range recipe.Spec.Constraints {
+			exp.Constraints[c.Name] = src
+		}
+		for _, ref := range recipe.Spec.ComponentRefs {
+			ce, exists := exp.Components[ref.Name]
+			if !exists {
+				ce = &ComponentExplanation{Fields: make(map[string]FieldSource)}
+				exp.Components[ref.Name] = ce
+			}
+			for _, field := range setComponentFields(ref) {
+				ce.Fields[field] = src
+			}
+		}
+	}
+
+	// Fields filled in by registry defaults after the merge
+	registrySrc := FieldSource{Overlay: registrySource, File: registryFileName}
+	for _, ref := range result.ComponentRefs {
+		ce, exists := exp.Components[ref.Name]
+		if !exists {
+			ce = &ComponentExplanation{Fields: make(map[string]FieldSource)}
+			exp.Components[ref.Name] = ce
+		}
+		for _, field := range setComponentFields(ref) {
+			if _, tracked := ce.Fields[field]; !tracked {
+				ce.Fields[field] = registrySrc
+			}
+		}
+
+		values, err := explainValues(ref, ce.Fields)
+		if err != nil {
+			return nil, err
+		}
+		ce.Values = values
+	}
+
+	// Overlays that were considered but not merged
+	rejected := make([]string, 0, len(s.Overlays))
+	for name := range s.Overlays {
+		if !applied[name] {
+			rejected = append(rejected, name)
+		}
+	}
+	sort.Strings(rejected)
+	for _, name := range rejected {
+		exp.Overlays = append(exp.Overlays, OverlayDecision{
+			Name:   name,
+			File:   s.RecipeFiles[name],
+			Status: OverlayRejected,
+			Reason: rejectedReason(s.Overlays[name], result),
+		})
+	}
+
+	return exp, nil
+}
+
+// appliedReason explains why an applied recipe is part of the result.
+func (s *MetadataStore) appliedReason(name string, recipe *RecipeMetadata, result *RecipeResult) string {
+	if name == "base" {
+		return "base recipe"
+	}
+	if recipe.Spec.Criteria != nil && recipe.Spec.Criteria.Matches(result.Criteria) {
+		return "criteria matched"
+	}
+	for _, other := range result.Metadata.AppliedOverlays {
+		if o, ok := s.Overlays[other]; ok && o.Spec.Base == name {
+			return fmt.Sprintf("inherited by %s", other)
+		}
+	}
+	return "inherited"
+}
+
+// rejectedReason explains why an overlay is not part of the result.
+func rejectedReason(overlay *RecipeMetadata, result *RecipeResult) string {
+	name := overlay.Metadata.Name
+
+	var failures []string
+	for _, w := range result.Metadata.ConstraintWarnings {
+		if w.Overlay == name {
+			failures = append(failures, fmt.Sprintf("%s: %s", w.Constraint, w.Reason))
+		}
+	}
+	if len(failures) > 0 {
+		return "constraints failed: " + strings.Join(failures, "; ")
+	}
+
+	if overlay.Spec.Criteria == nil {
+		return "no criteria and not inherited by an applied overlay"
+	}
+	if mismatches := criteriaMismatches(overlay.Spec.Criteria, result.Criteria); len(mismatches) > 0 {
+		return "criteria mismatch: " + strings.Join(mismatches, ", ")
+	}
+	return "not applied"
+}
+
+// criteriaMismatches lists the criteria fields for which the recipe does not
+// match the query, using the asymmetric rules of Criteria.Matches.
+func criteriaMismatches(recipe, query *Criteria) []string {
+	if query == nil {
+		return nil
+	}
+
+	var out []string
+	check := func(field, recipeValue, queryValue string) {
+		if !matchesCriteriaField(recipeValue, queryValue) {
+			out = append(out, fmt.Sprintf("%s=%s (requested %s)", field, recipeValue, orAny(queryValue)))
+		}
+	}
+	check("service", string(recipe.Service), string(query.Service))
+	check("accelerator", string(recipe.Accelerator), string(query.Accelerator))
+	check("intent", string(recipe.Intent), string(query.Intent))
+	check("os", string(recipe.OS), string(query.OS))
+	for _, name := range dimensionNames(recipe, query) {
+		check(name, recipe.Dimensions[name], query.Dimensions[name])
+	}
+
+	if recipe.Nodes != 0 && recipe.Nodes != query.Nodes {
+		requested := "any"
+		if query.Nodes != 0 {
+			requested = fmt.Sprintf("%d", query.Nodes)
+		}
+		out = append(out, fmt.Sprintf("nodes=%d (requested %s)", recipe.Nodes, requested))
+	}
+
+	return out
+}
+
+// orAny returns "any" for empty criteria values.
+func orAny(v string) string {
+	if v == "" {
+		return criteriaAnyValue
+	}
+	return v
+}
+
+// setComponentFields returns the names of the non-empty fields of a component
+// ref, i.e. the fields an overlay takes precedence for in mergeComponentRef.
+// Override keys are reported individually as "overrides.<key>".
+func setComponentFields(ref ComponentRef) []string {
+	var fields []string
+	add := func(name string, set bool) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add("type", ref.Type != "")
+	add("source", ref.Source != "")
+	add("version", ref.Version != "")
+	add("tag", ref.Tag != "")
+	add("valuesFile", ref.ValuesFile != "")
+	add("path", ref.Path != "")
+	add("patches", len(ref.Patches) > 0)
+	add("dependencyRefs", len(ref.DependencyRefs) > 0)
+	add("manifestFiles", len(ref.ManifestFiles) > 0)
+	for key := range ref.Overrides {
+		fields = append(fields, "overrides."+key)
+	}
+	return fields
+}
+
+// explainValues attributes every merged values key of a component to its
+// source, following the merge order of GetValuesForComponent:
+// base values → ValuesFile → Overrides.
+func explainValues(ref ComponentRef, fields map[string]FieldSource) (map[string]FieldSource, error) {
+	merged := make(map[string]any)
+	sources := make(map[string]FieldSource)
+
+	apply := func(values map[string]any, src func(path string) FieldSource) {
+		mergeValues(merged, values)
+		for _, path := range leafPaths(values, "") {
+			sources[path] = src(path)
+		}
+	}
+
+	if ref.ValuesFile != "" {
+		provider := GetDataProvider()
+
+		baseValuesFile := fmt.Sprintf("components/%s/values.yaml", ref.Name)
+		if ref.ValuesFile != baseValuesFile {
+			// The base values file is optional when an overlay values file is used
+			if data, err := provider.ReadFile(baseValuesFile); err == nil {
+				var base map[string]any
+				if err := yaml.Unmarshal(data, &base); err != nil {
+					return nil, cnserrors.WrapWithContext(cnserrors.ErrCodeInternal,
+						"failed to parse base values file", err, map[string]any{"file": baseValuesFile})
+				}
+				apply(base, func(string) FieldSource { return FieldSource{File: baseValuesFile} })
+			}
+		}
+
+		data, err := provider.ReadFile(ref.ValuesFile)
+		if err != nil {
+			return nil, cnserrors.WrapWithContext(cnserrors.ErrCodeNotFound,
+				"failed to read values file", err, map[string]any{"file": ref.ValuesFile})
+		}
+		var values map[string]any
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return nil, cnserrors.WrapWithContext(cnserrors.ErrCodeInternal,
+				"failed to parse values file", err, map[string]any{"file": ref.ValuesFile})
+		}
+		valuesSrc := FieldSource{Overlay: fields["valuesFile"].Overlay, File: ref.ValuesFile}
+		apply(values, func(string) FieldSource { return valuesSrc })
+	}
+
+	if len(ref.Overrides) > 0 {
+		apply(ref.Overrides, func(path string) FieldSource {
+			return fields["overrides."+strings.SplitN(path, ".", 2)[0]]
+		})
+	}
+
+	// Keep only keys that are still leaves after the merge; a later layer may
+	// have replaced a map with a scalar or the other way around
+	out := make(map[string]FieldSource)
+	for _, path := range leafPaths(merged, "") {
+		out[path] = sources[path]
+	}
+	return out, nil
+}
+
+// leafPaths returns the dot-separated paths of all non-map values.
+func leafPaths(values map[string]any, prefix string) []string {
+	var paths []string
+	for key, v := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			paths = append(paths, leafPaths(nested, path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
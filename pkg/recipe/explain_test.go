@@ -0,0 +1,295 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func eksTrainingCriteria() *Criteria {
+	c := NewCriteria()
+	c.Service = CriteriaServiceEKS
+	c.Accelerator = CriteriaAcceleratorH100
+	c.Intent = CriteriaIntentTraining
+	return c
+}
+
+func findDecision(exp *RecipeExplanation, name string) *OverlayDecision {
+	for i := range exp.Overlays {
+		if exp.Overlays[i].Name == name {
+			return &exp.Overlays[i]
+		}
+	}
+	return nil
+}
+
+func TestBuilder_Explain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result, err := NewBuilder().BuildFromCriteria(ctx, eksTrainingCriteria())
+		if err != nil {
+			t.Fatalf("BuildFromCriteria() error = %v", err)
+		}
+		if result.Explanation != nil {
+			t.Error("expected no explanation without explain mode")
+		}
+	})
+
+	result, err := NewBuilder(WithExplain(true)).BuildFromCriteria(ctx, eksTrainingCriteria())
+	if err != nil {
+		t.Fatalf("BuildFromCriteria() error = %v", err)
+	}
+	exp := result.Explanation
+	if exp == nil {
+		t.Fatal("expected explanation in explain mode")
+	}
+
+	t.Run("applied overlays in merge order", func(t *testing.T) {
+		if len(exp.Overlays) < len(result.Metadata.AppliedOverlays) {
+			t.Fatalf("got %d overlay decisions, want at least %d", len(exp.Overlays), len(result.Metadata.AppliedOverlays))
+		}
+		for i, name := range result.Metadata.AppliedOverlays {
+			d := exp.Overlays[i]
+			if d.Name != name || d.Status != OverlayApplied {
+				t.Errorf("overlay[%d] = %s/%s, want %s/applied", i, d.Name, d.Status, name)
+			}
+		}
+		if d := findDecision(exp, "eks"); d == nil || d.File != "overlays/eks.yaml" {
+			t.Errorf("eks decision = %+v, want file overlays/eks.yaml", d)
+		}
+	})
+
+	t.Run("rejected overlays", func(t *testing.T) {
+		d := findDecision(exp, "gke-cos")
+		if d == nil {
+			t.Fatal("expected gke-cos to be listed")
+		}
+		if d.Status != OverlayRejected {
+			t.Errorf("gke-cos status = %s, want rejected", d.Status)
+		}
+		if !strings.Contains(d.Reason, "service=gke (requested eks)") {
+			t.Errorf("gke-cos reason = %q, want service mismatch", d.Reason)
+		}
+		if d := findDecision(exp, ""); d != nil {
+			t.Errorf("unexpected unnamed overlay %+v", d)
+		}
+	})
+
+	t.Run("constraints", func(t *testing.T) {
+		for _, c := range result.Constraints {
+			src, ok := exp.Constraints[c.Name]
+			if !ok {
+				t.Errorf("constraint %s has no source", c.Name)
+				continue
+			}
+			if src.Overlay == "" || src.File == "" {
+				t.Errorf("constraint %s source = %+v, want overlay and file", c.Name, src)
+			}
+		}
+	})
+
+	t.Run("component fields and values", func(t *testing.T) {
+		ref := result.GetComponentRef("gpu-operator")
+		if ref == nil {
+			t.Fatal("expected gpu-operator in result")
+		}
+		ce := exp.Components["gpu-operator"]
+		if ce == nil {
+			t.Fatal("expected gpu-operator explanation")
+		}
+		for _, field := range setComponentFields(*ref) {
+			if _, ok := ce.Fields[field]; !ok {
+				t.Errorf("field %s has no source", field)
+			}
+		}
+
+		values, err := result.GetValuesForComponent("gpu-operator")
+		if err != nil {
+			t.Fatalf("GetValuesForComponent() error = %v", err)
+		}
+		paths := leafPaths(values, "")
+		if len(ce.Values) != len(paths) {
+			t.Errorf("got %d explained values, want %d", len(ce.Values), len(paths))
+		}
+		for _, path := range paths {
+			if src, ok := ce.Values[path]; !ok || src.File == "" {
+				t.Errorf("values key %s source = %+v", path, src)
+			}
+		}
+	})
+}
+
+func TestHandleRecipes_Explain(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantExplain bool
+	}{
+		{name: "omitted", query: "service=eks", wantStatus: http.StatusOK},
+		{name: "true", query: "service=eks&explain=true", wantStatus: http.StatusOK, wantExplain: true},
+		{name: "false", query: "service=eks&explain=false", wantStatus: http.StatusOK},
+		{name: "invalid", query: "service=eks&explain=maybe", wantStatus: http.StatusBadRequest},
+	}
+
+	builder := NewBuilder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/recipe?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			builder.HandleRecipes(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var result RecipeResult
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if (result.Explanation != nil) != tt.wantExplain {
+				t.Errorf("explanation present = %v, want %v", result.Explanation != nil, tt.wantExplain)
+			}
+		})
+	}
+
+	if builder.Explain {
+		t.Error("explain query parameter must not change the shared builder")
+	}
+}
+
+func TestBuilder_Explain_ConstraintFailures(t *testing.T) {
+	evaluator := func(c Constraint) ConstraintEvalResult {
+		return ConstraintEvalResult{Passed: false, Actual: "0"}
+	}
+
+	result, err := NewBuilder(WithExplain(true)).BuildFromCriteriaWithEvaluator(
+		context.Background(), eksTrainingCriteria(), evaluator)
+	if err != nil {
+		t.Fatalf("BuildFromCriteriaWithEvaluator() error = %v", err)
+	}
+	if len(result.Metadata.ExcludedOverlays) == 0 {
+		t.Fatal("expected overlays excluded by constraints")
+	}
+
+	for _, name := range result.Metadata.ExcludedOverlays {
+		d := findDecision(result.Explanation, name)
+		if d == nil {
+			t.Errorf("excluded overlay %s not listed", name)
+			continue
+		}
+		if d.Status != OverlayRejected || !strings.HasPrefix(d.Reason, "constraints failed: ") {
+			t.Errorf("overlay %s = %s %q, want rejected with constraint failures", name, d.Status, d.Reason)
+		}
+	}
+}
+
+func TestExplainValues_Overrides(t *testing.T) {
+	ref := ComponentRef{
+		Name: "custom",
+		Overrides: map[string]any{
+			"driver": map[string]any{"version": "1.0", "enabled": true},
+			"image":  "nvcr.io/custom",
+		},
+	}
+	fields := map[string]FieldSource{
+		"overrides.driver": {Overlay: "a", File: "overlays/a.yaml"},
+		"overrides.image":  {Overlay: "b", File: "overlays/b.yaml"},
+	}
+
+	got, err := explainValues(ref, fields)
+	if err != nil {
+		t.Fatalf("explainValues() error = %v", err)
+	}
+
+	want := map[string]string{
+		"driver.enabled": "a",
+		"driver.version": "a",
+		"image":          "b",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d keys, want %d: %v", len(got), len(want), got)
+	}
+	for path, overlay := range want {
+		if got[path].Overlay != overlay {
+			t.Errorf("%s overlay = %q, want %q", path, got[path].Overlay, overlay)
+		}
+	}
+}
+
+func TestLeafPaths(t *testing.T) {
+	values := map[string]any{
+		"a": map[string]any{
+			"b": 1,
+			"c": map[string]any{"d": "x"},
+		},
+		"e":     []any{1, 2},
+		"empty": map[string]any{},
+	}
+
+	got := leafPaths(values, "")
+	sort.Strings(got)
+	want := []string{"a.b", "a.c.d", "e", "empty"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("leafPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestCriteriaMismatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		recipe *Criteria
+		query  *Criteria
+		want   []string
+	}{
+		{
+			name:   "match",
+			recipe: &Criteria{Service: CriteriaServiceEKS},
+			query:  &Criteria{Service: CriteriaServiceEKS, Accelerator: CriteriaAcceleratorH100},
+			want:   nil,
+		},
+		{
+			name:   "different service",
+			recipe: &Criteria{Service: CriteriaServiceGKE},
+			query:  &Criteria{Service: CriteriaServiceEKS},
+			want:   []string{"service=gke (requested eks)"},
+		},
+		{
+			name:   "specific recipe for generic query",
+			recipe: &Criteria{OS: CriteriaOSUbuntu, Nodes: 8},
+			query:  NewCriteria(),
+			want:   []string{"os=ubuntu (requested any)", "nodes=8 (requested any)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := criteriaMismatches(tt.recipe, tt.query)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("criteriaMismatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
 	cnserrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
@@ -38,6 +39,7 @@ var (
 // HandleRecipes processes recipe requests using the criteria-based system.
 // It supports GET requests with query parameters and POST requests with JSON/YAML body
 // to specify recipe criteria.
+// The explain=true query parameter adds the overlay provenance of every field to the result.
 // The response returns a RecipeResult with component references and constraints.
 // Errors are handled and returned in a structured format.
 func (b *Builder) HandleRecipes(w http.ResponseWriter, r *http.Request) {
@@ -98,7 +100,23 @@ func (b *Builder) HandleRecipes(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	result, err := b.BuildFromCriteria(ctx, criteria)
+	// Explain mode is requested per call, so build with a copy of the builder
+	builder := b
+	if explain := r.URL.Query().Get("explain"); explain != "" {
+		enabled, parseErr := strconv.ParseBool(explain)
+		if parseErr != nil {
+			server.WriteError(w, r, http.StatusBadRequest, cnserrors.ErrCodeInvalidRequest,
+				"Invalid explain parameter", false, map[string]any{
+					"explain": explain,
+				})
+			return
+		}
+		eb := *b
+		eb.Explain = enabled
+		builder = &eb
+	}
+
+	result, err := builder.BuildFromCriteria(ctx, criteria)
 	if err != nil {
 		server.WriteErrorFromErr(w, r, err, "Failed to build recipe", nil)
 		return
@@ -109,3 +127,52 @@ func (b *Builder) HandleRecipes(w http.ResponseWriter, r *http.Request) {
 
 	serializer.RespondJSON(w, http.StatusOK, result)
 }
+
+// HandleMatrix serves the recipe coverage matrix for GET requests: the
+// overlays and components of every criteria combination allowed by the
+// builder's allowlists. The format query parameter selects json (default)
+// or markdown.
+func (b *Builder) HandleMatrix(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		server.WriteError(w, r, http.StatusMethodNotAllowed, cnserrors.ErrCodeMethodNotAllowed,
+			"Method not allowed", false, map[string]any{
+				"method":  r.Method,
+				"allowed": []string{http.MethodGet},
+			})
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "markdown" {
+		server.WriteError(w, r, http.StatusBadRequest, cnserrors.ErrCodeInvalidRequest,
+			"Invalid format parameter", false, map[string]any{
+				"format":  format,
+				"allowed": []string{"json", "markdown"},
+			})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), defaults.RecipeHandlerTimeout)
+	defer cancel()
+
+	matrix, err := b.BuildCoverageMatrix(ctx)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to build coverage matrix", nil)
+		return
+	}
+
+	// Set caching headers
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(recipeCacheTTL.Seconds())))
+
+	if format == "markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if err := matrix.WriteMarkdown(w); err != nil {
+			slog.Warn("failed to write coverage matrix", "error", err)
+		}
+		return
+	}
+
+	serializer.RespondJSON(w, http.StatusOK, matrix)
+}
@@ -0,0 +1,688 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintSeverity classifies a lint diagnostic.
+type LintSeverity string
+
+const (
+	// LintSeverityError marks a problem that breaks or silently changes recipe generation.
+	LintSeverityError LintSeverity = "error"
+
+	// LintSeverityWarning marks a suspicious but usable definition.
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Lint rules identify the check that produced a diagnostic.
+const (
+	// LintRuleParse reports files that are not valid recipe metadata.
+	LintRuleParse = "parse"
+
+	// LintRuleRegistry reports component registry validation failures.
+	LintRuleRegistry = "registry"
+
+	// LintRuleDuplicateName reports recipes sharing a metadata.name.
+	LintRuleDuplicateName = "duplicate-name"
+
+	// LintRuleBase reports spec.base references that do not resolve or form a cycle.
+	LintRuleBase = "base"
+
+	// LintRuleCriteria reports unknown or non-canonical criteria values.
+	LintRuleCriteria = "criteria"
+
+	// LintRuleMissingFile reports valuesFile and manifestFiles paths not found in the data set.
+	LintRuleMissingFile = "missing-file"
+
+	// LintRuleConstraint reports constraint names or expressions that do not parse.
+	LintRuleConstraint = "constraint"
+
+	// LintRuleDependency reports dependencyRefs that are unknown or circular.
+	LintRuleDependency = "dependency"
+
+	// LintRuleConflict reports overlays with identical criteria defining the
+	// same component or constraint differently.
+	LintRuleConflict = "conflict"
+)
+
+// LintDiagnostic describes a single problem found in a recipe data set.
+type LintDiagnostic struct {
+	// File is the path of the offending file relative to the data directory.
+	File string `json:"file" yaml:"file"`
+
+	// Line is the 1-based line of the offending node (0 when unknown).
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+
+	// Column is the 1-based column of the offending node (0 when unknown).
+	Column int `json:"column,omitempty" yaml:"column,omitempty"`
+
+	// Severity is error or warning.
+	Severity LintSeverity `json:"severity" yaml:"severity"`
+
+	// Rule is the check that produced the diagnostic.
+	Rule string `json:"rule" yaml:"rule"`
+
+	// Message explains the problem.
+	Message string `json:"message" yaml:"message"`
+}
+
+// Position formats the location of the diagnostic as file[:line[:column]].
+func (d LintDiagnostic) Position() string {
+	pos := d.File
+	if d.Line > 0 {
+		pos += ":" + strconv.Itoa(d.Line)
+		if d.Column > 0 {
+			pos += ":" + strconv.Itoa(d.Column)
+		}
+	}
+	return pos
+}
+
+// String formats the diagnostic as file:line:column: severity: message [rule].
+func (d LintDiagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", d.Position(), d.Severity, d.Message, d.Rule)
+}
+
+// LintReport is the result of linting a recipe data set.
+type LintReport struct {
+	// FilesChecked is the number of recipe metadata files linted.
+	FilesChecked int `json:"filesChecked" yaml:"filesChecked"`
+
+	// Errors is the number of error diagnostics.
+	Errors int `json:"errors" yaml:"errors"`
+
+	// Warnings is the number of warning diagnostics.
+	Warnings int `json:"warnings" yaml:"warnings"`
+
+	// Diagnostics lists the problems found, ordered by file and position.
+	Diagnostics []LintDiagnostic `json:"diagnostics" yaml:"diagnostics"`
+}
+
+// HasErrors reports whether the report contains error diagnostics.
+func (r *LintReport) HasErrors() bool {
+	return r.Errors > 0
+}
+
+// WriteTable renders the report one diagnostic per line, implementing
+// serializer.TableWriter.
+func (r *LintReport) WriteTable(w io.Writer) error {
+	if len(r.Diagnostics) == 0 {
+		_, err := fmt.Fprintf(w, "recipe data valid: %d files checked\n", r.FilesChecked)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POSITION\tSEVERITY\tRULE\tMESSAGE")
+	fmt.Fprintln(tw, "--------\t--------\t----\t-------")
+	for _, d := range r.Diagnostics {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Position(), d.Severity, d.Rule, d.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d error(s), %d warning(s) in %d files\n", r.Errors, r.Warnings, r.FilesChecked)
+	return err
+}
+
+// LintOption configures Lint.
+type LintOption func(*linter)
+
+// WithConstraintPathParser checks constraint names with fn, typically
+// validator.ParseConstraintPath (which cannot be imported here).
+func WithConstraintPathParser(fn func(path string) error) LintOption {
+	return func(l *linter) {
+		l.parsePath = fn
+	}
+}
+
+// WithConstraintExpressionParser checks constraint values with fn, typically
+// validator.ParseConstraintExpression.
+func WithConstraintExpressionParser(fn func(expr string) error) LintOption {
+	return func(l *linter) {
+		l.parseExpression = fn
+	}
+}
+
+// lintRecipe is a recipe metadata file loaded for linting.
+type lintRecipe struct {
+	file string
+	root *yaml.Node
+	meta RecipeMetadata
+}
+
+// name returns the recipe name used by spec.base references.
+func (r *lintRecipe) name() string {
+	if isBaseRecipeFile(r.file) {
+		return "base"
+	}
+	return r.meta.Metadata.Name
+}
+
+// linter holds the state of a single Lint run.
+type linter struct {
+	provider        DataProvider
+	parsePath       func(string) error
+	parseExpression func(string) error
+
+	registry *ComponentRegistry
+	recipes  []*lintRecipe
+	byName   map[string]*lintRecipe
+	report   *LintReport
+}
+
+// Lint checks the recipe data set served by provider without building recipes.
+// It reports:
+//   - files that do not parse as recipe metadata, including unknown fields
+//   - component registry validation errors
+//   - duplicate recipe names
+//   - spec.base references that do not resolve or form a cycle
+//   - criteria dimensions not declared in the registry, and unknown or
+//     non-canonical criteria values
+//   - valuesFile and manifestFiles paths missing from the data set
+//   - constraint names and expressions rejected by the configured parsers
+//   - unknown or circular dependencyRefs along each inheritance chain
+//   - overlays with identical criteria defining a component or constraint differently
+//
+// An error is returned only when the data set cannot be read.
+func Lint(provider DataProvider, opts ...LintOption) (*LintReport, error) {
+	l := &linter{
+		provider: provider,
+		byName:   make(map[string]*lintRecipe),
+		report:   &LintReport{Diagnostics: []LintDiagnostic{}},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+
+	l.lintRegistry()
+	for _, r := range l.recipes {
+		l.lintBase(r)
+		l.lintCriteria(r)
+		l.lintFiles(r)
+		l.lintConstraints(r)
+	}
+	l.lintDependencies()
+	l.lintConflicts()
+
+	sort.SliceStable(l.report.Diagnostics, func(i, j int) bool {
+		a, b := l.report.Diagnostics[i], l.report.Diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	for _, d := range l.report.Diagnostics {
+		if d.Severity == LintSeverityError {
+			l.report.Errors++
+		} else {
+			l.report.Warnings++
+		}
+	}
+
+	return l.report, nil
+}
+
+// isBaseRecipeFile mirrors how loadMetadataStore identifies the base recipe.
+func isBaseRecipeFile(path string) bool {
+	return filepath.Base(path) == "base.yaml" && strings.Contains(path, "overlays/")
+}
+
+// load reads every recipe metadata file the metadata store would load.
+func (l *linter) load() error {
+	err := l.provider.WalkDir("", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		filename := filepath.Base(path)
+		if d.IsDir() || strings.Contains(path, "components/") || !strings.HasSuffix(filename, ".yaml") {
+			return nil
+		}
+		if filename == "data-v1.yaml" || filename == registryFileName || filename == "remediations.yaml" {
+			return nil
+		}
+
+		content, readErr := l.provider.ReadFile(path)
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", path, readErr)
+		}
+		l.report.FilesChecked++
+		if r := l.parse(path, content); r != nil {
+			l.recipes = append(l.recipes, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk recipe data: %w", err)
+	}
+
+	sort.Slice(l.recipes, func(i, j int) bool { return l.recipes[i].file < l.recipes[j].file })
+
+	for _, r := range l.recipes {
+		name := r.name()
+		if name == "" {
+			l.add(r.file, r.root, LintSeverityError, LintRuleParse, "metadata.name is required")
+			continue
+		}
+		if prev, ok := l.byName[name]; ok {
+			l.add(r.file, lookupNode(r.root, "metadata", "name"), LintSeverityError, LintRuleDuplicateName,
+				"recipe %q is already defined in %s", name, prev.file)
+			continue
+		}
+		l.byName[name] = r
+	}
+	if _, ok := l.byName["base"]; !ok {
+		l.add("overlays/base.yaml", nil, LintSeverityError, LintRuleParse, "base recipe not found")
+	}
+
+	return nil
+}
+
+// yamlLinePattern extracts the line number from yaml.v3 error messages.
+var yamlLinePattern = regexp.MustCompile(`line (\d+): (.*)`)
+
+// parse decodes a recipe metadata file, reporting syntax errors and unknown fields.
+// It returns nil when the file cannot be used by the remaining checks.
+func (l *linter) parse(path string, content []byte) *lintRecipe {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		l.addYAMLError(path, err)
+		return nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		l.add(path, nil, LintSeverityError, LintRuleParse, "recipe metadata must be a YAML mapping")
+		return nil
+	}
+
+	r := &lintRecipe{file: path, root: doc.Content[0]}
+	if err := r.root.Decode(&r.meta); err != nil {
+		l.addYAMLError(path, err)
+		return nil
+	}
+
+	// Unknown fields are silently dropped at runtime, so a typo such as
+	// "valueFile" loses configuration without any error
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	var strict RecipeMetadata
+	if err := dec.Decode(&strict); err != nil {
+		l.addYAMLError(path, err)
+	}
+
+	return r
+}
+
+// addYAMLError reports a yaml.v3 error, one diagnostic per line it names.
+func (l *linter) addYAMLError(path string, err error) {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+	for _, msg := range messages {
+		d := LintDiagnostic{File: path, Severity: LintSeverityError, Rule: LintRuleParse, Message: msg}
+		if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = m[2]
+		}
+		l.report.Diagnostics = append(l.report.Diagnostics, d)
+	}
+}
+
+// add records a diagnostic positioned at node (or at the file when node is nil).
+func (l *linter) add(file string, node *yaml.Node, severity LintSeverity, rule, format string, args ...any) {
+	d := LintDiagnostic{
+		File:     file,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if node != nil {
+		d.Line, d.Column = node.Line, node.Column
+	}
+	l.report.Diagnostics = append(l.report.Diagnostics, d)
+}
+
+// lintRegistry validates the (merged) component registry.
+func (l *linter) lintRegistry() {
+	content, err := l.provider.ReadFile(registryFileName)
+	if err != nil {
+		l.add(registryFileName, nil, LintSeverityError, LintRuleRegistry, "failed to read registry: %v", err)
+		return
+	}
+	var registry ComponentRegistry
+	if err := yaml.Unmarshal(content, &registry); err != nil {
+		l.addYAMLError(registryFileName, err)
+		return
+	}
+	for _, verr := range registry.Validate() {
+		l.add(registryFileName, nil, LintSeverityError, LintRuleRegistry, "%v", verr)
+	}
+	l.registry = &registry
+}
+
+// lintBase checks that the spec.base chain of r resolves to the base recipe.
+func (l *linter) lintBase(r *lintRecipe) {
+	if r.meta.Spec.Base == "" || r.name() == "base" {
+		return
+	}
+	node := lookupNode(r.root, "spec", "base")
+
+	chain := []string{r.name()}
+	visited := map[string]bool{r.name(): true}
+	for current := r.meta.Spec.Base; current != "" && current != "base"; {
+		chain = append(chain, current)
+		if visited[current] {
+			l.add(r.file, node, LintSeverityError, LintRuleBase,
+				"circular inheritance: %s", strings.Join(chain, " -> "))
+			return
+		}
+		visited[current] = true
+
+		parent, ok := l.byName[current]
+		if !ok {
+			l.add(r.file, node, LintSeverityError, LintRuleBase,
+				"recipe %q not found (inheritance chain: %s)", current, strings.Join(chain, " -> "))
+			return
+		}
+		current = parent.meta.Spec.Base
+	}
+}
+
+// lintCriteria checks that every criteria field is a dimension declared in the
+// registry and every value is known and canonical. Matching compares values
+// literally, so an alias such as "EKS" or "self-managed" would never match a
+// parsed query.
+func (l *linter) lintCriteria(r *lintRecipe) {
+	c := r.meta.Spec.Criteria
+	if c == nil {
+		return
+	}
+
+	// Without a registry the dimensions are unknown (already reported)
+	if l.registry != nil {
+		keys := []string{CriteriaDimensionService, CriteriaDimensionAccelerator, CriteriaDimensionIntent, CriteriaDimensionOS}
+		for _, key := range append(keys, dimensionNames(c)...) {
+			value := c.Get(key)
+			if value == "" {
+				continue
+			}
+			node := lookupNode(r.root, "spec", "criteria", key)
+			d := l.registry.GetCriteriaDimension(key)
+			if d == nil || d.Name != key {
+				l.add(r.file, node, LintSeverityError, LintRuleCriteria,
+					"unknown criteria dimension %q (declared: %s)", key, strings.Join(l.declaredDimensions(), ", "))
+				continue
+			}
+			canonical, err := d.Parse(value)
+			switch {
+			case err != nil:
+				l.add(r.file, node, LintSeverityError, LintRuleCriteria,
+					"unknown %s %q (valid: %s, %s)", key, value, criteriaAnyValue, strings.Join(d.SortedValues(), ", "))
+			case canonical != value:
+				l.add(r.file, node, LintSeverityError, LintRuleCriteria,
+					"%s %q is not canonical and never matches; use %q", key, value, canonical)
+			}
+		}
+	}
+
+	if c.Nodes < 0 {
+		l.add(r.file, lookupNode(r.root, "spec", "criteria", criteriaNodesField), LintSeverityError, LintRuleCriteria,
+			"nodes must not be negative, got %d", c.Nodes)
+	}
+}
+
+// dimensionNames returns the names of the criteria dimensions declared in the
+// linted registry, plus nodes.
+func (l *linter) declaredDimensions() []string {
+	names := make([]string, 0, len(l.registry.Criteria)+1)
+	for _, d := range l.registry.Criteria {
+		names = append(names, d.Name)
+	}
+	return append(names, criteriaNodesField)
+}
+
+// lintFiles checks that the valuesFile and manifestFiles of every component exist.
+func (l *linter) lintFiles(r *lintRecipe) {
+	refs := lookupNode(r.root, "spec", "componentRefs")
+	for i, ref := range r.meta.Spec.ComponentRefs {
+		refNode := sequenceItem(refs, i)
+		if ref.ValuesFile != "" && !l.exists(ref.ValuesFile) {
+			l.add(r.file, lookupNode(refNode, "valuesFile"), LintSeverityError, LintRuleMissingFile,
+				"component %q: valuesFile %q not found", ref.Name, ref.ValuesFile)
+		}
+		manifests := lookupNode(refNode, "manifestFiles")
+		for j, manifest := range ref.ManifestFiles {
+			if !l.exists(manifest) {
+				l.add(r.file, sequenceItem(manifests, j), LintSeverityError, LintRuleMissingFile,
+					"component %q: manifest file %q not found", ref.Name, manifest)
+			}
+		}
+	}
+}
+
+// exists reports whether the data set contains path.
+func (l *linter) exists(path string) bool {
+	_, err := l.provider.ReadFile(path)
+	return err == nil
+}
+
+// lintConstraints checks every constraint name and expression with the
+// configured parsers.
+func (l *linter) lintConstraints(r *lintRecipe) {
+	constraints := lookupNode(r.root, "spec", "constraints")
+	for i, c := range r.meta.Spec.Constraints {
+		node := sequenceItem(constraints, i)
+		if c.Name == "" {
+			l.add(r.file, node, LintSeverityError, LintRuleConstraint, "constraint name is required")
+		} else if l.parsePath != nil {
+			if err := l.parsePath(c.Name); err != nil {
+				l.add(r.file, lookupNode(node, "name"), LintSeverityError, LintRuleConstraint,
+					"invalid constraint name %q: %v", c.Name, err)
+			}
+		}
+		if l.parseExpression != nil {
+			if err := l.parseExpression(c.Value); err != nil {
+				valueNode := lookupNode(node, "value")
+				if valueNode == nil {
+					valueNode = node
+				}
+				l.add(r.file, valueNode, LintSeverityError, LintRuleConstraint,
+					"invalid expression for constraint %q: %v", c.Name, err)
+			}
+		}
+	}
+}
+
+// lintDependencies validates dependencyRefs of each recipe merged with its
+// inheritance chain, the way the recipe builder merges them. A failure is
+// reported only on the first recipe of a chain that introduces it.
+func (l *linter) lintDependencies() {
+	valid := make(map[string]bool)
+	var check func(r *lintRecipe, depth int) bool
+	check = func(r *lintRecipe, depth int) bool {
+		name := r.name()
+		if ok, done := valid[name]; done {
+			return ok
+		}
+		// Broken chains are reported by lintBase
+		if depth > len(l.recipes) {
+			return false
+		}
+
+		spec := RecipeMetadataSpec{}
+		for _, chained := range l.chain(r) {
+			spec.Merge(&chained.meta.Spec)
+		}
+
+		parentValid := true
+		if parent := l.parent(r); parent != nil {
+			parentValid = check(parent, depth+1)
+		}
+
+		err := spec.ValidateDependencies()
+		valid[name] = err == nil
+		if err != nil && parentValid {
+			l.add(r.file, lookupNode(r.root, "spec", "componentRefs"), LintSeverityError, LintRuleDependency,
+				"%v", err)
+		}
+		return valid[name]
+	}
+
+	for _, r := range l.recipes {
+		check(r, 0)
+	}
+}
+
+// parent returns the recipe r inherits from, or nil for the base recipe and
+// unresolved references.
+func (l *linter) parent(r *lintRecipe) *lintRecipe {
+	if r.name() == "base" {
+		return nil
+	}
+	base := r.meta.Spec.Base
+	if base == "" {
+		base = "base"
+	}
+	return l.byName[base]
+}
+
+// chain returns the inheritance chain of r from the base recipe to r,
+// stopping at cycles and unresolved references.
+func (l *linter) chain(r *lintRecipe) []*lintRecipe {
+	var chain []*lintRecipe
+	seen := make(map[*lintRecipe]bool)
+	for current := r; current != nil && !seen[current]; current = l.parent(current) {
+		seen[current] = true
+		chain = append([]*lintRecipe{current}, chain...)
+	}
+	return chain
+}
+
+// lintConflicts reports overlays with identical criteria, and therefore
+// identical specificity, that define the same component or constraint
+// differently. Their merge order is undefined, so the generated recipe is
+// not deterministic. Overlays inheriting from one another are merged in
+// chain order and are not reported.
+func (l *linter) lintConflicts() {
+	groups := make(map[string][]*lintRecipe)
+	var keys []string
+	for _, r := range l.recipes {
+		if r.meta.Spec.Criteria == nil || l.byName[r.name()] != r {
+			continue
+		}
+		key := normalizeCriteria(r.meta.Spec.Criteria).String()
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				if l.inherits(group[i], group[j]) || l.inherits(group[j], group[i]) {
+					continue
+				}
+				l.compareOverlays(group[i], group[j])
+			}
+		}
+	}
+}
+
+// inherits reports whether ancestor is in the inheritance chain of r.
+func (l *linter) inherits(r, ancestor *lintRecipe) bool {
+	for _, chained := range l.chain(r) {
+		if chained == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// compareOverlays reports the components and constraints b defines differently from a.
+func (l *linter) compareOverlays(a, b *lintRecipe) {
+	refs := lookupNode(b.root, "spec", "componentRefs")
+	for i, ref := range b.meta.Spec.ComponentRefs {
+		for _, other := range a.meta.Spec.ComponentRefs {
+			if other.Name == ref.Name && !reflect.DeepEqual(other, ref) {
+				l.add(b.file, sequenceItem(refs, i), LintSeverityError, LintRuleConflict,
+					"component %q is also defined by overlay %q (%s) with identical criteria %s; merge order is undefined",
+					ref.Name, a.name(), a.file, normalizeCriteria(b.meta.Spec.Criteria))
+			}
+		}
+	}
+
+	constraints := lookupNode(b.root, "spec", "constraints")
+	for i, c := range b.meta.Spec.Constraints {
+		for _, other := range a.meta.Spec.Constraints {
+			if other.Name == c.Name && other.Value != c.Value {
+				l.add(b.file, sequenceItem(constraints, i), LintSeverityError, LintRuleConflict,
+					"constraint %q is also set to %q by overlay %q (%s) with identical criteria %s; merge order is undefined",
+					c.Name, other.Value, a.name(), a.file, normalizeCriteria(b.meta.Spec.Criteria))
+			}
+		}
+	}
+}
+
+// lookupNode returns the node at the mapping key path below n, or nil.
+func lookupNode(n *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if n == nil || n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		n = next
+	}
+	return n
+}
+
+// sequenceItem returns item i of a sequence node, or nil.
+func sequenceItem(n *yaml.Node, i int) *yaml.Node {
+	if n == nil || n.Kind != yaml.SequenceNode || i >= len(n.Content) {
+		return nil
+	}
+	return n.Content[i]
+}
@@ -0,0 +1,377 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testLintOptions stand in for the validator parsers, which cannot be
+// imported from the recipe package.
+var testLintOptions = []LintOption{
+	WithConstraintPathParser(func(path string) error {
+		if strings.Count(path, ".") < 2 {
+			return fmt.Errorf("expected format {Type}.{Subtype}.{Key}")
+		}
+		return nil
+	}),
+	WithConstraintExpressionParser(func(expr string) error {
+		if strings.HasSuffix(expr, ",") {
+			return fmt.Errorf("expected constraint")
+		}
+		return nil
+	}),
+}
+
+func TestLint_EmbeddedData(t *testing.T) {
+	report, err := Lint(NewEmbeddedDataProvider(dataFS, "data"), testLintOptions...)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.FilesChecked == 0 {
+		t.Error("expected embedded recipe files to be checked")
+	}
+	for _, d := range report.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s", d)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []LintDiagnostic
+	}{
+		{
+			name: "valid overlay",
+			files: map[string]string{
+				"overlays/custom.yaml": `kind: recipeMetadata
+metadata:
+  name: custom
+spec:
+  base: eks
+  criteria:
+    service: eks
+    accelerator: h100
+  constraints:
+    - name: K8s.server.version
+      value: ">= 1.30"
+  componentRefs:
+    - name: gpu-operator
+      valuesFile: components/gpu-operator/values.yaml
+`,
+			},
+		},
+		{
+			name: "syntax error",
+			files: map[string]string{
+				"overlays/broken.yaml": "metadata:\n  name: [broken\n",
+			},
+			want: []LintDiagnostic{{File: "overlays/broken.yaml", Line: 1, Rule: LintRuleParse}},
+		},
+		{
+			name: "unknown field",
+			files: map[string]string{
+				"overlays/typo.yaml": `kind: recipeMetadata
+metadata:
+  name: typo
+spec:
+  componentRefs:
+    - name: gpu-operator
+      valueFile: values.yaml
+`,
+			},
+			want: []LintDiagnostic{{File: "overlays/typo.yaml", Line: 7, Rule: LintRuleParse}},
+		},
+		{
+			name: "duplicate name",
+			files: map[string]string{
+				"overlays/zz-eks.yaml": `kind: recipeMetadata
+metadata:
+  name: eks
+`,
+			},
+			want: []LintDiagnostic{{File: "overlays/zz-eks.yaml", Line: 3, Column: 9, Rule: LintRuleDuplicateName}},
+		},
+		{
+			name: "unknown base",
+			files: map[string]string{
+				"overlays/orphan.yaml": `kind: recipeMetadata
+metadata:
+  name: orphan
+spec:
+  base: missing
+`,
+			},
+			want: []LintDiagnostic{{File: "overlays/orphan.yaml", Line: 5, Column: 9, Rule: LintRuleBase}},
+		},
+		{
+			name: "circular base",
+			files: map[string]string{
+				"overlays/a.yaml": "kind: recipeMetadata\nmetadata:\n  name: a\nspec:\n  base: b\n",
+				"overlays/b.yaml": "kind: recipeMetadata\nmetadata:\n  name: b\nspec:\n  base: a\n",
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/a.yaml", Line: 5, Column: 9, Rule: LintRuleBase},
+				{File: "overlays/b.yaml", Line: 5, Column: 9, Rule: LintRuleBase},
+			},
+		},
+		{
+			name: "unknown and non-canonical criteria",
+			files: map[string]string{
+				"overlays/criteria.yaml": `kind: recipeMetadata
+metadata:
+  name: criteria
+spec:
+  criteria:
+    service: EKS
+    accelerator: h200
+`,
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/criteria.yaml", Line: 6, Column: 14, Rule: LintRuleCriteria},
+				{File: "overlays/criteria.yaml", Line: 7, Column: 18, Rule: LintRuleCriteria},
+			},
+		},
+		{
+			name: "undeclared criteria dimension",
+			files: map[string]string{
+				"overlays/region.yaml": `kind: recipeMetadata
+metadata:
+  name: region
+spec:
+  criteria:
+    service: eks
+    region: us-east
+`,
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/region.yaml", Line: 7, Column: 13, Rule: LintRuleCriteria},
+			},
+		},
+		{
+			name: "criteria dimension declared in external registry",
+			files: map[string]string{
+				"registry.yaml": `apiVersion: cns.nvidia.com/v1alpha1
+kind: ComponentRegistry
+criteria:
+  - name: region
+    values: [eu-west, us-east]
+components: []
+`,
+				"overlays/region.yaml": `kind: recipeMetadata
+metadata:
+  name: region
+spec:
+  criteria:
+    service: eks
+    region: us-east
+`,
+				"overlays/region-alias.yaml": `kind: recipeMetadata
+metadata:
+  name: region-alias
+spec:
+  criteria:
+    service: eks
+    region: US-East
+`,
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/region-alias.yaml", Line: 7, Column: 13, Rule: LintRuleCriteria},
+			},
+		},
+		{
+			name: "missing files",
+			files: map[string]string{
+				"overlays/files.yaml": `kind: recipeMetadata
+metadata:
+  name: files
+spec:
+  componentRefs:
+    - name: gpu-operator
+      valuesFile: components/gpu-operator/missing.yaml
+      manifestFiles:
+        - components/gpu-operator/manifests/missing.yaml
+`,
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/files.yaml", Line: 7, Column: 19, Rule: LintRuleMissingFile},
+				{File: "overlays/files.yaml", Line: 9, Column: 11, Rule: LintRuleMissingFile},
+			},
+		},
+		{
+			name: "invalid constraints",
+			files: map[string]string{
+				"overlays/constraints.yaml": `kind: recipeMetadata
+metadata:
+  name: constraints
+spec:
+  constraints:
+    - name: k8s
+      value: ">= 1.30,"
+`,
+			},
+			want: []LintDiagnostic{
+				{File: "overlays/constraints.yaml", Line: 6, Column: 13, Rule: LintRuleConstraint},
+				{File: "overlays/constraints.yaml", Line: 7, Column: 14, Rule: LintRuleConstraint},
+			},
+		},
+		{
+			name: "unknown dependency",
+			files: map[string]string{
+				"overlays/deps.yaml": `kind: recipeMetadata
+metadata:
+  name: deps
+spec:
+  componentRefs:
+    - name: gpu-operator
+      dependencyRefs: [ghost]
+`,
+			},
+			want: []LintDiagnostic{{File: "overlays/deps.yaml", Line: 6, Column: 5, Rule: LintRuleDependency}},
+		},
+		{
+			name: "conflicting overlays with identical criteria",
+			files: map[string]string{
+				"overlays/one.yaml": `kind: recipeMetadata
+metadata:
+  name: one
+spec:
+  criteria:
+    accelerator: l40
+  componentRefs:
+    - name: gpu-operator
+      version: v1
+`,
+				"overlays/two.yaml": `kind: recipeMetadata
+metadata:
+  name: two
+spec:
+  criteria:
+    accelerator: l40
+    intent: any
+  componentRefs:
+    - name: gpu-operator
+      version: v2
+`,
+			},
+			want: []LintDiagnostic{{File: "overlays/two.yaml", Line: 9, Column: 7, Rule: LintRuleConflict}},
+		},
+		{
+			name: "identical criteria along an inheritance chain",
+			files: map[string]string{
+				"overlays/one.yaml": `kind: recipeMetadata
+metadata:
+  name: one
+spec:
+  criteria:
+    accelerator: l40
+  componentRefs:
+    - name: gpu-operator
+      version: v1
+`,
+				"overlays/two.yaml": `kind: recipeMetadata
+metadata:
+  name: two
+spec:
+  base: one
+  criteria:
+    accelerator: l40
+  componentRefs:
+    - name: gpu-operator
+      version: v2
+`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{"registry.yaml": testEmptyRegistryContent}
+			for path, content := range tt.files {
+				files[path] = content
+			}
+			for path, content := range files {
+				full := filepath.Join(dir, path)
+				if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(full, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			provider, err := NewLayeredDataProvider(NewEmbeddedDataProvider(dataFS, "data"), LayeredProviderConfig{ExternalDir: dir})
+			if err != nil {
+				t.Fatalf("failed to create layered provider: %v", err)
+			}
+
+			report, err := Lint(provider, testLintOptions...)
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+
+			if len(report.Diagnostics) != len(tt.want) {
+				t.Fatalf("got %d diagnostics, want %d: %v", len(report.Diagnostics), len(tt.want), report.Diagnostics)
+			}
+			for i, want := range tt.want {
+				got := report.Diagnostics[i]
+				if got.File != want.File || got.Line != want.Line || got.Column != want.Column || got.Rule != want.Rule {
+					t.Errorf("diagnostic[%d] = %s, want %s:%d:%d [%s]", i, got, want.File, want.Line, want.Column, want.Rule)
+				}
+				if got.Severity != LintSeverityError {
+					t.Errorf("diagnostic[%d] severity = %s, want error", i, got.Severity)
+				}
+			}
+			if report.Errors != len(tt.want) || report.HasErrors() != (len(tt.want) > 0) {
+				t.Errorf("Errors = %d, HasErrors() = %v, want %d errors", report.Errors, report.HasErrors(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestLintReport_WriteTable(t *testing.T) {
+	report := &LintReport{
+		FilesChecked: 2,
+		Errors:       1,
+		Diagnostics: []LintDiagnostic{
+			{File: "overlays/a.yaml", Line: 5, Column: 9, Severity: LintSeverityError, Rule: LintRuleBase, Message: "recipe \"b\" not found"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	for _, want := range []string{"overlays/a.yaml:5:9", "base", "recipe \"b\" not found", "1 error(s), 0 warning(s) in 2 files"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("table output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := (&LintReport{FilesChecked: 2}).WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "recipe data valid") {
+		t.Errorf("expected valid message, got %q", buf.String())
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	cnserrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+)
+
+// CoverageMatrix reports, for every combination of criteria values, which
+// overlays apply. Base-only cells are coverage gaps without a tuned overlay.
+type CoverageMatrix struct {
+	// Kind is always "coverageMatrix".
+	Kind string `json:"kind" yaml:"kind"`
+
+	// APIVersion is the API version.
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Metadata contains matrix metadata.
+	Metadata struct {
+		// Version is the version of the tool that generated the matrix.
+		Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	} `json:"metadata" yaml:"metadata"`
+
+	// Summary counts the cells by coverage.
+	Summary CoverageSummary `json:"summary" yaml:"summary"`
+
+	// Dimensions lists the enumerated criteria dimensions in declaration order.
+	Dimensions []string `json:"dimensions" yaml:"dimensions"`
+
+	// Cells lists every criteria combination, ordered by the values of the
+	// dimensions, the last dimension varying fastest.
+	Cells []CoverageCell `json:"cells" yaml:"cells"`
+}
+
+// CoverageSummary counts the cells of a coverage matrix.
+type CoverageSummary struct {
+	// Cells is the number of criteria combinations.
+	Cells int `json:"cells" yaml:"cells"`
+
+	// Tuned is the number of cells matched by at least one criteria-specific overlay.
+	Tuned int `json:"tuned" yaml:"tuned"`
+
+	// BaseOnly is the number of cells without a criteria-specific overlay.
+	BaseOnly int `json:"baseOnly" yaml:"baseOnly"`
+}
+
+// CoverageCell is the coverage of a single criteria combination.
+type CoverageCell struct {
+	// Criteria is the combination of criteria values.
+	Criteria *Criteria `json:"criteria" yaml:"criteria"`
+
+	// AppliedOverlays lists the applied recipes in merge order, starting with "base".
+	AppliedOverlays []string `json:"appliedOverlays" yaml:"appliedOverlays"`
+
+	// Components lists the names of the components in the merged recipe, sorted.
+	Components []string `json:"components" yaml:"components"`
+
+	// BaseOnly is true when no overlay specific to the criteria matched: only
+	// the base recipe and overlays with all-"any" criteria apply.
+	BaseOnly bool `json:"baseOnly" yaml:"baseOnly"`
+}
+
+// BuildCoverageMatrix enumerates the cross product of the values of the
+// criteria dimensions declared in the registry (restricted to the allowlists,
+// when configured) and reports the overlays and components each combination
+// resolves to.
+func (b *Builder) BuildCoverageMatrix(ctx context.Context) (*CoverageMatrix, error) {
+	store, err := loadMetadataStore(ctx)
+	if err != nil {
+		return nil, cnserrors.WrapWithContext(
+			cnserrors.ErrCodeInternal,
+			"failed to load metadata store",
+			err,
+			map[string]any{
+				"stage": "metadata_load",
+			},
+		)
+	}
+
+	dims := GetCriteriaDimensions()
+	names := make([]string, 0, len(dims))
+	values := make([][]string, 0, len(dims))
+	total := 1
+	for _, d := range dims {
+		allowed := allowedCriteriaValues(d.SortedValues(), b.AllowLists.Values(d.Name))
+		names = append(names, d.Name)
+		values = append(values, allowed)
+		total *= len(allowed)
+	}
+
+	matrix := &CoverageMatrix{
+		Kind:       "coverageMatrix",
+		APIVersion: "cns.nvidia.com/v1alpha1",
+		Dimensions: names,
+		Cells:      make([]CoverageCell, 0, total),
+	}
+	matrix.Metadata.Version = b.Version
+
+	// Walk the combinations like an odometer, the last dimension fastest
+	index := make([]int, len(values))
+	for range total {
+		if err := ctx.Err(); err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeTimeout, "coverage matrix build cancelled", err)
+		}
+
+		criteria := &Criteria{}
+		for i, name := range names {
+			criteria.Set(name, values[i][index[i]])
+		}
+		cell, err := store.coverageCell(criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		matrix.Cells = append(matrix.Cells, *cell)
+		if cell.BaseOnly {
+			matrix.Summary.BaseOnly++
+		} else {
+			matrix.Summary.Tuned++
+		}
+
+		for i := len(index) - 1; i >= 0; i-- {
+			index[i]++
+			if index[i] < len(values[i]) {
+				break
+			}
+			index[i] = 0
+		}
+	}
+	matrix.Summary.Cells = len(matrix.Cells)
+
+	return matrix, nil
+}
+
+// coverageCell merges the overlays matching criteria the way BuildRecipeResult does.
+func (s *MetadataStore) coverageCell(criteria *Criteria) (*CoverageCell, error) {
+	overlays := s.FindMatchingOverlays(criteria)
+	spec, applied, err := s.mergeOverlays(overlays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Overlays whose criteria are all "any" apply to every combination and
+	// do not tune it
+	baseOnly := true
+	for _, overlay := range overlays {
+		if normalizeCriteria(overlay.Spec.Criteria).Specificity() > 0 {
+			baseOnly = false
+			break
+		}
+	}
+
+	components := make([]string, 0, len(spec.ComponentRefs))
+	for _, ref := range spec.ComponentRefs {
+		components = append(components, ref.Name)
+	}
+	sort.Strings(components)
+
+	return &CoverageCell{
+		Criteria:        criteria,
+		AppliedOverlays: applied,
+		Components:      components,
+		BaseOnly:        baseOnly,
+	}, nil
+}
+
+// allowedCriteriaValues returns the values of all that are in allowed, or all
+// of them when allowed is empty.
+func allowedCriteriaValues(all, allowed []string) []string {
+	if len(allowed) == 0 {
+		return all
+	}
+	var values []string
+	for _, v := range all {
+		if slices.Contains(allowed, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// overlays returns the applied overlays without base, or "-" when none applied.
+func (c *CoverageCell) overlays() string {
+	if len(c.AppliedOverlays) <= 1 {
+		return "-"
+	}
+	return strings.Join(c.AppliedOverlays[1:], ", ")
+}
+
+// WriteTable renders one row per cell with the number of components,
+// implementing serializer.TableWriter.
+func (m *CoverageMatrix) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var header, rule strings.Builder
+	for _, name := range m.Dimensions {
+		header.WriteString(strings.ToUpper(name) + "\t")
+		rule.WriteString(strings.Repeat("-", len(name)) + "\t")
+	}
+	fmt.Fprintln(tw, header.String()+"BASE ONLY\tCOMPONENTS\tOVERLAYS")
+	fmt.Fprintln(tw, rule.String()+"---------\t----------\t--------")
+	for i := range m.Cells {
+		c := &m.Cells[i]
+		for _, name := range m.Dimensions {
+			fmt.Fprintf(tw, "%s\t", c.Criteria.Get(name))
+		}
+		fmt.Fprintf(tw, "%t\t%d\t%s\n", c.BaseOnly, len(c.Components), c.overlays())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d of %d combinations tuned, %d base only\n",
+		m.Summary.Tuned, m.Summary.Cells, m.Summary.BaseOnly)
+	return err
+}
+
+// WriteMarkdown renders the matrix as a Markdown table listing the components of each cell.
+func (m *CoverageMatrix) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%d of %d combinations tuned, %d base only**\n\n",
+		m.Summary.Tuned, m.Summary.Cells, m.Summary.BaseOnly)
+	var header, rule strings.Builder
+	for _, name := range m.Dimensions {
+		title := markdownTitle(name)
+		header.WriteString(" " + title + " |")
+		rule.WriteString(strings.Repeat("-", len(title)+2) + "|")
+	}
+	sb.WriteString("|" + header.String() + " Overlays | Components |\n")
+	sb.WriteString("|" + rule.String() + "----------|------------|\n")
+	for i := range m.Cells {
+		c := &m.Cells[i]
+		overlays := c.overlays()
+		if c.BaseOnly {
+			overlays += " _(base only)_"
+		}
+		sb.WriteString("|")
+		for _, name := range m.Dimensions {
+			fmt.Fprintf(&sb, " %s |", c.Criteria.Get(name))
+		}
+		fmt.Fprintf(&sb, " %s | %s |\n", overlays, strings.Join(c.Components, ", "))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownTitle returns the column title of a dimension: "OS" for "os" and
+// "Service" for "service".
+func markdownTitle(name string) string {
+	if len(name) <= 2 {
+		return strings.ToUpper(name)
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuilder_BuildCoverageMatrix(t *testing.T) {
+	matrix, err := NewBuilder(WithVersion("v1.2.3")).BuildCoverageMatrix(context.Background())
+	if err != nil {
+		t.Fatalf("BuildCoverageMatrix() error = %v", err)
+	}
+
+	want := len(GetCriteriaServiceTypes()) * len(GetCriteriaAcceleratorTypes()) *
+		len(GetCriteriaIntentTypes()) * len(GetCriteriaOSTypes())
+	if matrix.Summary.Cells != want || len(matrix.Cells) != want {
+		t.Fatalf("got %d cells (summary %d), want %d", len(matrix.Cells), matrix.Summary.Cells, want)
+	}
+	if matrix.Summary.Tuned+matrix.Summary.BaseOnly != want {
+		t.Errorf("summary %+v does not add up to %d cells", matrix.Summary, want)
+	}
+	if matrix.Metadata.Version != "v1.2.3" {
+		t.Errorf("version = %q, want v1.2.3", matrix.Metadata.Version)
+	}
+
+	cell := findCell(matrix, "eks", "gb200", "training", "ubuntu")
+	if cell == nil {
+		t.Fatal("eks/gb200/training/ubuntu cell not found")
+	}
+	if cell.BaseOnly {
+		t.Error("eks/gb200/training/ubuntu should be tuned")
+	}
+	for _, name := range []string{"base", "eks", "eks-training", "gb200-eks-training", "gb200-eks-ubuntu-training"} {
+		if !slices.Contains(cell.AppliedOverlays, name) {
+			t.Errorf("applied overlays %v missing %s", cell.AppliedOverlays, name)
+		}
+	}
+	if !slices.Contains(cell.Components, "gpu-operator") || !slices.IsSorted(cell.Components) {
+		t.Errorf("components = %v, want sorted set including gpu-operator", cell.Components)
+	}
+
+	// Overlays matching every combination do not count as tuning
+	cell = findCell(matrix, "aks", "a100", "inference", "rhel")
+	if cell == nil || !cell.BaseOnly {
+		t.Errorf("aks/a100/inference/rhel cell = %+v, want base only", cell)
+	}
+}
+
+func TestBuilder_BuildCoverageMatrix_AllowLists(t *testing.T) {
+	builder := NewBuilder(WithAllowLists(&AllowLists{
+		Services:     []CriteriaServiceType{CriteriaServiceEKS},
+		Accelerators: []CriteriaAcceleratorType{CriteriaAcceleratorH100, CriteriaAcceleratorGB200},
+	}))
+
+	matrix, err := builder.BuildCoverageMatrix(context.Background())
+	if err != nil {
+		t.Fatalf("BuildCoverageMatrix() error = %v", err)
+	}
+
+	want := 2 * len(GetCriteriaIntentTypes()) * len(GetCriteriaOSTypes())
+	if len(matrix.Cells) != want {
+		t.Fatalf("got %d cells, want %d", len(matrix.Cells), want)
+	}
+	for _, cell := range matrix.Cells {
+		if cell.Criteria.Service != CriteriaServiceEKS || cell.Criteria.Accelerator == CriteriaAcceleratorA100 {
+			t.Errorf("cell %s outside the allowlists", cell.Criteria)
+		}
+	}
+}
+
+func TestBuilder_BuildCoverageMatrix_Dimensions(t *testing.T) {
+	useTestDimensions(t)
+
+	builder := NewBuilder(WithAllowLists(&AllowLists{
+		Services:   []CriteriaServiceType{CriteriaServiceEKS},
+		Dimensions: map[string][]string{"region": {"us-east", "eu-west"}},
+	}))
+	matrix, err := builder.BuildCoverageMatrix(context.Background())
+	if err != nil {
+		t.Fatalf("BuildCoverageMatrix() error = %v", err)
+	}
+
+	if !slices.Equal(matrix.Dimensions, []string{"service", "accelerator", "intent", "os", "region"}) {
+		t.Errorf("dimensions = %v", matrix.Dimensions)
+	}
+	want := len(GetCriteriaAcceleratorTypes()) * len(GetCriteriaIntentTypes()) * len(GetCriteriaOSTypes()) * 2
+	if len(matrix.Cells) != want {
+		t.Fatalf("got %d cells, want %d", len(matrix.Cells), want)
+	}
+	if first := matrix.Cells[0].Criteria; first.Get("region") != "eu-west" || matrix.Cells[1].Criteria.Get("region") != "us-east" {
+		t.Errorf("cells not ordered by region last: %s, %s", first, matrix.Cells[1].Criteria)
+	}
+
+	var table bytes.Buffer
+	if err := matrix.WriteTable(&table); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(table.String(), "REGION") {
+		t.Errorf("table missing REGION column:\n%s", table.String())
+	}
+}
+
+func TestCoverageMatrix_Render(t *testing.T) {
+	matrix := &CoverageMatrix{
+		Summary:    CoverageSummary{Cells: 2, Tuned: 1, BaseOnly: 1},
+		Dimensions: []string{"service", "accelerator", "intent", "os"},
+		Cells: []CoverageCell{
+			{
+				Criteria:        &Criteria{Service: "eks", Accelerator: "h100", Intent: "training", OS: "ubuntu"},
+				AppliedOverlays: []string{"base", "eks", "eks-training"},
+				Components:      []string{"cert-manager", "gpu-operator"},
+			},
+			{
+				Criteria:        &Criteria{Service: "gke", Accelerator: "l40", Intent: "inference", OS: "rhel"},
+				AppliedOverlays: []string{"base"},
+				Components:      []string{"cert-manager"},
+				BaseOnly:        true,
+			},
+		},
+	}
+
+	var table bytes.Buffer
+	if err := matrix.WriteTable(&table); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	for _, want := range []string{"BASE ONLY", "eks, eks-training", "1 of 2 combinations tuned, 1 base only"} {
+		if !strings.Contains(table.String(), want) {
+			t.Errorf("table missing %q:\n%s", want, table.String())
+		}
+	}
+
+	var md bytes.Buffer
+	if err := matrix.WriteMarkdown(&md); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	for _, want := range []string{
+		"| Service | Accelerator | Intent | OS | Overlays | Components |",
+		"| eks | h100 | training | ubuntu | eks, eks-training | cert-manager, gpu-operator |",
+		"| gke | l40 | inference | rhel | - _(base only)_ | cert-manager |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown missing %q:\n%s", want, md.String())
+		}
+	}
+}
+
+func TestHandleMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		query       string
+		wantStatus  int
+		wantContent string
+	}{
+		{name: "json", method: http.MethodGet, wantStatus: http.StatusOK, wantContent: "application/json"},
+		{name: "markdown", method: http.MethodGet, query: "format=markdown", wantStatus: http.StatusOK, wantContent: "text/markdown"},
+		{name: "invalid format", method: http.MethodGet, query: "format=xml", wantStatus: http.StatusBadRequest},
+		{name: "method not allowed", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	builder := NewBuilder()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/v1/recipe/matrix?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			builder.HandleMatrix(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.wantContent) {
+				t.Errorf("Content-Type = %q, want %s", ct, tt.wantContent)
+			}
+			if tt.wantContent != "application/json" {
+				return
+			}
+
+			var matrix CoverageMatrix
+			if err := json.Unmarshal(w.Body.Bytes(), &matrix); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if matrix.Kind != "coverageMatrix" || len(matrix.Cells) == 0 {
+				t.Errorf("unexpected matrix: kind=%q cells=%d", matrix.Kind, len(matrix.Cells))
+			}
+		})
+	}
+}
+
+func findCell(m *CoverageMatrix, service, accelerator, intent, os string) *CoverageCell {
+	for i := range m.Cells {
+		c := m.Cells[i].Criteria
+		if string(c.Service) == service && string(c.Accelerator) == accelerator &&
+			string(c.Intent) == intent && string(c.OS) == os {
+			return &m.Cells[i]
+		}
+	}
+	return nil
+}
@@ -36,6 +36,10 @@ type Constraint struct {
 
 	// Value is the constraint expression (e.g., ">= 1.30", "ubuntu").
 	Value string `json:"value" yaml:"value"`
+
+	// Remediation optionally describes how to resolve a failure of this constraint.
+	// When unset, the built-in remediation catalog is consulted.
+	Remediation *Remediation `json:"remediation,omitempty" yaml:"remediation,omitempty"`
 }
 
 // ComponentRef represents a reference to a deployable component.
@@ -211,6 +215,10 @@ type RecipeResult struct {
 	// DeploymentOrder is the topologically sorted component names for deployment.
 	// Components should be deployed in this order to satisfy dependencies.
 	DeploymentOrder []string `json:"deploymentOrder" yaml:"deploymentOrder"`
+
+	// Explanation describes which overlay set each field, constraint and values
+	// key, and why other overlays were rejected. Only populated in explain mode.
+	Explanation *RecipeExplanation `json:"explanation,omitempty" yaml:"explanation,omitempty"`
 }
 
 // Merge merges another RecipeMetadataSpec into this one.
@@ -226,6 +234,10 @@ func (s *RecipeMetadataSpec) Merge(other *RecipeMetadataSpec) {
 		constraintMap[c.Name] = c
 	}
 	for _, c := range other.Constraints {
+		// Keep the base remediation when the overlay only changes the value
+		if base, exists := constraintMap[c.Name]; exists && c.Remediation == nil {
+			c.Remediation = base.Remediation
+		}
 		constraintMap[c.Name] = c
 	}
 	s.Constraints = make([]Constraint, 0, len(constraintMap))
@@ -44,6 +44,10 @@ type MetadataStore struct {
 
 	// ValuesFiles contains embedded values file contents indexed by filename.
 	ValuesFiles map[string][]byte
+
+	// RecipeFiles contains the data file each recipe was loaded from, indexed
+	// by recipe name ("base" for the base recipe).
+	RecipeFiles map[string]string
 }
 
 // loadMetadataStore loads and caches the metadata store from the data provider.
@@ -55,6 +59,7 @@ func loadMetadataStore(_ context.Context) (*MetadataStore, error) {
 		store := &MetadataStore{
 			Overlays:    make(map[string]*RecipeMetadata),
 			ValuesFiles: make(map[string][]byte),
+			RecipeFiles: make(map[string]string),
 		}
 
 		provider := GetDataProvider()
@@ -86,8 +91,8 @@ func loadMetadataStore(_ context.Context) (*MetadataStore, error) {
 				return nil
 			}
 
-			// Skip old data-v1.yaml format and registry.yaml (handled separately)
-			if filename == "data-v1.yaml" || filename == "registry.yaml" {
+			// Skip old data-v1.yaml format, registry.yaml and remediations.yaml (handled separately)
+			if filename == "data-v1.yaml" || filename == "registry.yaml" || filename == "remediations.yaml" {
 				return nil
 			}
 
@@ -106,8 +111,10 @@ func loadMetadataStore(_ context.Context) (*MetadataStore, error) {
 			// base.yaml is now in overlays/ directory but still identified by filename
 			if filename == "base.yaml" && strings.Contains(path, "overlays/") {
 				store.Base = &metadata
+				store.RecipeFiles["base"] = path
 			} else {
 				store.Overlays[metadata.Metadata.Name] = &metadata
+				store.RecipeFiles[metadata.Metadata.Name] = path
 			}
 
 			return nil
@@ -226,40 +233,20 @@ func (s *MetadataStore) FindMatchingOverlays(criteria *Criteria) []*RecipeMetada
 	return matches
 }
 
-// BuildRecipeResult builds a RecipeResult by merging base with matching overlays.
-// Each matching overlay is resolved through its inheritance chain before merging.
-// This enables multi-level inheritance: base → intermediate → overlay.
-func (s *MetadataStore) BuildRecipeResult(ctx context.Context, criteria *Criteria) (*RecipeResult, error) {
-	// Check if ctx has been canceled and exit early if so
-	select {
-	case <-ctx.Done():
-		return nil, cnserrors.WrapWithContext(
-			cnserrors.ErrCodeTimeout,
-			"build recipe result context cancelled during initialization",
-			ctx.Err(),
-			map[string]any{
-				"stage": "initialization",
-			},
-		)
-	default:
-	}
-
-	// Find matching overlays (sorted by specificity, least specific first)
-	overlays := s.FindMatchingOverlays(criteria)
-
-	// Track all applied recipes (from inheritance chains)
-	appliedOverlays := make([]string, 0)
-
+// mergeOverlays merges the inheritance chain of each overlay, in order, onto a
+// copy of the base spec. It returns the merged spec and the names of the applied
+// recipes, starting with "base".
+func (s *MetadataStore) mergeOverlays(overlays []*RecipeMetadata) (*RecipeMetadataSpec, []string, error) {
 	// Start with the base spec
-	mergedSpec := RecipeMetadataSpec{
+	mergedSpec := &RecipeMetadataSpec{
 		Constraints:   make([]Constraint, len(s.Base.Spec.Constraints)),
 		ComponentRefs: make([]ComponentRef, len(s.Base.Spec.ComponentRefs)),
 	}
 	copy(mergedSpec.Constraints, s.Base.Spec.Constraints)
 	copy(mergedSpec.ComponentRefs, s.Base.Spec.ComponentRefs)
-	appliedOverlays = append(appliedOverlays, "base")
+	appliedOverlays := []string{"base"}
 
-	// For each matching overlay, resolve its inheritance chain and merge
+	// For each overlay, resolve its inheritance chain and merge
 	// We only apply the leaf overlay's chain, not intermediate ones
 	// This avoids double-applying recipes that appear in multiple chains
 	processedChains := make(map[string]bool) // Track which recipes we've already applied
@@ -268,7 +255,7 @@ func (s *MetadataStore) BuildRecipeResult(ctx context.Context, criteria *Criteri
 		// Resolve the full inheritance chain for this overlay
 		chain, err := s.resolveInheritanceChain(overlay.Metadata.Name)
 		if err != nil {
-			return nil, cnserrors.WrapWithContext(
+			return nil, nil, cnserrors.WrapWithContext(
 				cnserrors.ErrCodeInvalidRequest,
 				"failed to resolve inheritance chain",
 				err,
@@ -291,6 +278,36 @@ func (s *MetadataStore) BuildRecipeResult(ctx context.Context, criteria *Criteri
 		}
 	}
 
+	return mergedSpec, appliedOverlays, nil
+}
+
+// BuildRecipeResult builds a RecipeResult by merging base with matching overlays.
+// Each matching overlay is resolved through its inheritance chain before merging.
+// This enables multi-level inheritance: base → intermediate → overlay.
+func (s *MetadataStore) BuildRecipeResult(ctx context.Context, criteria *Criteria) (*RecipeResult, error) {
+	// Check if ctx has been canceled and exit early if so
+	select {
+	case <-ctx.Done():
+		return nil, cnserrors.WrapWithContext(
+			cnserrors.ErrCodeTimeout,
+			"build recipe result context cancelled during initialization",
+			ctx.Err(),
+			map[string]any{
+				"stage": "initialization",
+			},
+		)
+	default:
+	}
+
+	// Find matching overlays (sorted by specificity, least specific first)
+	overlays := s.FindMatchingOverlays(criteria)
+
+	// Merge the base spec with the inheritance chain of each matching overlay
+	mergedSpec, appliedOverlays, err := s.mergeOverlays(overlays)
+	if err != nil {
+		return nil, err
+	}
+
 	// Warn if no overlays matched - user is getting base-only configuration
 	if len(appliedOverlays) <= 1 { // Only "base" was applied
 		slog.Warn("no environment-specific overlays matched, using base configuration only",
@@ -383,46 +400,10 @@ func (s *MetadataStore) BuildRecipeResultWithEvaluator(ctx context.Context, crit
 		}
 	}
 
-	// Track all applied recipes (from inheritance chains)
-	appliedOverlays := make([]string, 0)
-
-	// Start with the base spec
-	mergedSpec := RecipeMetadataSpec{
-		Constraints:   make([]Constraint, len(s.Base.Spec.Constraints)),
-		ComponentRefs: make([]ComponentRef, len(s.Base.Spec.ComponentRefs)),
-	}
-	copy(mergedSpec.Constraints, s.Base.Spec.Constraints)
-	copy(mergedSpec.ComponentRefs, s.Base.Spec.ComponentRefs)
-	appliedOverlays = append(appliedOverlays, "base")
-
-	// For each filtered overlay, resolve its inheritance chain and merge
-	processedChains := make(map[string]bool)
-
-	for _, overlay := range filteredOverlays {
-		// Resolve the full inheritance chain for this overlay
-		chain, err := s.resolveInheritanceChain(overlay.Metadata.Name)
-		if err != nil {
-			return nil, cnserrors.WrapWithContext(
-				cnserrors.ErrCodeInvalidRequest,
-				"failed to resolve inheritance chain",
-				err,
-				map[string]any{
-					"overlay": overlay.Metadata.Name,
-				},
-			)
-		}
-
-		// Apply each recipe in the chain that hasn't been applied yet
-		// Skip base (index 0) since we already started with it
-		for i := 1; i < len(chain); i++ {
-			recipe := chain[i]
-			if processedChains[recipe.Metadata.Name] {
-				continue
-			}
-			processedChains[recipe.Metadata.Name] = true
-			mergedSpec.Merge(&recipe.Spec)
-			appliedOverlays = append(appliedOverlays, recipe.Metadata.Name)
-		}
+	// Merge the base spec with the inheritance chain of each filtered overlay
+	mergedSpec, appliedOverlays, err := s.mergeOverlays(filteredOverlays)
+	if err != nil {
+		return nil, err
 	}
 
 	// Log information about filtered overlays
@@ -0,0 +1,314 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	cnserrors "github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// RecipeMetadataKind is the kind value for recipe metadata (base and overlay) files.
+const RecipeMetadataKind = "recipeMetadata"
+
+// pinnedReadings are the snapshot readings pinned by generated overlays, in
+// output order. Each is a fully qualified constraint path {Type}.{Subtype}.{Key}.
+var pinnedReadings = []struct {
+	mType   measurement.Type
+	subtype string
+	key     string
+}{
+	{measurement.TypeK8s, "server", measurement.KeyVersion},
+	{measurement.TypeGPU, "smi", measurement.KeyGPUDriver},
+	{measurement.TypeOS, "sysctl", "/proc/sys/kernel/osrelease"},
+	{measurement.TypeOS, "release", "ID"},
+	{measurement.TypeOS, "release", "VERSION_ID"},
+}
+
+// imageSubtype is the K8s measurement subtype holding observed container image tags.
+const imageSubtype = "image"
+
+// OverlayConfig configures the overlay generated from a snapshot.
+type OverlayConfig struct {
+	// Name is the overlay name (metadata.name).
+	Name string
+
+	// Base is the parent recipe the overlay inherits from. Defaults to the
+	// most specific overlay matching Criteria, so that the overlays it
+	// refines are applied before it.
+	Base string
+
+	// Criteria defines when the overlay applies. "any" fields are omitted.
+	Criteria *Criteria
+
+	// Registry maps observed container images to components. Defaults to the
+	// global component registry.
+	Registry *ComponentRegistry
+
+	// Store holds the recipes the overlay is added to. Defaults to the
+	// recipe data of the data provider.
+	Store *MetadataStore
+}
+
+// GenerateOverlay creates a recipe overlay from snapshot measurements.
+// The overlay constraints pin the observed Kubernetes version, GPU driver,
+// kernel and OS release, and its componentRefs pin the version of every
+// registry component whose version image (see ComponentConfig.VersionImages)
+// is running in the cluster. The result can be written to the overlays/
+// directory of an external data directory (--data).
+//
+// An error is returned if another overlay matching the criteria would be
+// applied after the generated one and replace one of its pins.
+func GenerateOverlay(cfg OverlayConfig, measurements []*measurement.Measurement) (*RecipeMetadata, error) {
+	if cfg.Name == "" {
+		return nil, cnserrors.New(cnserrors.ErrCodeInvalidRequest, "overlay name is required")
+	}
+	if cfg.Name == "base" {
+		return nil, cnserrors.New(cnserrors.ErrCodeInvalidRequest, `overlay name "base" is reserved`)
+	}
+
+	if cfg.Criteria == nil || cfg.Criteria.Specificity() == 0 {
+		return nil, cnserrors.New(cnserrors.ErrCodeInvalidRequest,
+			"no criteria could be derived: an overlay without criteria would apply to every recipe")
+	}
+
+	registry := cfg.Registry
+	if registry == nil {
+		var err error
+		if registry, err = GetComponentRegistry(); err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeInternal, "failed to load component registry", err)
+		}
+	}
+
+	overlay := &RecipeMetadata{}
+	overlay.Kind = RecipeMetadataKind
+	overlay.APIVersion = FullAPIVersion
+	overlay.Metadata.Name = cfg.Name
+	overlay.Spec.Base = cfg.Base
+	overlay.Spec.Criteria = overlayCriteria(cfg.Criteria)
+	overlay.Spec.Constraints = pinnedConstraints(measurements)
+	overlay.Spec.ComponentRefs = observedComponentRefs(registry, measurements)
+
+	store := cfg.Store
+	if store == nil {
+		var err error
+		if store, err = loadMetadataStore(context.Background()); err != nil {
+			return nil, cnserrors.Wrap(cnserrors.ErrCodeInternal, "failed to load recipe data", err)
+		}
+	}
+	if err := placeOverlay(store, overlay, cfg.Criteria); err != nil {
+		return nil, err
+	}
+
+	return overlay, nil
+}
+
+// placeOverlay defaults the base of a generated overlay to the most specific
+// overlay matching criteria and verifies that no matching overlay outside its
+// inheritance chain, applied after it, replaces one of its pins.
+func placeOverlay(store *MetadataStore, overlay *RecipeMetadata, criteria *Criteria) error {
+	var matches []*RecipeMetadata
+	for _, m := range store.FindMatchingOverlays(criteria) {
+		// A previously generated version of the overlay is replaced
+		if m.Metadata.Name != overlay.Metadata.Name {
+			matches = append(matches, m)
+		}
+	}
+
+	if overlay.Spec.Base == "" {
+		var best *RecipeMetadata
+		bestScore := 0
+		for _, m := range matches {
+			score := normalizeCriteria(m.Spec.Criteria).Specificity()
+			if best == nil || score > bestScore || (score == bestScore && m.Metadata.Name < best.Metadata.Name) {
+				best, bestScore = m, score
+			}
+		}
+		if best != nil {
+			overlay.Spec.Base = best.Metadata.Name
+		}
+	}
+
+	chain, err := store.resolveInheritanceChain(overlay.Spec.Base)
+	if err != nil {
+		return cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest,
+			fmt.Sprintf("invalid base %q", overlay.Spec.Base), err)
+	}
+	inChain := make(map[string]bool, len(chain))
+	for _, r := range chain {
+		inChain[r.Metadata.Name] = true
+	}
+
+	// Matching overlays are applied in order of specificity, together with
+	// the parts of their inheritance chain that have not been applied yet
+	specificity := overlay.Spec.Criteria.Specificity()
+	for _, m := range matches {
+		if inChain[m.Metadata.Name] || m.Spec.Criteria.Specificity() < specificity {
+			continue
+		}
+		mChain, err := store.resolveInheritanceChain(m.Metadata.Name)
+		if err != nil {
+			return cnserrors.Wrap(cnserrors.ErrCodeInvalidRequest,
+				fmt.Sprintf("invalid overlay %q", m.Metadata.Name), err)
+		}
+		for _, r := range mChain {
+			if inChain[r.Metadata.Name] {
+				continue
+			}
+			if pin := overriddenPin(overlay, r); pin != "" {
+				return cnserrors.New(cnserrors.ErrCodeInvalidRequest,
+					fmt.Sprintf("overlay %q is applied after the generated overlay and replaces its pinned %s; use a base that inherits from %q",
+						r.Metadata.Name, pin, m.Metadata.Name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// overriddenPin returns the first pin of overlay that r replaces, or "".
+func overriddenPin(overlay, r *RecipeMetadata) string {
+	for _, pinned := range overlay.Spec.Constraints {
+		for _, c := range r.Spec.Constraints {
+			if c.Name == pinned.Name {
+				return "constraint " + c.Name
+			}
+		}
+	}
+	for _, pinned := range overlay.Spec.ComponentRefs {
+		for _, ref := range r.Spec.ComponentRefs {
+			if ref.Name == pinned.Name && (ref.Version != "" || ref.Tag != "") {
+				return "version of " + ref.Name
+			}
+		}
+	}
+	return ""
+}
+
+// overlayCriteria returns a copy of c with "any" fields cleared, matching
+// hand-written overlays which omit unconstrained fields.
+func overlayCriteria(c *Criteria) *Criteria {
+	out := *c
+	if out.Service == CriteriaServiceAny {
+		out.Service = ""
+	}
+	if out.Accelerator == CriteriaAcceleratorAny {
+		out.Accelerator = ""
+	}
+	if out.Intent == CriteriaIntentAny {
+		out.Intent = ""
+	}
+	if out.OS == CriteriaOSAny {
+		out.OS = ""
+	}
+	out.Dimensions = nil
+	for name, value := range c.Dimensions {
+		if value != "" && value != criteriaAnyValue {
+			out.Set(name, value)
+		}
+	}
+	return &out
+}
+
+// pinnedConstraints returns exact-match constraints for the pinned readings
+// present in the measurements.
+func pinnedConstraints(measurements []*measurement.Measurement) []Constraint {
+	var constraints []Constraint
+	for _, p := range pinnedReadings {
+		st := findSubtype(measurements, p.mType, p.subtype)
+		if st == nil {
+			continue
+		}
+		r, ok := st.Data[p.key]
+		if !ok {
+			continue
+		}
+		value := strings.TrimSpace(r.String())
+		if value == "" {
+			continue
+		}
+		constraints = append(constraints, Constraint{
+			Name:  fmt.Sprintf("%s.%s.%s", p.mType, p.subtype, p.key),
+			Value: exactValue(value),
+		})
+	}
+	return constraints
+}
+
+// observedComponentRefs returns componentRefs pinning the version of every
+// registry component with a running version image, sorted by name.
+func observedComponentRefs(registry *ComponentRegistry, measurements []*measurement.Measurement) []ComponentRef {
+	images := findSubtype(measurements, measurement.TypeK8s, imageSubtype)
+	if images == nil {
+		return nil
+	}
+
+	var refs []ComponentRef
+	for i := range registry.Components {
+		comp := &registry.Components[i]
+		for _, image := range comp.VersionImages {
+			r, ok := images.Data[image]
+			if !ok {
+				continue
+			}
+			tag := r.String()
+			if tag == "" || tag == "latest" {
+				slog.Debug("skipping untagged component image", "component", comp.Name, "image", image)
+				continue
+			}
+
+			ref := ComponentRef{Name: comp.Name, Type: comp.GetType()}
+			if ref.Type == ComponentTypeKustomize {
+				ref.Tag = tag
+			} else {
+				ref.Version = tag
+			}
+			refs = append(refs, ref)
+			break
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// findSubtype returns the named subtype of the first measurement of type t.
+func findSubtype(measurements []*measurement.Measurement, t measurement.Type, name string) *measurement.Subtype {
+	for _, m := range measurements {
+		if m == nil || m.Type != t {
+			continue
+		}
+		if st := m.GetSubtype(name); st != nil {
+			return st
+		}
+	}
+	return nil
+}
+
+// exactValue returns an exact-match constraint expression for v, quoting it
+// when it contains characters reserved by the constraint expression syntax.
+func exactValue(v string) string {
+	if !strings.ContainsAny(v, ",()[]\"\\&|<>=!~ ") {
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
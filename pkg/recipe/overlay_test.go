@@ -0,0 +1,276 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+	"gopkg.in/yaml.v3"
+)
+
+func overlayTestMeasurements() []*measurement.Measurement {
+	return []*measurement.Measurement{
+		{
+			Type: measurement.TypeK8s,
+			Subtypes: []measurement.Subtype{
+				{Name: "server", Data: map[string]measurement.Reading{"version": measurement.Str("v1.33.5-eks-3025e55")}},
+				{Name: "image", Data: map[string]measurement.Reading{
+					"gpu-operator":            measurement.Str("v25.3.0"),
+					"cert-manager-controller": measurement.Str("latest"),
+					"kustomized":              measurement.Str("v1.2.0"),
+					"unrelated":               measurement.Str("v9.9.9"),
+				}},
+			},
+		},
+		{
+			Type: measurement.TypeGPU,
+			Subtypes: []measurement.Subtype{
+				{Name: "smi", Data: map[string]measurement.Reading{"driver": measurement.Str("580.82.07")}},
+			},
+		},
+		{
+			Type: measurement.TypeOS,
+			Subtypes: []measurement.Subtype{
+				{Name: "sysctl", Data: map[string]measurement.Reading{"/proc/sys/kernel/osrelease": measurement.Str("6.8.0-1028-aws")}},
+				{Name: "release", Data: map[string]measurement.Reading{
+					"ID":         measurement.Str("ubuntu"),
+					"VERSION_ID": measurement.Str("24.04"),
+				}},
+			},
+		},
+	}
+}
+
+func overlayTestRegistry() *ComponentRegistry {
+	return &ComponentRegistry{Components: []ComponentConfig{
+		{Name: "gpu-operator", VersionImages: []string{"gpu-operator"}, Helm: HelmConfig{DefaultRepository: "https://helm.ngc.nvidia.com/nvidia"}},
+		{Name: "cert-manager", VersionImages: []string{"cert-manager-controller"}, Helm: HelmConfig{DefaultRepository: "https://charts.jetstack.io"}},
+		{Name: "kustomized", VersionImages: []string{"kustomized"}, Kustomize: KustomizeConfig{DefaultSource: "https://github.com/example/repo"}},
+		{Name: "prometheus", Helm: HelmConfig{DefaultRepository: "https://prometheus-community.github.io/helm-charts"}},
+	}}
+}
+
+func TestGenerateOverlay(t *testing.T) {
+	criteria := NewCriteria()
+	criteria.Service = CriteriaServiceEKS
+	criteria.Intent = CriteriaIntentTraining
+
+	overlay, err := GenerateOverlay(OverlayConfig{
+		Name:     "my-cluster",
+		Base:     "eks-training",
+		Criteria: criteria,
+		Registry: overlayTestRegistry(),
+	}, overlayTestMeasurements())
+	if err != nil {
+		t.Fatalf("GenerateOverlay() error = %v", err)
+	}
+
+	if overlay.Kind != RecipeMetadataKind || overlay.APIVersion != FullAPIVersion || overlay.Metadata.Name != "my-cluster" {
+		t.Errorf("header = %+v", overlay.RecipeMetadataHeader)
+	}
+	if overlay.Spec.Base != "eks-training" {
+		t.Errorf("Base = %q, want eks-training", overlay.Spec.Base)
+	}
+	if c := overlay.Spec.Criteria; c.Service != CriteriaServiceEKS || c.Intent != CriteriaIntentTraining || c.Accelerator != "" || c.OS != "" {
+		t.Errorf("Criteria = %+v, want unset fields omitted", c)
+	}
+
+	wantConstraints := []Constraint{
+		{Name: "K8s.server.version", Value: "v1.33.5-eks-3025e55"},
+		{Name: "GPU.smi.driver", Value: "580.82.07"},
+		{Name: "OS.sysctl./proc/sys/kernel/osrelease", Value: "6.8.0-1028-aws"},
+		{Name: "OS.release.ID", Value: "ubuntu"},
+		{Name: "OS.release.VERSION_ID", Value: "24.04"},
+	}
+	if len(overlay.Spec.Constraints) != len(wantConstraints) {
+		t.Fatalf("Constraints = %+v", overlay.Spec.Constraints)
+	}
+	for i, want := range wantConstraints {
+		if got := overlay.Spec.Constraints[i]; got.Name != want.Name || got.Value != want.Value {
+			t.Errorf("Constraints[%d] = %s: %s, want %s: %s", i, got.Name, got.Value, want.Name, want.Value)
+		}
+	}
+
+	// cert-manager runs an untagged image and prometheus has no version image
+	wantRefs := []ComponentRef{
+		{Name: "gpu-operator", Type: ComponentTypeHelm, Version: "v25.3.0"},
+		{Name: "kustomized", Type: ComponentTypeKustomize, Tag: "v1.2.0"},
+	}
+	if len(overlay.Spec.ComponentRefs) != len(wantRefs) {
+		t.Fatalf("ComponentRefs = %+v", overlay.Spec.ComponentRefs)
+	}
+	for i, want := range wantRefs {
+		got := overlay.Spec.ComponentRefs[i]
+		if got.Name != want.Name || got.Type != want.Type || got.Version != want.Version || got.Tag != want.Tag {
+			t.Errorf("ComponentRefs[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// The overlay must load like a hand-written one
+	data, err := yaml.Marshal(overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed RecipeMetadata
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("generated overlay does not parse: %v", err)
+	}
+	if parsed.Metadata.Name != "my-cluster" || len(parsed.Spec.Constraints) != 5 || !parsed.Spec.Criteria.Matches(criteria) {
+		t.Errorf("round trip = %+v", parsed)
+	}
+}
+
+func TestGenerateOverlay_Errors(t *testing.T) {
+	eks := NewCriteria()
+	eks.Service = CriteriaServiceEKS
+
+	tests := []struct {
+		name string
+		cfg  OverlayConfig
+	}{
+		{"missing name", OverlayConfig{Criteria: eks}},
+		{"reserved name", OverlayConfig{Name: "base", Criteria: eks}},
+		{"nil criteria", OverlayConfig{Name: "my-cluster"}},
+		{"any criteria", OverlayConfig{Name: "my-cluster", Criteria: NewCriteria()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Registry = overlayTestRegistry()
+			if _, err := GenerateOverlay(tt.cfg, overlayTestMeasurements()); err == nil {
+				t.Error("GenerateOverlay() error = nil, want error")
+			}
+		})
+	}
+}
+
+// overlayTestStore returns recipe data with an EKS overlay and an EKS
+// training overlay that inherits from it.
+func overlayTestStore() *MetadataStore {
+	recipe := func(name, base string, criteria *Criteria, constraints []Constraint, refs []ComponentRef) *RecipeMetadata {
+		r := &RecipeMetadata{}
+		r.Kind = RecipeMetadataKind
+		r.Metadata.Name = name
+		r.Spec.Base = base
+		r.Spec.Criteria = criteria
+		r.Spec.Constraints = constraints
+		r.Spec.ComponentRefs = refs
+		return r
+	}
+	return &MetadataStore{
+		Base: recipe("base", "", nil,
+			[]Constraint{{Name: "K8s.server.version", Value: ">= 1.28"}},
+			[]ComponentRef{{Name: "gpu-operator", Type: ComponentTypeHelm, Version: "v25.10.1"}}),
+		Overlays: map[string]*RecipeMetadata{
+			"eks": recipe("eks", "", &Criteria{Service: CriteriaServiceEKS},
+				[]Constraint{{Name: "K8s.server.version", Value: ">= 1.30"}}, nil),
+			"eks-training": recipe("eks-training", "eks", &Criteria{Service: CriteriaServiceEKS, Intent: CriteriaIntentTraining},
+				[]Constraint{{Name: "GPU.smi.driver", Value: ">= 570"}},
+				[]ComponentRef{{Name: "gpu-operator", Version: "v25.10.0"}}),
+		},
+	}
+}
+
+func TestGenerateOverlay_RoundTrip(t *testing.T) {
+	criteria := NewCriteria()
+	criteria.Service = CriteriaServiceEKS
+	criteria.Intent = CriteriaIntentTraining
+	criteria.OS = CriteriaOSUbuntu
+
+	store := overlayTestStore()
+	overlay, err := GenerateOverlay(OverlayConfig{
+		Name:     "my-cluster",
+		Criteria: criteria,
+		Registry: overlayTestRegistry(),
+		Store:    store,
+	}, overlayTestMeasurements())
+	if err != nil {
+		t.Fatalf("GenerateOverlay() error = %v", err)
+	}
+	if overlay.Spec.Base != "eks-training" {
+		t.Errorf("Base = %q, want the most specific matching overlay eks-training", overlay.Spec.Base)
+	}
+
+	// Drop the overlay into the data and build a recipe for its criteria
+	store.Overlays[overlay.Metadata.Name] = overlay
+	result, err := store.BuildRecipeResult(context.Background(), criteria)
+	if err != nil {
+		t.Fatalf("BuildRecipeResult() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, c := range result.Constraints {
+		got[c.Name] = c.Value
+	}
+	for _, pin := range overlay.Spec.Constraints {
+		if got[pin.Name] != pin.Value {
+			t.Errorf("constraint %s = %q, want pinned %q", pin.Name, got[pin.Name], pin.Value)
+		}
+	}
+	if ref := result.GetComponentRef("gpu-operator"); ref == nil || ref.Version != "v25.3.0" {
+		t.Errorf("gpu-operator = %+v, want pinned version v25.3.0", ref)
+	}
+}
+
+func TestGenerateOverlay_OverriddenPin(t *testing.T) {
+	criteria := NewCriteria()
+	criteria.Service = CriteriaServiceEKS
+	criteria.Intent = CriteriaIntentTraining
+
+	// eks-training replaces the pinned driver and is not inherited from
+	_, err := GenerateOverlay(OverlayConfig{
+		Name:     "my-cluster",
+		Base:     "eks",
+		Criteria: criteria,
+		Registry: overlayTestRegistry(),
+		Store:    overlayTestStore(),
+	}, overlayTestMeasurements())
+	if err == nil {
+		t.Fatal("GenerateOverlay() error = nil, want error for overridden pin")
+	}
+
+	_, err = GenerateOverlay(OverlayConfig{
+		Name:     "my-cluster",
+		Base:     "missing",
+		Criteria: criteria,
+		Registry: overlayTestRegistry(),
+		Store:    overlayTestStore(),
+	}, overlayTestMeasurements())
+	if err == nil {
+		t.Error("GenerateOverlay() error = nil, want error for unknown base")
+	}
+}
+
+func TestExactValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"ubuntu", "ubuntu"},
+		{"v1.33.5-eks-3025e55", "v1.33.5-eks-3025e55"},
+		{"Ubuntu 24.04 LTS", `"Ubuntu 24.04 LTS"`},
+		{`a"b`, `"a\"b"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := exactValue(tt.in); got != tt.want {
+				t.Errorf("exactValue(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -404,6 +404,7 @@ func mergeRegistries(embedded, external *ComponentRegistry) *ComponentRegistry {
 	result := &ComponentRegistry{
 		APIVersion: embedded.APIVersion,
 		Kind:       embedded.Kind,
+		Criteria:   mergeCriteriaDimensions(embedded.Criteria, external.Criteria),
 		Components: make([]ComponentConfig, 0, len(embedded.Components)+len(external.Components)),
 	}
 
@@ -439,6 +440,35 @@ func mergeRegistries(embedded, external *ComponentRegistry) *ComponentRegistry {
 	return result
 }
 
+// mergeCriteriaDimensions merges external criteria dimensions into embedded.
+// Dimensions with the same name are replaced by the external declaration, so
+// an external registry extends a dimension by redeclaring it with more values.
+// New dimensions from external are added.
+func mergeCriteriaDimensions(embedded, external []CriteriaDimension) []CriteriaDimension {
+	externalByName := make(map[string]CriteriaDimension, len(external))
+	for _, d := range external {
+		externalByName[d.Name] = d
+	}
+
+	result := make([]CriteriaDimension, 0, len(embedded)+len(external))
+	added := make(map[string]bool)
+	for _, d := range embedded {
+		if ext, found := externalByName[d.Name]; found {
+			d = ext
+			slog.Debug("criteria dimension overridden from external", "name", d.Name)
+		}
+		result = append(result, d)
+		added[d.Name] = true
+	}
+	for _, d := range external {
+		if !added[d.Name] {
+			result = append(result, d)
+			slog.Debug("criteria dimension added from external", "name", d.Name)
+		}
+	}
+	return result
+}
+
 // Global data provider (defaults to embedded, can be set for layered)
 var (
 	globalDataProvider     DataProvider
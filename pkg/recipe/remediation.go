@@ -0,0 +1,165 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// remediationCatalogFile is the data file holding the built-in remediation catalog.
+const remediationCatalogFile = "remediations.yaml"
+
+// Remediation describes how to resolve a failed constraint.
+type Remediation struct {
+	// Description explains what the constraint checks and how to satisfy it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// DocsURL links to documentation with further details.
+	DocsURL string `json:"docsURL,omitempty" yaml:"docsURL,omitempty"`
+
+	// Command is a suggested command that resolves the failure.
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+
+	// Values is a suggested values override (e.g., for --set or a values file).
+	Values map[string]any `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// RemediationCatalog holds the built-in remediations for known constraints.
+// This is loaded from data/remediations.yaml.
+type RemediationCatalog struct {
+	APIVersion   string             `yaml:"apiVersion"`
+	Kind         string             `yaml:"kind"`
+	Remediations []RemediationEntry `yaml:"remediations"`
+
+	// Index for fast lookup by constraint name (populated after loading)
+	byConstraint map[string][]*RemediationEntry
+}
+
+// RemediationEntry associates a remediation with a constraint name.
+type RemediationEntry struct {
+	// Constraint is the fully qualified constraint name (e.g., "K8s.server.version").
+	Constraint string `yaml:"constraint"`
+
+	// Criteria restricts the entry to recipes matching these criteria (e.g.,
+	// os: ubuntu for an apt-get command). Entries without criteria apply to
+	// every recipe.
+	Criteria *Criteria `yaml:"criteria,omitempty"`
+
+	Remediation `yaml:",inline"`
+}
+
+// Global remediation catalog (loaded once per data provider, thread-safe access)
+var (
+	globalRemediationsMu         sync.Mutex
+	globalRemediations           *RemediationCatalog
+	globalRemediationsErr        error
+	globalRemediationsLoaded     bool
+	globalRemediationsGeneration int
+)
+
+// GetRemediationCatalog returns the built-in remediation catalog.
+// The catalog is loaded from the data provider and cached until the data
+// provider changes (see SetDataProvider).
+func GetRemediationCatalog() (*RemediationCatalog, error) {
+	globalRemediationsMu.Lock()
+	defer globalRemediationsMu.Unlock()
+
+	generation := GetDataProviderGeneration()
+	if !globalRemediationsLoaded || globalRemediationsGeneration != generation {
+		globalRemediations, globalRemediationsErr = loadRemediationCatalog()
+		globalRemediationsLoaded = true
+		globalRemediationsGeneration = generation
+	}
+	return globalRemediations, globalRemediationsErr
+}
+
+// loadRemediationCatalog loads the remediation catalog from the data provider.
+func loadRemediationCatalog() (*RemediationCatalog, error) {
+	provider := GetDataProvider()
+	data, err := provider.ReadFile(remediationCatalogFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", remediationCatalogFile, err)
+	}
+	return parseRemediationCatalog(data)
+}
+
+// parseRemediationCatalog parses and indexes a remediation catalog.
+func parseRemediationCatalog(data []byte) (*RemediationCatalog, error) {
+	var catalog RemediationCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", remediationCatalogFile, err)
+	}
+
+	catalog.byConstraint = make(map[string][]*RemediationEntry, len(catalog.Remediations))
+	for i := range catalog.Remediations {
+		entry := &catalog.Remediations[i]
+		if entry.Constraint == "" {
+			return nil, fmt.Errorf("remediation %d in %s has no constraint name", i, remediationCatalogFile)
+		}
+		catalog.byConstraint[entry.Constraint] = append(catalog.byConstraint[entry.Constraint], entry)
+	}
+
+	return &catalog, nil
+}
+
+// Lookup returns the remediation for the named constraint whose criteria match
+// the recipe criteria, or nil if the catalog has no such entry. When several
+// entries match, the most specific one wins. Nil criteria only match entries
+// without criteria.
+func (c *RemediationCatalog) Lookup(constraint string, criteria *Criteria) *Remediation {
+	if c == nil || c.byConstraint == nil {
+		return nil
+	}
+	if criteria == nil {
+		criteria = NewCriteria()
+	}
+
+	var best *RemediationEntry
+	bestScore := -1
+	for _, entry := range c.byConstraint[constraint] {
+		entryCriteria := NewCriteria()
+		if entry.Criteria != nil {
+			entryCriteria = normalizeCriteria(entry.Criteria)
+		}
+		if !entryCriteria.Matches(criteria) {
+			continue
+		}
+		if score := entryCriteria.Specificity(); score > bestScore {
+			best, bestScore = entry, score
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return &best.Remediation
+}
+
+// RemediationFor returns the remediation declared on the constraint, falling
+// back to the built-in catalog entry matching the recipe criteria. Returns nil
+// if neither provides one.
+func RemediationFor(constraint Constraint, criteria *Criteria) *Remediation {
+	if constraint.Remediation != nil {
+		return constraint.Remediation
+	}
+	catalog, err := GetRemediationCatalog()
+	if err != nil {
+		return nil
+	}
+	return catalog.Lookup(constraint.Name, criteria)
+}
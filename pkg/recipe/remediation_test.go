@@ -0,0 +1,299 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestRemediationCatalogCoversOverlayConstraints verifies that every constraint
+// shipped in the embedded overlays has a built-in remediation.
+func TestRemediationCatalogCoversOverlayConstraints(t *testing.T) {
+	catalog, err := GetRemediationCatalog()
+	if err != nil {
+		t.Fatalf("failed to load remediation catalog: %v", err)
+	}
+
+	for _, path := range collectMetadataFiles(t) {
+		content, err := testMetadataFS.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+
+		var metadata RecipeMetadata
+		if err := yaml.Unmarshal(content, &metadata); err != nil {
+			t.Fatalf("failed to parse %s: %v", path, err)
+		}
+
+		for _, c := range metadata.Spec.Constraints {
+			r := catalog.Lookup(c.Name, metadata.Spec.Criteria)
+			if r == nil {
+				t.Errorf("%s: constraint %q has no entry in %s", path, c.Name, remediationCatalogFile)
+				continue
+			}
+			if r.Description == "" {
+				t.Errorf("remediation for %q has no description", c.Name)
+			}
+		}
+	}
+}
+
+func TestParseRemediationCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		lookup  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `remediations:
+  - constraint: K8s.server.version
+    description: upgrade
+    command: kubectl get nodes
+    values:
+      driver:
+        version: "580"
+`,
+			lookup: "K8s.server.version",
+			want:   "upgrade",
+		},
+		{
+			name:    "unknown constraint",
+			content: "remediations:\n  - constraint: OS.release.ID\n    description: os\n",
+			lookup:  "K8s.server.version",
+		},
+		{
+			name:    "missing constraint name",
+			content: "remediations:\n  - description: orphan\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			content: "remediations: [",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			catalog, err := parseRemediationCatalog([]byte(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			r := catalog.Lookup(tt.lookup, nil)
+			if tt.want == "" {
+				if r != nil {
+					t.Errorf("Lookup(%q) = %+v, want nil", tt.lookup, r)
+				}
+				return
+			}
+			if r == nil {
+				t.Fatalf("Lookup(%q) = nil", tt.lookup)
+			}
+			if r.Description != tt.want {
+				t.Errorf("description = %q, want %q", r.Description, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemediationFor(t *testing.T) {
+	declared := &Remediation{Description: "declared in recipe"}
+
+	if got := RemediationFor(Constraint{Name: "K8s.server.version", Value: ">= 1.30", Remediation: declared}, nil); got != declared {
+		t.Errorf("expected declared remediation to take precedence, got %+v", got)
+	}
+
+	if got := RemediationFor(Constraint{Name: "K8s.server.version", Value: ">= 1.30"}, nil); got == nil || got.DocsURL == "" {
+		t.Errorf("expected catalog remediation with docs URL, got %+v", got)
+	}
+
+	if got := RemediationFor(Constraint{Name: "GPU.smi.unknown", Value: "x"}, nil); got != nil {
+		t.Errorf("expected nil for unknown constraint, got %+v", got)
+	}
+
+	var nilCatalog *RemediationCatalog
+	if got := nilCatalog.Lookup("K8s.server.version", nil); got != nil {
+		t.Errorf("nil catalog Lookup = %+v, want nil", got)
+	}
+}
+
+func TestGetRemediationCatalog_DataProviderChange(t *testing.T) {
+	before, err := GetRemediationCatalog()
+	if err != nil {
+		t.Fatalf("GetRemediationCatalog() error = %v", err)
+	}
+	if before.Lookup("Custom.check.enabled", nil) != nil {
+		t.Fatal("embedded catalog unexpectedly has Custom.check.enabled")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"registry.yaml": "apiVersion: cns.nvidia.com/v1alpha1\nkind: ComponentRegistry\ncomponents: []\n",
+		remediationCatalogFile: `apiVersion: cns.nvidia.com/v1alpha1
+kind: RemediationCatalog
+remediations:
+  - constraint: Custom.check.enabled
+    description: Enable the custom check
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	provider, err := NewLayeredDataProvider(NewEmbeddedDataProvider(dataFS, "data"), LayeredProviderConfig{ExternalDir: dir})
+	if err != nil {
+		t.Fatalf("failed to create layered provider: %v", err)
+	}
+	original := globalDataProvider
+	SetDataProvider(provider)
+	t.Cleanup(func() { SetDataProvider(original) })
+
+	after, err := GetRemediationCatalog()
+	if err != nil {
+		t.Fatalf("GetRemediationCatalog() error = %v", err)
+	}
+	if got := after.Lookup("Custom.check.enabled", nil); got == nil || got.Description != "Enable the custom check" {
+		t.Errorf("Lookup(Custom.check.enabled) = %+v, want the external remediation", got)
+	}
+}
+
+func TestRemediationCatalog_LookupCriteria(t *testing.T) {
+	catalog, err := parseRemediationCatalog([]byte(`remediations:
+  - constraint: OS.sysctl./proc/sys/kernel/osrelease
+    description: generic
+  - constraint: OS.sysctl./proc/sys/kernel/osrelease
+    criteria:
+      os: ubuntu
+    description: ubuntu
+  - constraint: OS.sysctl./proc/sys/kernel/osrelease
+    criteria:
+      service: eks
+      os: ubuntu
+    description: eks ubuntu
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		criteria *Criteria
+		want     string
+	}{
+		{"nil criteria", nil, "generic"},
+		{"any criteria", NewCriteria(), "generic"},
+		{"other os", &Criteria{OS: CriteriaOSRHEL}, "generic"},
+		{"matching os", &Criteria{Service: CriteriaServiceGKE, OS: CriteriaOSUbuntu}, "ubuntu"},
+		{"most specific", &Criteria{Service: CriteriaServiceEKS, OS: CriteriaOSUbuntu}, "eks ubuntu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := catalog.Lookup("OS.sysctl./proc/sys/kernel/osrelease", tt.criteria)
+			if r == nil {
+				t.Fatal("Lookup() = nil")
+			}
+			if r.Description != tt.want {
+				t.Errorf("description = %q, want %q", r.Description, tt.want)
+			}
+		})
+	}
+}
+
+// TestRemediationCatalog_CommandsGatedByOS verifies that the built-in catalog
+// only suggests package manager commands for the matching operating system.
+func TestRemediationCatalog_CommandsGatedByOS(t *testing.T) {
+	catalog, err := GetRemediationCatalog()
+	if err != nil {
+		t.Fatalf("failed to load remediation catalog: %v", err)
+	}
+
+	const kernel = "OS.sysctl./proc/sys/kernel/osrelease"
+	tests := []struct {
+		name     string
+		criteria *Criteria
+		want     string
+	}{
+		{"no os", nil, ""},
+		{"ubuntu", &Criteria{OS: CriteriaOSUbuntu}, "apt-get"},
+		{"rhel", &Criteria{OS: CriteriaOSRHEL}, "dnf"},
+		{"amazonlinux", &Criteria{OS: CriteriaOSAmazonLinux}, "yum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := catalog.Lookup(kernel, tt.criteria)
+			if r == nil {
+				t.Fatalf("Lookup(%q) = nil", kernel)
+			}
+			if tt.want == "" {
+				if r.Command != "" {
+					t.Errorf("command = %q, want none without an OS", r.Command)
+				}
+				return
+			}
+			if !strings.Contains(r.Command, tt.want) {
+				t.Errorf("command = %q, want %s", r.Command, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecipeMetadataSpecMerge_KeepsRemediation(t *testing.T) {
+	base := &RecipeMetadataSpec{
+		Constraints: []Constraint{
+			{Name: "K8s.server.version", Value: ">= 1.25", Remediation: &Remediation{Description: "base"}},
+			{Name: "OS.release.ID", Value: "ubuntu", Remediation: &Remediation{Description: "base os"}},
+		},
+	}
+	overlay := &RecipeMetadataSpec{
+		Constraints: []Constraint{
+			{Name: "K8s.server.version", Value: ">= 1.30"},
+			{Name: "OS.release.ID", Value: "rhel", Remediation: &Remediation{Description: "overlay os"}},
+		},
+	}
+
+	base.Merge(overlay)
+
+	want := map[string]string{
+		"K8s.server.version": "base",
+		"OS.release.ID":      "overlay os",
+	}
+	for _, c := range base.Constraints {
+		if c.Remediation == nil {
+			t.Errorf("constraint %s lost its remediation", c.Name)
+			continue
+		}
+		if c.Remediation.Description != want[c.Name] {
+			t.Errorf("constraint %s remediation = %q, want %q", c.Name, c.Remediation.Description, want[c.Name])
+		}
+	}
+}
@@ -20,6 +20,15 @@ const (
 	// Format: cm://namespace/configmap-name
 	ConfigMapURIScheme = "cm://"
 
+	// OCIURIScheme is the URI scheme for documents stored as OCI artifacts.
+	// Format: oci://registry/repository:tag[#file]
+	OCIURIScheme = "oci://"
+
+	// HTTPURIScheme and HTTPSURIScheme are the URI schemes for HTTP endpoints.
+	// Documents are read with GET and written with POST.
+	HTTPURIScheme  = "http://"
+	HTTPSURIScheme = "https://"
+
 	// StdoutURI is the special URI indicating output should be written to stdout.
 	StdoutURI = "-"
 )
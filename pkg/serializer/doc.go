@@ -135,6 +135,16 @@
 //	    log.Fatal(err)
 //	}
 //
+// Read from a file, URL, ConfigMap or OCI artifact with FromFile:
+//
+//	snap, err := serializer.FromFile[snapshotter.Snapshot]("cm://gpu-operator/cns-snapshot")
+//	rec, err := serializer.FromFile[recipe.RecipeResult]("oci://ghcr.io/org/cns-bundle:v1.0.0#recipe.yaml")
+//
+// OCI artifact URIs (oci://registry/repository:tag or @digest) are pulled into a
+// temporary directory. The document is the file named after '#', or the only
+// JSON/YAML file of the artifact. The pull itself is delegated to the function
+// registered with SetArtifactPuller (cnsctl registers oci.PullURI).
+//
 // # Format Detection
 //
 // File extension-based detection:
@@ -165,6 +175,9 @@
 //	│     ├─ driver: 570.158.01
 //	│     └─ model: H100
 //
+// Types implementing TableWriter render their own table instead (e.g., a
+// column-aligned list of changes).
+//
 // Table format:
 //   - Does not support deserialization (read-only)
 //   - Best for human viewing in terminals
@@ -25,8 +25,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
 )
 
@@ -50,6 +53,60 @@ func RespondJSON(w http.ResponseWriter, statusCode int, data any) {
 	}
 }
 
+// RespondYAML writes a YAML response with the given status code and data.
+// Like RespondJSON, it buffers the encoding before writing headers.
+func RespondYAML(w http.ResponseWriter, statusCode int, data any) {
+	w.Header().Set("Content-Type", "application/yaml")
+
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(data); err != nil {
+		slog.Error("yaml encoding failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := enc.Close(); err != nil {
+		slog.Error("yaml encoding failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		// Connection is broken, log but can't recover
+		slog.Warn("response write failed", "error", err)
+	}
+}
+
+// Respond writes data as YAML when the request Accept header asks for YAML
+// (application/yaml, application/x-yaml or text/yaml), and as JSON otherwise.
+func Respond(w http.ResponseWriter, r *http.Request, statusCode int, data any) {
+	if AcceptsYAML(r) {
+		RespondYAML(w, statusCode, data)
+		return
+	}
+	RespondJSON(w, statusCode, data)
+}
+
+// AcceptsYAML reports whether the request Accept header prefers YAML over JSON.
+// The first YAML or JSON media type listed wins; quality values are ignored.
+func AcceptsYAML(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.ToLower(strings.TrimSpace(part))
+		if idx := strings.Index(mediaType, ";"); idx != -1 {
+			mediaType = strings.TrimSpace(mediaType[:idx])
+		}
+		switch {
+		case mediaType == "application/yaml", mediaType == "application/x-yaml", mediaType == "text/yaml":
+			return true
+		case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+			return false
+		}
+	}
+	return false
+}
+
 const (
 	HttpReaderUserAgent = "CNS-Serializer/1.0"
 )
@@ -25,6 +25,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type testData struct {
@@ -187,6 +189,56 @@ func TestRespondJSON_BuffersBeforeWritingHeaders(t *testing.T) {
 	}
 }
 
+func TestRespond_ContentNegotiation(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{"no accept header", "", "application/json"},
+		{"json", "application/json", "application/json"},
+		{"yaml", "application/yaml", "application/yaml"},
+		{"x-yaml", "application/x-yaml", "application/yaml"},
+		{"text yaml with params", "text/yaml; charset=utf-8", "application/yaml"},
+		{"json listed first", "application/json, application/yaml", "application/json"},
+		{"yaml listed first", "application/yaml, application/json;q=0.9", "application/yaml"},
+		{"wildcard", "*/*", "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			Respond(w, r, http.StatusCreated, testData{Message: "ok", Code: 1})
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("expected status %d, got %d", http.StatusCreated, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != tt.want {
+				t.Errorf("expected Content-Type %s, got %s", tt.want, ct)
+			}
+
+			var result testData
+			var err error
+			if tt.want == "application/yaml" {
+				err = yaml.Unmarshal(w.Body.Bytes(), &result)
+			} else {
+				err = json.Unmarshal(w.Body.Bytes(), &result)
+			}
+			if err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if result.Message != "ok" {
+				t.Errorf("expected message ok, got %s", result.Message)
+			}
+		})
+	}
+}
+
 func TestNewHttpReader_Defaults(t *testing.T) {
 	reader := NewHttpReader()
 
@@ -0,0 +1,109 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize caps the response body included in HTTPWriter errors.
+const maxErrorBodySize = 512
+
+// HTTPWriter posts serialized data to an HTTP(S) endpoint, such as the
+// snapshot ingestion endpoint of the API server (POST /v1/snapshots).
+// Query parameters of the URL are sent as-is, e.g.
+// https://cns.example.com/v1/snapshots?cluster=prod.
+type HTTPWriter struct {
+	url    string
+	format Format
+	client *http.Client
+}
+
+// NewHTTPWriter creates a new HTTPWriter that posts to url in the given format.
+// Only JSON and YAML are supported; unknown formats default to JSON.
+func NewHTTPWriter(url string, format Format) *HTTPWriter {
+	if format.IsUnknown() {
+		slog.Warn("unknown format, defaulting to JSON", "format", format)
+		format = FormatJSON
+	}
+	return &HTTPWriter{
+		url:    url,
+		format: format,
+		client: &http.Client{Timeout: HttpReaderDefaultTimeout, Transport: newDefaultHTTPTransport()},
+	}
+}
+
+// Serialize posts data to the endpoint and fails unless it responds with a 2xx status.
+func (w *HTTPWriter) Serialize(ctx context.Context, data any) error {
+	var content []byte
+	var contentType string
+	var err error
+	switch w.format {
+	case FormatJSON:
+		content, err = serializeJSON(data)
+		contentType = "application/json"
+	case FormatYAML:
+		content, err = serializeYAML(data)
+		contentType = "application/yaml"
+	case FormatTable:
+		return fmt.Errorf("unsupported format for HTTP output: %s", w.format)
+	default:
+		return fmt.Errorf("unsupported format: %s", w.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to create request for url %s: %w", w.url, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", HttpReaderUserAgent)
+
+	slog.Info("posting document", "url", w.url, "format", w.format, "bytes", len(content))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("http request failed for url %s: %w", w.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("failed to post data: status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Close is a no-op for HTTPWriter as there are no resources to release.
+// This method exists to satisfy the Closer interface.
+func (w *HTTPWriter) Close() error {
+	return nil
+}
+
+// IsHTTPURI reports whether uri is an http:// or https:// URL.
+func IsHTTPURI(uri string) bool {
+	return strings.HasPrefix(uri, HTTPURIScheme) || strings.HasPrefix(uri, HTTPSURIScheme)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHTTPWriter_Serialize(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		contentType string
+		decode      func([]byte, any) error
+	}{
+		{"json", FormatJSON, "application/json", json.Unmarshal},
+		{"yaml", FormatYAML, "application/yaml", yaml.Unmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testData
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("expected POST, got %s", r.Method)
+				}
+				if r.URL.Query().Get("cluster") != "prod" {
+					t.Errorf("expected cluster query parameter, got %q", r.URL.RawQuery)
+				}
+				if ct := r.Header.Get("Content-Type"); ct != tt.contentType {
+					t.Errorf("expected Content-Type %s, got %s", tt.contentType, ct)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if err := tt.decode(body, &got); err != nil {
+					t.Errorf("failed to decode body: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer srv.Close()
+
+			ser, err := NewFileWriterOrStdout(tt.format, srv.URL+"/v1/snapshots?cluster=prod")
+			if err != nil {
+				t.Fatalf("NewFileWriterOrStdout() error = %v", err)
+			}
+			if _, ok := ser.(*HTTPWriter); !ok {
+				t.Fatalf("expected *HTTPWriter, got %T", ser)
+			}
+
+			if err := ser.Serialize(context.Background(), testData{Message: "posted", Code: 7}); err != nil {
+				t.Fatalf("Serialize() error = %v", err)
+			}
+			if got.Message != "posted" || got.Code != 7 {
+				t.Errorf("server received %+v", got)
+			}
+		})
+	}
+}
+
+func TestHTTPWriter_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "snapshot rejected", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	err := NewHTTPWriter(srv.URL, FormatJSON).Serialize(context.Background(), testData{})
+	if err == nil || !strings.Contains(err.Error(), "snapshot rejected") {
+		t.Errorf("expected error with response body, got %v", err)
+	}
+
+	if err := NewHTTPWriter(srv.URL, FormatTable).Serialize(context.Background(), testData{}); err == nil {
+		t.Error("expected error for table format")
+	}
+}
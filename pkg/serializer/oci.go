@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
+)
+
+// ArtifactPuller pulls the OCI artifact at an oci:// reference
+// (oci://registry/repository:tag or @digest) and unpacks its files into dir.
+type ArtifactPuller func(ctx context.Context, reference, dir string) error
+
+var (
+	artifactPullerMu sync.RWMutex
+	artifactPuller   ArtifactPuller
+)
+
+// SetArtifactPuller sets the function used to fetch oci:// URIs.
+// The serializer has no OCI client of its own, so applications that accept
+// OCI URIs register one at startup. Reading an oci:// URI fails until then.
+func SetArtifactPuller(puller ArtifactPuller) {
+	artifactPullerMu.Lock()
+	defer artifactPullerMu.Unlock()
+	artifactPuller = puller
+}
+
+func getArtifactPuller() ArtifactPuller {
+	artifactPullerMu.RLock()
+	defer artifactPullerMu.RUnlock()
+	return artifactPuller
+}
+
+// fromOCIArtifact reads and deserializes a document stored in an OCI artifact.
+//
+// The artifact is pulled into a temporary directory. The document is the file
+// named after '#' in the URI (e.g., oci://ghcr.io/org/bundle:v1#recipe.yaml),
+// or the only JSON/YAML file at the root of the artifact when no file is named.
+func fromOCIArtifact[T any](uri string) (*T, error) {
+	puller := getArtifactPuller()
+	if puller == nil {
+		return nil, fmt.Errorf("OCI URIs are not supported: no artifact puller configured")
+	}
+
+	target, file, _ := strings.Cut(uri, "#")
+
+	tmpDir, err := os.MkdirTemp("", "cns-oci-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(tmpDir); removeErr != nil {
+			slog.Warn("failed to remove temporary directory", "path", tmpDir, "error", removeErr)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaults.HTTPClientTimeout)
+	defer cancel()
+
+	if pullErr := puller(ctx, target, tmpDir); pullErr != nil {
+		return nil, fmt.Errorf("failed to pull %q: %w", target, pullErr)
+	}
+
+	path, err := selectArtifactFile(tmpDir, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", uri, err)
+	}
+
+	slog.Debug("reading from OCI artifact", "reference", target, "file", filepath.Base(path))
+
+	return FromFile[T](path)
+}
+
+// selectArtifactFile returns the path of the named file in an unpacked
+// artifact, or of its only JSON/YAML file when no name is given.
+func selectArtifactFile(dir, name string) (string, error) {
+	if name != "" {
+		if !filepath.IsLocal(name) {
+			return "", fmt.Errorf("file %q must be a relative path within the artifact", name)
+		}
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("artifact has no file %q", name)
+		}
+		return path, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list artifact files: %w", err)
+	}
+	var candidates []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			candidates = append(candidates, entry.Name())
+		}
+	}
+	sort.Strings(candidates)
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("artifact has no JSON or YAML file")
+	case 1:
+		return filepath.Join(dir, candidates[0]), nil
+	default:
+		return "", fmt.Errorf("artifact has several files (%s), select one with #<file>",
+			strings.Join(candidates, ", "))
+	}
+}
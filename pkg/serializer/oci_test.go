@@ -0,0 +1,111 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serializer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci"
+	"github.com/NVIDIA/cloud-native-stack/pkg/oci/ocitest"
+)
+
+func pushTestArtifact(t *testing.T, reg *ocitest.Registry, tag string, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := oci.PackageAndPush(context.Background(), oci.OutputConfig{
+		SourceDir: dir,
+		OutputDir: t.TempDir(),
+		Reference: &oci.Reference{IsOCI: true, Registry: reg.Host(), Repository: "test/docs", Tag: tag},
+		PlainHTTP: true,
+	})
+	if err != nil {
+		t.Fatalf("PackageAndPush() error = %v", err)
+	}
+	return result.Digest
+}
+
+func TestFromFile_OCI(t *testing.T) {
+	type doc struct {
+		Name string `json:"name" yaml:"name"`
+	}
+
+	SetArtifactPuller(func(ctx context.Context, reference, dir string) error {
+		_, err := oci.PullURI(ctx, reference, dir)
+		return err
+	})
+	t.Cleanup(func() { SetArtifactPuller(nil) })
+
+	reg := ocitest.NewRegistry(t)
+	single := pushTestArtifact(t, reg, "single", map[string]string{"recipe.yaml": "name: recipe\n"})
+	pushTestArtifact(t, reg, "multi", map[string]string{
+		"recipe.yaml":   "name: recipe\n",
+		"snapshot.json": `{"name": "snapshot"}`,
+		"README.md":     "# docs\n",
+	})
+	base := "oci://" + reg.Host() + "/test/docs"
+
+	tests := []struct {
+		name     string
+		uri      string
+		wantName string
+		wantErr  string
+	}{
+		{name: "single file", uri: base + ":single", wantName: "recipe"},
+		{name: "pinned digest", uri: base + "@" + single, wantName: "recipe"},
+		{name: "selected yaml file", uri: base + ":multi#recipe.yaml", wantName: "recipe"},
+		{name: "selected json file", uri: base + ":multi#snapshot.json", wantName: "snapshot"},
+		{name: "ambiguous", uri: base + ":multi", wantErr: "select one with #<file>"},
+		{name: "missing file", uri: base + ":multi#missing.yaml", wantErr: "artifact has no file"},
+		{name: "path traversal", uri: base + ":multi#../etc/passwd", wantErr: "relative path within the artifact"},
+		{name: "missing tag", uri: base, wantErr: "tag or digest is required"},
+		{name: "unknown tag", uri: base + ":missing", wantErr: "failed to pull"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromFile[doc](tt.uri)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FromFile() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromFile() error = %v", err)
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", got.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFromFile_OCIWithoutPuller(t *testing.T) {
+	_, err := FromFile[map[string]any]("oci://localhost:5000/test/docs:v1")
+	if err == nil || !strings.Contains(err.Error(), "no artifact puller configured") {
+		t.Fatalf("FromFile() error = %v, want missing puller error", err)
+	}
+}
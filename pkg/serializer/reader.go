@@ -148,7 +148,7 @@ func NewFileReader(format Format, filePath string) (*Reader, error) {
 	var file *os.File
 	var err error
 
-	if strings.HasPrefix(filePath, "http://") || strings.HasPrefix(filePath, "https://") {
+	if IsHTTPURI(filePath) {
 		name := fmt.Sprintf("cns-%d.tmp", time.Now().UnixNano())
 		tempFilePath := filepath.Join(os.TempDir(), name)
 		httpReader := NewHttpReader()
@@ -302,11 +302,13 @@ func (r *Reader) Close() error {
 //   - Local file paths: /path/to/file.json, ./config.yaml
 //   - HTTP URLs: http://example.com/data.json, https://api.example.com/config.yaml
 //   - ConfigMap URIs: cm://namespace/configmap-name
+//   - OCI artifact URIs: oci://registry/repository:tag[#file]
 //
 // Format detection:
 //   - File paths: Determined by extension (.json, .yaml, .yml)
 //   - URLs: Determined by URL path extension or response Content-Type
 //   - ConfigMap: Always YAML format (ConfigMaps store data as YAML)
+//   - OCI artifacts: Determined by the extension of the selected file
 //
 // Returns:
 //   - Pointer to deserialized object of type T
@@ -324,13 +326,14 @@ func FromFile[T any](path string) (*T, error) {
 	return FromFileWithKubeconfig[T](path, "")
 }
 
-// FromFileWithKubeconfig reads and deserializes data from a file path, HTTP URL, or ConfigMap URI with custom kubeconfig.
+// FromFileWithKubeconfig reads and deserializes data from a file path, HTTP URL, ConfigMap URI,
+// or OCI artifact URI with custom kubeconfig.
 //
 // This is identical to FromFile but allows specifying a custom kubeconfig path for ConfigMap URIs.
 // The kubeconfig parameter is only used when path is a ConfigMap URI (cm://namespace/name).
 //
 // Parameters:
-//   - path: File path, HTTP/HTTPS URL, or ConfigMap URI (cm://namespace/name)
+//   - path: File path, HTTP/HTTPS URL, ConfigMap URI (cm://namespace/name), or OCI URI (oci://registry/repository:tag)
 //   - kubeconfig: Path to kubeconfig file (only used for ConfigMap URIs, empty string uses default discovery)
 //
 // Example:
@@ -346,6 +349,11 @@ func FromFileWithKubeconfig[T any](path, kubeconfig string) (*T, error) {
 		return fromConfigMapWithKubeconfig[T](namespace, name, kubeconfig)
 	}
 
+	// Check for OCI artifact URI
+	if strings.HasPrefix(path, OCIURIScheme) {
+		return fromOCIArtifact[T](path)
+	}
+
 	fileFormat := FormatFromPath(path)
 	slog.Debug("determined file format",
 		slog.String("path", path),
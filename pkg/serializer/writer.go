@@ -62,6 +62,12 @@ func SupportedFormats() []string {
 	}
 }
 
+// TableWriter is implemented by types that render their own table format
+// instead of the generic flattened field/value listing.
+type TableWriter interface {
+	WriteTable(w io.Writer) error
+}
+
 // Writer handles serialization of configuration data to various formats.
 // Close must be called to release file handles when using NewFileWriterOrStdout.
 type Writer struct {
@@ -92,7 +98,8 @@ func NewWriter(format Format, output io.Writer) *Writer {
 // Returns an error if the path is invalid or the file cannot be created.
 // Remember to call Close() on the returned Writer to ensure the file is properly closed.
 //
-// Supports ConfigMap URIs in the format cm://namespace/name for Kubernetes ConfigMap output.
+// Supports ConfigMap URIs in the format cm://namespace/name for Kubernetes ConfigMap output,
+// and http:// or https:// URLs, which receive the document with a POST request.
 func NewFileWriterOrStdout(format Format, path string) (Serializer, error) {
 	trimmed := strings.TrimSpace(path)
 	if trimmed == "" || trimmed == "-" || trimmed == StdoutURI {
@@ -108,6 +115,11 @@ func NewFileWriterOrStdout(format Format, path string) (Serializer, error) {
 		return NewConfigMapWriter(namespace, name, format), nil
 	}
 
+	// Check for HTTP endpoint (e.g., https://cns.example.com/v1/snapshots)
+	if IsHTTPURI(trimmed) {
+		return NewHTTPWriter(trimmed, format), nil
+	}
+
 	file, err := os.Create(trimmed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create output file %q: %w", trimmed, err)
@@ -183,6 +195,10 @@ func (w *Writer) serializeYAML(config any) error {
 }
 
 func (w *Writer) serializeTable(config any) error {
+	if tw, ok := config.(TableWriter); ok {
+		return tw.WriteTable(w.output)
+	}
+
 	flat := make(map[string]any)
 	flattenValue(flat, reflect.ValueOf(config), "")
 	if len(flat) == 0 {
@@ -283,6 +299,14 @@ func serializeYAML(data any) ([]byte, error) {
 // serializeTable serializes data to table format and returns the bytes.
 // This is used by ConfigMapWriter to serialize data without needing an io.Writer.
 func serializeTable(data any) ([]byte, error) {
+	if tw, ok := data.(TableWriter); ok {
+		var builder strings.Builder
+		if err := tw.WriteTable(&builder); err != nil {
+			return nil, fmt.Errorf("failed to write table: %w", err)
+		}
+		return []byte(builder.String()), nil
+	}
+
 	flat := make(map[string]any)
 	flattenValue(flat, reflect.ValueOf(data), "")
 	if len(flat) == 0 {
@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -347,6 +349,39 @@ func TestWriter_SerializeTable_EmptyData(t *testing.T) {
 	}
 }
 
+type customTable struct {
+	Rows []string
+}
+
+func (c customTable) WriteTable(w io.Writer) error {
+	for _, r := range c.Rows {
+		if _, err := fmt.Fprintln(w, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestWriter_SerializeTable_TableWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(FormatTable, &buf)
+
+	if err := writer.Serialize(context.Background(), customTable{Rows: []string{"a", "b"}}); err != nil {
+		t.Fatalf("Serialize failed: %v", err)
+	}
+	if got := buf.String(); got != "a\nb\n" {
+		t.Errorf("output = %q, want custom table", got)
+	}
+
+	data, err := serializeTable(customTable{Rows: []string{"c"}})
+	if err != nil {
+		t.Fatalf("serializeTable failed: %v", err)
+	}
+	if string(data) != "c\n" {
+		t.Errorf("serializeTable = %q, want custom table", data)
+	}
+}
+
 func TestWriter_SerializeTable_NestedStructs(t *testing.T) {
 	var buf bytes.Buffer
 	writer := NewWriter(FormatTable, &buf)
@@ -15,6 +15,7 @@
 package snapshotter
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -79,6 +80,14 @@ type AgentConfig struct {
 	// Privileged enables privileged mode (hostPID, hostNetwork, privileged container).
 	// Required for GPU and SystemD collectors. When false, only K8s and OS collectors work.
 	Privileged bool
+
+	// AllNodes runs the agent on every node matching NodeSelector and merges the
+	// results into a ClusterSnapshot written with the snapshotter's Serializer.
+	AllNodes bool
+
+	// PoolKeys overrides the measurement paths used to group nodes into pools
+	// (AllNodes only). Defaults to DefaultPoolKeys.
+	PoolKeys []string
 }
 
 // ParseNodeSelectors parses node selector strings in format "key=value".
@@ -168,9 +177,12 @@ func (n *NodeSnapshotter) measureWithAgent(ctx context.Context) error {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	// Default output to ConfigMap if not specified
+	// Default output to ConfigMap if not specified. In all-nodes mode the agents
+	// always write to ConfigMaps; the merged result goes to the Serializer.
+	// Otherwise an HTTP(S) output is passed to the agent, which posts its
+	// snapshot directly (e.g., to a central API server).
 	output := n.AgentConfig.Output
-	if output == "" {
+	if output == "" || (n.AgentConfig.AllNodes && !strings.HasPrefix(output, serializer.ConfigMapURIScheme)) {
 		output = fmt.Sprintf("%s%s/cns-snapshot", serializer.ConfigMapURIScheme, n.AgentConfig.Namespace)
 	}
 
@@ -186,6 +198,7 @@ func (n *NodeSnapshotter) measureWithAgent(ctx context.Context) error {
 		Output:             output,
 		Debug:              n.AgentConfig.Debug,
 		Privileged:         n.AgentConfig.Privileged,
+		AllNodes:           n.AgentConfig.AllNodes,
 	}
 
 	// Create deployer
@@ -266,6 +279,16 @@ func (n *NodeSnapshotter) measureWithAgent(ctx context.Context) error {
 
 	slog.Info("job completed successfully")
 
+	if n.AgentConfig.AllNodes {
+		return n.writeClusterSnapshot(ctx, deployer)
+	}
+
+	// The agent posted the snapshot itself; there is no ConfigMap to retrieve
+	if serializer.IsHTTPURI(output) {
+		slog.Info("snapshot sent to HTTP endpoint", slog.String("url", output))
+		return nil
+	}
+
 	// Retrieve snapshot from ConfigMap
 	slog.Debug("retrieving snapshot from ConfigMap")
 	snapshotData, err := deployer.GetSnapshot(ctx)
@@ -291,3 +314,54 @@ func (n *NodeSnapshotter) measureWithAgent(ctx context.Context) error {
 
 	return nil
 }
+
+// writeClusterSnapshot retrieves the per-node snapshots, merges them into a
+// ClusterSnapshot and serializes the result.
+func (n *NodeSnapshotter) writeClusterSnapshot(ctx context.Context, deployer *agent.Deployer) error {
+	slog.Debug("retrieving node snapshots from ConfigMaps")
+	data, err := deployer.GetSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve node snapshots: %w", err)
+	}
+
+	snapshots := make([]*Snapshot, 0, len(data))
+	for i, d := range data {
+		snap, parseErr := parseSnapshot(d)
+		if parseErr != nil {
+			return fmt.Errorf("failed to parse snapshot %d: %w", i, parseErr)
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	cluster, err := NewClusterSnapshot(n.Version, snapshots, n.AgentConfig.PoolKeys)
+	if err != nil {
+		return fmt.Errorf("failed to build cluster snapshot: %w", err)
+	}
+
+	slog.Info("cluster snapshot assembled",
+		slog.Int("nodes", len(cluster.Nodes)),
+		slog.Int("pools", len(cluster.Pools)),
+		slog.Bool("drift", cluster.HasDrift()))
+
+	if n.Serializer == nil {
+		n.Serializer = serializer.NewStdoutWriter(serializer.FormatYAML)
+	}
+	if err := n.Serializer.Serialize(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to serialize cluster snapshot: %w", err)
+	}
+	return nil
+}
+
+// parseSnapshot decodes a YAML (or JSON) snapshot document.
+func parseSnapshot(data []byte) (*Snapshot, error) {
+	reader, err := serializer.NewReader(serializer.FormatYAML, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := reader.Deserialize(&snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
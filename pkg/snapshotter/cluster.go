@@ -0,0 +1,305 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// SourceNodeKey is the snapshot metadata key holding the name of the node
+// the snapshot was captured on.
+const SourceNodeKey = "source-node"
+
+// DefaultPoolKeys are the measurement paths ({Type}.{Subtype}.{Key}) that
+// identify a node pool. Nodes agreeing on every pool key are grouped together.
+var DefaultPoolKeys = []string{
+	"GPU.smi.gpu.model",
+	"GPU.smi.gpu-count",
+	"OS.release.ID",
+	"OS.release.VERSION_ID",
+}
+
+// DefaultDriftIgnore are reading path patterns ({Type}.{Subtype}.{Key}) excluded
+// from drift detection: node and device identities, plus VolatileReadings.
+// Supports measurement.FilterOut patterns.
+var DefaultDriftIgnore = append([]string{
+	"K8s.node.source-node",
+	"K8s.node.provider-id",
+	"GPU.*.uuid",     // per-GPU identity (GPU.gpu.<index>.uuid)
+	"GPU.*.serial",   // per-GPU board serial number
+	"Network.*-guid", // per-device RDMA GUIDs (Network.rdma.<device>.node-guid)
+}, VolatileReadings...)
+
+// ClusterSnapshot aggregates per-node snapshots and groups nodes with the same
+// configuration into pools.
+type ClusterSnapshot struct {
+	header.Header `json:",inline" yaml:",inline"`
+
+	// PoolKeys are the measurement paths used to group nodes into pools.
+	PoolKeys []string `json:"poolKeys" yaml:"poolKeys"`
+
+	// Pools contains the node pools, ordered by name.
+	Pools []*NodePool `json:"pools" yaml:"pools"`
+
+	// Nodes contains the per-node measurements, ordered by node name.
+	Nodes []*NodeSnapshot `json:"nodes" yaml:"nodes"`
+}
+
+// NodePool is a group of nodes that share the same values for all pool keys.
+type NodePool struct {
+	// Name is the generated pool name (e.g., "pool-0").
+	Name string `json:"name" yaml:"name"`
+
+	// Attributes maps each pool key to the value shared by all nodes in the pool.
+	Attributes map[string]string `json:"attributes" yaml:"attributes"`
+
+	// Reference is the node whose measurements other pool members are compared against.
+	Reference string `json:"reference" yaml:"reference"`
+
+	// Nodes lists the names of the nodes in the pool.
+	Nodes []string `json:"nodes" yaml:"nodes"`
+
+	// Drift lists readings that differ from the reference node within the pool.
+	Drift []NodeDrift `json:"drift,omitempty" yaml:"drift,omitempty"`
+}
+
+// NodeDrift describes a reading on a node that differs from the pool reference.
+type NodeDrift struct {
+	// Node is the name of the drifting node.
+	Node string `json:"node" yaml:"node"`
+
+	// Path is the fully qualified reading path ({Type}.{Subtype}.{Key}).
+	Path string `json:"path" yaml:"path"`
+
+	// Expected is the value on the reference node (empty if absent there).
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+
+	// Actual is the value on the drifting node (empty if absent there).
+	Actual string `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// NodeSnapshot holds the measurements captured on a single node.
+type NodeSnapshot struct {
+	// Name is the node name.
+	Name string `json:"name" yaml:"name"`
+
+	// Pool is the name of the pool the node belongs to.
+	Pool string `json:"pool" yaml:"pool"`
+
+	// Measurements contains the measurements collected on the node.
+	Measurements []*measurement.Measurement `json:"measurements" yaml:"measurements"`
+}
+
+// NewClusterSnapshot merges per-node snapshots into a ClusterSnapshot.
+// Nodes are identified by the "source-node" metadata of each snapshot and grouped
+// into pools by poolKeys (DefaultPoolKeys when empty). Within each pool, the first
+// node by name is the reference and every other node is checked for drift against it.
+func NewClusterSnapshot(version string, snapshots []*Snapshot, poolKeys []string) (*ClusterSnapshot, error) {
+	if len(snapshots) == 0 {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "at least one node snapshot is required")
+	}
+	if len(poolKeys) == 0 {
+		poolKeys = DefaultPoolKeys
+	}
+
+	cs := &ClusterSnapshot{
+		PoolKeys: poolKeys,
+		Pools:    make([]*NodePool, 0),
+		Nodes:    make([]*NodeSnapshot, 0, len(snapshots)),
+	}
+	cs.Init(header.KindClusterSnapshot, FullAPIVersion, version)
+
+	seen := make(map[string]bool)
+	for i, snap := range snapshots {
+		if snap == nil {
+			return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest, "node snapshot is nil",
+				map[string]any{"index": i})
+		}
+		name := snap.Metadata[SourceNodeKey]
+		if name == "" {
+			name = fmt.Sprintf("node-%d", i)
+		}
+		if seen[name] {
+			return nil, errors.NewWithContext(errors.ErrCodeInvalidRequest, "duplicate node in cluster snapshot",
+				map[string]any{"node": name})
+		}
+		seen[name] = true
+		cs.Nodes = append(cs.Nodes, &NodeSnapshot{Name: name, Measurements: snap.Measurements})
+	}
+	sort.Slice(cs.Nodes, func(i, j int) bool { return cs.Nodes[i].Name < cs.Nodes[j].Name })
+
+	// Group nodes by the values of the pool keys
+	groups := make(map[string][]*NodeSnapshot)
+	attributes := make(map[string]map[string]string)
+	for _, node := range cs.Nodes {
+		attrs := make(map[string]string, len(poolKeys))
+		parts := make([]string, 0, len(poolKeys))
+		for _, key := range poolKeys {
+			value, _ := lookupReading(node.Measurements, key)
+			attrs[key] = value
+			parts = append(parts, key+"="+value)
+		}
+		fingerprint := strings.Join(parts, ",")
+		groups[fingerprint] = append(groups[fingerprint], node)
+		attributes[fingerprint] = attrs
+	}
+
+	fingerprints := make([]string, 0, len(groups))
+	for fp := range groups {
+		fingerprints = append(fingerprints, fp)
+	}
+	sort.Strings(fingerprints)
+
+	for i, fp := range fingerprints {
+		members := groups[fp]
+		pool := &NodePool{
+			Name:       fmt.Sprintf("pool-%d", i),
+			Attributes: attributes[fp],
+			Reference:  members[0].Name,
+			Nodes:      make([]string, 0, len(members)),
+		}
+		for _, node := range members {
+			node.Pool = pool.Name
+			pool.Nodes = append(pool.Nodes, node.Name)
+			if node != members[0] {
+				pool.Drift = append(pool.Drift, diffNodes(members[0], node)...)
+			}
+		}
+		cs.Pools = append(cs.Pools, pool)
+	}
+
+	return cs, nil
+}
+
+// Pool returns the pool with the given name, or nil if not found.
+func (c *ClusterSnapshot) Pool(name string) *NodePool {
+	for _, p := range c.Pools {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Node returns the node with the given name, or nil if not found.
+func (c *ClusterSnapshot) Node(name string) *NodeSnapshot {
+	for _, n := range c.Nodes {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// NodeSnapshotFor returns the measurements of the named node as a standalone
+// Snapshot, or nil if the node is not part of the cluster snapshot.
+func (c *ClusterSnapshot) NodeSnapshotFor(name string) *Snapshot {
+	node := c.Node(name)
+	if node == nil {
+		return nil
+	}
+
+	snap := NewSnapshot()
+	snap.Kind = header.KindSnapshot
+	snap.APIVersion = c.APIVersion
+	snap.Metadata = map[string]string{SourceNodeKey: node.Name}
+	if v, ok := c.Metadata["version"]; ok {
+		snap.Metadata["version"] = v
+	}
+	snap.Measurements = node.Measurements
+	return snap
+}
+
+// HasDrift reports whether any pool contains nodes that differ from their reference.
+func (c *ClusterSnapshot) HasDrift() bool {
+	for _, p := range c.Pools {
+		if len(p.Drift) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// diffNodes returns the readings on node that differ from ref, sorted by path.
+func diffNodes(ref, node *NodeSnapshot) []NodeDrift {
+	expected := flattenReadings(ref.Measurements)
+	actual := flattenReadings(node.Measurements)
+
+	paths := make(map[string]bool, len(expected)+len(actual))
+	for p := range expected {
+		paths[p] = true
+	}
+	for p := range actual {
+		paths[p] = true
+	}
+
+	drift := make([]NodeDrift, 0)
+	for p := range paths {
+		e, eok := expected[p]
+		a, aok := actual[p]
+		if eok == aok && e == a {
+			continue
+		}
+		drift = append(drift, NodeDrift{Node: node.Name, Path: p, Expected: e, Actual: a})
+	}
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Path < drift[j].Path })
+	return drift
+}
+
+// flattenReadings maps every reading path to its string value, skipping
+// DefaultDriftIgnore.
+func flattenReadings(measurements []*measurement.Measurement) map[string]string {
+	readings := make(map[string]measurement.Reading)
+	for _, m := range measurements {
+		if m == nil {
+			continue
+		}
+		for _, st := range m.Subtypes {
+			for k, v := range st.Data {
+				readings[fmt.Sprintf("%s.%s.%s", m.Type, st.Name, k)] = v
+			}
+		}
+	}
+
+	out := make(map[string]string, len(readings))
+	for p, v := range measurement.FilterOut(readings, DefaultDriftIgnore) {
+		out[p] = v.String()
+	}
+	return out
+}
+
+// lookupReading returns the value at a {Type}.{Subtype}.{Key} path.
+// The subtype and key may themselves contain dots.
+func lookupReading(measurements []*measurement.Measurement, path string) (string, bool) {
+	typ, rest, ok := strings.Cut(path, ".")
+	if !ok {
+		return "", false
+	}
+	for _, m := range measurements {
+		if m == nil || string(m.Type) != typ {
+			continue
+		}
+		if r, found := m.Lookup(rest); found {
+			return r.String(), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// newNodeSnapshot builds a snapshot for a node with the given GPU model and kernel.
+func newNodeSnapshot(node, model, kernel string) *Snapshot {
+	snap := NewSnapshot()
+	snap.Init(header.KindSnapshot, FullAPIVersion, "v1.0.0")
+	snap.Metadata[SourceNodeKey] = node
+	snap.Measurements = []*measurement.Measurement{
+		measurement.NewMeasurement(measurement.TypeGPU).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("smi").
+				SetString("gpu.model", model).
+				SetInt("gpu-count", 8)).
+			Build(),
+		measurement.NewMeasurement(measurement.TypeOS).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("release").
+				SetString("ID", "ubuntu").
+				SetString("VERSION_ID", "24.04")).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("sysctl").
+				SetString("/proc/sys/kernel/osrelease", kernel)).
+			Build(),
+		measurement.NewMeasurement(measurement.TypeK8s).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("node").
+				SetString("source-node", node)).
+			Build(),
+	}
+	return snap
+}
+
+func TestNewClusterSnapshot_Pools(t *testing.T) {
+	snaps := []*Snapshot{
+		newNodeSnapshot("h100-b", "H100", "6.8.0"),
+		newNodeSnapshot("gb200-a", "GB200", "6.8.0"),
+		newNodeSnapshot("h100-a", "H100", "6.8.0"),
+	}
+
+	cs, err := NewClusterSnapshot("v1.0.0", snaps, nil)
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+
+	if cs.Kind != header.KindClusterSnapshot {
+		t.Errorf("Kind = %s, want %s", cs.Kind, header.KindClusterSnapshot)
+	}
+	if len(cs.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d", len(cs.Nodes))
+	}
+	if cs.Nodes[0].Name != "gb200-a" {
+		t.Errorf("nodes not sorted: first = %s", cs.Nodes[0].Name)
+	}
+	if len(cs.Pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(cs.Pools))
+	}
+
+	var h100 *NodePool
+	for _, p := range cs.Pools {
+		if p.Attributes["GPU.smi.gpu.model"] == "H100" {
+			h100 = p
+		}
+	}
+	if h100 == nil {
+		t.Fatal("H100 pool not found")
+	}
+	if len(h100.Nodes) != 2 || h100.Reference != "h100-a" {
+		t.Errorf("unexpected H100 pool: %+v", h100)
+	}
+	if len(h100.Drift) != 0 {
+		t.Errorf("expected no drift, got %+v", h100.Drift)
+	}
+	if cs.HasDrift() {
+		t.Error("HasDrift() = true, want false")
+	}
+	if node := cs.Node("h100-b"); node == nil || node.Pool != h100.Name {
+		t.Errorf("h100-b not assigned to pool %s", h100.Name)
+	}
+}
+
+func TestNewClusterSnapshot_Drift(t *testing.T) {
+	snaps := []*Snapshot{
+		newNodeSnapshot("node-a", "H100", "6.8.0-1028-aws"),
+		newNodeSnapshot("node-b", "H100", "6.8.0-1031-aws"),
+	}
+
+	cs, err := NewClusterSnapshot("v1.0.0", snaps, nil)
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+
+	if len(cs.Pools) != 1 {
+		t.Fatalf("expected 1 pool, got %d", len(cs.Pools))
+	}
+	drift := cs.Pools[0].Drift
+	if len(drift) != 1 {
+		t.Fatalf("expected 1 drift entry (source-node is ignored), got %+v", drift)
+	}
+	want := NodeDrift{
+		Node:     "node-b",
+		Path:     "OS.sysctl./proc/sys/kernel/osrelease",
+		Expected: "6.8.0-1028-aws",
+		Actual:   "6.8.0-1031-aws",
+	}
+	if drift[0] != want {
+		t.Errorf("drift = %+v, want %+v", drift[0], want)
+	}
+	if !cs.HasDrift() {
+		t.Error("HasDrift() = false, want true")
+	}
+}
+
+// newRealisticNodeSnapshot builds a snapshot with the sysctl, systemd, GPU and
+// RDMA readings of a freshly booted node, varying only the per-node values.
+func newRealisticNodeSnapshot(node, bootID string, pid int, started string) *Snapshot {
+	snap := newNodeSnapshot(node, "H100", "6.8.0-1028-aws")
+	sysctl := snap.Measurements[1].GetSubtype("sysctl").Data
+	for k, v := range map[string]string{
+		"/proc/sys/kernel/hostname":             node,
+		"/proc/sys/kernel/random/boot_id":       bootID,
+		"/proc/sys/kernel/random/uuid":          bootID + "-uuid",
+		"/proc/sys/kernel/random/entropy_avail": fmt.Sprint(256 + pid%7),
+		"/proc/sys/kernel/ns_last_pid":          fmt.Sprint(pid * 3),
+		"/proc/sys/fs/file-nr":                  fmt.Sprintf("%d\t0\t9223372036854775807", pid*2),
+		"/proc/sys/fs/inode-nr":                 fmt.Sprintf("%d\t%d", pid*5, pid),
+		"/proc/sys/fs/dentry-state":             fmt.Sprintf("%d\t%d\t45\t0\t0\t0", pid*7, pid),
+		"/proc/sys/vm/swappiness":               "60",
+	} {
+		sysctl[k] = measurement.Str(v)
+	}
+	snap.Measurements = append(snap.Measurements,
+		measurement.NewMeasurement(measurement.TypeSystemD).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("containerd.service").
+				SetString("ActiveState", "active").
+				SetString("LimitNOFILE", "infinity").
+				SetInt("MainPID", pid).
+				SetInt("ExecMainPID", pid).
+				SetString("ExecMainStartTimestamp", started).
+				SetString("ActiveEnterTimestamp", started).
+				SetString("StateChangeTimestampMonotonic", fmt.Sprint(pid*1000)).
+				SetString("InvocationID", bootID+"-invocation").
+				SetString("MemoryCurrent", fmt.Sprint(pid*4096)).
+				SetString("CPUUsageNSec", fmt.Sprint(pid*1e6)).
+				SetInt("TasksCurrent", pid%50)).
+			Build(),
+		measurement.NewMeasurement(measurement.TypeGPU).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("gpu.0").
+				SetString("uuid", "GPU-"+bootID).
+				SetString("serial", node+"-serial").
+				SetString("ecc-mode", "Enabled").
+				SetInt("ecc.volatile-correctable", pid%3).
+				SetInt("ecc.aggregate-correctable", pid%11)).
+			Build(),
+		measurement.NewMeasurement(measurement.TypeNetwork).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("rdma").
+				SetString("mlx5_0.node-guid", bootID+"-guid").
+				SetString("mlx5_0.port1.state", "ACTIVE")).
+			Build(),
+	)
+	return snap
+}
+
+func TestNewClusterSnapshot_VolatileReadingsIgnored(t *testing.T) {
+	snaps := []*Snapshot{
+		newRealisticNodeSnapshot("ip-10-0-1-17", "5b4e1d4c-6d1e-4cfb-9a0b-3c1f0f6a1e11", 1187, "Mon 2025-06-02 09:14:03 UTC"),
+		newRealisticNodeSnapshot("ip-10-0-2-94", "0f9c2a77-8b3e-4a55-b1d2-7e6c5d4b3a22", 2204, "Mon 2025-06-02 09:16:48 UTC"),
+	}
+
+	cs, err := NewClusterSnapshot("v1.0.0", snaps, nil)
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+	if len(cs.Pools) != 1 {
+		t.Fatalf("expected 1 pool, got %d", len(cs.Pools))
+	}
+	if drift := cs.Pools[0].Drift; len(drift) != 0 {
+		t.Errorf("expected no drift between identically configured nodes, got %+v", drift)
+	}
+}
+
+func TestNewClusterSnapshot_CustomPoolKeys(t *testing.T) {
+	snaps := []*Snapshot{
+		newNodeSnapshot("node-a", "H100", "6.8.0"),
+		newNodeSnapshot("node-b", "H100", "6.9.0"),
+	}
+
+	cs, err := NewClusterSnapshot("v1.0.0", snaps, []string{"OS.sysctl./proc/sys/kernel/osrelease"})
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+	if len(cs.Pools) != 2 {
+		t.Errorf("expected kernels to split nodes into 2 pools, got %d", len(cs.Pools))
+	}
+}
+
+func TestNewClusterSnapshot_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		snaps []*Snapshot
+	}{
+		{"empty", nil},
+		{"nil snapshot", []*Snapshot{nil}},
+		{"duplicate node", []*Snapshot{
+			newNodeSnapshot("node-a", "H100", "6.8.0"),
+			newNodeSnapshot("node-a", "H100", "6.8.0"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClusterSnapshot("v1.0.0", tt.snaps, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestClusterSnapshot_NodeSnapshotFor(t *testing.T) {
+	cs, err := NewClusterSnapshot("v1.0.0", []*Snapshot{newNodeSnapshot("node-a", "H100", "6.8.0")}, nil)
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+
+	snap := cs.NodeSnapshotFor("node-a")
+	if snap == nil {
+		t.Fatal("NodeSnapshotFor() returned nil")
+	}
+	if snap.Kind != header.KindSnapshot || snap.Metadata[SourceNodeKey] != "node-a" {
+		t.Errorf("unexpected header: %+v", snap.Header)
+	}
+	if len(snap.Measurements) != 3 {
+		t.Errorf("expected 3 measurements, got %d", len(snap.Measurements))
+	}
+	if cs.NodeSnapshotFor("missing") != nil {
+		t.Error("NodeSnapshotFor() should return nil for unknown node")
+	}
+}
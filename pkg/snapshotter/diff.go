@@ -0,0 +1,278 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+// ChangeType describes how a reading differs between two snapshots.
+type ChangeType string
+
+const (
+	// ChangeAdded indicates the reading only exists in the after snapshot.
+	ChangeAdded ChangeType = "added"
+
+	// ChangeRemoved indicates the reading only exists in the before snapshot.
+	ChangeRemoved ChangeType = "removed"
+
+	// ChangeModified indicates the reading exists in both snapshots with different values.
+	ChangeModified ChangeType = "changed"
+)
+
+// maxTableValueLen is the number of characters of a reading shown in table
+// output. Longer values are truncated; JSON and YAML keep the full value.
+const maxTableValueLen = 60
+
+// DiffFilter selects the readings included in a snapshot diff.
+// Patterns match the fully qualified reading path ({Type}.{Subtype}.{Key}) and
+// support the wildcards of measurement.FilterIn and measurement.FilterOut
+// (e.g., "OS.sysctl.*", "*.version").
+type DiffFilter struct {
+	// Include keeps only readings matching at least one pattern (all when empty).
+	Include []string
+
+	// Exclude drops readings matching any pattern.
+	Exclude []string
+}
+
+// SnapshotDiff lists the reading changes between two snapshots.
+type SnapshotDiff struct {
+	header.Header `json:",inline" yaml:",inline"`
+
+	// Before is the source of the baseline snapshot.
+	Before string `json:"before,omitempty" yaml:"before,omitempty"`
+
+	// After is the source of the snapshot compared against the baseline.
+	After string `json:"after,omitempty" yaml:"after,omitempty"`
+
+	// Summary contains the number of changes by type.
+	Summary DiffSummary `json:"summary" yaml:"summary"`
+
+	// Changes lists the changed readings, ordered by path.
+	Changes []ReadingChange `json:"changes" yaml:"changes"`
+}
+
+// DiffSummary counts the changes in a SnapshotDiff.
+type DiffSummary struct {
+	Added   int `json:"added" yaml:"added"`
+	Removed int `json:"removed" yaml:"removed"`
+	Changed int `json:"changed" yaml:"changed"`
+	Total   int `json:"total" yaml:"total"`
+}
+
+// ReadingChange describes a single reading that differs between two snapshots.
+type ReadingChange struct {
+	// Type is the measurement type (e.g., "K8s", "GPU").
+	Type measurement.Type `json:"type" yaml:"type"`
+
+	// Subtype is the measurement subtype name (e.g., "server", "smi").
+	Subtype string `json:"subtype" yaml:"subtype"`
+
+	// Key is the reading key within the subtype.
+	Key string `json:"key" yaml:"key"`
+
+	// Change is how the reading differs.
+	Change ChangeType `json:"change" yaml:"change"`
+
+	// Before is the value in the baseline snapshot (empty when added).
+	Before string `json:"before,omitempty" yaml:"before,omitempty"`
+
+	// After is the value in the compared snapshot (empty when removed).
+	After string `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// Path returns the fully qualified reading path ({Type}.{Subtype}.{Key}).
+func (c ReadingChange) Path() string {
+	return fmt.Sprintf("%s.%s.%s", c.Type, c.Subtype, c.Key)
+}
+
+// NewSnapshotDiff compares two snapshots measurement by measurement using
+// measurement.Compare and reports every added, removed and changed reading
+// that passes the filter.
+func NewSnapshotDiff(version string, before, after *Snapshot, filter DiffFilter) (*SnapshotDiff, error) {
+	if before == nil || after == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "both snapshots are required")
+	}
+
+	d := &SnapshotDiff{Changes: make([]ReadingChange, 0)}
+	d.Init(header.KindSnapshotDiff, FullAPIVersion, version)
+
+	beforeByType := indexMeasurements(before.Measurements)
+	afterByType := indexMeasurements(after.Measurements)
+
+	types := make([]measurement.Type, 0, len(beforeByType)+len(afterByType))
+	for t := range beforeByType {
+		types = append(types, t)
+	}
+	for t := range afterByType {
+		if _, ok := beforeByType[t]; !ok {
+			types = append(types, t)
+		}
+	}
+
+	changes := make(map[string]ReadingChange)
+	for _, t := range types {
+		b := measurementOrEmpty(beforeByType, t)
+		a := measurementOrEmpty(afterByType, t)
+
+		// Readings new in or different from the baseline
+		forward, err := measurement.Compare(*b, *a)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to compare measurements", err)
+		}
+		for _, st := range forward {
+			for key, reading := range st.Data {
+				c := ReadingChange{Type: t, Subtype: st.Name, Key: key, Change: ChangeAdded, After: reading.String()}
+				if prev, ok := findReading(b, st.Name, key); ok {
+					c.Change = ChangeModified
+					c.Before = prev.String()
+				}
+				changes[c.Path()] = c
+			}
+		}
+
+		// Readings missing from the compared snapshot
+		backward, err := measurement.Compare(*a, *b)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInternal, "failed to compare measurements", err)
+		}
+		for _, st := range backward {
+			for key, reading := range st.Data {
+				if _, ok := findReading(a, st.Name, key); ok {
+					continue // already reported as changed
+				}
+				c := ReadingChange{Type: t, Subtype: st.Name, Key: key, Change: ChangeRemoved, Before: reading.String()}
+				changes[c.Path()] = c
+			}
+		}
+	}
+
+	for _, path := range filterPaths(changes, filter) {
+		c := changes[path]
+		d.Changes = append(d.Changes, c)
+		switch c.Change {
+		case ChangeAdded:
+			d.Summary.Added++
+		case ChangeRemoved:
+			d.Summary.Removed++
+		case ChangeModified:
+			d.Summary.Changed++
+		}
+	}
+	d.Summary.Total = len(d.Changes)
+
+	return d, nil
+}
+
+// HasDrift reports whether the snapshots differ.
+func (d *SnapshotDiff) HasDrift() bool {
+	return len(d.Changes) > 0
+}
+
+// WriteTable renders the changes as an aligned table, implementing
+// serializer.TableWriter.
+func (d *SnapshotDiff) WriteTable(w io.Writer) error {
+	if !d.HasDrift() {
+		_, err := fmt.Fprintln(w, "no differences")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHANGE\tPATH\tBEFORE\tAFTER")
+	fmt.Fprintln(tw, "------\t----\t------\t-----")
+	for _, c := range d.Changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Change, c.Path(), tableValue(c.Before), tableValue(c.After))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d added, %d removed, %d changed\n",
+		d.Summary.Added, d.Summary.Removed, d.Summary.Changed)
+	return err
+}
+
+// indexMeasurements maps measurements by type, skipping nil entries.
+func indexMeasurements(measurements []*measurement.Measurement) map[measurement.Type]*measurement.Measurement {
+	out := make(map[measurement.Type]*measurement.Measurement, len(measurements))
+	for _, m := range measurements {
+		if m != nil {
+			out[m.Type] = m
+		}
+	}
+	return out
+}
+
+// measurementOrEmpty returns the measurement of the given type, or an empty
+// measurement when the snapshot does not contain it.
+func measurementOrEmpty(byType map[measurement.Type]*measurement.Measurement, t measurement.Type) *measurement.Measurement {
+	if m, ok := byType[t]; ok {
+		return m
+	}
+	return &measurement.Measurement{Type: t}
+}
+
+// findReading returns the reading at subtype/key in the measurement.
+func findReading(m *measurement.Measurement, subtype, key string) (measurement.Reading, bool) {
+	st := m.GetSubtype(subtype)
+	if st == nil {
+		return nil, false
+	}
+	r, ok := st.Data[key]
+	return r, ok
+}
+
+// filterPaths applies the include and exclude patterns to the change paths and
+// returns the remaining paths in sorted order.
+func filterPaths(changes map[string]ReadingChange, filter DiffFilter) []string {
+	readings := make(map[string]measurement.Reading, len(changes))
+	for path := range changes {
+		readings[path] = measurement.Str(path)
+	}
+	if len(filter.Include) > 0 {
+		readings = measurement.FilterIn(readings, filter.Include)
+	}
+	if len(filter.Exclude) > 0 {
+		readings = measurement.FilterOut(readings, filter.Exclude)
+	}
+
+	paths := make([]string, 0, len(readings))
+	for path := range readings {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// tableValue returns "-" for empty values so table columns stay aligned, and
+// truncates values longer than maxTableValueLen so one long reading does not
+// widen every row.
+func tableValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	if r := []rune(s); len(r) > maxTableValueLen {
+		return string(r[:maxTableValueLen-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+)
+
+func TestNewSnapshotDiff(t *testing.T) {
+	before := newNodeSnapshot("node-a", "H100", "6.8.0")
+	after := newNodeSnapshot("node-a", "H100", "6.8.1")
+
+	// Add a reading and a whole measurement type, and remove a subtype
+	after.Measurements[0].Subtypes[0].Data["driver"] = measurement.Str("580.82.07")
+	after.Measurements = append(after.Measurements,
+		measurement.NewMeasurement(measurement.TypeSystemD).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("kubelet.service").
+				SetString("ActiveState", "active")).
+			Build())
+	after.Measurements[1].Subtypes = after.Measurements[1].Subtypes[1:] // drop "release"
+
+	tests := []struct {
+		name   string
+		filter DiffFilter
+		want   []ReadingChange
+	}{
+		{
+			name: "all changes",
+			want: []ReadingChange{
+				{Type: measurement.TypeGPU, Subtype: "smi", Key: "driver", Change: ChangeAdded, After: "580.82.07"},
+				{Type: measurement.TypeOS, Subtype: "release", Key: "ID", Change: ChangeRemoved, Before: "ubuntu"},
+				{Type: measurement.TypeOS, Subtype: "release", Key: "VERSION_ID", Change: ChangeRemoved, Before: "24.04"},
+				{Type: measurement.TypeOS, Subtype: "sysctl", Key: "/proc/sys/kernel/osrelease", Change: ChangeModified, Before: "6.8.0", After: "6.8.1"},
+				{Type: measurement.TypeSystemD, Subtype: "kubelet.service", Key: "ActiveState", Change: ChangeAdded, After: "active"},
+			},
+		},
+		{
+			name:   "include",
+			filter: DiffFilter{Include: []string{"OS.sysctl.*"}},
+			want: []ReadingChange{
+				{Type: measurement.TypeOS, Subtype: "sysctl", Key: "/proc/sys/kernel/osrelease", Change: ChangeModified, Before: "6.8.0", After: "6.8.1"},
+			},
+		},
+		{
+			name:   "exclude",
+			filter: DiffFilter{Exclude: []string{"OS.*", "SystemD.*"}},
+			want: []ReadingChange{
+				{Type: measurement.TypeGPU, Subtype: "smi", Key: "driver", Change: ChangeAdded, After: "580.82.07"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewSnapshotDiff("v1.0.0", before, after, tt.filter)
+			if err != nil {
+				t.Fatalf("NewSnapshotDiff() error = %v", err)
+			}
+			if d.Kind != header.KindSnapshotDiff {
+				t.Errorf("Kind = %q, want %q", d.Kind, header.KindSnapshotDiff)
+			}
+			if len(d.Changes) != len(tt.want) {
+				t.Fatalf("got %d changes, want %d: %+v", len(d.Changes), len(tt.want), d.Changes)
+			}
+			for i, want := range tt.want {
+				if d.Changes[i] != want {
+					t.Errorf("change[%d] = %+v, want %+v", i, d.Changes[i], want)
+				}
+			}
+			if d.Summary.Total != len(tt.want) {
+				t.Errorf("Summary.Total = %d, want %d", d.Summary.Total, len(tt.want))
+			}
+			if !d.HasDrift() {
+				t.Error("HasDrift() = false, want true")
+			}
+		})
+	}
+}
+
+func TestNewSnapshotDiff_Identical(t *testing.T) {
+	snap := newNodeSnapshot("node-a", "H100", "6.8.0")
+
+	d, err := NewSnapshotDiff("v1.0.0", snap, snap, DiffFilter{})
+	if err != nil {
+		t.Fatalf("NewSnapshotDiff() error = %v", err)
+	}
+	if d.HasDrift() {
+		t.Errorf("HasDrift() = true, changes: %+v", d.Changes)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "no differences") {
+		t.Errorf("WriteTable() = %q, want no differences", buf.String())
+	}
+}
+
+func TestNewSnapshotDiff_Nil(t *testing.T) {
+	if _, err := NewSnapshotDiff("v1.0.0", nil, NewSnapshot(), DiffFilter{}); err == nil {
+		t.Error("expected error for nil snapshot")
+	}
+}
+
+func TestSnapshotDiff_WriteTable(t *testing.T) {
+	d, err := NewSnapshotDiff("v1.0.0",
+		newNodeSnapshot("node-a", "H100", "6.8.0"),
+		newNodeSnapshot("node-a", "GB200", "6.8.0"),
+		DiffFilter{})
+	if err != nil {
+		t.Fatalf("NewSnapshotDiff() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"CHANGE", "changed", "GPU.smi.gpu.model", "H100", "GB200", "0 added, 0 removed, 1 changed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteTable() output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSnapshotDiff_WriteTableTruncatesLongValues(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	d, err := NewSnapshotDiff("v1.0.0",
+		newNodeSnapshot("node-a", "H100", "6.8.0"),
+		newNodeSnapshot("node-a", "H100", long),
+		DiffFilter{})
+	if err != nil {
+		t.Fatalf("NewSnapshotDiff() error = %v", err)
+	}
+	if d.Changes[0].After != long {
+		t.Errorf("After = %q, want the full value", d.Changes[0].After)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+
+	want := strings.Repeat("x", maxTableValueLen-3) + "..."
+	if !strings.Contains(buf.String(), want) || strings.Contains(buf.String(), long) {
+		t.Errorf("WriteTable() did not truncate the long value:\n%s", buf.String())
+	}
+}
@@ -17,7 +17,7 @@
 // # Overview
 //
 // The snapshotter package orchestrates parallel collection of system measurements
-// from multiple sources (Kubernetes, GPU, OS, SystemD) and produces structured
+// from multiple sources (Kubernetes, GPU, OS, SystemD, Network, ContainerRuntime) and produces structured
 // snapshots that can be serialized for analysis, auditing, or recommendation generation.
 //
 // # Core Types
@@ -134,6 +134,8 @@
 //  3. SystemD services (containerd, kubelet)
 //  4. OS configuration (grub, sysctl, modules)
 //  5. GPU hardware (driver, model, settings)
+//  6. Network fabric (RDMA devices, network interfaces)
+//  7. Container runtime configuration (containerd, CRI-O)
 //
 // If any collector fails, all are canceled and an error is returned.
 //
@@ -146,6 +148,19 @@
 //
 // This ensures correct node identification in various deployment scenarios.
 //
+// # Cluster Snapshots
+//
+// With AgentConfig.AllNodes, the agent runs on every matching node and the
+// per-node snapshots are merged by NewClusterSnapshot. Nodes that share the
+// values of all pool keys (DefaultPoolKeys: GPU model and count, OS ID and
+// version) form a NodePool; every other node in a pool is compared against the
+// pool's reference node and differing readings are recorded as NodeDrift.
+//
+//	cluster, err := snapshotter.NewClusterSnapshot(version, snapshots, nil)
+//	if cluster.HasDrift() {
+//	    // inspect cluster.Pools[i].Drift
+//	}
+//
 // # Error Handling
 //
 // Measure() returns an error when:
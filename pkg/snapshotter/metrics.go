@@ -43,7 +43,7 @@ var (
 			Help:    "Time taken by individual collectors",
 			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30},
 		},
-		[]string{"collector"}, // image, k8s, kmod, systemd, grub, sysctl, smi, metadata
+		[]string{"collector"}, // image, k8s, kmod, systemd, grub, sysctl, smi, network, cri, metadata
 	)
 
 	snapshotMeasurementCount = promauto.NewGauge(
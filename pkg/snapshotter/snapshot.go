@@ -65,6 +65,27 @@ func (n *NodeSnapshotter) Measure(ctx context.Context) error {
 
 // measure collects configuration measurements from the current node.
 func (n *NodeSnapshotter) measure(ctx context.Context) error {
+	snap, err := n.Collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Serialize output
+	if n.Serializer == nil {
+		n.Serializer = serializer.NewStdoutWriter(serializer.FormatJSON)
+	}
+
+	if err := n.Serializer.Serialize(ctx, snap); err != nil {
+		slog.Error("failed to serialize", slog.String("error", err.Error()))
+		return fmt.Errorf("failed to serialize: %w", err)
+	}
+
+	return nil
+}
+
+// Collect runs the collectors on the current node in parallel and returns the
+// snapshot without serializing it. AgentConfig is ignored.
+func (n *NodeSnapshotter) Collect(ctx context.Context) (*Snapshot, error) {
 	if n.Factory == nil {
 		n.Factory = collector.NewDefaultFactory()
 	}
@@ -88,8 +109,8 @@ func (n *NodeSnapshotter) measure(ctx context.Context) error {
 
 	// Initialize snapshot structure
 	snap := NewSnapshot()
-	// Pre-allocate measurements slice with capacity for 5 collectors
-	snap.Measurements = make([]*measurement.Measurement, 0, 5)
+	// Pre-allocate measurements slice with capacity for 7 collectors
+	snap.Measurements = make([]*measurement.Measurement, 0, 7)
 
 	// Collect metadata
 	g.Go(func() error {
@@ -100,7 +121,7 @@ func (n *NodeSnapshotter) measure(ctx context.Context) error {
 		nodeName := k8s.GetNodeName()
 		mu.Lock()
 		snap.Init(header.KindSnapshot, FullAPIVersion, n.Version)
-		snap.Metadata["source-node"] = nodeName
+		snap.Metadata[SourceNodeKey] = nodeName
 		mu.Unlock()
 		slog.Debug("obtained node metadata", slog.String("name", nodeName), slog.String("version", n.Version))
 		return nil
@@ -182,10 +203,48 @@ func (n *NodeSnapshotter) measure(ctx context.Context) error {
 		return nil
 	})
 
+	// Collect network
+	g.Go(func() error {
+		collectorStart := time.Now()
+		defer func() {
+			snapshotCollectorDuration.WithLabelValues("network").Observe(time.Since(collectorStart).Seconds())
+		}()
+		slog.Debug("collecting network configuration")
+		nc := n.Factory.CreateNetworkCollector()
+		network, err := nc.Collect(gctx)
+		if err != nil {
+			slog.Error("failed to collect network", slog.String("error", err.Error()))
+			return fmt.Errorf("failed to collect network info: %w", err)
+		}
+		mu.Lock()
+		snap.Measurements = append(snap.Measurements, network)
+		mu.Unlock()
+		return nil
+	})
+
+	// Collect container runtime
+	g.Go(func() error {
+		collectorStart := time.Now()
+		defer func() {
+			snapshotCollectorDuration.WithLabelValues("cri").Observe(time.Since(collectorStart).Seconds())
+		}()
+		slog.Debug("collecting container runtime configuration")
+		cc := n.Factory.CreateContainerRuntimeCollector()
+		runtime, err := cc.Collect(gctx)
+		if err != nil {
+			slog.Error("failed to collect container runtime", slog.String("error", err.Error()))
+			return fmt.Errorf("failed to collect container runtime info: %w", err)
+		}
+		mu.Lock()
+		snap.Measurements = append(snap.Measurements, runtime)
+		mu.Unlock()
+		return nil
+	})
+
 	// Wait for all collectors to complete
 	if err := g.Wait(); err != nil {
 		snapshotCollectionTotal.WithLabelValues("error").Inc()
-		return err
+		return nil, err
 	}
 
 	snapshotCollectionTotal.WithLabelValues("success").Inc()
@@ -193,15 +252,5 @@ func (n *NodeSnapshotter) measure(ctx context.Context) error {
 
 	slog.Debug("snapshot collection complete", slog.Int("total_configs", len(snap.Measurements)))
 
-	// Serialize output
-	if n.Serializer == nil {
-		n.Serializer = serializer.NewStdoutWriter(serializer.FormatJSON)
-	}
-
-	if err := n.Serializer.Serialize(ctx, snap); err != nil {
-		slog.Error("failed to serialize", slog.String("error", err.Error()))
-		return fmt.Errorf("failed to serialize: %w", err)
-	}
-
-	return nil
+	return snap, nil
 }
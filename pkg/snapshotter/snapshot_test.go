@@ -90,6 +90,14 @@ func TestNodeSnapshotter_Measure(t *testing.T) {
 		if !factory.osCalled {
 			t.Error("OS collector not called")
 		}
+
+		if !factory.networkCalled {
+			t.Error("Network collector not called")
+		}
+
+		if !factory.containerRuntimeCalled {
+			t.Error("Container runtime collector not called")
+		}
 	})
 
 	t.Run("handles collector errors", func(t *testing.T) {
@@ -253,11 +261,17 @@ type mockFactory struct {
 	systemdCalled bool
 	osCalled      bool
 	gpuCalled     bool
+	networkCalled bool
+
+	containerRuntimeCalled bool
 
 	k8sError     error
 	systemdError error
 	osError      error
 	gpuError     error
+	networkError error
+
+	containerRuntimeError error
 }
 
 func (m *mockFactory) CreateKubernetesCollector() collector.Collector {
@@ -280,6 +294,16 @@ func (m *mockFactory) CreateGPUCollector() collector.Collector {
 	return &mockCollector{err: m.gpuError}
 }
 
+func (m *mockFactory) CreateNetworkCollector() collector.Collector {
+	m.networkCalled = true
+	return &mockCollector{err: m.networkError}
+}
+
+func (m *mockFactory) CreateContainerRuntimeCollector() collector.Collector {
+	m.containerRuntimeCalled = true
+	return &mockCollector{err: m.containerRuntimeError}
+}
+
 type mockCollector struct {
 	err error
 }
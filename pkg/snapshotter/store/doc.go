@@ -0,0 +1,53 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package store provides snapshot storage and the snapshot ingestion and
+// history API of the API server.
+//
+// # Overview
+//
+// A Store keeps snapshots keyed by cluster and node. Each stored snapshot gets
+// a time-ordered ID and a Record describing it. FileStore is the embedded
+// implementation: one JSON document per snapshot in a local directory, indexed
+// in memory when opened.
+//
+// # API
+//
+// Handler serves the store over HTTP:
+//
+//	POST /v1/snapshots                    store a Snapshot (or each node of a ClusterSnapshot)
+//	GET  /v1/snapshots                    list records (cluster, node, since, limit)
+//	GET  /v1/snapshots/{id}               get a snapshot
+//	GET  /v1/snapshots/{id}/diff/{other}  diff two snapshots ({other} may be "latest")
+//
+// Agents report to the API server with an HTTP output:
+//
+//	cnsctl snapshot --output "https://cns.example.com/v1/snapshots?cluster=prod"
+//
+// # Usage
+//
+//	s, err := store.OpenFileStore("/var/lib/cnsd/snapshots")
+//	if err != nil {
+//	    return err
+//	}
+//	defer s.Close()
+//
+//	h := store.NewHandler(s, store.WithVersion(version))
+//	routes := map[string]http.HandlerFunc{
+//	    store.Path:       h.HandleSnapshots,
+//	    store.Path + "/": h.HandleSnapshot,
+//	}
+//
+// In cnsd, the API is enabled by setting CNS_SNAPSHOT_STORE_DIR to the store directory.
+package store
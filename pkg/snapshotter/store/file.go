@@ -0,0 +1,223 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+const fileExt = ".json"
+
+// fileDocument is the on-disk format of a stored snapshot.
+type fileDocument struct {
+	Record   Record                `json:"record"`
+	Snapshot *snapshotter.Snapshot `json:"snapshot"`
+}
+
+// FileStore is an embedded Store keeping one JSON document per snapshot in a
+// local directory. Records are indexed in memory when the store is opened, so
+// list queries do not touch the disk. A directory must not be shared by
+// several processes.
+type FileStore struct {
+	dir string
+
+	mu      sync.RWMutex
+	records map[string]Record
+	closed  bool
+}
+
+// OpenFileStore opens the store in dir, creating the directory if needed, and
+// indexes the snapshots already stored there.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "snapshot store directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInternal, "failed to create snapshot store directory", err,
+			map[string]any{"dir": dir})
+	}
+
+	s := &FileStore{dir: dir, records: make(map[string]Record)}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInternal, "failed to read snapshot store directory", err,
+			map[string]any{"dir": dir})
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != fileExt {
+			continue
+		}
+		doc, readErr := s.read(strings.TrimSuffix(e.Name(), fileExt))
+		if readErr != nil {
+			// A corrupt document must not keep the server from starting
+			slog.Warn("skipping unreadable stored snapshot", "file", e.Name(), "error", readErr)
+			continue
+		}
+		s.records[doc.Record.ID] = doc.Record
+	}
+
+	slog.Debug("snapshot store opened", "dir", dir, "snapshots", len(s.records))
+	return s, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(ctx context.Context, rec Record, snap *snapshotter.Snapshot) (*Record, error) {
+	if snap == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "snapshot is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeUnavailable, "operation canceled", err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to generate snapshot ID", err)
+	}
+	rec.ID = id.String()
+	rec.ReceivedAt = time.Now().UTC()
+
+	data, err := json.Marshal(fileDocument{Record: rec, Snapshot: snap})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to encode snapshot", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New(errors.ErrCodeUnavailable, "snapshot store is closed")
+	}
+
+	// Write to a temporary file first so readers never see a partial document
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to create snapshot file", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to write snapshot file", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to write snapshot file", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(rec.ID)); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInternal, "failed to store snapshot file", err)
+	}
+
+	s.records[rec.ID] = rec
+	return &rec, nil
+}
+
+// Get implements Store.
+func (s *FileStore) Get(ctx context.Context, id string) (*Record, *snapshotter.Snapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeUnavailable, "operation canceled", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, nil, errors.New(errors.ErrCodeUnavailable, "snapshot store is closed")
+	}
+
+	// The index also guards against IDs escaping the store directory
+	if _, ok := s.records[id]; !ok {
+		return nil, nil, errors.NewWithContext(errors.ErrCodeNotFound, "snapshot not found",
+			map[string]any{"id": id})
+	}
+
+	doc, err := s.read(id)
+	if err != nil {
+		return nil, nil, errors.WrapWithContext(errors.ErrCodeInternal, "failed to read stored snapshot", err,
+			map[string]any{"id": id})
+	}
+	return &doc.Record, doc.Snapshot, nil
+}
+
+// List implements Store.
+func (s *FileStore) List(ctx context.Context, q Query) ([]Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeUnavailable, "operation canceled", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, errors.New(errors.ErrCodeUnavailable, "snapshot store is closed")
+	}
+
+	records := make([]Record, 0, len(s.records))
+	for _, rec := range s.records {
+		if q.Matches(&rec) {
+			records = append(records, rec)
+		}
+	}
+
+	// Newest first; IDs are time-ordered and break ties within a clock tick
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].ReceivedAt.Equal(records[j].ReceivedAt) {
+			return records[i].ReceivedAt.After(records[j].ReceivedAt)
+		}
+		return records[i].ID > records[j].ID
+	})
+
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[:q.Limit]
+	}
+	return records, nil
+}
+
+// Close implements Store. Further calls fail with ErrCodeUnavailable.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+fileExt)
+}
+
+func (s *FileStore) read(id string) (*fileDocument, error) {
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		return nil, err
+	}
+	var doc fileDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid snapshot document: %w", err)
+	}
+	if doc.Record.ID != id || doc.Snapshot == nil {
+		return nil, fmt.Errorf("snapshot document does not match its file name")
+	}
+	return &doc, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+func testSnapshot(node, kernel string) *snapshotter.Snapshot {
+	snap := snapshotter.NewSnapshot()
+	snap.Metadata = map[string]string{snapshotter.SourceNodeKey: node, "version": "v0.1.0"}
+	snap.Measurements = []*measurement.Measurement{
+		{
+			Type: measurement.TypeOS,
+			Subtypes: []measurement.Subtype{
+				{Name: "sysctl", Data: map[string]measurement.Reading{"/proc/sys/kernel/osrelease": measurement.Str(kernel)}},
+			},
+		},
+	}
+	return snap
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+
+	puts := []Record{
+		{Cluster: "prod", Node: "node-1"},
+		{Cluster: "prod", Node: "node-2"},
+		{Cluster: "dev", Node: "node-1"},
+		{Cluster: "prod", Node: "node-1"},
+	}
+	ids := make([]string, 0, len(puts))
+	for _, rec := range puts {
+		got, putErr := s.Put(ctx, rec, testSnapshot(rec.Node, "6.8.0"))
+		if putErr != nil {
+			t.Fatalf("Put() error = %v", putErr)
+		}
+		if got.ID == "" || got.ReceivedAt.IsZero() {
+			t.Fatalf("Put() record = %+v, want ID and ReceivedAt set", got)
+		}
+		ids = append(ids, got.ID)
+	}
+
+	rec, snap, err := s.Get(ctx, ids[1])
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if rec.Node != "node-2" || snap.Metadata[snapshotter.SourceNodeKey] != "node-2" || len(snap.Measurements) != 1 {
+		t.Errorf("Get() = %+v, %+v", rec, snap)
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"all newest first", Query{}, []string{ids[3], ids[2], ids[1], ids[0]}},
+		{"cluster", Query{Cluster: "prod"}, []string{ids[3], ids[1], ids[0]}},
+		{"cluster and node", Query{Cluster: "prod", Node: "node-1"}, []string{ids[3], ids[0]}},
+		{"limit", Query{Cluster: "prod", Limit: 1}, []string{ids[3]}},
+		{"since", Query{Since: time.Now().Add(time.Hour)}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, listErr := s.List(ctx, tt.query)
+			if listErr != nil {
+				t.Fatalf("List() error = %v", listErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("List() returned %d records, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].ID != tt.want[i] {
+					t.Errorf("List()[%d] = %s, want %s", i, got[i].ID, tt.want[i])
+				}
+			}
+		})
+	}
+
+	// Reopening indexes the stored snapshots and skips unreadable documents
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStore() reopen error = %v", err)
+	}
+	defer s.Close()
+	all, err := s.List(ctx, Query{})
+	if err != nil || len(all) != len(ids) {
+		t.Errorf("List() after reopen = %d records, %v; want %d", len(all), err, len(ids))
+	}
+}
+
+func TestFileStore_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := OpenFileStore(" "); err == nil {
+		t.Error("OpenFileStore() with empty dir: error = nil")
+	}
+
+	s, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var se *errors.StructuredError
+	if _, _, err := s.Get(ctx, "../../etc/passwd"); !stderrors.As(err, &se) || se.Code != errors.ErrCodeNotFound {
+		t.Errorf("Get() unknown ID error = %v, want %s", err, errors.ErrCodeNotFound)
+	}
+	if _, err := s.Put(ctx, Record{Cluster: "prod", Node: "node-1"}, nil); err == nil {
+		t.Error("Put() nil snapshot: error = nil")
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Put(ctx, Record{}, testSnapshot("node-1", "6.8.0")); err == nil {
+		t.Error("Put() after Close: error = nil")
+	}
+}
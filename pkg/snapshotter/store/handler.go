@@ -0,0 +1,400 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/server"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+const (
+	// Path is the route of the snapshot collection. Single snapshots are
+	// served below it (Path + "/{id}" and Path + "/{id}/diff/{other}").
+	Path = "/v1/snapshots"
+
+	// Latest can be used as the {other} snapshot of a diff to compare against
+	// the most recent snapshot of the same cluster and node.
+	Latest = "latest"
+
+	// MaxRequestSize is the maximum size of an ingested snapshot document.
+	MaxRequestSize = 32 * 1024 * 1024
+
+	// DefaultListLimit and MaxListLimit bound the number of listed snapshots.
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// RecordList is the response of snapshot list and ingestion requests.
+type RecordList struct {
+	// Snapshots are the matching (or created) snapshot records, newest first.
+	Snapshots []Record `json:"snapshots" yaml:"snapshots"`
+}
+
+// Handler serves the snapshot ingestion and history API from a Store.
+type Handler struct {
+	store   Store
+	version string
+}
+
+// HandlerOption is a functional option for configuring Handler instances.
+type HandlerOption func(*Handler)
+
+// WithVersion returns a HandlerOption that sets the version reported in snapshot diffs.
+func WithVersion(version string) HandlerOption {
+	return func(h *Handler) {
+		h.version = version
+	}
+}
+
+// NewHandler creates a Handler serving the snapshots of s.
+func NewHandler(s Store, opts ...HandlerOption) *Handler {
+	h := &Handler{store: s}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// HandleSnapshots serves the snapshot collection (Path).
+//   - POST stores a Snapshot, or each node of a ClusterSnapshot, and responds
+//     with the created records. The cluster is taken from the cluster query
+//     parameter, then the "cluster" snapshot metadata, then "default". The
+//     node is taken from the node query parameter, then the "source-node"
+//     snapshot metadata; ClusterSnapshot nodes always use their own name.
+//   - GET lists stored records, newest first, filtered by the cluster, node,
+//     since (RFC 3339) and limit query parameters.
+func (h *Handler) HandleSnapshots(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), defaults.SnapshotHandlerTimeout)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		h.list(ctx, w, r)
+	case http.MethodPost:
+		h.ingest(ctx, w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		server.WriteError(w, r, http.StatusMethodNotAllowed, errors.ErrCodeMethodNotAllowed,
+			"Method not allowed", false, map[string]any{
+				"method":  r.Method,
+				"allowed": []string{"GET", "POST"},
+			})
+	}
+}
+
+// HandleSnapshot serves single snapshots (Path + "/...").
+//   - GET {id} returns the stored snapshot.
+//   - GET {id}/diff/{other} returns the SnapshotDiff from {id} to {other},
+//     filtered by the include and exclude query parameters. {other} may be
+//     "latest" for the most recent snapshot of the same cluster and node.
+func (h *Handler) HandleSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		server.WriteError(w, r, http.StatusMethodNotAllowed, errors.ErrCodeMethodNotAllowed,
+			"Method not allowed", false, map[string]any{
+				"method": r.Method,
+			})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), defaults.SnapshotHandlerTimeout)
+	defer cancel()
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Path), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		h.get(ctx, w, r, parts[0])
+	case len(parts) == 3 && parts[0] != "" && parts[1] == "diff" && parts[2] != "":
+		h.diff(ctx, w, r, parts[0], parts[2])
+	default:
+		server.WriteError(w, r, http.StatusNotFound, errors.ErrCodeNotFound,
+			"Not found", false, map[string]any{
+				"path": r.URL.Path,
+			})
+	}
+}
+
+func (h *Handler) ingest(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	defer r.Body.Close()
+
+	snaps, isCluster, err := decodeSnapshots(r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			server.WriteError(w, r, http.StatusRequestEntityTooLarge, errors.ErrCodeInvalidRequest,
+				"Request body too large", false, map[string]any{
+					"limit": tooLarge.Limit,
+				})
+			return
+		}
+		server.WriteError(w, r, http.StatusBadRequest, errors.ErrCodeInvalidRequest,
+			"Invalid snapshot", false, map[string]any{
+				"error": err.Error(),
+			})
+		return
+	}
+
+	query := r.URL.Query()
+	records := make([]Record, 0, len(snaps))
+	for _, snap := range snaps {
+		rec := Record{
+			Cluster:    firstNonEmpty(query.Get("cluster"), snap.Metadata[ClusterKey], DefaultCluster),
+			Node:       firstNonEmpty(query.Get("node"), snap.Metadata[snapshotter.SourceNodeKey]),
+			Version:    snap.Metadata["version"],
+			CapturedAt: snap.Metadata["timestamp"],
+		}
+		if isCluster {
+			// Each node of a ClusterSnapshot names itself
+			rec.Node = snap.Metadata[snapshotter.SourceNodeKey]
+		}
+		if rec.Node == "" {
+			server.WriteError(w, r, http.StatusBadRequest, errors.ErrCodeInvalidRequest,
+				"Snapshot node is unknown", false, map[string]any{
+					"error": fmt.Sprintf("set the %q snapshot metadata or the node query parameter", snapshotter.SourceNodeKey),
+				})
+			return
+		}
+		records = append(records, rec)
+	}
+
+	created := make([]Record, 0, len(records))
+	for i := range records {
+		rec, putErr := h.store.Put(ctx, records[i], snaps[i])
+		if putErr != nil {
+			server.WriteErrorFromErr(w, r, putErr, "Failed to store snapshot", nil)
+			return
+		}
+		slog.Info("snapshot stored", "id", rec.ID, "cluster", rec.Cluster, "node", rec.Node)
+		created = append(created, *rec)
+	}
+
+	if len(created) == 1 {
+		w.Header().Set("Location", Path+"/"+created[0].ID)
+	}
+	serializer.Respond(w, r, http.StatusCreated, RecordList{Snapshots: created})
+}
+
+func (h *Handler) list(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := Query{
+		Cluster: query.Get("cluster"),
+		Node:    query.Get("node"),
+		Limit:   DefaultListLimit,
+	}
+
+	if v := query.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			server.WriteError(w, r, http.StatusBadRequest, errors.ErrCodeInvalidRequest,
+				"Invalid since parameter", false, map[string]any{
+					"since": v,
+					"error": "must be an RFC 3339 timestamp (e.g. 2025-01-15T10:30:00Z)",
+				})
+			return
+		}
+		q.Since = since
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > MaxListLimit {
+			server.WriteError(w, r, http.StatusBadRequest, errors.ErrCodeInvalidRequest,
+				"Invalid limit parameter", false, map[string]any{
+					"limit": v,
+					"error": fmt.Sprintf("must be an integer between 1 and %d", MaxListLimit),
+				})
+			return
+		}
+		q.Limit = limit
+	}
+
+	records, err := h.store.List(ctx, q)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to list snapshots", nil)
+		return
+	}
+	serializer.Respond(w, r, http.StatusOK, RecordList{Snapshots: records})
+}
+
+func (h *Handler) get(ctx context.Context, w http.ResponseWriter, r *http.Request, id string) {
+	_, snap, err := h.store.Get(ctx, id)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to get snapshot", nil)
+		return
+	}
+	serializer.Respond(w, r, http.StatusOK, snap)
+}
+
+func (h *Handler) diff(ctx context.Context, w http.ResponseWriter, r *http.Request, id, otherID string) {
+	rec, before, err := h.store.Get(ctx, id)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to get snapshot", nil)
+		return
+	}
+
+	if otherID == Latest {
+		latest, listErr := h.store.List(ctx, Query{Cluster: rec.Cluster, Node: rec.Node, Limit: 1})
+		if listErr != nil {
+			server.WriteErrorFromErr(w, r, listErr, "Failed to list snapshots", nil)
+			return
+		}
+		// The snapshot itself is returned at least
+		otherID = latest[0].ID
+	}
+
+	_, after, err := h.store.Get(ctx, otherID)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to get snapshot", nil)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := snapshotter.DiffFilter{
+		Include: splitList(query["include"]),
+		Exclude: splitList(query["exclude"]),
+	}
+
+	d, err := snapshotter.NewSnapshotDiff(h.version, before, after, filter)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to compare snapshots", nil)
+		return
+	}
+	d.Before = id
+	d.After = otherID
+
+	serializer.Respond(w, r, http.StatusOK, d)
+}
+
+// decodeSnapshots reads a Snapshot, or the nodes of a ClusterSnapshot, from
+// the JSON or YAML request body. isCluster reports a ClusterSnapshot.
+func decodeSnapshots(r *http.Request) (snaps []*snapshotter.Snapshot, isCluster bool, err error) {
+	if r.Body == nil {
+		return nil, false, errors.New(errors.ErrCodeInvalidRequest, "request body is empty")
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, false, errors.New(errors.ErrCodeInvalidRequest, "request body is empty")
+	}
+
+	// YAML is a superset of JSON, so only explicit JSON uses the JSON decoder
+	format := serializer.FormatYAML
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt == "application/json" {
+		format = serializer.FormatJSON
+	}
+
+	var h header.Header
+	if err := decode(format, data, &h); err != nil {
+		return nil, false, err
+	}
+
+	switch h.Kind {
+	case header.KindSnapshot, "":
+		var snap snapshotter.Snapshot
+		if err := decode(format, data, &snap); err != nil {
+			return nil, false, err
+		}
+		if snap.Metadata == nil {
+			snap.Metadata = make(map[string]string)
+		}
+		return []*snapshotter.Snapshot{&snap}, false, nil
+	case header.KindClusterSnapshot:
+		var cluster snapshotter.ClusterSnapshot
+		if err := decode(format, data, &cluster); err != nil {
+			return nil, true, err
+		}
+		snaps, err := splitClusterSnapshot(&cluster)
+		return snaps, true, err
+	default:
+		return nil, false, errors.New(errors.ErrCodeInvalidRequest,
+			fmt.Sprintf("unsupported kind %q: expected %s or %s", h.Kind, header.KindSnapshot, header.KindClusterSnapshot))
+	}
+}
+
+// splitClusterSnapshot returns one Snapshot per node of a ClusterSnapshot,
+// carrying the cluster metadata and the node name as "source-node".
+func splitClusterSnapshot(cluster *snapshotter.ClusterSnapshot) ([]*snapshotter.Snapshot, error) {
+	if len(cluster.Nodes) == 0 {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "cluster snapshot has no nodes")
+	}
+
+	snaps := make([]*snapshotter.Snapshot, 0, len(cluster.Nodes))
+	for _, node := range cluster.Nodes {
+		if node == nil || node.Name == "" {
+			return nil, errors.New(errors.ErrCodeInvalidRequest, "cluster snapshot node has no name")
+		}
+		snap := snapshotter.NewSnapshot()
+		snap.Kind = header.KindSnapshot
+		snap.APIVersion = cluster.APIVersion
+		snap.Metadata = maps.Clone(cluster.Metadata)
+		if snap.Metadata == nil {
+			snap.Metadata = make(map[string]string)
+		}
+		snap.Metadata[snapshotter.SourceNodeKey] = node.Name
+		snap.Measurements = node.Measurements
+		snaps = append(snaps, snap)
+	}
+	return snaps, nil
+}
+
+func decode(format serializer.Format, data []byte, v any) error {
+	reader, err := serializer.NewReader(format, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	return reader.Deserialize(v)
+}
+
+// splitList splits repeated and comma-separated query values.
+func splitList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
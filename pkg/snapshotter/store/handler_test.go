@@ -0,0 +1,209 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+// testServer serves a Handler over a FileStore in a temporary directory.
+func testServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	s, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	h := NewHandler(s, WithVersion("v0.1.0"))
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, h.HandleSnapshots)
+	mux.HandleFunc(Path+"/", h.HandleSnapshot)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// post stores a snapshot through the serializer HTTP sink used by agents.
+func post(t *testing.T, url string, format serializer.Format, doc any) {
+	t.Helper()
+	if err := serializer.NewHTTPWriter(url, format).Serialize(context.Background(), doc); err != nil {
+		t.Fatalf("POST %s error = %v", url, err)
+	}
+}
+
+func getJSON(t *testing.T, url string, wantStatus int, v any) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("GET %s status = %d, want %d", url, resp.StatusCode, wantStatus)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("GET %s: invalid JSON: %v", url, err)
+		}
+	}
+}
+
+func TestHandler_History(t *testing.T) {
+	srv := testServer(t)
+	base := srv.URL + Path
+
+	post(t, base+"?cluster=prod", serializer.FormatJSON, testSnapshot("node-1", "6.8.0"))
+	post(t, base+"?cluster=prod", serializer.FormatYAML, testSnapshot("node-1", "6.8.1"))
+	post(t, base, serializer.FormatJSON, testSnapshot("node-2", "6.8.0"))
+
+	var list RecordList
+	getJSON(t, base+"?cluster=prod&node=node-1", http.StatusOK, &list)
+	if len(list.Snapshots) != 2 {
+		t.Fatalf("list = %+v, want 2 snapshots", list)
+	}
+	newest, oldest := list.Snapshots[0], list.Snapshots[1]
+	if newest.Cluster != "prod" || newest.Node != "node-1" || newest.Version != "v0.1.0" {
+		t.Errorf("record = %+v", newest)
+	}
+
+	getJSON(t, base+"?cluster="+DefaultCluster, http.StatusOK, &list)
+	if len(list.Snapshots) != 1 || list.Snapshots[0].Node != "node-2" {
+		t.Errorf("default cluster list = %+v", list)
+	}
+
+	// Snapshots are served in a form the serializer readers accept
+	snap, err := serializer.FromFile[snapshotter.Snapshot](base + "/" + oldest.ID)
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if snap.Metadata[snapshotter.SourceNodeKey] != "node-1" || len(snap.Measurements) != 1 {
+		t.Errorf("snapshot = %+v", snap)
+	}
+
+	for _, other := range []string{newest.ID, Latest} {
+		var d snapshotter.SnapshotDiff
+		getJSON(t, base+"/"+oldest.ID+"/diff/"+other, http.StatusOK, &d)
+		if d.Kind != header.KindSnapshotDiff || d.Before != oldest.ID || d.After != newest.ID {
+			t.Errorf("diff header = %+v, before %s, after %s", d.Header, d.Before, d.After)
+		}
+		if d.Summary.Changed != 1 || d.Changes[0].Before != "6.8.0" || d.Changes[0].After != "6.8.1" {
+			t.Errorf("diff = %+v", d.Changes)
+		}
+	}
+
+	var filtered snapshotter.SnapshotDiff
+	getJSON(t, base+"/"+oldest.ID+"/diff/"+newest.ID+"?exclude=OS.sysctl.*", http.StatusOK, &filtered)
+	if filtered.HasDrift() {
+		t.Errorf("filtered diff = %+v, want no changes", filtered.Changes)
+	}
+}
+
+func TestHandler_ClusterSnapshot(t *testing.T) {
+	srv := testServer(t)
+
+	cluster, err := snapshotter.NewClusterSnapshot("v0.1.0", []*snapshotter.Snapshot{
+		testSnapshot("node-1", "6.8.0"),
+		testSnapshot("node-2", "6.8.0"),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster.Metadata[ClusterKey] = "prod"
+
+	data, err := yaml.Marshal(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(srv.URL+Path+"?node=ignored", "application/yaml", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", resp.StatusCode)
+	}
+
+	var created RecordList
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Snapshots) != 2 {
+		t.Fatalf("created = %+v, want one record per node", created)
+	}
+	for i, node := range []string{"node-1", "node-2"} {
+		if rec := created.Snapshots[i]; rec.Cluster != "prod" || rec.Node != node {
+			t.Errorf("created[%d] = %+v", i, rec)
+		}
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	srv := testServer(t)
+	base := srv.URL + Path
+
+	noNode := snapshotter.NewSnapshot()
+	noNodeData, _ := json.Marshal(noNode)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       []byte
+		wantStatus int
+	}{
+		{"unknown node", http.MethodPost, base, noNodeData, http.StatusBadRequest},
+		{"node from query", http.MethodPost, base + "?node=node-1", noNodeData, http.StatusCreated},
+		{"unsupported kind", http.MethodPost, base, []byte("kind: Recipe\n"), http.StatusBadRequest},
+		{"malformed body", http.MethodPost, base, []byte("{"), http.StatusBadRequest},
+		{"empty body", http.MethodPost, base, nil, http.StatusBadRequest},
+		{"collection method", http.MethodDelete, base, nil, http.StatusMethodNotAllowed},
+		{"item method", http.MethodPost, base + "/some-id", nil, http.StatusMethodNotAllowed},
+		{"invalid limit", http.MethodGet, base + "?limit=0", nil, http.StatusBadRequest},
+		{"invalid since", http.MethodGet, base + "?since=yesterday", nil, http.StatusBadRequest},
+		{"unknown snapshot", http.MethodGet, base + "/unknown", nil, http.StatusNotFound},
+		{"unknown diff snapshot", http.MethodGet, base + "/unknown/diff/latest", nil, http.StatusNotFound},
+		{"unknown subresource", http.MethodGet, base + "/some-id/history", nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
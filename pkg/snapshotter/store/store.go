@@ -0,0 +1,98 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+// EnvDir is the environment variable enabling the snapshot API in cnsd with a
+// FileStore in the given directory.
+const EnvDir = "CNS_SNAPSHOT_STORE_DIR"
+
+// ClusterKey is the snapshot metadata key naming the cluster a snapshot was
+// captured in. The cluster query parameter of POST /v1/snapshots takes precedence.
+const ClusterKey = "cluster"
+
+// DefaultCluster is the cluster recorded for snapshots that name none.
+const DefaultCluster = "default"
+
+// Store persists snapshots keyed by cluster and node.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Put stores the snapshot under the cluster and node of rec and returns
+	// the stored record with its ID and ReceivedAt set.
+	Put(ctx context.Context, rec Record, snap *snapshotter.Snapshot) (*Record, error)
+
+	// Get returns the record and snapshot with the given ID. It returns an
+	// ErrCodeNotFound error when no such snapshot exists.
+	Get(ctx context.Context, id string) (*Record, *snapshotter.Snapshot, error)
+
+	// List returns the records matching the query, newest first.
+	List(ctx context.Context, q Query) ([]Record, error)
+
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// Record describes a stored snapshot.
+type Record struct {
+	// ID uniquely identifies the snapshot in the store.
+	ID string `json:"id" yaml:"id"`
+
+	// Cluster is the cluster the snapshot was captured in.
+	Cluster string `json:"cluster" yaml:"cluster"`
+
+	// Node is the node the snapshot was captured on.
+	Node string `json:"node" yaml:"node"`
+
+	// Version is the version of the tool that captured the snapshot.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// CapturedAt is the capture time reported by the snapshot, if any.
+	CapturedAt string `json:"capturedAt,omitempty" yaml:"capturedAt,omitempty"`
+
+	// ReceivedAt is when the store received the snapshot.
+	ReceivedAt time.Time `json:"receivedAt" yaml:"receivedAt"`
+}
+
+// Query selects stored snapshots. Empty fields match everything.
+type Query struct {
+	// Cluster restricts the results to one cluster.
+	Cluster string
+
+	// Node restricts the results to one node.
+	Node string
+
+	// Since excludes snapshots received before this time.
+	Since time.Time
+
+	// Limit caps the number of results (no limit when 0).
+	Limit int
+}
+
+// Matches reports whether the record is selected by the query, ignoring Limit.
+func (q Query) Matches(rec *Record) bool {
+	if q.Cluster != "" && rec.Cluster != q.Cluster {
+		return false
+	}
+	if q.Node != "" && rec.Node != q.Node {
+		return false
+	}
+	return q.Since.IsZero() || !rec.ReceivedAt.Before(q.Since)
+}
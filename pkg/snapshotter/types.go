@@ -37,7 +37,7 @@ func NewSnapshot() *Snapshot {
 
 // Snapshot represents a collected configuration snapshot from a system node.
 // It contains metadata and measurements from various collectors including
-// Kubernetes, GPU, OS configuration, and systemd services.
+// Kubernetes, GPU, OS configuration, systemd services, network fabric, and container runtimes.
 type Snapshot struct {
 	header.Header `json:",inline" yaml:",inline"`
 
@@ -0,0 +1,67 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshotter
+
+// VolatileReadings are reading path patterns ({Type}.{Subtype}.{Key}) of values
+// that change between two collections on the same node, or that naturally
+// differ between otherwise identical nodes: host identity, kernel counters,
+// process IDs, timestamps and resource usage of systemd units, and error
+// counters. They are excluded from cross-node drift detection and from the
+// drift controller's snapshot comparison. Supports measurement.FilterOut patterns.
+var VolatileReadings = []string{
+	// Host and boot identity
+	"OS.sysctl./proc/sys/kernel/hostname",
+	"OS.sysctl./proc/sys/kernel/random/boot_id",
+	"OS.sysctl./proc/sys/kernel/random/uuid",
+
+	// Kernel counters and self-tuned values
+	"OS.sysctl./proc/sys/kernel/random/entropy_avail",
+	"OS.sysctl./proc/sys/kernel/ns_last_pid",
+	"OS.sysctl./proc/sys/kernel/perf_event_max_sample_rate",
+	"OS.sysctl./proc/sys/kernel/pty/nr",
+	"OS.sysctl./proc/sys/fs/aio-nr",
+	"OS.sysctl./proc/sys/fs/dentry-negative",
+	"OS.sysctl./proc/sys/fs/dentry-state",
+	"OS.sysctl./proc/sys/fs/file-nr",
+	"OS.sysctl./proc/sys/fs/inode-nr",
+	"OS.sysctl./proc/sys/fs/inode-state",
+	"OS.sysctl./proc/sys/fs/quota/*",
+
+	// Runtime state of systemd units
+	"SystemD.*.MainPID",
+	"SystemD.*.ControlPID",
+	"SystemD.*.ExecMain*",
+	"SystemD.*.ExecStart*",
+	"SystemD.*.ExecReload*",
+	"SystemD.*.ExecStop*",
+	"SystemD.*.ExecCondition*",
+	"SystemD.*.InvocationID",
+	"SystemD.*.ControlGroupId",
+	"SystemD.*.NRestarts",
+	"SystemD.*Timestamp*",
+	"SystemD.*.CPUUsageNSec",
+	"SystemD.*.Memory*Current",
+	"SystemD.*.MemoryPeak",
+	"SystemD.*.MemoryAvailable",
+	"SystemD.*.TasksCurrent",
+	"SystemD.*.IO*Bytes",
+	"SystemD.*.IO*Operations",
+	"SystemD.*.IP*Bytes",
+	"SystemD.*.IP*Packets",
+
+	// GPU error counters (health is reported separately)
+	"GPU.*-correctable",
+	"GPU.*-uncorrectable",
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+// ValidateCluster evaluates the recipe constraints against every node pool of
+// a ClusterSnapshot. Each constraint is evaluated on every node in the pool:
+// it fails for the pool if it fails on any node, and is skipped if it cannot
+// be evaluated on some node. The top-level summary aggregates all pools.
+func (v *Validator) ValidateCluster(ctx context.Context, recipeResult *recipe.RecipeResult, cluster *snapshotter.ClusterSnapshot) (*ValidationResult, error) {
+	start := time.Now()
+
+	if recipeResult == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "recipe cannot be nil")
+	}
+	if cluster == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "cluster snapshot cannot be nil")
+	}
+
+	result := NewValidationResult()
+	result.Init(header.KindValidationResult, APIVersion, v.Version)
+	result.Pools = make([]PoolValidation, 0, len(cluster.Pools))
+
+	for _, pool := range cluster.Pools {
+		pv := PoolValidation{
+			Pool:    pool.Name,
+			Nodes:   pool.Nodes,
+			Results: make([]ConstraintValidation, 0, len(recipeResult.Constraints)),
+		}
+
+		for _, constraint := range recipeResult.Constraints {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			cv := v.evaluatePoolConstraint(constraint, recipeResult.Criteria, cluster, pool)
+			pv.Results = append(pv.Results, cv)
+
+			switch cv.Status {
+			case ConstraintStatusPassed:
+				pv.Summary.Passed++
+			case ConstraintStatusFailed:
+				pv.Summary.Failed++
+			case ConstraintStatusSkipped:
+				pv.Summary.Skipped++
+			}
+		}
+
+		pv.Summary.Total = len(recipeResult.Constraints)
+		pv.Summary.Duration = time.Since(start)
+		pv.Summary.Status = overallStatus(pv.Summary)
+
+		result.Summary.Passed += pv.Summary.Passed
+		result.Summary.Failed += pv.Summary.Failed
+		result.Summary.Skipped += pv.Summary.Skipped
+		result.Summary.Total += pv.Summary.Total
+		result.Pools = append(result.Pools, pv)
+	}
+
+	result.Summary.Duration = time.Since(start)
+	result.Summary.Status = overallStatus(result.Summary)
+
+	slog.Debug("cluster validation completed",
+		"pools", len(result.Pools),
+		"passed", result.Summary.Passed,
+		"failed", result.Summary.Failed,
+		"skipped", result.Summary.Skipped,
+		"status", result.Summary.Status,
+		"duration", result.Summary.Duration)
+
+	return result, nil
+}
+
+// evaluatePoolConstraint evaluates a constraint on every node of the pool and
+// merges the per-node outcomes. The reference node provides the reported value
+// unless some node failed, in which case the first failing node's value is used.
+func (v *Validator) evaluatePoolConstraint(constraint recipe.Constraint, criteria *recipe.Criteria, cluster *snapshotter.ClusterSnapshot, pool *snapshotter.NodePool) ConstraintValidation {
+	var reference *ConstraintValidation
+	var firstFailed, firstSkipped *ConstraintValidation
+	var failedNodes, skippedNodes []string
+
+	for _, node := range pool.Nodes {
+		snap := cluster.NodeSnapshotFor(node)
+		if snap == nil {
+			continue
+		}
+
+		cv := v.evaluateConstraint(constraint, criteria, snap)
+		switch cv.Status {
+		case ConstraintStatusFailed:
+			failedNodes = append(failedNodes, node)
+			if firstFailed == nil {
+				firstFailed = &cv
+			}
+		case ConstraintStatusSkipped:
+			skippedNodes = append(skippedNodes, node)
+			if firstSkipped == nil {
+				firstSkipped = &cv
+			}
+		}
+		if node == pool.Reference {
+			reference = &cv
+		}
+	}
+
+	switch {
+	case firstFailed != nil:
+		cv := *firstFailed
+		cv.Message = fmt.Sprintf("failed on %d/%d nodes (%s): %s",
+			len(failedNodes), len(pool.Nodes), strings.Join(failedNodes, ", "), firstFailed.Message)
+		return cv
+	case firstSkipped != nil:
+		cv := *firstSkipped
+		cv.Message = fmt.Sprintf("not evaluated on %d/%d nodes (%s): %s",
+			len(skippedNodes), len(pool.Nodes), strings.Join(skippedNodes, ", "), firstSkipped.Message)
+		return cv
+	case reference != nil:
+		return *reference
+	default:
+		return ConstraintValidation{
+			Name:     constraint.Name,
+			Expected: constraint.Value,
+			Status:   ConstraintStatusSkipped,
+			Message:  fmt.Sprintf("pool %s has no nodes", pool.Name),
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/measurement"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+func newClusterTestSnapshot(node, model, osVersion string) *snapshotter.Snapshot {
+	snap := snapshotter.NewSnapshot()
+	snap.Metadata = map[string]string{snapshotter.SourceNodeKey: node}
+	snap.Measurements = []*measurement.Measurement{
+		measurement.NewMeasurement(measurement.TypeGPU).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("smi").
+				SetString("gpu.model", model)).
+			Build(),
+		measurement.NewMeasurement(measurement.TypeOS).
+			WithSubtypeBuilder(measurement.NewSubtypeBuilder("release").
+				SetString("ID", "ubuntu").
+				SetString("VERSION_ID", osVersion)).
+			Build(),
+	}
+	return snap
+}
+
+func TestValidator_ValidateCluster(t *testing.T) {
+	// Pool by GPU model only so the OS version mismatch shows up as drift
+	cluster, err := snapshotter.NewClusterSnapshot("v1.0.0", []*snapshotter.Snapshot{
+		newClusterTestSnapshot("h100-a", "H100", "24.04"),
+		newClusterTestSnapshot("h100-b", "H100", "22.04"),
+		newClusterTestSnapshot("gb200-a", "GB200", "24.04"),
+	}, []string{"GPU.smi.gpu.model"})
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+
+	rec := &recipe.RecipeResult{
+		Constraints: []recipe.Constraint{
+			{Name: "OS.release.ID", Value: "ubuntu"},
+			{Name: "OS.release.VERSION_ID", Value: "24.04"},
+			{Name: "OS.sysctl./proc/sys/kernel/osrelease", Value: ">= 6.8"},
+		},
+	}
+
+	result, err := New(WithVersion("v1.0.0")).ValidateCluster(context.Background(), rec, cluster)
+	if err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	if len(result.Pools) != 2 {
+		t.Fatalf("expected 2 pool results, got %d", len(result.Pools))
+	}
+
+	pools := make(map[string]PoolValidation)
+	for _, pv := range result.Pools {
+		pools[strings.Join(pv.Nodes, ",")] = pv
+	}
+
+	gb200, ok := pools["gb200-a"]
+	if !ok {
+		t.Fatal("missing GB200 pool result")
+	}
+	if gb200.Summary.Passed != 2 || gb200.Summary.Skipped != 1 || gb200.Summary.Status != ValidationStatusPartial {
+		t.Errorf("unexpected GB200 summary: %+v", gb200.Summary)
+	}
+
+	h100, ok := pools["h100-a,h100-b"]
+	if !ok {
+		t.Fatal("missing H100 pool result")
+	}
+	if h100.Summary.Status != ValidationStatusFail {
+		t.Errorf("H100 pool status = %s, want fail", h100.Summary.Status)
+	}
+	versionResult := h100.Results[1]
+	if versionResult.Status != ConstraintStatusFailed {
+		t.Errorf("VERSION_ID status = %s, want failed", versionResult.Status)
+	}
+	if versionResult.Actual != "22.04" || !strings.Contains(versionResult.Message, "h100-b") {
+		t.Errorf("expected failure attributed to h100-b, got actual=%q message=%q",
+			versionResult.Actual, versionResult.Message)
+	}
+
+	if result.Summary.Total != 6 {
+		t.Errorf("Total = %d, want 6", result.Summary.Total)
+	}
+	if result.Summary.Passed != 3 || result.Summary.Failed != 1 || result.Summary.Skipped != 2 {
+		t.Errorf("unexpected aggregate summary: %+v", result.Summary)
+	}
+	if result.Summary.Status != ValidationStatusFail {
+		t.Errorf("Status = %s, want fail", result.Summary.Status)
+	}
+}
+
+func TestValidator_ValidateCluster_NilInputs(t *testing.T) {
+	v := New()
+	cluster, err := snapshotter.NewClusterSnapshot("", []*snapshotter.Snapshot{
+		newClusterTestSnapshot("node-a", "H100", "24.04"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewClusterSnapshot() error = %v", err)
+	}
+
+	if _, err := v.ValidateCluster(context.Background(), nil, cluster); err == nil {
+		t.Error("expected error for nil recipe")
+	}
+	if _, err := v.ValidateCluster(context.Background(), &recipe.RecipeResult{}, nil); err == nil {
+		t.Error("expected error for nil cluster snapshot")
+	}
+}
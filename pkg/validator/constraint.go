@@ -16,6 +16,8 @@ package validator
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
@@ -46,9 +48,32 @@ const (
 
 	// OperatorExact represents no operator (exact string match).
 	OperatorExact Operator = ""
+
+	// OperatorIn represents "in [a, b]" (value is one of the listed values).
+	OperatorIn Operator = "in"
+
+	// OperatorNotIn represents "not in [a, b]" (value is none of the listed values).
+	OperatorNotIn Operator = "not in"
+
+	// OperatorMatch represents "~=" (regular expression match).
+	OperatorMatch Operator = "~="
+
+	// OperatorAnd combines operands that must all be satisfied ("&&" or ",").
+	OperatorAnd Operator = "&&"
+
+	// OperatorOr combines operands of which at least one must be satisfied ("||").
+	OperatorOr Operator = "||"
 )
 
+// numberPattern matches a decimal number with an optional unit suffix
+// (e.g., "8", "81559 MiB", "700.00 W").
+var numberPattern = regexp.MustCompile(`^\s*([+-]?\d+(?:\.\d+)?)\s*[A-Za-z%/]*\s*$`)
+
 // ParsedConstraint represents a parsed constraint expression.
+//
+// Simple comparisons use Operator and Value. Membership tests (OperatorIn,
+// OperatorNotIn) use Values, and boolean combinations (OperatorAnd, OperatorOr)
+// use Operands. For OperatorMatch, Value holds the regular expression.
 type ParsedConstraint struct {
 	// Operator is the comparison operator (or empty for exact match).
 	Operator Operator
@@ -56,8 +81,17 @@ type ParsedConstraint struct {
 	// Value is the expected value after the operator.
 	Value string
 
+	// Values holds the list of an "in" or "not in" membership test.
+	Values []string
+
+	// Operands holds the sub-expressions of an "&&" or "||" combination.
+	Operands []*ParsedConstraint
+
 	// IsVersionComparison indicates if this should be treated as a version comparison.
 	IsVersionComparison bool
+
+	// pattern is the compiled regular expression for OperatorMatch.
+	pattern *regexp.Regexp
 }
 
 // ParseConstraintExpression parses a constraint value expression.
@@ -65,43 +99,35 @@ type ParsedConstraint struct {
 //   - ">= 1.32.4" -> {Operator: ">=", Value: "1.32.4", IsVersionComparison: true}
 //   - "ubuntu" -> {Operator: "", Value: "ubuntu", IsVersionComparison: false}
 //   - "== 24.04" -> {Operator: "==", Value: "24.04", IsVersionComparison: false}
+//   - ">= 1.30, < 1.34" -> {Operator: "&&", Operands: [>= 1.30, < 1.34]}
+//   - "in [ubuntu, rhel]" -> {Operator: "in", Values: [ubuntu, rhel]}
+//   - "~= ^5\.15\." -> {Operator: "~=", Value: "^5\.15\."}
+//   - "ubuntu || (rhel && >= 9)" -> {Operator: "||", Operands: [...]}
+//
+// Syntax errors wrap a *ParseError carrying the position of the offending input.
 func ParseConstraintExpression(expr string) (*ParsedConstraint, error) {
-	expr = strings.TrimSpace(expr)
-	if expr == "" {
-		return nil, errors.New(errors.ErrCodeInvalidRequest, "constraint expression cannot be empty")
-	}
-
-	pc := &ParsedConstraint{}
-
-	// Check for operators (longest first to avoid matching ">" when ">=" is intended)
-	operators := []Operator{OperatorGTE, OperatorLTE, OperatorNE, OperatorEQ, OperatorGT, OperatorLT}
-	for _, op := range operators {
-		if strings.HasPrefix(expr, string(op)) {
-			pc.Operator = op
-			pc.Value = strings.TrimSpace(strings.TrimPrefix(expr, string(op)))
-			break
-		}
-	}
-
-	// If no operator found, treat as exact match
-	if pc.Operator == "" {
-		pc.Operator = OperatorExact
-		pc.Value = expr
+	pc, err := parseExpression(expr)
+	if err != nil {
+		return nil, errors.WrapWithContext(errors.ErrCodeInvalidRequest, "invalid constraint expression", err,
+			map[string]any{"expression": expr})
 	}
+	return pc, nil
+}
 
-	if pc.Value == "" {
-		return nil, errors.New(errors.ErrCodeInvalidRequest, "constraint value cannot be empty after operator")
-	}
+// newComparison builds a single comparison, flagging ordering operators and
+// version-like values for version-aware evaluation.
+func newComparison(op Operator, value string) *ParsedConstraint {
+	pc := &ParsedConstraint{Operator: op, Value: value}
 
-	// Determine if this is a version comparison (operators other than exact match with version-like value)
-	if pc.Operator != OperatorExact && pc.Operator != OperatorEQ && pc.Operator != OperatorNE {
-		pc.IsVersionComparison = true
-	} else if looksLikeVersion(pc.Value) {
-		// For exact match or == with version-like values, still treat as potential version
-		pc.IsVersionComparison = looksLikeVersion(pc.Value)
+	// Ordering operators compare versions unless the value is a plain integer
+	// (e.g., a GPU count); exact match and == still treat version-like values as versions
+	if op != OperatorExact && op != OperatorEQ && op != OperatorNE {
+		pc.IsVersionComparison = !isPlainInteger(value)
+	} else {
+		pc.IsVersionComparison = looksLikeVersion(value)
 	}
 
-	return pc, nil
+	return pc
 }
 
 // looksLikeVersion returns true if the value appears to be a version string.
@@ -162,21 +188,11 @@ func (pc *ParsedConstraint) Evaluate(actual string) (bool, error) {
 		return actual != pc.Value, nil
 
 	case OperatorGTE, OperatorGT, OperatorLTE, OperatorLT:
-		// Version comparison required
-		expectedVer, err := version.ParseVersion(pc.Value)
-		if err != nil {
-			return false, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
-				"cannot parse expected version", err, map[string]any{"version": pc.Value})
-		}
-
-		actualVer, err := version.ParseVersion(actual)
+		cmp, err := compareOrdered(actual, pc.Value)
 		if err != nil {
-			return false, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
-				"cannot parse actual version", err, map[string]any{"version": actual})
+			return false, err
 		}
 
-		cmp := actualVer.Compare(expectedVer)
-
 		//nolint:exhaustive // Only comparison operators reach this point; EQ, NE, Exact are handled above
 		switch pc.Operator {
 		case OperatorGTE:
@@ -192,16 +208,174 @@ func (pc *ParsedConstraint) Evaluate(actual string) (bool, error) {
 			return false, errors.NewWithContext(errors.ErrCodeInternal,
 				"unexpected operator in version comparison", map[string]any{"operator": pc.Operator})
 		}
+
+	case OperatorIn, OperatorNotIn:
+		found := false
+		for _, v := range pc.Values {
+			if valuesEqual(actual, v) {
+				found = true
+				break
+			}
+		}
+		return found == (pc.Operator == OperatorIn), nil
+
+	case OperatorMatch:
+		re := pc.pattern
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(pc.Value); err != nil {
+				return false, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+					"invalid pattern", err, map[string]any{"pattern": pc.Value})
+			}
+		}
+		return re.MatchString(actual), nil
+
+	case OperatorAnd:
+		for _, operand := range pc.Operands {
+			ok, err := operand.Evaluate(actual)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case OperatorOr:
+		// An operand that cannot be evaluated only fails the expression if no
+		// other operand is satisfied
+		var firstErr error
+		for _, operand := range pc.Operands {
+			ok, err := operand.Evaluate(actual)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, firstErr
+
 	default:
 		return false, errors.NewWithContext(errors.ErrCodeInvalidRequest,
 			"unknown operator", map[string]any{"operator": pc.Operator})
 	}
 }
 
+// compareOrdered compares actual against expected for ordering operators and
+// returns -1, 0 or 1. Plain integers (e.g., GPU counts) are compared
+// numerically, allowing a unit suffix on the actual value. Anything else is
+// compared as a version, falling back to a numeric comparison when either side
+// is not a valid version but both are numbers (e.g., "700.00 W").
+func compareOrdered(actual, expected string) (int, error) {
+	if isPlainInteger(expected) {
+		if cmp, ok := compareNumbers(actual, expected); ok {
+			return cmp, nil
+		}
+	}
+
+	expectedVer, err := version.ParseVersion(expected)
+	if err != nil {
+		if cmp, ok := compareNumbers(actual, expected); ok {
+			return cmp, nil
+		}
+		return 0, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+			"cannot parse expected version", err, map[string]any{"version": expected})
+	}
+
+	actualVer, err := version.ParseVersion(actual)
+	if err != nil {
+		if cmp, ok := compareNumbers(actual, expected); ok {
+			return cmp, nil
+		}
+		return 0, errors.WrapWithContext(errors.ErrCodeInvalidRequest,
+			"cannot parse actual version", err, map[string]any{"version": actual})
+	}
+
+	return actualVer.Compare(expectedVer), nil
+}
+
+// compareNumbers compares two numeric values, ignoring unit suffixes.
+// Returns false if either value is not a number.
+func compareNumbers(actual, expected string) (int, bool) {
+	a, ok := parseNumber(actual)
+	if !ok {
+		return 0, false
+	}
+	e, ok := parseNumber(expected)
+	if !ok {
+		return 0, false
+	}
+	switch {
+	case a < e:
+		return -1, true
+	case a > e:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// parseNumber parses a decimal number with an optional unit suffix.
+func parseNumber(s string) (float64, bool) {
+	m := numberPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// isPlainInteger reports whether s is a base-10 integer.
+func isPlainInteger(s string) bool {
+	_, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return err == nil
+}
+
+// valuesEqual compares two values as versions when the expected value looks
+// like a version, otherwise as strings.
+func valuesEqual(actual, expected string) bool {
+	if looksLikeVersion(expected) {
+		expectedVer, err := version.ParseVersion(expected)
+		if err == nil {
+			actualVer, err := version.ParseVersion(actual)
+			if err == nil {
+				return expectedVer.Equals(actualVer)
+			}
+		}
+	}
+	return actual == expected
+}
+
 // String returns a string representation of the parsed constraint.
+// The result parses back into an equivalent constraint.
 func (pc *ParsedConstraint) String() string {
-	if pc.Operator == OperatorExact {
-		return pc.Value
+	switch pc.Operator {
+	case OperatorExact:
+		return quoteValue(pc.Value)
+	case OperatorIn, OperatorNotIn:
+		values := make([]string, 0, len(pc.Values))
+		for _, v := range pc.Values {
+			values = append(values, quoteValue(v))
+		}
+		return fmt.Sprintf("%s [%s]", pc.Operator, strings.Join(values, ", "))
+	case OperatorMatch:
+		return fmt.Sprintf("%s %s", pc.Operator, quotePattern(pc.Value))
+	case OperatorAnd, OperatorOr:
+		parts := make([]string, 0, len(pc.Operands))
+		for _, operand := range pc.Operands {
+			s := operand.String()
+			if operand.Operator == OperatorAnd || operand.Operator == OperatorOr {
+				s = "(" + s + ")"
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, fmt.Sprintf(" %s ", pc.Operator))
+	default:
+		return fmt.Sprintf("%s %s", pc.Operator, quoteValue(pc.Value))
 	}
-	return fmt.Sprintf("%s %s", pc.Operator, pc.Value)
 }
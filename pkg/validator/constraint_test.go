@@ -229,3 +229,104 @@ func TestParsedConstraint_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func TestParsedConstraint_EvaluateExpressions(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		actual      string
+		want        bool
+		expectError bool
+	}{
+		// Ranges
+		{name: "range - inside", expression: ">= 1.30, < 1.34", actual: "v1.33.5-eks-3025e55", want: true},
+		{name: "range - lower bound", expression: ">= 1.30, < 1.34", actual: "1.30.0", want: true},
+		{name: "range - below", expression: ">= 1.30, < 1.34", actual: "1.29.9", want: false},
+		{name: "range - upper bound excluded", expression: ">= 1.30, < 1.34", actual: "1.34.0", want: false},
+		{name: "range with &&", expression: ">= 1.30 && < 1.34", actual: "1.31.2", want: true},
+
+		// Membership
+		{name: "in - match", expression: "in [ubuntu, rhel]", actual: "rhel", want: true},
+		{name: "in - no match", expression: "in [ubuntu, rhel]", actual: "cos", want: false},
+		{name: "in - version values", expression: "in [22.04, 24.04]", actual: "24.04", want: true},
+		{name: "in - quoted value", expression: `in ["Red Hat", ubuntu]`, actual: "Red Hat", want: true},
+		{name: "not in - match", expression: "not in [ubuntu, rhel]", actual: "cos", want: true},
+		{name: "not in - no match", expression: "not in [ubuntu, rhel]", actual: "ubuntu", want: false},
+
+		// Regular expressions
+		{name: "match - pass", expression: `~= ^5\.15\.`, actual: "5.15.0-1050-aws", want: true},
+		{name: "match - fail", expression: `~= ^5\.15\.`, actual: "6.8.0-1028-aws", want: false},
+		{name: "match - quoted", expression: `~= "^(ubuntu|rhel)$"`, actual: "rhel", want: true},
+		{name: "match - alternation in group", expression: `(~= ^H100 || ~= ^H200) && ~= SXM`, actual: "H200-SXM", want: true},
+
+		// Boolean combinations
+		{name: "or - first", expression: "ubuntu || rhel", actual: "ubuntu", want: true},
+		{name: "or - second", expression: "ubuntu || rhel", actual: "rhel", want: true},
+		{name: "or - none", expression: "ubuntu || rhel", actual: "cos", want: false},
+		{name: "and binds tighter than or", expression: "ubuntu || >= 9, < 10", actual: "9.4", want: true},
+		{name: "parens", expression: "(>= 6.8 || ~= ^5\\.15\\.) && != 6.9.1", actual: "5.15.0-1050-aws", want: true},
+		{name: "parens - excluded", expression: "(>= 6.8 || ~= ^5\\.15\\.) && != 6.9.1", actual: "6.9.1", want: false},
+		{name: "or - unparsable operand ignored when other passes", expression: ">= 1.30 || ubuntu", actual: "ubuntu", want: true},
+		{name: "or - unparsable operand fails", expression: ">= 1.30 || ubuntu", actual: "rhel", expectError: true},
+
+		// Numeric comparisons
+		{name: "numeric gte - pass", expression: ">= 8", actual: "8", want: true},
+		{name: "numeric gte - multi digit", expression: ">= 8", actual: "16", want: true},
+		{name: "numeric gte - fail", expression: ">= 8", actual: "4", want: false},
+		{name: "numeric with unit", expression: ">= 80000", actual: "81559 MiB", want: true},
+		{name: "numeric decimal with unit", expression: "> 500.5", actual: "700.00 W", want: true},
+		{name: "numeric range", expression: "> 0, <= 8", actual: "8", want: true},
+		{name: "non-numeric actual", expression: ">= 8", actual: "many", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc, err := ParseConstraintExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseConstraintExpression(%q) error: %v", tt.expression, err)
+			}
+			result, err := pc.Evaluate(tt.actual)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("%q.Evaluate(%q) = %v, want %v", tt.expression, tt.actual, result, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsedConstraint_String(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{expression: "ubuntu", want: "ubuntu"},
+		{expression: ">=1.32.4", want: ">= 1.32.4"},
+		{expression: ">= 1.30,< 1.34", want: ">= 1.30 && < 1.34"},
+		{expression: "in [ubuntu,rhel]", want: "in [ubuntu, rhel]"},
+		{expression: `not in ["a, b", c]`, want: `not in ["a, b", c]`},
+		{expression: `~= ^5\.15\.`, want: `~= ^5\.15\.`},
+		{expression: `~= ^(a|b)$`, want: `~= "^(a|b)$"`},
+		{expression: "(a || b) && c", want: "(a || b) && c"},
+		{expression: "a || b && c", want: "a || (b && c)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			pc, err := ParseConstraintExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := pc.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,8 +17,8 @@
 // # Overview
 //
 // The validator package evaluates recipe constraints against actual system measurements
-// captured in snapshots. It supports version comparison operators and exact string matching
-// to determine if a cluster meets the requirements specified in a recipe.
+// captured in snapshots. It supports version and numeric comparisons, membership tests,
+// regular expressions and boolean combinations of these to determine if a cluster meets the requirements specified in a recipe.
 //
 // # Constraint Format
 //
@@ -41,6 +41,27 @@
 //   - "==" - Exact match (string or version)
 //   - "!=" - Not equal (string or version)
 //   - (no operator) - Exact string match
+//   - "in [a, b]" / "not in [a, b]" - Membership in a list of values
+//   - "~= pattern" - Regular expression match (RE2 syntax)
+//
+// Ordering operators compare versions, except when the expected value is a plain
+// integer (e.g., "GPU.smi.gpu.count: >= 8"), which is compared numerically. Actual
+// values may carry a unit suffix for numeric comparisons (e.g., "81559 MiB").
+//
+// # Expressions
+//
+// Comparisons can be combined with "&&" (or ",") and "||", grouped with
+// parentheses. "&&" binds tighter than "||". Values containing spaces are allowed;
+// values containing reserved characters can be double-quoted.
+//
+//	>= 1.30, < 1.34                 -> version range
+//	in [ubuntu, rhel]               -> one of the listed values
+//	~= ^5\.15\.                      -> regular expression
+//	ubuntu || (rhel && >= 9.4)      -> boolean combination
+//
+// Expressions are limited to 256 bytes, and patterns whose counted repetitions
+// expand too far are rejected. Syntax errors are reported with the column of
+// the offending input as a *ParseError wrapped in the returned error.
 //
 // # Usage
 //
@@ -63,6 +84,10 @@
 //   - Summary: Overall pass/fail counts and status
 //   - Results: Per-constraint validation results with expected/actual values
 //
+// Failed constraints carry a Remediation taken from the recipe constraint or,
+// when the recipe declares none, from the built-in catalog entry matching the
+// recipe criteria (see recipe.RemediationFor).
+//
 // # Error Handling
 //
 // Constraints that cannot be evaluated (e.g., path not found in snapshot) are
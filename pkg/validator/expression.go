@@ -0,0 +1,460 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// ParseError describes a syntax error in a constraint expression.
+// Pos is the zero-based byte offset of the offending input.
+type ParseError struct {
+	Expression string
+	Pos        int
+	Message    string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at column %d in %q", e.Message, e.Pos+1, e.Expression)
+}
+
+// expressionParser is a recursive descent parser for constraint expressions.
+//
+// Grammar:
+//
+//	expr       = and { "||" and }
+//	and        = term { ( "&&" | "," ) term }
+//	term       = "(" expr ")" | membership | match | comparison
+//	membership = [ "not" ] "in" "[" value { "," value } "]"
+//	match      = "~=" pattern
+//	comparison = [ ">=" | "<=" | ">" | "<" | "==" | "!=" ] value
+//	value      = quoted | bare
+//
+// Bare values run until the next ",", "]", ")", "&&", "||" or the end of input
+// and may contain spaces. Unquoted patterns run until the next "&&", "||", an
+// unbalanced ")" or the end of input. Quoted strings support \" and \\ escapes.
+type expressionParser struct {
+	input string
+	pos   int
+	depth int
+}
+
+// maxExpressionLength bounds the size of a constraint expression. Constraint
+// values are short; the bound keeps parsing and pattern compilation cheap.
+const maxExpressionLength = 256
+
+// maxPatternSize bounds the estimated instruction count of a compiled "~="
+// pattern, so counted repetitions (e.g. "(a{1000}){1000}") cannot make
+// compilation and matching expensive.
+const maxPatternSize = 5000
+
+// comparisonOperators are matched longest first so ">=" wins over ">".
+var comparisonOperators = []Operator{OperatorGTE, OperatorLTE, OperatorNE, OperatorEQ, OperatorGT, OperatorLT}
+
+func parseExpression(input string) (*ParsedConstraint, error) {
+	p := &expressionParser{input: input}
+	if len(input) > maxExpressionLength {
+		return nil, p.errorfAt(maxExpressionLength, "constraint expression exceeds %d bytes", maxExpressionLength)
+	}
+
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf("constraint expression cannot be empty")
+	}
+
+	pc, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.rest(1))
+	}
+	return pc, nil
+}
+
+func (p *expressionParser) parseOr() (*ParsedConstraint, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*ParsedConstraint{left}
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			break
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &ParsedConstraint{Operator: OperatorOr, Operands: operands}, nil
+}
+
+func (p *expressionParser) parseAnd() (*ParsedConstraint, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*ParsedConstraint{left}
+	for {
+		p.skipSpace()
+		if !p.consume("&&") && !p.consume(",") {
+			break
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &ParsedConstraint{Operator: OperatorAnd, Operands: operands}, nil
+}
+
+func (p *expressionParser) parseTerm() (*ParsedConstraint, error) {
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf("expected constraint")
+	}
+
+	// Parenthesized group
+	if p.consume("(") {
+		p.depth++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected \")\"")
+		}
+		p.depth--
+		return inner, nil
+	}
+
+	// Membership ("in" / "not in" must be followed by a list, otherwise the
+	// words are treated as part of a bare value)
+	if values, negated, ok, err := p.tryParseMembership(); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		if negated {
+			return &ParsedConstraint{Operator: OperatorNotIn, Values: values}, nil
+		}
+		return &ParsedConstraint{Operator: OperatorIn, Values: values}, nil
+	}
+
+	// Regular expression match
+	if p.consume(string(OperatorMatch)) {
+		return p.parseMatch()
+	}
+
+	// Comparison with optional operator
+	op := OperatorExact
+	for _, candidate := range comparisonOperators {
+		if p.consume(string(candidate)) {
+			op = candidate
+			break
+		}
+	}
+
+	start := p.pos
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		if op == OperatorExact {
+			return nil, p.errorfAt(start, "expected value")
+		}
+		return nil, p.errorfAt(start, "expected value after %q", op)
+	}
+
+	return newComparison(op, value), nil
+}
+
+// tryParseMembership parses an "in [...]" or "not in [...]" term. If the input
+// does not start with a membership keyword followed by "[", the position is left
+// unchanged and ok is false.
+func (p *expressionParser) tryParseMembership() (values []string, negated, ok bool, err error) {
+	start := p.pos
+	if p.consumeKeyword("not") {
+		p.skipSpace()
+		negated = true
+	}
+	if !p.consumeKeyword("in") {
+		p.pos = start
+		return nil, false, false, nil
+	}
+	p.skipSpace()
+	if !p.peek("[") {
+		p.pos = start
+		return nil, false, false, nil
+	}
+
+	values, err = p.parseList()
+	return values, negated, err == nil, err
+}
+
+// parseList parses a bracketed, comma-separated list of values.
+func (p *expressionParser) parseList() ([]string, error) {
+	p.skipSpace()
+	if !p.consume("[") {
+		return nil, p.errorf("expected \"[\"")
+	}
+
+	var values []string
+	for {
+		start := p.pos
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, p.errorfAt(start, "expected list value")
+		}
+		values = append(values, value)
+
+		p.skipSpace()
+		if p.consume("]") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			if p.eof() {
+				return nil, p.errorf("expected \"]\"")
+			}
+			return nil, p.errorf("expected \",\" or \"]\"")
+		}
+	}
+}
+
+// parseMatch parses the pattern of a "~=" term and compiles it.
+func (p *expressionParser) parseMatch() (*ParsedConstraint, error) {
+	p.skipSpace()
+	start := p.pos
+
+	var pattern string
+	if p.peek("\"") {
+		quoted, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		pattern = quoted
+	} else {
+		pattern = p.scanPattern()
+	}
+
+	if pattern == "" {
+		return nil, p.errorfAt(start, "expected pattern after %q", OperatorMatch)
+	}
+
+	if parsed, err := syntax.Parse(pattern, syntax.Perl); err == nil && patternSize(parsed) > maxPatternSize {
+		return nil, p.errorfAt(start, "pattern too complex: repetitions expand beyond %d instructions", maxPatternSize)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, p.errorfAt(start, "invalid pattern: %v", err)
+	}
+
+	return &ParsedConstraint{Operator: OperatorMatch, Value: pattern, pattern: re}, nil
+}
+
+// patternSize estimates the number of instructions of the compiled pattern,
+// expanding counted repetitions. It stops counting past maxPatternSize.
+func patternSize(re *syntax.Regexp) int {
+	size := 1
+	if re.Op == syntax.OpLiteral {
+		size = len(re.Rune)
+	}
+	for _, sub := range re.Sub {
+		size += patternSize(sub)
+		if size > maxPatternSize {
+			return size
+		}
+	}
+	if re.Op == syntax.OpRepeat {
+		size *= max(re.Min, re.Max, 1)
+	}
+	return size
+}
+
+// scanPattern reads an unquoted pattern up to "&&", "||", an unbalanced ")"
+// inside a group, or the end of input.
+func (p *expressionParser) scanPattern() string {
+	start := p.pos
+	open := 0
+	for !p.eof() {
+		if p.peek("&&") || p.peek("||") {
+			break
+		}
+		c := p.input[p.pos]
+		if c == '\\' && p.pos+1 < len(p.input) {
+			p.pos += 2
+			continue
+		}
+		if c == '(' {
+			open++
+		}
+		if c == ')' {
+			if open == 0 && p.depth > 0 {
+				break
+			}
+			open--
+		}
+		p.pos++
+	}
+	return strings.TrimSpace(p.input[start:p.pos])
+}
+
+// parseValue parses a quoted or bare value. Returns "" if no value is present.
+func (p *expressionParser) parseValue() (string, error) {
+	p.skipSpace()
+	if p.peek("\"") {
+		return p.parseQuoted()
+	}
+
+	start := p.pos
+	for !p.eof() {
+		c := p.input[p.pos]
+		if c == ',' || c == ']' || c == ')' || c == '"' || p.peek("&&") || p.peek("||") {
+			break
+		}
+		p.pos++
+	}
+	return strings.TrimSpace(p.input[start:p.pos]), nil
+}
+
+// parseQuoted parses a double-quoted string starting at the current position.
+func (p *expressionParser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.input[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\' && p.pos+1 < len(p.input) && (p.input[p.pos+1] == '"' || p.input[p.pos+1] == '\\'):
+			b.WriteByte(p.input[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorfAt(start, "unterminated quoted string")
+}
+
+// consumeKeyword consumes a lowercase keyword followed by whitespace or "[".
+func (p *expressionParser) consumeKeyword(kw string) bool {
+	if !p.peek(kw) {
+		return false
+	}
+	end := p.pos + len(kw)
+	if end >= len(p.input) {
+		return false
+	}
+	if next := p.input[end]; next != ' ' && next != '\t' && next != '[' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *expressionParser) consume(s string) bool {
+	if p.peek(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) peek(s string) bool {
+	return strings.HasPrefix(p.input[p.pos:], s)
+}
+
+func (p *expressionParser) skipSpace() {
+	for !p.eof() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *expressionParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+// rest returns up to n bytes of remaining input.
+func (p *expressionParser) rest(n int) string {
+	end := p.pos + n
+	if end > len(p.input) {
+		end = len(p.input)
+	}
+	return p.input[p.pos:end]
+}
+
+func (p *expressionParser) errorf(format string, args ...any) *ParseError {
+	return p.errorfAt(p.pos, format, args...)
+}
+
+func (p *expressionParser) errorfAt(pos int, format string, args ...any) *ParseError {
+	return &ParseError{Expression: p.input, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// quoteValue returns v quoted when it would not parse back as a bare value.
+func quoteValue(v string) string {
+	needsQuote := v == "" || v != strings.TrimSpace(v) ||
+		strings.ContainsAny(v, ",()[]\"\\&|<>=!~") ||
+		strings.HasPrefix(v, "in ") || strings.HasPrefix(v, "in[") || strings.HasPrefix(v, "not ")
+	if !needsQuote {
+		return v
+	}
+	return quote(v)
+}
+
+// quotePattern returns pattern quoted when it would not scan back unchanged
+// as an unquoted pattern.
+func quotePattern(pattern string) string {
+	needsQuote := pattern != strings.TrimSpace(pattern) || strings.HasPrefix(pattern, `"`) ||
+		strings.ContainsAny(pattern, "()") || strings.Contains(pattern, "&&") || strings.Contains(pattern, "||")
+	if !needsQuote {
+		return pattern
+	}
+	return quote(pattern)
+}
+
+// quote wraps s in double quotes, escaping backslashes and quotes.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
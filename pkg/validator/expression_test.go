@@ -0,0 +1,169 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExpression_Structure(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantOp     Operator
+		wantValues []string
+		wantCount  int // number of operands for && / ||
+	}{
+		{name: "range", expression: ">= 1.30, < 1.34", wantOp: OperatorAnd, wantCount: 2},
+		{name: "and", expression: ">= 1.30 && < 1.34 && != 1.32.0", wantOp: OperatorAnd, wantCount: 3},
+		{name: "or", expression: "ubuntu || rhel", wantOp: OperatorOr, wantCount: 2},
+		{name: "or of ands", expression: "a, b || c", wantOp: OperatorOr, wantCount: 2},
+		{name: "group", expression: "(a || b)", wantOp: OperatorOr, wantCount: 2},
+		{name: "in", expression: "in [ubuntu, rhel]", wantOp: OperatorIn, wantValues: []string{"ubuntu", "rhel"}},
+		{name: "in no space", expression: "in[ubuntu]", wantOp: OperatorIn, wantValues: []string{"ubuntu"}},
+		{name: "not in", expression: "not in [cos]", wantOp: OperatorNotIn, wantValues: []string{"cos"}},
+		{name: "quoted list value", expression: `in ["a, b", "c\"d"]`, wantOp: OperatorIn, wantValues: []string{"a, b", `c"d`}},
+		{name: "match", expression: `~= ^5\.15\.`, wantOp: OperatorMatch},
+		{name: "value with spaces", expression: "Red Hat Enterprise Linux", wantOp: OperatorExact},
+		{name: "value starting with in", expression: "in progress", wantOp: OperatorExact},
+		{name: "value starting with not", expression: "not supported", wantOp: OperatorExact},
+		{name: "keyword alone", expression: "in", wantOp: OperatorExact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc, err := parseExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pc.Operator != tt.wantOp {
+				t.Errorf("operator = %q, want %q", pc.Operator, tt.wantOp)
+			}
+			if tt.wantValues != nil && !reflect.DeepEqual(pc.Values, tt.wantValues) {
+				t.Errorf("values = %q, want %q", pc.Values, tt.wantValues)
+			}
+			if len(pc.Operands) != tt.wantCount {
+				t.Errorf("operands = %d, want %d", len(pc.Operands), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestParseExpression_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantPos    int
+		wantMsg    string
+	}{
+		{name: "empty", expression: "", wantPos: 0, wantMsg: "constraint expression cannot be empty"},
+		{name: "operator without value", expression: ">=", wantPos: 2, wantMsg: `expected value after ">="`},
+		{name: "dangling and", expression: ">= 1.30 &&", wantPos: 10, wantMsg: "expected constraint"},
+		{name: "dangling comma", expression: ">= 1.30,", wantPos: 8, wantMsg: "expected constraint"},
+		{name: "empty operand", expression: "a || || b", wantPos: 5, wantMsg: "expected value"},
+		{name: "unclosed group", expression: "(a || b", wantPos: 7, wantMsg: `expected ")"`},
+		{name: "unbalanced close", expression: "a)", wantPos: 1, wantMsg: `unexpected ")"`},
+		{name: "unterminated list", expression: "in [a, b", wantPos: 8, wantMsg: `expected "]"`},
+		{name: "empty list", expression: "in []", wantPos: 4, wantMsg: "expected list value"},
+		{name: "empty list value", expression: "in [a,,b]", wantPos: 6, wantMsg: "expected list value"},
+		{name: "unterminated quote", expression: `"abc`, wantPos: 0, wantMsg: "unterminated quoted string"},
+		{name: "missing pattern", expression: "~=", wantPos: 2, wantMsg: `expected pattern after "~="`},
+		{name: "invalid pattern", expression: "~= ^(abc", wantPos: 3, wantMsg: "invalid pattern"},
+		{name: "trailing junk after quote", expression: `"a" b`, wantPos: 4, wantMsg: `unexpected "b"`},
+		{name: "pattern too complex", expression: "~= " + strings.Repeat("(a{1000})", 10), wantPos: 3, wantMsg: "pattern too complex"},
+		{name: "too long", expression: "~= " + strings.Repeat("a", maxExpressionLength), wantPos: maxExpressionLength, wantMsg: "constraint expression exceeds 256 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConstraintExpression(tt.expression)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError, got %T: %v", err, err)
+			}
+			if pe.Pos != tt.wantPos {
+				t.Errorf("pos = %d, want %d (%v)", pe.Pos, tt.wantPos, pe)
+			}
+			if len(pe.Message) < len(tt.wantMsg) || pe.Message[:len(tt.wantMsg)] != tt.wantMsg {
+				t.Errorf("message = %q, want prefix %q", pe.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	err := &ParseError{Expression: ">= 1.30 &&", Pos: 10, Message: "expected constraint"}
+	want := `expected constraint at column 11 in ">= 1.30 &&"`
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+// FuzzParseConstraintExpression checks that the parser never panics, that
+// errors carry a position within the input, and that successfully parsed
+// expressions survive a String() round trip.
+func FuzzParseConstraintExpression(f *testing.F) {
+	seeds := []string{
+		">= 1.32.4",
+		"ubuntu",
+		">= 1.30, < 1.34",
+		"in [ubuntu, rhel]",
+		"not in [cos]",
+		`~= ^5\.15\.`,
+		"(a || b) && >= 8",
+		`in ["a, b", "c\"d"]`,
+		"((",
+		"in [",
+	}
+	for _, s := range seeds {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, expr string) {
+		pc, err := parseExpression(expr)
+		if err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error is not a *ParseError: %v", err)
+			}
+			if pe.Pos < 0 || pe.Pos > len(expr) {
+				t.Fatalf("error position %d out of range for %q", pe.Pos, expr)
+			}
+			return
+		}
+
+		rendered := pc.String()
+		if len(rendered) > maxExpressionLength {
+			return // quoting can push a valid expression past the length limit
+		}
+		reparsed, err := parseExpression(rendered)
+		if err != nil {
+			t.Fatalf("String() of %q = %q does not parse: %v", expr, rendered, err)
+		}
+		if again := reparsed.String(); again != rendered {
+			t.Fatalf("round trip of %q unstable: %q != %q", expr, rendered, again)
+		}
+
+		// Evaluation must not panic either
+		_, _ = pc.Evaluate("1.2.3")
+	})
+}
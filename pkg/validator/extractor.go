@@ -91,22 +91,16 @@ func (cp *ConstraintPath) ExtractValue(snap *snapshotter.Snapshot) (string, erro
 	}
 
 	// Find the subtype
-	var targetSubtype *measurement.Subtype
-	for i := range targetMeasurement.Subtypes {
-		if targetMeasurement.Subtypes[i].Name == cp.Subtype {
-			targetSubtype = &targetMeasurement.Subtypes[i]
-			break
-		}
-	}
+	targetSubtype := targetMeasurement.GetSubtype(cp.Subtype)
 
-	if targetSubtype == nil {
+	// Find the key in data; subtype names may contain dots themselves
+	// (e.g., "GPU.gpu.0.model" resolves to subtype "gpu.0", key "model")
+	reading, exists := targetMeasurement.Lookup(cp.Subtype + "." + cp.Key)
+	if !exists && targetSubtype == nil {
 		return "", errors.NewWithContext(errors.ErrCodeNotFound,
 			"subtype not found in measurement",
 			map[string]any{"subtype": cp.Subtype, "type": cp.Type})
 	}
-
-	// Find the key in data
-	reading, exists := targetSubtype.Data[cp.Key]
 	if !exists {
 		return "", errors.NewWithContext(errors.ErrCodeNotFound,
 			"key not found in subtype",
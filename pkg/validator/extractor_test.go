@@ -160,6 +160,13 @@ func TestConstraintPath_ExtractValue(t *testing.T) {
 							"driver": measurement.Str("550.107.02"),
 						},
 					},
+					{
+						Name: "gpu.0",
+						Data: map[string]measurement.Reading{
+							"model":        measurement.Str("NVIDIA H100 80GB HBM3"),
+							"fabric.state": measurement.Str("Completed"),
+						},
+					},
 				},
 			},
 		},
@@ -217,6 +224,24 @@ func TestConstraintPath_ExtractValue(t *testing.T) {
 			},
 			want: "H100",
 		},
+		{
+			name: "dotted subtype",
+			path: ConstraintPath{
+				Type:    measurement.TypeGPU,
+				Subtype: "gpu",
+				Key:     "0.model",
+			},
+			want: "NVIDIA H100 80GB HBM3",
+		},
+		{
+			name: "dotted subtype and key",
+			path: ConstraintPath{
+				Type:    measurement.TypeGPU,
+				Subtype: "gpu",
+				Key:     "0.fabric.state",
+			},
+			want: "Completed",
+		},
 
 		// Error cases - not found
 		{
@@ -237,6 +262,15 @@ func TestConstraintPath_ExtractValue(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "dotted subtype not found",
+			path: ConstraintPath{
+				Type:    measurement.TypeGPU,
+				Subtype: "gpu",
+				Key:     "1.model",
+			},
+			expectError: true,
+		},
 		{
 			name: "key not found",
 			path: ConstraintPath{
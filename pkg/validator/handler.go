@@ -0,0 +1,242 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/defaults"
+	"github.com/NVIDIA/cloud-native-stack/pkg/errors"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+	"github.com/NVIDIA/cloud-native-stack/pkg/serializer"
+	"github.com/NVIDIA/cloud-native-stack/pkg/server"
+	"github.com/NVIDIA/cloud-native-stack/pkg/snapshotter"
+)
+
+const (
+	// RecipePart is the multipart form part holding the recipe.
+	RecipePart = "recipe"
+
+	// SnapshotPart is the multipart form part holding the snapshot.
+	SnapshotPart = "snapshot"
+
+	// MaxRequestSize is the maximum size of a validation request body.
+	MaxRequestSize = 10 * 1024 * 1024
+)
+
+// Request is the envelope accepted by HandleValidate: a recipe and the
+// snapshot to validate it against.
+type Request struct {
+	// Recipe is the recipe whose constraints are evaluated.
+	Recipe *recipe.RecipeResult `json:"recipe" yaml:"recipe"`
+
+	// Snapshot is the snapshot the constraints are evaluated against.
+	Snapshot *snapshotter.Snapshot `json:"snapshot" yaml:"snapshot"`
+
+	// recipeSource and snapshotSource are the multipart file names, if any.
+	recipeSource   string
+	snapshotSource string
+}
+
+// HandleValidate processes validation requests.
+// It accepts a POST request with either:
+//   - multipart/form-data with "recipe" and "snapshot" parts (JSON or YAML each)
+//   - a JSON (or YAML) envelope {"recipe": {...}, "snapshot": {...}}
+//
+// The response is a ValidationResult, encoded as YAML when the Accept header
+// asks for application/yaml and as JSON otherwise. A failed validation is
+// still a 200 response; the outcome is in summary.status.
+func (v *Validator) HandleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		server.WriteError(w, r, http.StatusMethodNotAllowed, errors.ErrCodeMethodNotAllowed,
+			"Method not allowed", false, map[string]any{
+				"method": r.Method,
+			})
+		return
+	}
+
+	// Add request-scoped timeout
+	ctx, cancel := context.WithTimeout(r.Context(), defaults.ValidateHandlerTimeout)
+	defer cancel()
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestSize)
+	defer r.Body.Close()
+
+	req, err := ParseRequest(r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			server.WriteError(w, r, http.StatusRequestEntityTooLarge, errors.ErrCodeInvalidRequest,
+				"Request body too large", false, map[string]any{
+					"limit": tooLarge.Limit,
+				})
+			return
+		}
+		server.WriteError(w, r, http.StatusBadRequest, errors.ErrCodeInvalidRequest,
+			"Invalid validation request", false, map[string]any{
+				"error": err.Error(),
+			})
+		return
+	}
+
+	// Validate recipe criteria against allowlists (if configured)
+	if v.AllowLists != nil && req.Recipe.Criteria != nil {
+		if validateErr := v.AllowLists.ValidateCriteria(req.Recipe.Criteria); validateErr != nil {
+			server.WriteErrorFromErr(w, r, validateErr, "Recipe criteria value not allowed", nil)
+			return
+		}
+	}
+
+	slog.Debug("validation request received",
+		"constraints", len(req.Recipe.Constraints),
+		"measurements", len(req.Snapshot.Measurements),
+	)
+
+	result, err := v.Validate(ctx, req.Recipe, req.Snapshot)
+	if err != nil {
+		server.WriteErrorFromErr(w, r, err, "Failed to validate recipe", nil)
+		return
+	}
+	result.RecipeSource = req.recipeSource
+	result.SnapshotSource = req.snapshotSource
+
+	serializer.Respond(w, r, http.StatusOK, result)
+}
+
+// ParseRequest reads a validation request from a multipart form or a JSON or
+// YAML envelope, depending on the Content-Type. Both the recipe and the
+// snapshot are required.
+func ParseRequest(r *http.Request) (*Request, error) {
+	if r.Body == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "request body is empty")
+	}
+
+	mediaType := ""
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInvalidRequest, "invalid Content-Type", err)
+		}
+		mediaType = mt
+	}
+
+	req := &Request{}
+	if mediaType == "multipart/form-data" {
+		if err := parseMultipart(r, req); err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInvalidRequest, "failed to read request body", err)
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil, errors.New(errors.ErrCodeInvalidRequest, "request body is empty")
+		}
+		if err := decodeDocument(data, mediaType, "", req); err != nil {
+			return nil, errors.Wrap(errors.ErrCodeInvalidRequest, "failed to parse request body", err)
+		}
+	}
+
+	if req.Recipe == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "recipe is required")
+	}
+	if req.Snapshot == nil {
+		return nil, errors.New(errors.ErrCodeInvalidRequest, "snapshot is required")
+	}
+	return req, nil
+}
+
+// parseMultipart reads the recipe and snapshot parts of a multipart form.
+func parseMultipart(r *http.Request, req *Request) error {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeInvalidRequest, "invalid multipart request", err)
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeInvalidRequest, "failed to read multipart request", err)
+		}
+
+		name := part.FormName()
+		data, err := io.ReadAll(part)
+		part.Close()
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeInvalidRequest, fmt.Sprintf("failed to read %q part", name), err)
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+
+		switch name {
+		case RecipePart:
+			req.Recipe = &recipe.RecipeResult{}
+			err = decodeDocument(data, mediaType, part.FileName(), req.Recipe)
+			req.recipeSource = part.FileName()
+		case SnapshotPart:
+			req.Snapshot = &snapshotter.Snapshot{}
+			err = decodeDocument(data, mediaType, part.FileName(), req.Snapshot)
+			req.snapshotSource = part.FileName()
+		default:
+			return errors.New(errors.ErrCodeInvalidRequest,
+				fmt.Sprintf("unexpected part %q: expected %q and %q", name, RecipePart, SnapshotPart))
+		}
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeInvalidRequest, fmt.Sprintf("failed to parse %q part", name), err)
+		}
+	}
+}
+
+// decodeDocument decodes JSON or YAML data into v. The format is taken from the
+// media type, then the file name extension, and is otherwise sniffed: documents
+// starting with "{" are JSON.
+func decodeDocument(data []byte, mediaType, fileName string, v any) error {
+	isYAML := false
+	switch {
+	case mediaType == "application/yaml", mediaType == "application/x-yaml", mediaType == "text/yaml":
+		isYAML = true
+	case mediaType == "application/json":
+	default:
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".yaml", ".yml":
+			isYAML = true
+		case ".json":
+		default:
+			isYAML = !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+		}
+	}
+
+	if isYAML {
+		return yaml.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
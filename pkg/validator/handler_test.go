@@ -0,0 +1,319 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
+)
+
+// specPath is the OpenAPI spec the handler tests are driven from.
+const specPath = "../../api/cns/v1/server.yaml"
+
+// openAPISpec is the subset of the OpenAPI document used by the tests.
+type openAPISpec struct {
+	Paths map[string]map[string]struct {
+		RequestBody struct {
+			Content map[string]struct {
+				Examples map[string]struct {
+					Value any `yaml:"value"`
+				} `yaml:"examples"`
+			} `yaml:"content"`
+		} `yaml:"requestBody"`
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref string `yaml:"$ref"`
+				} `yaml:"schema"`
+				Examples map[string]struct {
+					Value map[string]any `yaml:"value"`
+				} `yaml:"examples"`
+			} `yaml:"content"`
+		} `yaml:"responses"`
+	} `yaml:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Required []string `yaml:"required"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func loadSpec(t *testing.T) *openAPISpec {
+	t.Helper()
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatalf("failed to read OpenAPI spec: %v", err)
+	}
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("failed to parse OpenAPI spec: %v", err)
+	}
+	if _, ok := spec.Paths["/v1/validate"]["post"]; !ok {
+		t.Fatal("OpenAPI spec does not document POST /v1/validate")
+	}
+	return &spec
+}
+
+// requestExamples returns the documented application/json request bodies.
+func requestExamples(t *testing.T, spec *openAPISpec) map[string][]byte {
+	t.Helper()
+	examples := spec.Paths["/v1/validate"]["post"].RequestBody.Content["application/json"].Examples
+	if len(examples) == 0 {
+		t.Fatal("OpenAPI spec has no request examples for POST /v1/validate")
+	}
+	out := make(map[string][]byte, len(examples))
+	for name, ex := range examples {
+		data, err := json.Marshal(ex.Value)
+		if err != nil {
+			t.Fatalf("failed to encode example %q: %v", name, err)
+		}
+		out[name] = data
+	}
+	return out
+}
+
+// checkRequired fails if body lacks a field required by the named schema.
+func checkRequired(t *testing.T, spec *openAPISpec, schema string, body map[string]any) {
+	t.Helper()
+	for _, field := range spec.Components.Schemas[schema].Required {
+		if _, ok := body[field]; !ok {
+			t.Errorf("response lacks field %q required by schema %s", field, schema)
+		}
+	}
+}
+
+func TestHandleValidate_SpecExamples(t *testing.T) {
+	spec := loadSpec(t)
+	op := spec.Paths["/v1/validate"]["post"]
+
+	wantStatus := map[string]ValidationStatus{
+		"passing": ValidationStatusPass,
+		"failing": ValidationStatusFail,
+	}
+
+	for name, body := range requestExamples(t, spec) {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/validate", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			New().HandleValidate(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON response: %v", err)
+			}
+			ref := op.Responses["200"].Content["application/json"].Schema.Ref
+			checkRequired(t, spec, ref[strings.LastIndex(ref, "/")+1:], got)
+
+			summary, _ := got["summary"].(map[string]any)
+			checkRequired(t, spec, "ValidationSummary", summary)
+			if want, ok := wantStatus[name]; ok && summary["status"] != string(want) {
+				t.Errorf("summary.status = %v, want %s", summary["status"], want)
+			}
+		})
+	}
+}
+
+func TestHandleValidate_Multipart(t *testing.T) {
+	spec := loadSpec(t)
+	var envelope struct {
+		Recipe   any `json:"recipe"`
+		Snapshot any `json:"snapshot"`
+	}
+	if err := json.Unmarshal(requestExamples(t, spec)["passing"], &envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	// Send the recipe as YAML and the snapshot as JSON
+	recipeData, err := yaml.Marshal(envelope.Recipe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotData, err := json.Marshal(envelope.Snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for _, part := range []struct {
+		name, file string
+		data       []byte
+	}{
+		{RecipePart, "recipe.yaml", recipeData},
+		{SnapshotPart, "snapshot.json", snapshotData},
+	} {
+		fw, err := mw.CreateFormFile(part.name, part.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(part.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/validate", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+
+	New().HandleValidate(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/yaml" {
+		t.Errorf("Content-Type = %q, want application/yaml", ct)
+	}
+
+	var got ValidationResult
+	if err := yaml.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid YAML response: %v", err)
+	}
+	if got.RecipeSource != "recipe.yaml" || got.SnapshotSource != "snapshot.json" {
+		t.Errorf("sources = %q, %q", got.RecipeSource, got.SnapshotSource)
+	}
+	if got.Summary.Status != ValidationStatusPass || got.Summary.Passed != 2 {
+		t.Errorf("summary = %+v, want 2 passed", got.Summary)
+	}
+}
+
+func TestHandleValidate_Errors(t *testing.T) {
+	spec := loadSpec(t)
+	op := spec.Paths["/v1/validate"]["post"]
+	passing := requestExamples(t, spec)["passing"]
+
+	missingSnapshot := op.Responses["400"].Content["application/json"].Examples["missingSnapshot"].Value
+	if missingSnapshot == nil {
+		t.Fatal("OpenAPI spec has no missingSnapshot example")
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        []byte
+		validator   *Validator
+		wantStatus  int
+		wantMessage string
+		wantDetail  any
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "missing snapshot",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        []byte(`{"recipe": {"constraints": []}}`),
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: missingSnapshot["message"].(string),
+			wantDetail:  missingSnapshot["details"].(map[string]any)["error"],
+		},
+		{
+			name:        "malformed body",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        []byte(`{"recipe":`),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "empty body",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "too large",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        bytes.Repeat([]byte(" "), MaxRequestSize+1),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "criteria not allowed",
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        passing,
+			validator: New(WithAllowLists(&recipe.AllowLists{
+				Services: []recipe.CriteriaServiceType{recipe.CriteriaServiceGKE},
+			})),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := op.Responses[strconv.Itoa(tt.wantStatus)]; !ok {
+				t.Fatalf("status %d is not documented in the OpenAPI spec", tt.wantStatus)
+			}
+
+			v := tt.validator
+			if v == nil {
+				v = New()
+			}
+			req := httptest.NewRequest(tt.method, "/v1/validate", bytes.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			v.HandleValidate(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid error response: %v", err)
+			}
+			checkRequired(t, spec, "Error", got)
+			if tt.wantMessage != "" && got["message"] != tt.wantMessage {
+				t.Errorf("message = %v, want %q", got["message"], tt.wantMessage)
+			}
+			if tt.wantDetail != nil {
+				details, _ := got["details"].(map[string]any)
+				if details["error"] != tt.wantDetail {
+					t.Errorf("details.error = %v, want %v", details["error"], tt.wantDetail)
+				}
+			}
+		})
+	}
+}
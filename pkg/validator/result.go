@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/cloud-native-stack/pkg/header"
+	"github.com/NVIDIA/cloud-native-stack/pkg/recipe"
 )
 
 // ValidationStatus represents the overall validation outcome.
@@ -63,6 +64,25 @@ type ValidationResult struct {
 
 	// Results contains per-constraint validation details.
 	Results []ConstraintValidation `json:"results" yaml:"results"`
+
+	// Pools contains per-pool results when a ClusterSnapshot was validated.
+	// The Summary then aggregates the results of all pools.
+	Pools []PoolValidation `json:"pools,omitempty" yaml:"pools,omitempty"`
+}
+
+// PoolValidation contains the validation results for a single node pool.
+type PoolValidation struct {
+	// Pool is the node pool name.
+	Pool string `json:"pool" yaml:"pool"`
+
+	// Nodes lists the nodes the constraints were evaluated on.
+	Nodes []string `json:"nodes" yaml:"nodes"`
+
+	// Summary contains the validation statistics for the pool.
+	Summary ValidationSummary `json:"summary" yaml:"summary"`
+
+	// Results contains per-constraint validation details for the pool.
+	Results []ConstraintValidation `json:"results" yaml:"results"`
 }
 
 // ValidationSummary contains aggregate statistics about the validation.
@@ -102,6 +122,10 @@ type ConstraintValidation struct {
 
 	// Message provides additional context, especially for failures or skipped constraints.
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Remediation describes how to resolve a failed constraint, taken from the
+	// recipe or the built-in remediation catalog. Only set for failed constraints.
+	Remediation *recipe.Remediation `json:"remediation,omitempty" yaml:"remediation,omitempty"`
 }
 
 // NewValidationResult creates a new ValidationResult with initialized slices.
@@ -87,6 +87,10 @@ func EvaluateConstraint(constraint recipe.Constraint, snap *snapshotter.Snapshot
 type Validator struct {
 	// Version is the validator version (typically the CLI version).
 	Version string
+
+	// AllowLists defines which criteria values are permitted for validation requests.
+	// When set, HandleValidate rejects recipes whose criteria are outside the allowed values.
+	AllowLists *recipe.AllowLists
 }
 
 // Option is a functional option for configuring Validator instances.
@@ -99,6 +103,13 @@ func WithVersion(version string) Option {
 	}
 }
 
+// WithAllowLists returns an Option that sets the criteria allowlists for the Validator.
+func WithAllowLists(al *recipe.AllowLists) Option {
+	return func(v *Validator) {
+		v.AllowLists = al
+	}
+}
+
 // New creates a new Validator with the provided options.
 func New(opts ...Option) *Validator {
 	v := &Validator{}
@@ -131,7 +142,7 @@ func (v *Validator) Validate(ctx context.Context, recipeResult *recipe.RecipeRes
 		default:
 		}
 
-		cv := v.evaluateConstraint(constraint, snap)
+		cv := v.evaluateConstraint(constraint, recipeResult.Criteria, snap)
 		result.Results = append(result.Results, cv)
 
 		// Update summary counts
@@ -148,16 +159,7 @@ func (v *Validator) Validate(ctx context.Context, recipeResult *recipe.RecipeRes
 	// Calculate summary
 	result.Summary.Total = len(recipeResult.Constraints)
 	result.Summary.Duration = time.Since(start)
-
-	// Determine overall status
-	switch {
-	case result.Summary.Failed > 0:
-		result.Summary.Status = ValidationStatusFail
-	case result.Summary.Skipped > 0:
-		result.Summary.Status = ValidationStatusPartial
-	default:
-		result.Summary.Status = ValidationStatusPass
-	}
+	result.Summary.Status = overallStatus(result.Summary)
 
 	slog.Debug("validation completed",
 		"passed", result.Summary.Passed,
@@ -169,8 +171,9 @@ func (v *Validator) Validate(ctx context.Context, recipeResult *recipe.RecipeRes
 	return result, nil
 }
 
-// evaluateConstraint evaluates a single constraint against the snapshot.
-func (v *Validator) evaluateConstraint(constraint recipe.Constraint, snap *snapshotter.Snapshot) ConstraintValidation {
+// evaluateConstraint evaluates a single constraint against the snapshot. The
+// recipe criteria select the built-in remediation of a failed constraint.
+func (v *Validator) evaluateConstraint(constraint recipe.Constraint, criteria *recipe.Criteria, snap *snapshotter.Snapshot) ConstraintValidation {
 	cv := ConstraintValidation{
 		Name:     constraint.Name,
 		Expected: constraint.Value,
@@ -220,6 +223,7 @@ func (v *Validator) evaluateConstraint(constraint recipe.Constraint, snap *snaps
 	if err != nil {
 		cv.Status = ConstraintStatusFailed
 		cv.Message = fmt.Sprintf("evaluation failed: %v", err)
+		cv.Remediation = recipe.RemediationFor(constraint, criteria)
 		slog.Debug("constraint evaluation failed",
 			"name", constraint.Name,
 			"expected", constraint.Value,
@@ -237,6 +241,7 @@ func (v *Validator) evaluateConstraint(constraint recipe.Constraint, snap *snaps
 	} else {
 		cv.Status = ConstraintStatusFailed
 		cv.Message = fmt.Sprintf("expected %s, got %s", constraint.Value, actual)
+		cv.Remediation = recipe.RemediationFor(constraint, criteria)
 		slog.Debug("constraint failed",
 			"name", constraint.Name,
 			"expected", constraint.Value,
@@ -246,6 +251,18 @@ func (v *Validator) evaluateConstraint(constraint recipe.Constraint, snap *snaps
 	return cv
 }
 
+// overallStatus determines the validation status from the summary counts.
+func overallStatus(summary ValidationSummary) ValidationStatus {
+	switch {
+	case summary.Failed > 0:
+		return ValidationStatusFail
+	case summary.Skipped > 0:
+		return ValidationStatusPartial
+	default:
+		return ValidationStatusPass
+	}
+}
+
 // printDetectedCriteria prints detected criteria based on the constraint path and value.
 func printDetectedCriteria(path, value string) {
 	switch path {
@@ -295,6 +295,73 @@ func TestValidator_Validate_ConstraintDetails(t *testing.T) {
 	}
 }
 
+func TestValidator_Validate_Remediation(t *testing.T) {
+	snapshot := &snapshotter.Snapshot{
+		Measurements: []*measurement.Measurement{
+			{
+				Type: measurement.TypeK8s,
+				Subtypes: []measurement.Subtype{
+					{
+						Name: "server",
+						Data: map[string]measurement.Reading{
+							"version": measurement.Str("v1.29.3"),
+						},
+					},
+				},
+			},
+			{
+				Type: measurement.TypeOS,
+				Subtypes: []measurement.Subtype{
+					{
+						Name: "release",
+						Data: map[string]measurement.Reading{
+							"ID": measurement.Str("ubuntu"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	declared := &recipe.Remediation{
+		Description: "Use an Ubuntu node image",
+		Values:      map[string]any{"driver": map[string]any{"enabled": false}},
+	}
+
+	recipeResult := &recipe.RecipeResult{
+		Constraints: []recipe.Constraint{
+			{Name: "K8s.server.version", Value: ">= 1.32.4"},
+			{Name: "OS.release.ID", Value: "rhel", Remediation: declared},
+			{Name: "OS.release.ID", Value: "ubuntu"},
+		},
+	}
+
+	v := New(WithVersion("test"))
+	result, err := v.Validate(context.Background(), recipeResult, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(result.Results))
+	}
+
+	// Failed constraint without declared remediation uses the built-in catalog
+	if r := result.Results[0].Remediation; r == nil || r.DocsURL == "" {
+		t.Errorf("expected catalog remediation for K8s.server.version, got %+v", r)
+	}
+
+	// Declared remediation takes precedence
+	if r := result.Results[1].Remediation; r != declared {
+		t.Errorf("expected declared remediation, got %+v", r)
+	}
+
+	// Passed constraints carry no remediation
+	if r := result.Results[2].Remediation; r != nil {
+		t.Errorf("expected no remediation for passed constraint, got %+v", r)
+	}
+}
+
 func TestNew(t *testing.T) {
 	t.Run("default validator", func(t *testing.T) {
 		v := New()